@@ -53,6 +53,8 @@ type Document struct {
 	Refs                         [][8]int
 	RefIndex                     int
 	Index                        Index
+	Comments                     Comments
+	SourceMap                    SourceMap
 }
 
 func NewDocument() *Document {
@@ -151,6 +153,8 @@ func (d *Document) Reset() {
 	d.RefIndex = -1
 	d.Index.Reset()
 	d.Input.Reset()
+	d.Comments.Reset()
+	d.SourceMap.Reset()
 }
 
 func (d *Document) NextRefIndex() int {
@@ -0,0 +1,90 @@
+package ast
+
+// CommentPlacement describes where a comment is located relative to the node it is attached to
+type CommentPlacement int
+
+const (
+	// CommentPlacementLeading comments are on their own lines right before the node
+	CommentPlacementLeading CommentPlacement = iota
+	// CommentPlacementTrailing comments are on the same line right after the node
+	CommentPlacementTrailing
+	// CommentPlacementAfter comments are on their own lines after the node without preceding another node,
+	// e.g. at the end of a fields definition or at the end of the document
+	CommentPlacementAfter
+)
+
+// Comment is a single '#' comment line of the source document
+// example:
+// # the primary key
+type Comment struct {
+	Node            Node               // the node the comment is attached to
+	Placement       CommentPlacement   // position of the comment relative to the node
+	Content         ByteSliceReference // the comment including the leading '#', e.g. # the primary key
+	BlankLineBefore bool               // the comment was separated from the previous line by at least one blank line
+}
+
+// Comments holds the '#' comments and blank lines of a parsed document.
+// It's only populated when the document was parsed with comments enabled.
+type Comments struct {
+	Comments        []Comment
+	nodeComments    map[Node][]int
+	blankLineBefore map[Node]struct{}
+}
+
+func (c *Comments) Reset() {
+	c.Comments = c.Comments[:0]
+	for node := range c.nodeComments {
+		delete(c.nodeComments, node)
+	}
+	for node := range c.blankLineBefore {
+		delete(c.blankLineBefore, node)
+	}
+}
+
+// HasComments returns true if the document has at least one comment attached
+func (c *Comments) HasComments() bool {
+	return len(c.Comments) != 0
+}
+
+// AddComment attaches a comment to the node of the comment and returns its ref
+func (c *Comments) AddComment(comment Comment) (ref int) {
+	if c.nodeComments == nil {
+		c.nodeComments = map[Node][]int{}
+	}
+	c.Comments = append(c.Comments, comment)
+	ref = len(c.Comments) - 1
+	c.nodeComments[comment.Node] = append(c.nodeComments[comment.Node], ref)
+	return ref
+}
+
+// NodeComments returns the refs of all comments of the given placement attached to the node in source order
+func (c *Comments) NodeComments(node Node, placement CommentPlacement) (refs []int) {
+	for _, ref := range c.nodeComments[node] {
+		if c.Comments[ref].Placement == placement {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// SetBlankLineBefore marks the node as being separated from the previous line by at least one blank line
+func (c *Comments) SetBlankLineBefore(node Node) {
+	if c.blankLineBefore == nil {
+		c.blankLineBefore = map[Node]struct{}{}
+	}
+	c.blankLineBefore[node] = struct{}{}
+}
+
+// HasBlankLineBefore returns true if the node was separated from the previous line by at least one blank line
+func (c *Comments) HasBlankLineBefore(node Node) bool {
+	_, ok := c.blankLineBefore[node]
+	return ok
+}
+
+func (d *Document) CommentContentBytes(ref int) ByteSlice {
+	return d.Input.ByteSlice(d.Comments.Comments[ref].Content)
+}
+
+func (d *Document) CommentContentString(ref int) string {
+	return d.Input.ByteSliceString(d.Comments.Comments[ref].Content)
+}
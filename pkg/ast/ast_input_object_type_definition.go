@@ -7,6 +7,9 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 )
 
+// OneOfDirectiveName is the name of the directive which turns an input object into a OneOf Input Object
+const OneOfDirectiveName = "oneOf"
+
 type InputObjectTypeDefinition struct {
 	Description              Description        // optional, describes the input type
 	InputLiteral             position.Position  // input
@@ -25,6 +28,13 @@ func (d *Document) InputObjectTypeDefinitionNameString(ref int) string {
 	return unsafebytes.BytesToString(d.Input.ByteSlice(d.InputObjectTypeDefinitions[ref].Name))
 }
 
+// InputObjectTypeDefinitionIsOneOf returns true if the input object is annotated with @oneOf,
+// which requires exactly one of its fields to be provided with a non-null value
+func (d *Document) InputObjectTypeDefinitionIsOneOf(ref int) bool {
+	return d.InputObjectTypeDefinitions[ref].HasDirectives &&
+		d.InputObjectTypeDefinitions[ref].Directives.HasDirectiveByName(d, OneOfDirectiveName)
+}
+
 func (d *Document) InputObjectTypeDefinitionDescriptionBytes(ref int) ByteSlice {
 	if !d.InputObjectTypeDefinitions[ref].Description.IsDefined {
 		return nil
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 )
 
 type Node struct {
@@ -60,6 +61,10 @@ func (d *Document) NodeNameBytes(node Node) ByteSlice {
 		ref = d.UnionTypeExtensions[node.Ref].Name
 	case NodeKindEnumTypeExtension:
 		ref = d.EnumTypeExtensions[node.Ref].Name
+	case NodeKindInputObjectTypeExtension:
+		ref = d.InputObjectTypeExtensions[node.Ref].Name
+	case NodeKindScalarTypeExtension:
+		ref = d.ScalarTypeExtensions[node.Ref].Name
 	}
 
 	return d.Input.ByteSlice(ref)
@@ -87,6 +92,63 @@ func (d *Document) NodeNameString(node Node) string {
 	return string(d.NodeNameBytes(node))
 }
 
+// NodePosition returns the text position of a node to locate errors,
+// which is the position of the name for named type system nodes and the position of the keyword for schema definitions and extensions.
+// Nodes without a name or position return a zero position.
+func (d *Document) NodePosition(node Node) position.Position {
+	var ref ByteSliceReference
+
+	switch node.Kind {
+	case NodeKindSchemaDefinition:
+		return d.SchemaDefinitions[node.Ref].SchemaLiteral
+	case NodeKindSchemaExtension:
+		return d.SchemaExtensions[node.Ref].ExtendLiteral
+	case NodeKindField:
+		return d.Fields[node.Ref].Position
+	case NodeKindObjectTypeDefinition:
+		ref = d.ObjectTypeDefinitions[node.Ref].Name
+	case NodeKindObjectTypeExtension:
+		ref = d.ObjectTypeExtensions[node.Ref].Name
+	case NodeKindInterfaceTypeDefinition:
+		ref = d.InterfaceTypeDefinitions[node.Ref].Name
+	case NodeKindInterfaceTypeExtension:
+		ref = d.InterfaceTypeExtensions[node.Ref].Name
+	case NodeKindInputObjectTypeDefinition:
+		ref = d.InputObjectTypeDefinitions[node.Ref].Name
+	case NodeKindInputObjectTypeExtension:
+		ref = d.InputObjectTypeExtensions[node.Ref].Name
+	case NodeKindUnionTypeDefinition:
+		ref = d.UnionTypeDefinitions[node.Ref].Name
+	case NodeKindUnionTypeExtension:
+		ref = d.UnionTypeExtensions[node.Ref].Name
+	case NodeKindScalarTypeDefinition:
+		ref = d.ScalarTypeDefinitions[node.Ref].Name
+	case NodeKindScalarTypeExtension:
+		ref = d.ScalarTypeExtensions[node.Ref].Name
+	case NodeKindEnumTypeDefinition:
+		ref = d.EnumTypeDefinitions[node.Ref].Name
+	case NodeKindEnumTypeExtension:
+		ref = d.EnumTypeExtensions[node.Ref].Name
+	case NodeKindDirectiveDefinition:
+		ref = d.DirectiveDefinitions[node.Ref].Name
+	case NodeKindFieldDefinition:
+		ref = d.FieldDefinitions[node.Ref].Name
+	case NodeKindInputValueDefinition:
+		ref = d.InputValueDefinitions[node.Ref].Name
+	case NodeKindEnumValueDefinition:
+		ref = d.EnumValueDefinitions[node.Ref].EnumValue
+	case NodeKindDirective:
+		ref = d.Directives[node.Ref].Name
+	default:
+		return position.Position{}
+	}
+
+	if ref.Start == ref.End {
+		return position.Position{}
+	}
+	return d.Input.Position(ref)
+}
+
 // Node directives
 
 // NodeHasDirectiveByNameString returns whether the given node has a directive with the given name as string.
@@ -223,6 +285,12 @@ func (d *Document) NodeDirectiveLocation(node Node) (location DirectiveLocation,
 		location = TypeSystemDirectiveLocationInputObject
 	case NodeKindScalarTypeDefinition:
 		location = TypeSystemDirectiveLocationScalar
+	case NodeKindScalarTypeExtension:
+		location = TypeSystemDirectiveLocationScalar
+	case NodeKindFieldDefinition:
+		location = TypeSystemDirectiveLocationFieldDefinition
+	case NodeKindEnumValueDefinition:
+		location = TypeSystemDirectiveLocationEnumValue
 	case NodeKindOperationDefinition:
 		switch d.OperationDefinitions[node.Ref].OperationType {
 		case OperationTypeQuery:
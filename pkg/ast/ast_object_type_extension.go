@@ -48,10 +48,14 @@ func (d *Document) ExtendObjectTypeDefinitionByObjectTypeExtension(objectTypeDef
 		d.ObjectTypeDefinitions[objectTypeDefinitionRef].HasDirectives = true
 	}
 
-	if len(d.ObjectTypeExtensions[objectTypeExtensionRef].ImplementsInterfaces.Refs) > 0 {
+	for _, typeRef := range d.ObjectTypeExtensions[objectTypeExtensionRef].ImplementsInterfaces.Refs {
+		// interfaces implemented by both the definition and the extension are only added once
+		if d.ObjectTypeDefinitionImplementsInterface(objectTypeDefinitionRef, d.TypeNameBytes(typeRef)) {
+			continue
+		}
 		d.ObjectTypeDefinitions[objectTypeDefinitionRef].ImplementsInterfaces.Refs = append(
 			d.ObjectTypeDefinitions[objectTypeDefinitionRef].ImplementsInterfaces.Refs,
-			d.ObjectTypeExtensions[objectTypeExtensionRef].ImplementsInterfaces.Refs...,
+			typeRef,
 		)
 	}
 
@@ -66,6 +66,29 @@ func (i *Input) ByteSliceString(reference ByteSliceReference) string {
 	return unsafebytes.BytesToString(i.ByteSlice(reference))
 }
 
+// Position returns the text position of a ByteSliceReference, lines and characters start at 1 and CharEnd is exclusive like in lexed tokens.
+// It scans the input up to the reference, so it's meant to locate errors rather than to be used in a hot path.
+func (i *Input) Position(reference ByteSliceReference) position.Position {
+	pos := position.Position{LineStart: 1, CharStart: 1}
+	line, char := uint32(1), uint32(1)
+	for offset := uint32(0); offset < reference.End && int(offset) < len(i.RawBytes); offset++ {
+		if offset == reference.Start {
+			pos.LineStart, pos.CharStart = line, char
+		}
+		if i.RawBytes[offset] == '\n' {
+			line++
+			char = 1
+			continue
+		}
+		char++
+	}
+	if reference.Start >= reference.End {
+		pos.LineStart, pos.CharStart = line, char
+	}
+	pos.LineEnd, pos.CharEnd = line, char
+	return pos
+}
+
 // ByteSliceReferenceContentEquals compares the content of two byte slices and returns true if they are the same
 func (i *Input) ByteSliceReferenceContentEquals(left, right ByteSliceReference) bool {
 	if left.Length() != right.Length() {
@@ -0,0 +1,54 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+)
+
+// Source is an input, e.g. a .graphql file, of a document built from multiple inputs
+type Source struct {
+	Name   string // e.g. the path of the file
+	Offset uint32 // offset of the first byte of the source in Input.RawBytes
+	Line   uint32 // line of the first byte of the source in the Input, starting at 1
+}
+
+// SourceMap maps lines of a document built from multiple inputs back to the sources.
+// It's only populated when the sources were added with AppendSource.
+type SourceMap struct {
+	Sources []Source
+}
+
+func (s *SourceMap) Reset() {
+	s.Sources = s.Sources[:0]
+}
+
+// Resolve returns the source containing a line of the Input and the line relative to the beginning of this source.
+// It returns false if the line is not part of any source.
+func (s *SourceMap) Resolve(line uint32) (source Source, sourceLine uint32, ok bool) {
+	for i := len(s.Sources) - 1; i >= 0; i-- {
+		if s.Sources[i].Line <= line {
+			return s.Sources[i], line - s.Sources[i].Line + 1, true
+		}
+	}
+	return Source{}, 0, false
+}
+
+// AppendSource appends the content of a source to the Input and records it in the SourceMap.
+// Every source starts on a new line, so that the lines of the document can be mapped back to the lines of its source
+// while the columns stay the same.
+func (d *Document) AppendSource(name string, content []byte) ByteSliceReference {
+	switch {
+	case len(d.Input.RawBytes) == 0:
+		// like ResetInputBytes, the lexer has to start at the first line
+		d.Input.TextPosition.Reset()
+	case d.Input.RawBytes[len(d.Input.RawBytes)-1] != '\n':
+		d.Input.AppendInputBytes(literal.LINETERMINATOR)
+	}
+	d.SourceMap.Sources = append(d.SourceMap.Sources, Source{
+		Name:   name,
+		Offset: uint32(len(d.Input.RawBytes)),
+		Line:   uint32(bytes.Count(d.Input.RawBytes, literal.LINETERMINATOR)) + 1,
+	})
+	return d.Input.AppendInputBytes(content)
+}
@@ -0,0 +1,114 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestInput_Position(t *testing.T) {
+	var input ast.Input
+	input.ResetInputString("type Query {\n\thello: String\n}")
+
+	t.Run("first line", func(t *testing.T) {
+		assert.Equal(t, position.Position{LineStart: 1, LineEnd: 1, CharStart: 6, CharEnd: 11},
+			input.Position(ast.ByteSliceReference{Start: 5, End: 10}))
+	})
+	t.Run("following line", func(t *testing.T) {
+		assert.Equal(t, position.Position{LineStart: 2, LineEnd: 2, CharStart: 2, CharEnd: 7},
+			input.Position(ast.ByteSliceReference{Start: 14, End: 19}))
+	})
+	t.Run("multiple lines", func(t *testing.T) {
+		assert.Equal(t, position.Position{LineStart: 1, LineEnd: 3, CharStart: 12, CharEnd: 2},
+			input.Position(ast.ByteSliceReference{Start: 11, End: 29}))
+	})
+	t.Run("empty reference", func(t *testing.T) {
+		assert.Equal(t, position.Position{LineStart: 2, LineEnd: 2, CharStart: 1, CharEnd: 1},
+			input.Position(ast.ByteSliceReference{Start: 13, End: 13}))
+	})
+}
+
+func TestDocument_NodePosition(t *testing.T) {
+	doc := unsafeparser.ParseGraphqlDocumentString(`
+schema {
+	query: Query
+}
+type Query {
+	hello(name: String): String @deprecated
+}
+enum Color {
+	RED
+}
+extend enum Color {
+	GREEN
+}
+directive @custom on FIELD_DEFINITION`)
+
+	run := func(kind ast.NodeKind, ref int, line, column uint32) func(t *testing.T) {
+		return func(t *testing.T) {
+			pos := doc.NodePosition(ast.Node{Kind: kind, Ref: ref})
+			assert.Equal(t, line, pos.LineStart)
+			assert.Equal(t, column, pos.CharStart)
+		}
+	}
+
+	t.Run("schema definition", run(ast.NodeKindSchemaDefinition, 0, 2, 1))
+	t.Run("object type definition", run(ast.NodeKindObjectTypeDefinition, 0, 5, 6))
+	t.Run("field definition", run(ast.NodeKindFieldDefinition, 0, 6, 2))
+	t.Run("input value definition", run(ast.NodeKindInputValueDefinition, 0, 6, 8))
+	t.Run("directive", run(ast.NodeKindDirective, 0, 6, 31))
+	t.Run("enum value definition", run(ast.NodeKindEnumValueDefinition, 1, 12, 2))
+	t.Run("enum type extension", run(ast.NodeKindEnumTypeExtension, 0, 11, 13))
+	t.Run("directive definition", run(ast.NodeKindDirectiveDefinition, 0, 14, 12))
+	t.Run("unknown node", func(t *testing.T) {
+		assert.Equal(t, position.Position{}, doc.NodePosition(ast.Node{Kind: ast.NodeKindUnknown}))
+	})
+}
+
+func TestDocument_AppendSource(t *testing.T) {
+	doc := ast.NewDocument()
+	doc.AppendSource("schema.graphql", []byte("schema {\n\tquery: Query\n}"))
+	doc.AppendSource("query.graphql", []byte("type Query {\n\thello: String\n}\n"))
+	doc.AppendSource("scalars.graphql", []byte("scalar JSON"))
+
+	assert.Equal(t, "schema {\n\tquery: Query\n}\ntype Query {\n\thello: String\n}\nscalar JSON", string(doc.Input.RawBytes))
+	assert.Equal(t, []ast.Source{
+		{Name: "schema.graphql", Offset: 0, Line: 1},
+		{Name: "query.graphql", Offset: 25, Line: 4},
+		{Name: "scalars.graphql", Offset: 55, Line: 7},
+	}, doc.SourceMap.Sources)
+
+	t.Run("parsed positions start at the first line", func(t *testing.T) {
+		report := operationreport.Report{}
+		astparser.NewParser().Parse(doc, &report)
+		require.False(t, report.HasErrors(), report.Error())
+		assert.Equal(t, uint32(2), doc.RootOperationTypeDefinitions[0].NamedType.Position.LineStart)
+	})
+
+	t.Run("resolve lines", func(t *testing.T) {
+		source, line, ok := doc.SourceMap.Resolve(5)
+		assert.True(t, ok)
+		assert.Equal(t, "query.graphql", source.Name)
+		assert.Equal(t, uint32(2), line)
+
+		source, line, ok = doc.SourceMap.Resolve(7)
+		assert.True(t, ok)
+		assert.Equal(t, "scalars.graphql", source.Name)
+		assert.Equal(t, uint32(1), line)
+
+		_, _, ok = doc.SourceMap.Resolve(0)
+		assert.False(t, ok)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		doc.Reset()
+		assert.Len(t, doc.SourceMap.Sources, 0)
+	})
+}
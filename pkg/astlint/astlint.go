@@ -0,0 +1,237 @@
+// Package astlint checks GraphQL schemas against an API style guide.
+// Unlike astvalidation, which only covers the correctness of a schema according to the spec,
+// the rules of astlint report diagnostics for valid schemas which don't follow conventions,
+// e.g. naming conventions, missing descriptions or unused types.
+package astlint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// Severity is the level of a diagnostic, SeverityOff disables a rule
+type Severity int
+
+const (
+	SeverityOff Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityOff:
+		return "off"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Diagnostic is a single violation of a rule
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	Message  string
+	// Coordinate is the schema coordinate of the offending element, e.g. "Query.droid" or "Query.droid(id:)"
+	Coordinate string
+	// Position is the position of the name of the offending element
+	Position position.Position
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s (%s)", d.Position.LineStart, d.Position.CharStart, d.Severity, d.Message, d.Rule)
+}
+
+// Diagnostics is the list of diagnostics returned by Lint ordered by position
+type Diagnostics []Diagnostic
+
+// HasErrors returns true if at least one diagnostic has the severity error
+func (d Diagnostics) HasErrors() bool {
+	for i := range d {
+		if d[i].Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Rule registers its callback functions on the Walker and reports diagnostics to the Reporter.
+// Severity is the default severity of the rule which can be changed per Linter by SetSeverity.
+type Rule struct {
+	Name     string
+	Severity Severity
+	Register func(walker *astvisitor.Walker, reporter *Reporter)
+}
+
+// Reporter collects the diagnostics of a single rule
+type Reporter struct {
+	linter *Linter
+	rule   string
+}
+
+// Report adds a diagnostic located at the name of the offending element,
+// the diagnostic is dropped if the rule is turned off.
+func (r *Reporter) Report(coordinate string, name ast.ByteSliceReference, format string, args ...interface{}) {
+	severity := r.linter.severities[r.rule]
+	if severity == SeverityOff {
+		return
+	}
+	r.linter.diagnostics = append(r.linter.diagnostics, Diagnostic{
+		Rule:       r.rule,
+		Severity:   severity,
+		Message:    fmt.Sprintf(format, args...),
+		Coordinate: coordinate,
+		Position:   r.linter.position(name),
+	})
+}
+
+// DefaultLinter returns a Linter with all built-in rules
+func DefaultLinter() *Linter {
+	return NewLinter(
+		NamingConvention(),
+		RequireDescriptions(),
+		NoUnusedTypes(),
+		RequireDeprecationReason(),
+		RelayConnectionSpec(),
+		InputTypeSuffix(),
+	)
+}
+
+func NewLinter(rules ...Rule) *Linter {
+	linter := &Linter{
+		walker:     astvisitor.NewWalker(48),
+		severities: map[string]Severity{},
+	}
+
+	for _, rule := range rules {
+		linter.RegisterRule(rule)
+	}
+
+	return linter
+}
+
+// Linter walks a schema with all registered rules
+type Linter struct {
+	walker      astvisitor.Walker
+	severities  map[string]Severity
+	document    *ast.Document
+	lineStarts  []uint32
+	diagnostics Diagnostics
+}
+
+func (l *Linter) RegisterRule(rule Rule) {
+	if _, configured := l.severities[rule.Name]; !configured {
+		l.severities[rule.Name] = rule.Severity
+	}
+	rule.Register(&l.walker, &Reporter{linter: l, rule: rule.Name})
+}
+
+// SetSeverity overrides the default severity of a rule, SeverityOff disables the rule
+func (l *Linter) SetSeverity(rule string, severity Severity) {
+	l.severities[rule] = severity
+}
+
+// Lint walks the schema and returns the diagnostics of all enabled rules.
+// An error is only returned if the schema can't be walked.
+func (l *Linter) Lint(definition *ast.Document) (Diagnostics, error) {
+	l.document = definition
+	l.lineStarts = l.lineStarts[:0]
+	l.diagnostics = nil
+
+	report := operationreport.Report{}
+	l.walker.Walk(definition, definition, &report)
+	if report.HasErrors() {
+		return nil, report
+	}
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		left, right := l.diagnostics[i].Position, l.diagnostics[j].Position
+		if left.LineStart != right.LineStart {
+			return left.LineStart < right.LineStart
+		}
+		return left.CharStart < right.CharStart
+	})
+
+	return l.diagnostics, nil
+}
+
+// position returns the line and column of a name in the input of the linted document
+func (l *Linter) position(name ast.ByteSliceReference) position.Position {
+	if len(l.lineStarts) == 0 {
+		l.lineStarts = append(l.lineStarts, 0)
+		for i, b := range l.document.Input.RawBytes {
+			if b == '\n' {
+				l.lineStarts = append(l.lineStarts, uint32(i+1))
+			}
+		}
+	}
+
+	line := sort.Search(len(l.lineStarts), func(i int) bool {
+		return l.lineStarts[i] > name.Start
+	})
+	lineStart := l.lineStarts[line-1]
+
+	return position.Position{
+		LineStart: uint32(line),
+		LineEnd:   uint32(line),
+		CharStart: name.Start - lineStart + 1,
+		CharEnd:   name.End - lineStart + 1,
+	}
+}
+
+// isReservedName returns true for names with the prefix reserved for introspection, which are not linted
+func isReservedName(name ast.ByteSlice) bool {
+	return bytes.HasPrefix(name, reservedNamePrefix)
+}
+
+var reservedNamePrefix = []byte("__")
+
+// fieldDefinitionCoordinate returns the schema coordinate of the field definition the walker currently visits
+func fieldDefinitionCoordinate(walker *astvisitor.Walker, definition *ast.Document, ref int) string {
+	return definition.NodeNameString(walker.Ancestors[len(walker.Ancestors)-1]) + "." + definition.FieldDefinitionNameString(ref)
+}
+
+// inputValueDefinitionCoordinate returns the schema coordinate of the argument or input field the walker currently visits
+func inputValueDefinitionCoordinate(walker *astvisitor.Walker, definition *ast.Document, ref int) string {
+	name := definition.InputValueDefinitionNameString(ref)
+	parent := walker.Ancestors[len(walker.Ancestors)-1]
+	switch parent.Kind {
+	case ast.NodeKindFieldDefinition:
+		typeName := definition.NodeNameString(walker.Ancestors[len(walker.Ancestors)-2])
+		return typeName + "." + definition.FieldDefinitionNameString(parent.Ref) + "(" + name + ":)"
+	case ast.NodeKindDirectiveDefinition:
+		return "@" + definition.DirectiveDefinitionNameString(parent.Ref) + "(" + name + ":)"
+	default:
+		return definition.NodeNameString(parent) + "." + name
+	}
+}
+
+var builtInScalars = [][]byte{
+	[]byte("String"),
+	[]byte("Int"),
+	[]byte("Float"),
+	[]byte("Boolean"),
+	[]byte("ID"),
+}
+
+func isBuiltInScalar(typeName ast.ByteSlice) bool {
+	for i := range builtInScalars {
+		if bytes.Equal(builtInScalars[i], typeName) {
+			return true
+		}
+	}
+	return false
+}
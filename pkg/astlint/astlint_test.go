@@ -0,0 +1,107 @@
+package astlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+)
+
+// runLinter lints the schema with the given rules and returns the diagnostics as strings
+func runLinter(t *testing.T, schema string, rules ...Rule) []string {
+	t.Helper()
+
+	definition := unsafeparser.ParseGraphqlDocumentString(schema)
+	diagnostics, err := NewLinter(rules...).Lint(&definition)
+	require.NoError(t, err)
+
+	out := make([]string, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		out = append(out, diagnostic.String())
+	}
+	return out
+}
+
+func TestLinter(t *testing.T) {
+	t.Run("diagnostics are ordered by position", func(t *testing.T) {
+		diagnostics := runLinter(t, `type Query { droid: droid }
+type droid { Name: String }`,
+			NoUnusedTypes(), NamingConvention(),
+		)
+		assert.Equal(t, []string{
+			"2:6: warning: Type 'droid' should be PascalCase (naming-convention)",
+			"2:14: warning: Field 'Name' should be camelCase (naming-convention)",
+		}, diagnostics)
+	})
+
+	t.Run("diagnostic details", func(t *testing.T) {
+		definition := unsafeparser.ParseGraphqlDocumentString(`
+			type Query {
+				droid(ID: ID!): String
+			}`)
+		diagnostics, err := NewLinter(NamingConvention()).Lint(&definition)
+		require.NoError(t, err)
+		assert.Equal(t, Diagnostics{
+			{
+				Rule:       RuleNamingConvention,
+				Severity:   SeverityWarning,
+				Message:    "Argument 'ID' should be camelCase",
+				Coordinate: "Query.droid(ID:)",
+				Position:   position.Position{LineStart: 3, LineEnd: 3, CharStart: 11, CharEnd: 13},
+			},
+		}, diagnostics)
+		assert.False(t, diagnostics.HasErrors())
+	})
+
+	t.Run("severity can be configured per rule", func(t *testing.T) {
+		definition := unsafeparser.ParseGraphqlDocumentString(`
+			type Query { Droid: String }
+			input Filter { name: String }`)
+
+		linter := NewLinter(NamingConvention(), InputTypeSuffix(), NoUnusedTypes())
+		linter.SetSeverity(RuleNamingConvention, SeverityError)
+		linter.SetSeverity(RuleNoUnusedTypes, SeverityOff)
+
+		diagnostics, err := linter.Lint(&definition)
+		require.NoError(t, err)
+		require.Len(t, diagnostics, 2)
+		assert.Equal(t, RuleNamingConvention, diagnostics[0].Rule)
+		assert.Equal(t, SeverityError, diagnostics[0].Severity)
+		assert.Equal(t, RuleInputTypeSuffix, diagnostics[1].Rule)
+		assert.Equal(t, SeverityWarning, diagnostics[1].Severity)
+		assert.True(t, diagnostics.HasErrors())
+	})
+
+	t.Run("linter can be reused", func(t *testing.T) {
+		linter := NewLinter(NamingConvention())
+
+		first := unsafeparser.ParseGraphqlDocumentString(`type Query { Droid: String }`)
+		diagnostics, err := linter.Lint(&first)
+		require.NoError(t, err)
+		assert.Len(t, diagnostics, 1)
+
+		second := unsafeparser.ParseGraphqlDocumentString(`type Query { droid: String }`)
+		diagnostics, err = linter.Lint(&second)
+		require.NoError(t, err)
+		assert.Len(t, diagnostics, 0)
+	})
+
+	t.Run("default linter ignores the base schema", func(t *testing.T) {
+		definition := unsafeparser.ParseGraphqlDocumentString(`
+			"""The query type"""
+			type Query {
+				droid(id: ID!): Droid @deprecated(reason: "use character")
+			}
+			"""A droid"""
+			type Droid { name: String }`)
+		require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definition))
+
+		diagnostics, err := DefaultLinter().Lint(&definition)
+		require.NoError(t, err)
+		assert.Len(t, diagnostics, 0)
+	})
+}
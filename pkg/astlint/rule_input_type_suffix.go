@@ -0,0 +1,46 @@
+package astlint
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+)
+
+const RuleInputTypeSuffix = "input-type-suffix"
+
+var inputTypeSuffix = []byte("Input")
+
+// InputTypeSuffix reports input object types whose name doesn't end with 'Input'
+func InputTypeSuffix() Rule {
+	return Rule{
+		Name:     RuleInputTypeSuffix,
+		Severity: SeverityWarning,
+		Register: func(walker *astvisitor.Walker, reporter *Reporter) {
+			visitor := &inputTypeSuffixVisitor{
+				reporter: reporter,
+			}
+
+			walker.RegisterEnterDocumentVisitor(visitor)
+			walker.RegisterEnterInputObjectTypeDefinitionVisitor(visitor)
+		},
+	}
+}
+
+type inputTypeSuffixVisitor struct {
+	reporter   *Reporter
+	definition *ast.Document
+}
+
+func (i *inputTypeSuffixVisitor) EnterDocument(operation, _ *ast.Document) {
+	i.definition = operation
+}
+
+func (i *inputTypeSuffixVisitor) EnterInputObjectTypeDefinition(ref int) {
+	name := i.definition.InputObjectTypeDefinitions[ref].Name
+	typeName := i.definition.Input.ByteSlice(name)
+	if isReservedName(typeName) || bytes.HasSuffix(typeName, inputTypeSuffix) {
+		return
+	}
+	i.reporter.Report(string(typeName), name, "Input object '%s' should end with '%s'", typeName, inputTypeSuffix)
+}
@@ -0,0 +1,27 @@
+package astlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputTypeSuffix(t *testing.T) {
+	t.Run("input types with suffix", func(t *testing.T) {
+		assert.Empty(t, runLinter(t, `
+			input DroidFilterInput { name: String }
+			type Droid { name: String }`,
+			InputTypeSuffix(),
+		))
+	})
+
+	t.Run("input types without suffix", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"1:7: warning: Input object 'DroidFilter' should end with 'Input' (input-type-suffix)",
+			"2:7: warning: Input object 'Inputs' should end with 'Input' (input-type-suffix)",
+		}, runLinter(t, `input DroidFilter { name: String }
+input Inputs { name: String }`,
+			InputTypeSuffix(),
+		))
+	})
+}
@@ -0,0 +1,152 @@
+package astlint
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+)
+
+const RuleNamingConvention = "naming-convention"
+
+// NamingConvention reports type names which are not PascalCase, field and argument names which are not camelCase
+// and enum values which are not SCREAMING_SNAKE_CASE
+func NamingConvention() Rule {
+	return Rule{
+		Name:     RuleNamingConvention,
+		Severity: SeverityWarning,
+		Register: func(walker *astvisitor.Walker, reporter *Reporter) {
+			visitor := &namingConventionVisitor{
+				Walker:   walker,
+				reporter: reporter,
+			}
+
+			walker.RegisterEnterDocumentVisitor(visitor)
+			walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterInterfaceTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterUnionTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterScalarTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterEnumTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterInputObjectTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterFieldDefinitionVisitor(visitor)
+			walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+			walker.RegisterEnterEnumValueDefinitionVisitor(visitor)
+		},
+	}
+}
+
+type namingConventionVisitor struct {
+	*astvisitor.Walker
+	reporter   *Reporter
+	definition *ast.Document
+}
+
+func (n *namingConventionVisitor) EnterDocument(operation, _ *ast.Document) {
+	n.definition = operation
+}
+
+func (n *namingConventionVisitor) EnterObjectTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.ObjectTypeDefinitions[ref].Name)
+}
+
+func (n *namingConventionVisitor) EnterInterfaceTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.InterfaceTypeDefinitions[ref].Name)
+}
+
+func (n *namingConventionVisitor) EnterUnionTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.UnionTypeDefinitions[ref].Name)
+}
+
+func (n *namingConventionVisitor) EnterScalarTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.ScalarTypeDefinitions[ref].Name)
+}
+
+func (n *namingConventionVisitor) EnterEnumTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.EnumTypeDefinitions[ref].Name)
+}
+
+func (n *namingConventionVisitor) EnterInputObjectTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.InputObjectTypeDefinitions[ref].Name)
+}
+
+func (n *namingConventionVisitor) EnterFieldDefinition(ref int) {
+	name := n.definition.FieldDefinitions[ref].Name
+	if isReservedName(n.definition.Input.ByteSlice(name)) || isCamelCase(n.definition.Input.ByteSlice(name)) {
+		return
+	}
+	n.reporter.Report(fieldDefinitionCoordinate(n.Walker, n.definition, ref), name,
+		"Field '%s' should be camelCase", n.definition.Input.ByteSliceString(name))
+}
+
+func (n *namingConventionVisitor) EnterInputValueDefinition(ref int) {
+	name := n.definition.InputValueDefinitions[ref].Name
+	if isReservedName(n.definition.Input.ByteSlice(name)) || isCamelCase(n.definition.Input.ByteSlice(name)) {
+		return
+	}
+	kind := "Input field"
+	if parent := n.Ancestors[len(n.Ancestors)-1]; parent.Kind == ast.NodeKindFieldDefinition || parent.Kind == ast.NodeKindDirectiveDefinition {
+		kind = "Argument"
+	}
+	n.reporter.Report(inputValueDefinitionCoordinate(n.Walker, n.definition, ref), name,
+		"%s '%s' should be camelCase", kind, n.definition.Input.ByteSliceString(name))
+}
+
+func (n *namingConventionVisitor) EnterEnumValueDefinition(ref int) {
+	name := n.definition.EnumValueDefinitions[ref].EnumValue
+	if isReservedName(n.definition.Input.ByteSlice(name)) || isScreamingSnakeCase(n.definition.Input.ByteSlice(name)) {
+		return
+	}
+	enumName := n.definition.NodeNameString(n.Ancestors[len(n.Ancestors)-1])
+	n.reporter.Report(enumName+"."+n.definition.Input.ByteSliceString(name), name,
+		"Enum value '%s' should be SCREAMING_SNAKE_CASE", n.definition.Input.ByteSliceString(name))
+}
+
+func (n *namingConventionVisitor) checkTypeName(name ast.ByteSliceReference) {
+	if isReservedName(n.definition.Input.ByteSlice(name)) || isPascalCase(n.definition.Input.ByteSlice(name)) {
+		return
+	}
+	typeName := n.definition.Input.ByteSliceString(name)
+	n.reporter.Report(typeName, name, "Type '%s' should be PascalCase", typeName)
+}
+
+// isPascalCase returns true for names starting with an upper case letter which contain no underscores
+func isPascalCase(name []byte) bool {
+	return len(name) != 0 && isUpper(name[0]) && isAlphanumeric(name)
+}
+
+// isCamelCase returns true for names starting with a lower case letter which contain no underscores
+func isCamelCase(name []byte) bool {
+	return len(name) != 0 && isLower(name[0]) && isAlphanumeric(name)
+}
+
+// isScreamingSnakeCase returns true for names consisting of upper case letters, digits and underscores
+func isScreamingSnakeCase(name []byte) bool {
+	if len(name) == 0 || !isUpper(name[0]) {
+		return false
+	}
+	for _, b := range name {
+		if !isUpper(b) && !isDigit(b) && b != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(name []byte) bool {
+	for _, b := range name {
+		if !isUpper(b) && !isLower(b) && !isDigit(b) {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpper(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+func isLower(b byte) bool {
+	return b >= 'a' && b <= 'z'
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
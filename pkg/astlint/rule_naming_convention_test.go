@@ -0,0 +1,61 @@
+package astlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamingConvention(t *testing.T) {
+	t.Run("conventional names", func(t *testing.T) {
+		assert.Empty(t, runLinter(t, `
+			type Query { droid(id: ID!, filter: DroidInput2): Droid __typename: String! }
+			type Droid implements Node { id: ID! primaryFunction: String episode: Episode }
+			interface Node { id: ID! }
+			union SearchResult = Droid
+			scalar DateTime
+			enum Episode { NEWHOPE EMPIRE_STRIKES_BACK JEDI_3 }
+			input DroidInput2 { nameContains: String }
+			directive @cacheControl(maxAge: Int) on FIELD_DEFINITION`,
+			NamingConvention(),
+		))
+	})
+
+	t.Run("type names", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"1:6: warning: Type 'query' should be PascalCase (naming-convention)",
+			"2:11: warning: Type 'Search_Result' should be PascalCase (naming-convention)",
+			"3:6: warning: Type 'dateTime' should be PascalCase (naming-convention)",
+			"4:7: warning: Type 'droid_input' should be PascalCase (naming-convention)",
+		}, runLinter(t, `type query { a: String }
+interface Search_Result { a: String }
+enum dateTime { A }
+input droid_input { a: String }`,
+			NamingConvention(),
+		))
+	})
+
+	t.Run("field, argument and input field names", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"2:2: warning: Field 'Droid' should be camelCase (naming-convention)",
+			"3:8: warning: Argument 'droid_id' should be camelCase (naming-convention)",
+			"5:26: warning: Input field 'name_contains' should be camelCase (naming-convention)",
+			"6:25: warning: Argument 'MaxAge' should be camelCase (naming-convention)",
+		}, runLinter(t, `type Query {
+	Droid: String
+	droid(droid_id: ID): String
+}
+input DroidFilterInput { name_contains: String }
+directive @cacheControl(MaxAge: Int) on FIELD_DEFINITION`,
+			NamingConvention(),
+		))
+	})
+
+	t.Run("enum values", func(t *testing.T) {
+		diagnostics := runLinter(t, `enum Episode { newHope Empire JEDI }`, NamingConvention())
+		assert.Equal(t, []string{
+			"1:16: warning: Enum value 'newHope' should be SCREAMING_SNAKE_CASE (naming-convention)",
+			"1:24: warning: Enum value 'Empire' should be SCREAMING_SNAKE_CASE (naming-convention)",
+		}, diagnostics)
+	})
+}
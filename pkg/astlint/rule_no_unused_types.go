@@ -0,0 +1,145 @@
+package astlint
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+)
+
+const RuleNoUnusedTypes = "no-unused-types"
+
+// NoUnusedTypes reports types which are neither a root operation type nor referenced by another type,
+// e.g. as the type of a field, argument or input field, as union member or as implemented interface.
+// References of a type to itself don't count as usage.
+func NoUnusedTypes() Rule {
+	return Rule{
+		Name:     RuleNoUnusedTypes,
+		Severity: SeverityWarning,
+		Register: func(walker *astvisitor.Walker, reporter *Reporter) {
+			visitor := &noUnusedTypesVisitor{
+				Walker:   walker,
+				reporter: reporter,
+			}
+
+			walker.RegisterDocumentVisitor(visitor)
+			walker.RegisterEnterRootOperationTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterObjectTypeExtensionVisitor(visitor)
+			walker.RegisterEnterInterfaceTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterInterfaceTypeExtensionVisitor(visitor)
+			walker.RegisterEnterUnionTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterUnionTypeExtensionVisitor(visitor)
+			walker.RegisterEnterFieldDefinitionVisitor(visitor)
+			walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+		},
+	}
+}
+
+type noUnusedTypesVisitor struct {
+	*astvisitor.Walker
+	reporter   *Reporter
+	definition *ast.Document
+	usedTypes  map[string]struct{}
+}
+
+func (n *noUnusedTypesVisitor) EnterDocument(operation, _ *ast.Document) {
+	n.definition = operation
+	n.usedTypes = map[string]struct{}{}
+
+	if len(operation.SchemaDefinitions) == 0 && len(operation.SchemaExtensions) == 0 {
+		n.usedTypes[string(ast.DefaultQueryTypeName)] = struct{}{}
+		n.usedTypes[string(ast.DefaultMutationTypeName)] = struct{}{}
+		n.usedTypes[string(ast.DefaultSubscriptionTypeName)] = struct{}{}
+	}
+}
+
+func (n *noUnusedTypesVisitor) LeaveDocument(_, _ *ast.Document) {
+	for _, node := range n.definition.RootNodes {
+		var name ast.ByteSliceReference
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition:
+			name = n.definition.ObjectTypeDefinitions[node.Ref].Name
+		case ast.NodeKindInterfaceTypeDefinition:
+			name = n.definition.InterfaceTypeDefinitions[node.Ref].Name
+		case ast.NodeKindUnionTypeDefinition:
+			name = n.definition.UnionTypeDefinitions[node.Ref].Name
+		case ast.NodeKindScalarTypeDefinition:
+			name = n.definition.ScalarTypeDefinitions[node.Ref].Name
+		case ast.NodeKindEnumTypeDefinition:
+			name = n.definition.EnumTypeDefinitions[node.Ref].Name
+		case ast.NodeKindInputObjectTypeDefinition:
+			name = n.definition.InputObjectTypeDefinitions[node.Ref].Name
+		default:
+			continue
+		}
+
+		typeName := n.definition.Input.ByteSlice(name)
+		if isReservedName(typeName) || isBuiltInScalar(typeName) {
+			continue
+		}
+		if _, used := n.usedTypes[string(typeName)]; used {
+			continue
+		}
+		n.reporter.Report(string(typeName), name, "Type '%s' is not used", typeName)
+	}
+}
+
+func (n *noUnusedTypesVisitor) EnterRootOperationTypeDefinition(ref int) {
+	typeName := n.definition.Input.ByteSliceString(n.definition.RootOperationTypeDefinitions[ref].NamedType.Name)
+	n.usedTypes[typeName] = struct{}{}
+}
+
+func (n *noUnusedTypesVisitor) EnterObjectTypeDefinition(ref int) {
+	n.useTypes(n.definition.ObjectTypeDefinitions[ref].ImplementsInterfaces.Refs, n.definition.ObjectTypeDefinitionNameString(ref))
+}
+
+func (n *noUnusedTypesVisitor) EnterObjectTypeExtension(ref int) {
+	n.useTypes(n.definition.ObjectTypeExtensions[ref].ImplementsInterfaces.Refs, n.definition.ObjectTypeExtensionNameString(ref))
+}
+
+func (n *noUnusedTypesVisitor) EnterInterfaceTypeDefinition(ref int) {
+	n.useTypes(n.definition.InterfaceTypeDefinitions[ref].ImplementsInterfaces.Refs, n.definition.InterfaceTypeDefinitionNameString(ref))
+}
+
+func (n *noUnusedTypesVisitor) EnterInterfaceTypeExtension(ref int) {
+	n.useTypes(n.definition.InterfaceTypeExtensions[ref].ImplementsInterfaces.Refs, n.definition.InterfaceTypeExtensionNameString(ref))
+}
+
+func (n *noUnusedTypesVisitor) EnterUnionTypeDefinition(ref int) {
+	n.useTypes(n.definition.UnionTypeDefinitions[ref].UnionMemberTypes.Refs, n.definition.UnionTypeDefinitionNameString(ref))
+}
+
+func (n *noUnusedTypesVisitor) EnterUnionTypeExtension(ref int) {
+	n.useTypes(n.definition.UnionTypeExtensions[ref].UnionMemberTypes.Refs, n.definition.UnionTypeExtensionNameString(ref))
+}
+
+func (n *noUnusedTypesVisitor) EnterFieldDefinition(ref int) {
+	n.use(n.definition.FieldDefinitions[ref].Type, n.definition.NodeNameString(n.Ancestors[len(n.Ancestors)-1]))
+}
+
+func (n *noUnusedTypesVisitor) EnterInputValueDefinition(ref int) {
+	var enclosingTypeName string
+	switch parent := n.Ancestors[len(n.Ancestors)-1]; parent.Kind {
+	case ast.NodeKindFieldDefinition:
+		enclosingTypeName = n.definition.NodeNameString(n.Ancestors[len(n.Ancestors)-2])
+	case ast.NodeKindDirectiveDefinition:
+		// directive arguments have no enclosing type
+	default:
+		enclosingTypeName = n.definition.NodeNameString(parent)
+	}
+	n.use(n.definition.InputValueDefinitions[ref].Type, enclosingTypeName)
+}
+
+func (n *noUnusedTypesVisitor) useTypes(refs []int, enclosingTypeName string) {
+	for _, ref := range refs {
+		n.use(ref, enclosingTypeName)
+	}
+}
+
+// use marks the named type of the type reference as used unless it is the enclosing type itself
+func (n *noUnusedTypesVisitor) use(typeRef int, enclosingTypeName string) {
+	typeName := n.definition.ResolveTypeNameString(typeRef)
+	if typeName == enclosingTypeName {
+		return
+	}
+	n.usedTypes[typeName] = struct{}{}
+}
@@ -0,0 +1,63 @@
+package astlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoUnusedTypes(t *testing.T) {
+	t.Run("all types are used", func(t *testing.T) {
+		assert.Empty(t, runLinter(t, `
+			type Query { search(filter: Filter): [SearchResult] }
+			type Mutation { a: String }
+			union SearchResult = Droid
+			interface Node { id: ID! }
+			type Droid implements Node { id: ID! born: DateTime }
+			input Filter { episode: Episode }
+			enum Episode { NEWHOPE }
+			scalar DateTime
+			scalar String
+			directive @auth(role: Role) on FIELD_DEFINITION
+			enum Role { ADMIN }`,
+			NoUnusedTypes(),
+		))
+	})
+
+	t.Run("types used by extensions", func(t *testing.T) {
+		assert.Empty(t, runLinter(t, `
+			type Query { a: String }
+			extend type Query { droid: Droid }
+			type Droid { id: ID! }
+			extend type Droid implements Node { name: String }
+			interface Node { id: ID! }
+			union SearchResult = Droid
+			extend union SearchResult = Human
+			type Human { search: SearchResult }`,
+			NoUnusedTypes(),
+		))
+	})
+
+	t.Run("unused types", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"2:6: warning: Type 'Droid' is not used (no-unused-types)",
+			"3:7: warning: Type 'Filter' is not used (no-unused-types)",
+			"4:8: warning: Type 'DateTime' is not used (no-unused-types)",
+		}, runLinter(t, `type Query { a: String }
+type Droid { friends: [Droid] }
+input Filter { and: [Filter] }
+scalar DateTime`,
+			NoUnusedTypes(),
+		))
+	})
+
+	t.Run("root operation types of the schema definition", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"3:6: warning: Type 'Query' is not used (no-unused-types)",
+		}, runLinter(t, `schema { query: RootQuery }
+type RootQuery { a: String }
+type Query { a: String }`,
+			NoUnusedTypes(),
+		))
+	})
+}
@@ -0,0 +1,200 @@
+package astlint
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+)
+
+const RuleRelayConnectionSpec = "relay-connection-spec"
+
+var (
+	connectionTypeSuffix = []byte("Connection")
+	pageInfoTypeName     = []byte("PageInfo")
+	booleanTypeName      = []byte("Boolean")
+)
+
+// RelayConnectionSpec reports object types with the suffix 'Connection' which don't conform
+// to the Relay cursor connections specification (https://relay.dev/graphql/connections.htm):
+// a connection has a list of edges and a non-null PageInfo, an edge has a node and a cursor,
+// PageInfo has the fields hasPreviousPage, hasNextPage, startCursor and endCursor,
+// and fields returning a connection accept the arguments first and after or last and before.
+func RelayConnectionSpec() Rule {
+	return Rule{
+		Name:     RuleRelayConnectionSpec,
+		Severity: SeverityWarning,
+		Register: func(walker *astvisitor.Walker, reporter *Reporter) {
+			visitor := &relayConnectionSpecVisitor{
+				Walker:   walker,
+				reporter: reporter,
+			}
+
+			walker.RegisterEnterDocumentVisitor(visitor)
+			walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterFieldDefinitionVisitor(visitor)
+		},
+	}
+}
+
+type relayConnectionSpecVisitor struct {
+	*astvisitor.Walker
+	reporter     *Reporter
+	definition   *ast.Document
+	checkedEdges map[int]struct{}
+}
+
+func (r *relayConnectionSpecVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.definition = operation
+	r.checkedEdges = map[int]struct{}{}
+}
+
+func (r *relayConnectionSpecVisitor) EnterObjectTypeDefinition(ref int) {
+	typeName := r.definition.ObjectTypeDefinitionNameBytes(ref)
+	switch {
+	case bytes.Equal(typeName, pageInfoTypeName):
+		r.checkPageInfo(ref)
+	case bytes.HasSuffix(typeName, connectionTypeSuffix) && !bytes.Equal(typeName, connectionTypeSuffix):
+		r.checkConnection(ref)
+	}
+}
+
+func (r *relayConnectionSpecVisitor) EnterFieldDefinition(ref int) {
+	if !r.isConnection(r.definition.FieldDefinitionType(ref)) {
+		return
+	}
+	forward := r.hasArgument(ref, "first") && r.hasArgument(ref, "after")
+	backward := r.hasArgument(ref, "last") && r.hasArgument(ref, "before")
+	if forward || backward {
+		return
+	}
+	coordinate := fieldDefinitionCoordinate(r.Walker, r.definition, ref)
+	r.reporter.Report(coordinate, r.definition.FieldDefinitions[ref].Name,
+		"Connection field '%s' should have the arguments 'first' and 'after' or 'last' and 'before'", coordinate)
+}
+
+func (r *relayConnectionSpecVisitor) checkConnection(ref int) {
+	node := ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref}
+	name := r.definition.ObjectTypeDefinitions[ref].Name
+	typeName := r.definition.Input.ByteSliceString(name)
+
+	edges, hasEdges := r.definition.NodeFieldDefinitionByName(node, []byte("edges"))
+	if !hasEdges || !r.definition.TypeIsList(r.definition.FieldDefinitionType(edges)) {
+		r.reporter.Report(typeName, name, "Connection '%s' should have a field 'edges' returning a list of edges", typeName)
+	} else if edge, isObject := r.objectType(r.definition.FieldDefinitionType(edges)); !isObject {
+		r.reporter.Report(typeName+".edges", r.definition.FieldDefinitions[edges].Name,
+			"Field '%s.edges' should return a list of object types", typeName)
+	} else {
+		r.checkEdge(edge)
+	}
+
+	pageInfo, hasPageInfo := r.definition.NodeFieldDefinitionByName(node, []byte("pageInfo"))
+	if !hasPageInfo {
+		r.reporter.Report(typeName, name, "Connection '%s' should have a field 'pageInfo' of type 'PageInfo!'", typeName)
+		return
+	}
+	pageInfoType := r.definition.FieldDefinitionType(pageInfo)
+	if !r.definition.TypeIsNonNull(pageInfoType) || r.definition.TypeIsList(pageInfoType) ||
+		!bytes.Equal(r.definition.ResolveTypeNameBytes(pageInfoType), pageInfoTypeName) {
+		r.reporter.Report(typeName+".pageInfo", r.definition.FieldDefinitions[pageInfo].Name,
+			"Field '%s.pageInfo' should be of type 'PageInfo!'", typeName)
+	}
+}
+
+// checkEdge checks an edge type once, even if it is shared by multiple connections
+func (r *relayConnectionSpecVisitor) checkEdge(ref int) {
+	if _, checked := r.checkedEdges[ref]; checked {
+		return
+	}
+	r.checkedEdges[ref] = struct{}{}
+
+	node := ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref}
+	name := r.definition.ObjectTypeDefinitions[ref].Name
+	typeName := r.definition.Input.ByteSliceString(name)
+
+	if field, exists := r.definition.NodeFieldDefinitionByName(node, []byte("node")); !exists {
+		r.reporter.Report(typeName, name, "Edge '%s' should have a field 'node'", typeName)
+	} else if r.definition.TypeIsList(r.definition.FieldDefinitionType(field)) {
+		r.reporter.Report(typeName+".node", r.definition.FieldDefinitions[field].Name, "Field '%s.node' should not return a list", typeName)
+	}
+
+	if field, exists := r.definition.NodeFieldDefinitionByName(node, []byte("cursor")); !exists {
+		r.reporter.Report(typeName, name, "Edge '%s' should have a field 'cursor'", typeName)
+	} else if !r.isScalar(r.definition.FieldDefinitionType(field)) {
+		r.reporter.Report(typeName+".cursor", r.definition.FieldDefinitions[field].Name, "Field '%s.cursor' should return a scalar", typeName)
+	}
+}
+
+func (r *relayConnectionSpecVisitor) checkPageInfo(ref int) {
+	node := ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref}
+	name := r.definition.ObjectTypeDefinitions[ref].Name
+
+	for _, fieldName := range []string{"hasPreviousPage", "hasNextPage"} {
+		field, exists := r.definition.NodeFieldDefinitionByName(node, []byte(fieldName))
+		if !exists {
+			r.reporter.Report("PageInfo", name, "PageInfo should have a field '%s' of type 'Boolean!'", fieldName)
+			continue
+		}
+		fieldType := r.definition.FieldDefinitionType(field)
+		if !r.definition.TypeIsNonNull(fieldType) || r.definition.TypeIsList(fieldType) ||
+			!bytes.Equal(r.definition.ResolveTypeNameBytes(fieldType), booleanTypeName) {
+			r.reporter.Report("PageInfo."+fieldName, r.definition.FieldDefinitions[field].Name,
+				"Field 'PageInfo.%s' should be of type 'Boolean!'", fieldName)
+		}
+	}
+
+	for _, fieldName := range []string{"startCursor", "endCursor"} {
+		field, exists := r.definition.NodeFieldDefinitionByName(node, []byte(fieldName))
+		if !exists {
+			r.reporter.Report("PageInfo", name, "PageInfo should have a field '%s'", fieldName)
+			continue
+		}
+		if !r.isScalar(r.definition.FieldDefinitionType(field)) {
+			r.reporter.Report("PageInfo."+fieldName, r.definition.FieldDefinitions[field].Name,
+				"Field 'PageInfo.%s' should return a scalar", fieldName)
+		}
+	}
+}
+
+// objectType returns the object type definition of the named type of a type reference
+func (r *relayConnectionSpecVisitor) objectType(typeRef int) (ref int, exists bool) {
+	node, exists := r.definition.Index.FirstNonExtensionNodeByNameBytes(r.definition.ResolveTypeNameBytes(typeRef))
+	if !exists || node.Kind != ast.NodeKindObjectTypeDefinition {
+		return ast.InvalidRef, false
+	}
+	return node.Ref, true
+}
+
+func (r *relayConnectionSpecVisitor) isConnection(typeRef int) bool {
+	if r.definition.TypeIsList(typeRef) {
+		return false
+	}
+	ref, isObject := r.objectType(typeRef)
+	if !isObject {
+		return false
+	}
+	typeName := r.definition.ObjectTypeDefinitionNameBytes(ref)
+	return bytes.HasSuffix(typeName, connectionTypeSuffix) && !bytes.Equal(typeName, connectionTypeSuffix)
+}
+
+// isScalar returns true for non-list types of a built-in or custom scalar
+func (r *relayConnectionSpecVisitor) isScalar(typeRef int) bool {
+	if r.definition.TypeIsList(typeRef) {
+		return false
+	}
+	typeName := r.definition.ResolveTypeNameBytes(typeRef)
+	if isBuiltInScalar(typeName) {
+		return true
+	}
+	node, exists := r.definition.Index.FirstNonExtensionNodeByNameBytes(typeName)
+	return exists && node.Kind == ast.NodeKindScalarTypeDefinition
+}
+
+func (r *relayConnectionSpecVisitor) hasArgument(fieldDefinition int, name string) bool {
+	for _, ref := range r.definition.FieldDefinitionArgumentsDefinitions(fieldDefinition) {
+		if r.definition.InputValueDefinitionNameString(ref) == name {
+			return true
+		}
+	}
+	return false
+}
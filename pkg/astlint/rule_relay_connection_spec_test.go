@@ -0,0 +1,91 @@
+package astlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayConnectionSpec(t *testing.T) {
+	t.Run("conforming connections", func(t *testing.T) {
+		assert.Empty(t, runLinter(t, `
+			type Query {
+				droids(first: Int, after: Cursor): DroidConnection!
+				humans(last: Int, before: String): HumanConnection
+			}
+			type DroidConnection { edges: [DroidEdge] pageInfo: PageInfo! }
+			type DroidEdge { node: Droid cursor: Cursor! }
+			type HumanConnection { edges: [HumanEdge!]! pageInfo: PageInfo! totalCount: Int }
+			type HumanEdge { node: Human! cursor: String! }
+			type PageInfo { hasPreviousPage: Boolean! hasNextPage: Boolean! startCursor: String endCursor: String }
+			type Droid { name: String }
+			type Human { name: String }
+			type Connection { name: String }
+			scalar Cursor`,
+			RelayConnectionSpec(),
+		))
+	})
+
+	t.Run("connection without edges and page info", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"1:6: warning: Connection 'DroidConnection' should have a field 'edges' returning a list of edges (relay-connection-spec)",
+			"1:6: warning: Connection 'DroidConnection' should have a field 'pageInfo' of type 'PageInfo!' (relay-connection-spec)",
+			"2:6: warning: Connection 'HumanConnection' should have a field 'edges' returning a list of edges (relay-connection-spec)",
+			"2:35: warning: Field 'HumanConnection.pageInfo' should be of type 'PageInfo!' (relay-connection-spec)",
+			"3:27: warning: Field 'StarshipConnection.edges' should return a list of object types (relay-connection-spec)",
+		}, runLinter(t, `type DroidConnection { nodes: [String] }
+type HumanConnection { edges: Int pageInfo: PageInfo }
+type StarshipConnection { edges: [String] pageInfo: PageInfo! }`,
+			RelayConnectionSpec(),
+		))
+	})
+
+	t.Run("edges are checked once", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"3:6: warning: Edge 'DroidEdge' should have a field 'cursor' (relay-connection-spec)",
+			"3:18: warning: Field 'DroidEdge.node' should not return a list (relay-connection-spec)",
+		}, runLinter(t, `type DroidConnection { edges: [DroidEdge] pageInfo: PageInfo! }
+type OtherDroidConnection { edges: [DroidEdge] pageInfo: PageInfo! }
+type DroidEdge { node: [Droid] }
+type Droid { name: String }`,
+			RelayConnectionSpec(),
+		))
+	})
+
+	t.Run("edge without node and cursor", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"2:6: warning: Edge 'DroidEdge' should have a field 'node' (relay-connection-spec)",
+			"2:18: warning: Field 'DroidEdge.cursor' should return a scalar (relay-connection-spec)",
+		}, runLinter(t, `type DroidConnection { edges: [DroidEdge] pageInfo: PageInfo! }
+type DroidEdge { cursor: Droid }
+type Droid { name: String }`,
+			RelayConnectionSpec(),
+		))
+	})
+
+	t.Run("page info", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"1:6: warning: PageInfo should have a field 'hasNextPage' of type 'Boolean!' (relay-connection-spec)",
+			"1:6: warning: PageInfo should have a field 'endCursor' (relay-connection-spec)",
+			"1:17: warning: Field 'PageInfo.hasPreviousPage' should be of type 'Boolean!' (relay-connection-spec)",
+			"1:42: warning: Field 'PageInfo.startCursor' should return a scalar (relay-connection-spec)",
+		}, runLinter(t, `type PageInfo { hasPreviousPage: Boolean startCursor: [String] }`,
+			RelayConnectionSpec(),
+		))
+	})
+
+	t.Run("connection fields without pagination arguments", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"2:2: warning: Connection field 'Query.droids' should have the arguments 'first' and 'after' or 'last' and 'before' (relay-connection-spec)",
+			"3:2: warning: Connection field 'Query.humans' should have the arguments 'first' and 'after' or 'last' and 'before' (relay-connection-spec)",
+		}, runLinter(t, `type Query {
+	droids: DroidConnection
+	humans(first: Int, before: String): DroidConnection!
+	list: [DroidConnection]
+}
+type DroidConnection { edges: [DroidEdge] pageInfo: PageInfo! }
+type DroidEdge { node: String cursor: String }`,
+			RelayConnectionSpec(),
+		))
+	})
+}
@@ -0,0 +1,78 @@
+package astlint
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+)
+
+const RuleRequireDeprecationReason = "require-deprecation-reason"
+
+var (
+	deprecatedDirectiveName = []byte("deprecated")
+	reasonArgumentName      = []byte("reason")
+)
+
+// RequireDeprecationReason reports @deprecated directives on fields, arguments, input fields and enum values
+// which don't state an explicit, non-empty reason
+func RequireDeprecationReason() Rule {
+	return Rule{
+		Name:     RuleRequireDeprecationReason,
+		Severity: SeverityWarning,
+		Register: func(walker *astvisitor.Walker, reporter *Reporter) {
+			visitor := &requireDeprecationReasonVisitor{
+				Walker:   walker,
+				reporter: reporter,
+			}
+
+			walker.RegisterEnterDocumentVisitor(visitor)
+			walker.RegisterEnterFieldDefinitionVisitor(visitor)
+			walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+			walker.RegisterEnterEnumValueDefinitionVisitor(visitor)
+		},
+	}
+}
+
+type requireDeprecationReasonVisitor struct {
+	*astvisitor.Walker
+	reporter   *Reporter
+	definition *ast.Document
+}
+
+func (r *requireDeprecationReasonVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.definition = operation
+}
+
+func (r *requireDeprecationReasonVisitor) EnterFieldDefinition(ref int) {
+	directive, deprecated := r.definition.FieldDefinitionDirectiveByName(ref, deprecatedDirectiveName)
+	if !deprecated {
+		return
+	}
+	r.checkReason(directive, fieldDefinitionCoordinate(r.Walker, r.definition, ref))
+}
+
+func (r *requireDeprecationReasonVisitor) EnterInputValueDefinition(ref int) {
+	directive, deprecated := r.definition.InputValueDefinitionDirectiveByName(ref, deprecatedDirectiveName)
+	if !deprecated {
+		return
+	}
+	r.checkReason(directive, inputValueDefinitionCoordinate(r.Walker, r.definition, ref))
+}
+
+func (r *requireDeprecationReasonVisitor) EnterEnumValueDefinition(ref int) {
+	directive, deprecated := r.definition.EnumValueDefinitionDirectiveByName(ref, deprecatedDirectiveName)
+	if !deprecated {
+		return
+	}
+	enumName := r.definition.NodeNameString(r.Ancestors[len(r.Ancestors)-1])
+	r.checkReason(directive, enumName+"."+r.definition.EnumValueDefinitionNameString(ref))
+}
+
+func (r *requireDeprecationReasonVisitor) checkReason(directive int, coordinate string) {
+	reason, hasReason := r.definition.DirectiveArgumentValueByName(directive, reasonArgumentName)
+	if hasReason && reason.Kind == ast.ValueKindString && len(bytes.TrimSpace(r.definition.StringValueContentBytes(reason.Ref))) != 0 {
+		return
+	}
+	r.reporter.Report(coordinate, r.definition.Directives[directive].Name, "Deprecation of '%s' should have a reason", coordinate)
+}
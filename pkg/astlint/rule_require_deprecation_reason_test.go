@@ -0,0 +1,37 @@
+package astlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireDeprecationReason(t *testing.T) {
+	t.Run("deprecations with reason", func(t *testing.T) {
+		assert.Empty(t, runLinter(t, `
+			type Query {
+				droid(id: ID @deprecated(reason: "use name")): String @deprecated(reason: "use character")
+			}
+			input Filter { name: String @deprecated(reason: """use nameContains""") }
+			enum Episode { NEWHOPE @deprecated(reason: "use A_NEW_HOPE") }`,
+			RequireDeprecationReason(),
+		))
+	})
+
+	t.Run("deprecations without reason", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"2:16: warning: Deprecation of 'Query.droid(id:)' should have a reason (require-deprecation-reason)",
+			"2:49: warning: Deprecation of 'Query.droid' should have a reason (require-deprecation-reason)",
+			"4:30: warning: Deprecation of 'Filter.name' should have a reason (require-deprecation-reason)",
+			"5:25: warning: Deprecation of 'Episode.NEWHOPE' should have a reason (require-deprecation-reason)",
+			"6:31: warning: Deprecation of '@auth(role:)' should have a reason (require-deprecation-reason)",
+		}, runLinter(t, `type Query {
+	droid(id: ID @deprecated(reason: "")): String @deprecated
+}
+input Filter { name: String @deprecated(reason: "  ") }
+enum Episode { NEWHOPE @deprecated }
+directive @auth(role: String @deprecated) on FIELD_DEFINITION`,
+			RequireDeprecationReason(),
+		))
+	})
+}
@@ -0,0 +1,74 @@
+package astlint
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+)
+
+const RuleRequireDescriptions = "require-descriptions"
+
+// RequireDescriptions reports object, interface, union, enum, input object and scalar types without a description.
+// Introspection types and the built-in scalars are skipped.
+func RequireDescriptions() Rule {
+	return Rule{
+		Name:     RuleRequireDescriptions,
+		Severity: SeverityWarning,
+		Register: func(walker *astvisitor.Walker, reporter *Reporter) {
+			visitor := &requireDescriptionsVisitor{
+				reporter: reporter,
+			}
+
+			walker.RegisterEnterDocumentVisitor(visitor)
+			walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterInterfaceTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterUnionTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterScalarTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterEnumTypeDefinitionVisitor(visitor)
+			walker.RegisterEnterInputObjectTypeDefinitionVisitor(visitor)
+		},
+	}
+}
+
+type requireDescriptionsVisitor struct {
+	reporter   *Reporter
+	definition *ast.Document
+}
+
+func (r *requireDescriptionsVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.definition = operation
+}
+
+func (r *requireDescriptionsVisitor) EnterObjectTypeDefinition(ref int) {
+	r.checkDescription("Object type", r.definition.ObjectTypeDefinitions[ref].Name, r.definition.ObjectTypeDefinitions[ref].Description)
+}
+
+func (r *requireDescriptionsVisitor) EnterInterfaceTypeDefinition(ref int) {
+	r.checkDescription("Interface", r.definition.InterfaceTypeDefinitions[ref].Name, r.definition.InterfaceTypeDefinitions[ref].Description)
+}
+
+func (r *requireDescriptionsVisitor) EnterUnionTypeDefinition(ref int) {
+	r.checkDescription("Union", r.definition.UnionTypeDefinitions[ref].Name, r.definition.UnionTypeDefinitions[ref].Description)
+}
+
+func (r *requireDescriptionsVisitor) EnterScalarTypeDefinition(ref int) {
+	r.checkDescription("Scalar", r.definition.ScalarTypeDefinitions[ref].Name, r.definition.ScalarTypeDefinitions[ref].Description)
+}
+
+func (r *requireDescriptionsVisitor) EnterEnumTypeDefinition(ref int) {
+	r.checkDescription("Enum", r.definition.EnumTypeDefinitions[ref].Name, r.definition.EnumTypeDefinitions[ref].Description)
+}
+
+func (r *requireDescriptionsVisitor) EnterInputObjectTypeDefinition(ref int) {
+	r.checkDescription("Input object", r.definition.InputObjectTypeDefinitions[ref].Name, r.definition.InputObjectTypeDefinitions[ref].Description)
+}
+
+func (r *requireDescriptionsVisitor) checkDescription(kind string, name ast.ByteSliceReference, description ast.Description) {
+	if description.IsDefined && description.Content.Length() != 0 {
+		return
+	}
+	typeName := r.definition.Input.ByteSlice(name)
+	if isReservedName(typeName) || isBuiltInScalar(typeName) {
+		return
+	}
+	r.reporter.Report(string(typeName), name, "%s '%s' should have a description", kind, typeName)
+}
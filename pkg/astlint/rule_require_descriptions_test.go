@@ -0,0 +1,44 @@
+package astlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireDescriptions(t *testing.T) {
+	t.Run("types with descriptions", func(t *testing.T) {
+		assert.Empty(t, runLinter(t, `
+			"The query type"
+			type Query { a: String }
+			"""
+			A node
+			"""
+			interface Node { id: ID! }
+			"A search result" union SearchResult = Query
+			"A date" scalar DateTime
+			"An episode" enum Episode { NEWHOPE }
+			"A filter" input Filter { a: String }
+			scalar String`,
+			RequireDescriptions(),
+		))
+	})
+
+	t.Run("types without descriptions", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"1:6: warning: Object type 'Query' should have a description (require-descriptions)",
+			"2:11: warning: Interface 'Node' should have a description (require-descriptions)",
+			"3:7: warning: Union 'SearchResult' should have a description (require-descriptions)",
+			"4:8: warning: Scalar 'DateTime' should have a description (require-descriptions)",
+			"5:6: warning: Enum 'Episode' should have a description (require-descriptions)",
+			"6:10: warning: Input object 'Filter' should have a description (require-descriptions)",
+		}, runLinter(t, `type Query { a: String }
+interface Node { id: ID! }
+union SearchResult = Query
+scalar DateTime
+enum Episode { NEWHOPE }
+"" input Filter { a: String }`,
+			RequireDescriptions(),
+		))
+	})
+}
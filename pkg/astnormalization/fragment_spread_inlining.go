@@ -55,7 +55,9 @@ func (f *fragmentSpreadInlineVisitor) EnterFragmentSpread(ref int) {
 	fragmentTypeName := f.operation.FragmentDefinitionTypeName(fragmentDefinitionRef)
 	fragmentNode, exists := f.definition.NodeByName(fragmentTypeName)
 	if !exists {
-		f.StopWithExternalErr(operationreport.ErrTypeUndefined(fragmentTypeName))
+		err := operationreport.ErrTypeUndefined(fragmentTypeName)
+		err.Locations = operationreport.LocationsFromPosition(f.operation.Types[f.operation.FragmentDefinitions[fragmentDefinitionRef].TypeCondition.Type].Position)
+		f.StopWithExternalErr(err)
 		return
 	}
 
@@ -124,6 +124,30 @@ func TestExtendObjectType(t *testing.T) {
 					}
 					`)
 	})
+	t.Run("extend object type by interface it already implements", func(t *testing.T) {
+		run(extendObjectTypeDefinition, testDefinition, `
+					type Dog implements ToyLover {
+						name: String
+					}
+					extend type Dog implements ToyLover {
+						favoriteToy: String
+					}
+					interface ToyLover {
+						favoriteToy: String
+					}
+					 `, `
+					type Dog implements ToyLover {
+						name: String
+						favoriteToy: String
+					}
+					extend type Dog implements ToyLover {
+						favoriteToy: String
+					}
+					interface ToyLover {
+						favoriteToy: String
+					}
+					`)
+	})
 	t.Run("extend object type which implements interface by interface", func(t *testing.T) {
 		run(extendObjectTypeDefinition, testDefinition, `
 					type Dog implements ToyHater {
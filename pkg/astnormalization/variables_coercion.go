@@ -0,0 +1,438 @@
+package astnormalization
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// CoerceVariableValues validates the variables of the operation against its variable definitions
+// and coerces them as defined by the CoerceVariableValues algorithm of the spec:
+// a single value is coerced to a list of one item, an integral Float value to an Int
+// and an Int value to an ID string. Default values of variables and input object fields are applied.
+// The coerced values replace the variables of the operation; variables which are not defined by the operation are kept as is.
+// Every invalid variable is reported as an external error with the code operationreport.ErrCodeBadUserInput.
+// Literal values which the normalization has extracted into variables are reported like the validation of values does,
+// at the position of the value in the operation and without naming the extracted variable.
+// If the document contains more than one operation, operationName selects the operation.
+func CoerceVariableValues(operation, definition *ast.Document, operationName []byte, report *operationreport.Report) {
+	operationRef, ok := coercedOperation(operation, operationName)
+	if !ok || !operation.OperationDefinitions[operationRef].HasVariableDefinitions {
+		return
+	}
+
+	coercion := &variablesCoercion{
+		operation:  operation,
+		definition: definition,
+		report:     report,
+	}
+
+	variables := operation.Input.Variables
+	if len(bytes.TrimSpace(variables)) == 0 {
+		variables = []byte("{}")
+	}
+	for _, variableDefinitionRef := range operation.OperationDefinitions[operationRef].VariableDefinitions.Refs {
+		coerced, ok := coercion.coerceVariable(variableDefinitionRef, variables)
+		if !ok {
+			continue
+		}
+		updated, err := jsonparser.Set(variables, coerced, operation.VariableDefinitionNameString(variableDefinitionRef))
+		if err != nil {
+			report.AddInternalError(err)
+			return
+		}
+		variables = updated
+	}
+
+	if !report.HasErrors() {
+		operation.Input.Variables = variables
+	}
+}
+
+func coercedOperation(operation *ast.Document, operationName []byte) (int, bool) {
+	operationRef := -1
+	for i := range operation.RootNodes {
+		if operation.RootNodes[i].Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		ref := operation.RootNodes[i].Ref
+		if len(operationName) == 0 {
+			if operationRef != -1 {
+				// the operation is ambiguous, the validation of the operation reports it
+				return -1, false
+			}
+			operationRef = ref
+			continue
+		}
+		if bytes.Equal(operation.OperationDefinitionNameBytes(ref), operationName) {
+			return ref, true
+		}
+	}
+	return operationRef, operationRef != -1
+}
+
+type variablesCoercion struct {
+	operation, definition *ast.Document
+	report                *operationreport.Report
+
+	variableDefinitionRef int
+}
+
+// coerceVariable returns the coerced value of a variable and false if the variable has no value or is invalid
+func (c *variablesCoercion) coerceVariable(variableDefinitionRef int, variables []byte) ([]byte, bool) {
+	c.variableDefinitionRef = variableDefinitionRef
+	variableName := c.operation.VariableDefinitionNameString(variableDefinitionRef)
+	typeRef := c.operation.VariableDefinitions[variableDefinitionRef].Type
+
+	value, valueType, _, err := jsonparser.Get(variables, variableName)
+	if err == jsonparser.KeyPathNotFoundError {
+		if c.operation.VariableDefinitionHasDefaultValue(variableDefinitionRef) {
+			defaultValue, err := c.operation.ValueToJSON(c.operation.VariableDefinitionDefaultValue(variableDefinitionRef))
+			if err != nil {
+				c.report.AddInternalError(err)
+				return nil, false
+			}
+			value, valueType, _, err = jsonparser.Get(defaultValue)
+			if err != nil {
+				c.report.AddInternalError(err)
+				return nil, false
+			}
+		} else {
+			if c.operation.TypeIsNonNull(typeRef) {
+				c.addError(operationreport.ErrVariableOfRequiredTypeNotProvided(variableName, c.printType(c.operation, typeRef)))
+			}
+			return nil, false
+		}
+	} else if err != nil {
+		c.report.AddInternalError(err)
+		return nil, false
+	}
+
+	if valueType == jsonparser.Null {
+		if c.operation.TypeIsNonNull(typeRef) {
+			if c.isExtractedVariable() {
+				c.addError(operationreport.ErrValueInvalid(fmt.Sprintf(operationreport.NullValueErrMsg, c.printType(c.operation, typeRef))))
+			} else {
+				c.addError(operationreport.ErrVariableOfNonNullTypeMustNotBeNull(variableName, c.printType(c.operation, typeRef)))
+			}
+			return nil, false
+		}
+		return value, true
+	}
+
+	out := &bytes.Buffer{}
+	if !c.coerceValue(c.operation, typeRef, value, valueType, []string{variableName}, out) {
+		return nil, false
+	}
+	return out.Bytes(), true
+}
+
+// coerceValue writes the coerced value of the type to out, the type is either a type of the operation or of the definition
+func (c *variablesCoercion) coerceValue(document *ast.Document, typeRef int, value []byte, valueType jsonparser.ValueType, path []string, out *bytes.Buffer) bool {
+	switch document.Types[typeRef].TypeKind {
+	case ast.TypeKindNonNull:
+		if valueType == jsonparser.Null {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Expected non-nullable type "%s" not to be null.`, c.printType(document, typeRef)))
+			return false
+		}
+		return c.coerceValue(document, document.Types[typeRef].OfType, value, valueType, path, out)
+	case ast.TypeKindList:
+		if valueType == jsonparser.Null {
+			out.Write(value)
+			return true
+		}
+		itemTypeRef := document.Types[typeRef].OfType
+		out.WriteByte('[')
+		if valueType != jsonparser.Array {
+			// a single value is coerced to a list of one item
+			valid := c.coerceValue(document, itemTypeRef, value, valueType, path, out)
+			out.WriteByte(']')
+			return valid
+		}
+		valid := true
+		i := 0
+		_, _ = jsonparser.ArrayEach(value, func(item []byte, itemType jsonparser.ValueType, _ int, _ error) {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			itemPath := append(path[:len(path):len(path)], strconv.Itoa(i))
+			if !c.coerceValue(document, itemTypeRef, item, itemType, itemPath, out) {
+				valid = false
+			}
+			i++
+		})
+		out.WriteByte(']')
+		return valid
+	}
+
+	if valueType == jsonparser.Null {
+		out.Write(value)
+		return true
+	}
+
+	typeName := document.TypeNameBytes(typeRef)
+	node, exists := c.definition.Index.FirstNodeByNameBytes(typeName)
+	if !exists {
+		c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.UnknownTypeErrMsg, typeName))
+		return false
+	}
+
+	switch node.Kind {
+	case ast.NodeKindScalarTypeDefinition:
+		return c.coerceScalar(string(typeName), value, valueType, path, out)
+	case ast.NodeKindEnumTypeDefinition:
+		if valueType != jsonparser.String {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Enum "%s" cannot represent non-string value: %s.`, typeName, c.printValue(value, valueType)))
+			return false
+		}
+		if !c.definition.EnumTypeDefinitionContainsEnumValue(node.Ref, value) {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Value "%s" does not exist in "%s" enum.`, value, typeName))
+			return false
+		}
+		c.writeString(value, out)
+		return true
+	case ast.NodeKindInputObjectTypeDefinition:
+		return c.coerceInputObject(node.Ref, value, valueType, path, out)
+	default:
+		c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Type "%s" is not an input type.`, typeName))
+		return false
+	}
+}
+
+func (c *variablesCoercion) coerceScalar(typeName string, value []byte, valueType jsonparser.ValueType, path []string, out *bytes.Buffer) bool {
+	switch typeName {
+	case "Int":
+		integer, ok := c.integerValue(value, valueType)
+		if !ok {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.NotIntegerErrMsg, typeName, c.printValue(value, valueType)))
+			return false
+		}
+		if integer > math.MaxInt32 || integer < math.MinInt32 {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.BigIntegerErrMsg, typeName, c.printValue(value, valueType)))
+			return false
+		}
+		out.WriteString(strconv.FormatInt(integer, 10))
+	case "Float":
+		// an Int value is a valid Float value
+		if valueType != jsonparser.Number {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.NotFloatErrMsg, typeName, c.printValue(value, valueType)))
+			return false
+		}
+		out.Write(value)
+	case "String":
+		if valueType != jsonparser.String {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.NotStringErrMsg, typeName, c.printValue(value, valueType)))
+			return false
+		}
+		c.writeString(value, out)
+	case "Boolean":
+		if valueType != jsonparser.Boolean {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.NotBooleanErrMsg, typeName, c.printValue(value, valueType)))
+			return false
+		}
+		out.Write(value)
+	case "ID":
+		switch valueType {
+		case jsonparser.String:
+			c.writeString(value, out)
+		case jsonparser.Number:
+			// an Int value is coerced to the string of the ID
+			integer, ok := c.integerValue(value, valueType)
+			if !ok {
+				c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.NotIDErrMsg, typeName, c.printValue(value, valueType)))
+				return false
+			}
+			out.WriteString(strconv.Quote(strconv.FormatInt(integer, 10)))
+		default:
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.NotIDErrMsg, typeName, c.printValue(value, valueType)))
+			return false
+		}
+	default:
+		// custom scalars accept any value
+		c.writeValue(value, valueType, out)
+	}
+	return true
+}
+
+func (c *variablesCoercion) coerceInputObject(inputObjectRef int, value []byte, valueType jsonparser.ValueType, path []string, out *bytes.Buffer) bool {
+	typeName := c.definition.InputObjectTypeDefinitionNameString(inputObjectRef)
+	if valueType != jsonparser.Object {
+		c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Expected type "%s" to be an object.`, typeName))
+		return false
+	}
+
+	valid := true
+	_ = jsonparser.ObjectEach(value, func(key []byte, _ []byte, _ jsonparser.ValueType, _ int) error {
+		if c.definition.InputObjectTypeDefinitionInputValueDefinitionByName(inputObjectRef, key) == -1 {
+			c.addInvalidValue(path, value, valueType, fmt.Sprintf(operationreport.UnknownFieldOfInputObjectErrMsg, key, typeName))
+			valid = false
+		}
+		return nil
+	})
+
+	if c.definition.InputObjectTypeDefinitionIsOneOf(inputObjectRef) && !c.validateOneOfInputObject(typeName, value, valueType, path) {
+		valid = false
+	}
+
+	out.WriteByte('{')
+	written := 0
+	for _, inputValueDefinitionRef := range c.definition.InputObjectTypeDefinitions[inputObjectRef].InputFieldsDefinition.Refs {
+		fieldName := c.definition.InputValueDefinitionNameString(inputValueDefinitionRef)
+		fieldTypeRef := c.definition.InputValueDefinitionType(inputValueDefinitionRef)
+
+		fieldValue, fieldValueType, _, err := jsonparser.Get(value, fieldName)
+		if err == jsonparser.KeyPathNotFoundError {
+			if c.definition.InputValueDefinitionHasDefaultValue(inputValueDefinitionRef) {
+				defaultValue, err := c.definition.ValueToJSON(c.definition.InputValueDefinitionDefaultValue(inputValueDefinitionRef))
+				if err != nil {
+					c.report.AddInternalError(err)
+					return false
+				}
+				fieldValue, fieldValueType, _, _ = jsonparser.Get(defaultValue)
+			} else {
+				if c.definition.TypeIsNonNull(fieldTypeRef) {
+					c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Field "%s" of required type "%s" was not provided.`, fieldName, c.printType(c.definition, fieldTypeRef)))
+					valid = false
+				}
+				continue
+			}
+		}
+
+		if written > 0 {
+			out.WriteByte(',')
+		}
+		out.WriteString(strconv.Quote(fieldName))
+		out.WriteByte(':')
+		fieldPath := append(path[:len(path):len(path)], fieldName)
+		if !c.coerceValue(c.definition, fieldTypeRef, fieldValue, fieldValueType, fieldPath, out) {
+			valid = false
+		}
+		written++
+	}
+	out.WriteByte('}')
+	return valid
+}
+
+// validateOneOfInputObject validates if the value of a OneOf Input Object contains exactly one non-null field
+func (c *variablesCoercion) validateOneOfInputObject(typeName string, value []byte, valueType jsonparser.ValueType, path []string) bool {
+	var fieldName []byte
+	var fieldValueType jsonparser.ValueType
+	fieldCount := 0
+	_ = jsonparser.ObjectEach(value, func(key []byte, _ []byte, dataType jsonparser.ValueType, _ int) error {
+		fieldName, fieldValueType = key, dataType
+		fieldCount++
+		return nil
+	})
+
+	if fieldCount != 1 {
+		if c.isExtractedVariable() {
+			// a literal value is reported like the validation of OneOf Input Objects does
+			c.addError(operationreport.ErrValueInvalid(fmt.Sprintf(operationreport.OneOfInputObjectKeyCountErrMsg, typeName)))
+			return false
+		}
+		c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Exactly one key must be specified for OneOf type "%s".`, typeName))
+		return false
+	}
+	if fieldValueType == jsonparser.Null {
+		if c.isExtractedVariable() {
+			c.addError(operationreport.ErrValueInvalid(fmt.Sprintf(operationreport.OneOfInputObjectNullFieldErrMsg, typeName, fieldName)))
+			return false
+		}
+		c.addInvalidValue(path, value, valueType, fmt.Sprintf(`Field "%s" must be non-null.`, fieldName))
+		return false
+	}
+	return true
+}
+
+// integerValue returns the value of a number without fractional part
+func (c *variablesCoercion) integerValue(value []byte, valueType jsonparser.ValueType) (int64, bool) {
+	if valueType != jsonparser.Number {
+		return 0, false
+	}
+	if integer, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+		return integer, true
+	}
+	float, err := strconv.ParseFloat(string(value), 64)
+	if err != nil || float != math.Trunc(float) || math.Abs(float) > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(float), true
+}
+
+func (c *variablesCoercion) writeString(value []byte, out *bytes.Buffer) {
+	out.WriteByte('"')
+	out.Write(value)
+	out.WriteByte('"')
+}
+
+func (c *variablesCoercion) writeValue(value []byte, valueType jsonparser.ValueType, out *bytes.Buffer) {
+	if valueType == jsonparser.String {
+		c.writeString(value, out)
+		return
+	}
+	out.Write(value)
+}
+
+func (c *variablesCoercion) printValue(value []byte, valueType jsonparser.ValueType) string {
+	if valueType == jsonparser.String {
+		return `"` + string(value) + `"`
+	}
+	return string(value)
+}
+
+func (c *variablesCoercion) printType(document *ast.Document, typeRef int) string {
+	printed, err := document.PrintTypeBytes(typeRef, nil)
+	if err != nil {
+		return document.ResolveTypeNameString(typeRef)
+	}
+	return string(printed)
+}
+
+func (c *variablesCoercion) addInvalidValue(path []string, value []byte, valueType jsonparser.ValueType, reason string) {
+	if c.isExtractedVariable() {
+		c.addError(operationreport.ErrValueInvalid(reason))
+		return
+	}
+	c.addError(operationreport.ErrVariableValueInvalid(path[0], c.printPath(path), c.printValue(value, valueType), reason))
+}
+
+func (c *variablesCoercion) addError(err operationreport.ExternalError) {
+	variableValue := c.operation.VariableDefinitions[c.variableDefinitionRef].VariableValue
+	if c.isExtractedVariable() {
+		err.Locations = operationreport.LocationsFromPosition(variableValue.Position)
+	} else {
+		err.Locations = operationreport.LocationsFromPosition(c.operation.VariableValues[variableValue.Ref].Dollar)
+	}
+	c.report.AddExternalError(err)
+}
+
+// isExtractedVariable returns true if the normalization has extracted the variable from a literal value,
+// such a variable is unknown to the client, so that its errors are reported at the position of the literal value
+func (c *variablesCoercion) isExtractedVariable() bool {
+	// only the variable definitions of the operation document have a position
+	return c.operation.VariableDefinitions[c.variableDefinitionRef].Colon.LineStart == 0
+}
+
+// printPath prints the path of an invalid value within a variable, e.g. input.items[0].name
+func (c *variablesCoercion) printPath(path []string) string {
+	if len(path) < 2 {
+		return ""
+	}
+	var builder strings.Builder
+	builder.WriteString(path[0])
+	for _, element := range path[1:] {
+		if _, err := strconv.Atoi(element); err == nil {
+			builder.WriteString("[" + element + "]")
+			continue
+		}
+		builder.WriteString("." + element)
+	}
+	return builder.String()
+}
@@ -0,0 +1,217 @@
+package astnormalization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+const testVariablesCoercionSchema = `
+schema {
+  query: Query
+}
+
+type Query {
+  int(in: Int): String
+  float(in: Float): String
+  id(in: ID): String
+  ids(in: [ID!]): String
+  enum(in: Color): String
+  input(in: ItemsInput!): String
+  custom(in: JSON): String
+  oneOf(in: ItemBy): String
+}
+
+scalar JSON
+
+enum Color {
+  RED
+  GREEN
+}
+
+input ItemsInput {
+  items: [ItemInput!]!
+  limit: Int = 10
+}
+
+input ItemBy @oneOf {
+  name: String
+  id: ID
+}
+
+input ItemInput {
+  name: String!
+  color: Color
+}
+`
+
+func TestCoerceVariableValues(t *testing.T) {
+	run := func(t *testing.T, operation, variables, expectedVariables string, expectedErrors ...string) {
+		t.Helper()
+
+		definitionDocument := unsafeparser.ParseGraphqlDocumentString(testVariablesCoercionSchema)
+		require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definitionDocument))
+
+		operationDocument := unsafeparser.ParseGraphqlDocumentString(operation)
+		operationDocument.Input.Variables = []byte(variables)
+
+		report := operationreport.Report{}
+		CoerceVariableValues(&operationDocument, &definitionDocument, nil, &report)
+
+		if len(expectedErrors) == 0 {
+			require.False(t, report.HasErrors(), report.Error())
+			assert.JSONEq(t, expectedVariables, string(operationDocument.Input.Variables))
+			return
+		}
+
+		require.Len(t, report.ExternalErrors, len(expectedErrors))
+		for i := range expectedErrors {
+			assert.Equal(t, expectedErrors[i], report.ExternalErrors[i].Message)
+			assert.Equal(t, operationreport.ErrCodeBadUserInput, report.ExternalErrors[i].Code)
+			assert.NotEmpty(t, report.ExternalErrors[i].Locations)
+		}
+		assert.Equal(t, variables, string(operationDocument.Input.Variables), "variables must not change on errors")
+	}
+
+	t.Run("valid variables are kept", func(t *testing.T) {
+		run(t, `query($a: Int, $b: Float) { int(in: $a) float(in: $b) }`,
+			`{"a":1,"b":1.5}`,
+			`{"a":1,"b":1.5}`)
+	})
+	t.Run("variables not defined by the operation are kept", func(t *testing.T) {
+		run(t, `query($a: Int) { int(in: $a) }`,
+			`{"a":1,"other":true}`,
+			`{"a":1,"other":true}`)
+	})
+	t.Run("missing nullable variable", func(t *testing.T) {
+		run(t, `query($a: Int) { int(in: $a) }`, `{}`, `{}`)
+	})
+	t.Run("missing non-null variable", func(t *testing.T) {
+		run(t, `query($in: ItemsInput!) { input(in: $in) }`, `{}`, ``,
+			`Variable "$in" of required type "ItemsInput!" was not provided.`)
+	})
+	t.Run("null for non-null variable", func(t *testing.T) {
+		run(t, `query($in: ItemsInput!) { input(in: $in) }`, `{"in":null}`, ``,
+			`Variable "$in" of non-null type "ItemsInput!" must not be null.`)
+	})
+	t.Run("variable default value is applied", func(t *testing.T) {
+		run(t, `query($a: Int = 3) { int(in: $a) }`, `{}`, `{"a":3}`)
+	})
+	t.Run("Int", func(t *testing.T) {
+		t.Run("string is invalid", func(t *testing.T) {
+			run(t, `query($a: Int) { int(in: $a) }`, `{"a":"1"}`, ``,
+				`Variable "$a" got invalid value "1"; Int cannot represent non-integer value: "1"`)
+		})
+		t.Run("fraction is invalid", func(t *testing.T) {
+			run(t, `query($a: Int) { int(in: $a) }`, `{"a":1.5}`, ``,
+				`Variable "$a" got invalid value 1.5; Int cannot represent non-integer value: 1.5`)
+		})
+		t.Run("out of 32 bit range is invalid", func(t *testing.T) {
+			run(t, `query($a: Int) { int(in: $a) }`, `{"a":2147483648}`, ``,
+				`Variable "$a" got invalid value 2147483648; Int cannot represent non 32-bit signed integer value: 2147483648`)
+		})
+		t.Run("integral float is coerced", func(t *testing.T) {
+			run(t, `query($a: Int) { int(in: $a) }`, `{"a":1.0}`, `{"a":1}`)
+		})
+	})
+	t.Run("Int is coerced to Float", func(t *testing.T) {
+		run(t, `query($a: Float) { float(in: $a) }`, `{"a":1}`, `{"a":1}`)
+	})
+	t.Run("Int is coerced to ID", func(t *testing.T) {
+		run(t, `query($a: ID) { id(in: $a) }`, `{"a":42}`, `{"a":"42"}`)
+	})
+	t.Run("single value is coerced to a list", func(t *testing.T) {
+		run(t, `query($a: [ID!]) { ids(in: $a) }`, `{"a":"1"}`, `{"a":["1"]}`)
+	})
+	t.Run("list items are coerced", func(t *testing.T) {
+		run(t, `query($a: [ID!]) { ids(in: $a) }`, `{"a":[1,"2"]}`, `{"a":["1","2"]}`)
+	})
+	t.Run("null list item of non-null type", func(t *testing.T) {
+		run(t, `query($a: [ID!]) { ids(in: $a) }`, `{"a":["1",null]}`, ``,
+			`Variable "$a" got invalid value null at "a[1]"; Expected non-nullable type "ID!" not to be null.`)
+	})
+	t.Run("enum", func(t *testing.T) {
+		t.Run("valid value", func(t *testing.T) {
+			run(t, `query($a: Color) { enum(in: $a) }`, `{"a":"RED"}`, `{"a":"RED"}`)
+		})
+		t.Run("unknown value", func(t *testing.T) {
+			run(t, `query($a: Color) { enum(in: $a) }`, `{"a":"BLUE"}`, ``,
+				`Variable "$a" got invalid value "BLUE"; Value "BLUE" does not exist in "Color" enum.`)
+		})
+	})
+	t.Run("custom scalar accepts any value", func(t *testing.T) {
+		run(t, `query($a: JSON) { custom(in: $a) }`, `{"a":{"any":[1,"two"]}}`, `{"a":{"any":[1,"two"]}}`)
+	})
+	t.Run("input object", func(t *testing.T) {
+		t.Run("field default values are applied", func(t *testing.T) {
+			run(t, `query($in: ItemsInput!) { input(in: $in) }`,
+				`{"in":{"items":{"name":"a"}}}`,
+				`{"in":{"items":[{"name":"a"}],"limit":10}}`)
+		})
+		t.Run("unknown field", func(t *testing.T) {
+			run(t, `query($in: ItemsInput!) { input(in: $in) }`,
+				`{"in":{"items":[],"unknown":1}}`, ``,
+				`Variable "$in" got invalid value {"items":[],"unknown":1}; Field "unknown" is not defined by type "ItemsInput".`)
+		})
+		t.Run("missing required field", func(t *testing.T) {
+			run(t, `query($in: ItemsInput!) { input(in: $in) }`,
+				`{"in":{"limit":1}}`, ``,
+				`Variable "$in" got invalid value {"limit":1}; Field "items" of required type "[ItemInput!]!" was not provided.`)
+		})
+		t.Run("invalid nested value", func(t *testing.T) {
+			run(t, `query($in: ItemsInput!) { input(in: $in) }`,
+				`{"in":{"items":[{"name":"a"},{"name":"b","color":"BLUE"}]}}`, ``,
+				`Variable "$in" got invalid value "BLUE" at "in.items[1].color"; Value "BLUE" does not exist in "Color" enum.`)
+		})
+		t.Run("not an object", func(t *testing.T) {
+			run(t, `query($in: ItemsInput!) { input(in: $in) }`, `{"in":"a"}`, ``,
+				`Variable "$in" got invalid value "a"; Expected type "ItemsInput" to be an object.`)
+		})
+	})
+	t.Run("oneOf input object", func(t *testing.T) {
+		t.Run("exactly one field", func(t *testing.T) {
+			run(t, `query($in: ItemBy) { oneOf(in: $in) }`, `{"in":{"id":1}}`, `{"in":{"id":"1"}}`)
+		})
+		t.Run("no fields", func(t *testing.T) {
+			run(t, `query($in: ItemBy) { oneOf(in: $in) }`, `{"in":{}}`, ``,
+				`Variable "$in" got invalid value {}; Exactly one key must be specified for OneOf type "ItemBy".`)
+		})
+		t.Run("more than one field", func(t *testing.T) {
+			run(t, `query($in: ItemBy) { oneOf(in: $in) }`, `{"in":{"id":1,"name":"a"}}`, ``,
+				`Variable "$in" got invalid value {"id":1,"name":"a"}; Exactly one key must be specified for OneOf type "ItemBy".`)
+		})
+		t.Run("null field", func(t *testing.T) {
+			run(t, `query($in: ItemBy) { oneOf(in: $in) }`, `{"in":{"name":null}}`, ``,
+				`Variable "$in" got invalid value {"name":null}; Field "name" must be non-null.`)
+		})
+	})
+	t.Run("extracted literal values are reported at their position", func(t *testing.T) {
+		definitionDocument := unsafeparser.ParseGraphqlDocumentString(testVariablesCoercionSchema)
+		require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definitionDocument))
+
+		operationDocument := unsafeparser.ParseGraphqlDocumentString(`query($a: Int) { int(in: $a) float(in: "x") }`)
+		operationDocument.Input.Variables = []byte(`{"a":1}`)
+
+		report := operationreport.Report{}
+		NewWithOpts(WithExtractVariables()).NormalizeOperation(&operationDocument, &definitionDocument, &report)
+		require.False(t, report.HasErrors(), report.Error())
+
+		CoerceVariableValues(&operationDocument, &definitionDocument, nil, &report)
+		require.Len(t, report.ExternalErrors, 1)
+		assert.Equal(t, `Float cannot represent non numeric value: "x"`, report.ExternalErrors[0].Message)
+		assert.Empty(t, report.ExternalErrors[0].Code)
+		require.Len(t, report.ExternalErrors[0].Locations, 1)
+		assert.Equal(t, uint32(1), report.ExternalErrors[0].Locations[0].Line)
+		assert.Equal(t, uint32(40), report.ExternalErrors[0].Locations[0].Column)
+	})
+	t.Run("all invalid variables are reported", func(t *testing.T) {
+		run(t, `query($a: Int, $b: Float) { int(in: $a) float(in: $b) }`, `{"a":true,"b":"x"}`, ``,
+			`Variable "$a" got invalid value true; Int cannot represent non-integer value: true`,
+			`Variable "$b" got invalid value "x"; Float cannot represent non numeric value: "x"`)
+	})
+}
@@ -67,6 +67,7 @@ func (v *variablesExtractionVisitor) EnterArgument(ref int) {
 	}
 
 	variableNameBytes := v.operation.GenerateUnusedVariableDefinitionName(v.Ancestors[0].Ref)
+	valuePosition := v.operation.Arguments[ref].Value.Position
 	valueBytes, err := v.operation.ValueToJSON(v.operation.Arguments[ref].Value)
 	if err != nil {
 		return
@@ -101,6 +102,8 @@ func (v *variablesExtractionVisitor) EnterArgument(ref int) {
 		VariableValue: ast.Value{
 			Kind: ast.ValueKindVariable,
 			Ref:  varRef,
+			// the position of the extracted value allows to report an invalid value at its location in the operation
+			Position: valuePosition,
 		},
 		Type: importedDefType,
 	})
@@ -186,6 +189,8 @@ func (v *variablesExtractionVisitor) extractObjectValue(objectField int, fieldVa
 		VariableValue: ast.Value{
 			Kind: ast.ValueKindVariable,
 			Ref:  varRef,
+			// the position of the extracted value allows to report an invalid value at its location in the operation
+			Position: fieldValue.Position,
 		},
 		Type: importedDefType,
 	})
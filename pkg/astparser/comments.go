@@ -0,0 +1,156 @@
+package astparser
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// ParseGraphqlDocumentStringWithComments is the same as ParseGraphqlDocumentString
+// but additionally attaches all '#' comments and blank lines of the input to the nodes of the AST.
+func ParseGraphqlDocumentStringWithComments(input string) (ast.Document, operationreport.Report) {
+	return ParseGraphqlDocumentBytesWithComments([]byte(input))
+}
+
+// ParseGraphqlDocumentBytesWithComments is the same as ParseGraphqlDocumentBytes
+// but additionally attaches all '#' comments and blank lines of the input to the nodes of the AST.
+func ParseGraphqlDocumentBytesWithComments(input []byte) (ast.Document, operationreport.Report) {
+	parser := NewParserWithComments()
+	doc := *ast.NewDocument()
+	doc.Input.ResetInputBytes(input)
+	report := operationreport.Report{}
+	parser.Parse(&doc, &report)
+	return doc, report
+}
+
+// NewParserWithComments returns a new parser which attaches all '#' comments and blank lines of the input
+// to the nearest node of the AST, see ast.Document.Comments.
+// Keeping comments is slower than regular parsing, so it should only be used for tooling, not in the hot path.
+func NewParserWithComments() *Parser {
+	parser := NewParser()
+	parser.keepComments = true
+	return parser
+}
+
+// nodeSpan describes the range of tokens a node was parsed from
+type nodeSpan struct {
+	node  ast.Node
+	start int
+	end   int
+}
+
+// startNode returns the index of the first token of the node which is parsed next
+func (p *Parser) startNode() int {
+	if !p.keepComments {
+		return 0
+	}
+	return p.tokenizer.peekIndex()
+}
+
+// endNode records the token range of a node after it was parsed completely
+func (p *Parser) endNode(node ast.Node, start int) {
+	if !p.keepComments {
+		return
+	}
+	p.nodeSpans = append(p.nodeSpans, nodeSpan{
+		node:  node,
+		start: start,
+		end:   p.tokenizer.currentToken,
+	})
+}
+
+// attachComments attaches every comment token to the nearest node:
+// a comment on the same line after the last token of a node trails this node,
+// a comment on its own line leads the node starting right after it,
+// all other comments are placed after the node ending right before them.
+func (p *Parser) attachComments() {
+	tokens := p.tokenizer.tokens[:p.tokenizer.maxTokens]
+
+	startsAt := make(map[int]nodeSpan, len(p.nodeSpans))
+	endsAt := make(map[int]nodeSpan, len(p.nodeSpans))
+	for _, span := range p.nodeSpans {
+		if outer, ok := startsAt[span.start]; !ok || span.end > outer.end {
+			startsAt[span.start] = span
+		}
+		if outer, ok := endsAt[span.end]; !ok || span.start < outer.start {
+			endsAt[span.end] = span
+		}
+		if p.blankLineBefore(span.start) {
+			p.document.Comments.SetBlankLineBefore(span.node)
+		}
+	}
+
+	previous := -1
+	for i := range tokens {
+		if tokens[i].Keyword != keyword.COMMENT {
+			previous = i
+			continue
+		}
+
+		comment := ast.Comment{
+			Content:         tokens[i].Literal,
+			BlankLineBefore: p.blankLineBefore(i),
+		}
+
+		if span, ok := endsAt[previous]; ok && previous != -1 && tokens[previous].TextPosition.LineEnd == tokens[i].TextPosition.LineStart {
+			comment.Node, comment.Placement = span.node, ast.CommentPlacementTrailing
+			p.document.Comments.AddComment(comment)
+			continue
+		}
+
+		if span, ok := startsAt[p.nextNonCommentToken(i)]; ok {
+			comment.Node, comment.Placement = span.node, ast.CommentPlacementLeading
+			p.document.Comments.AddComment(comment)
+			continue
+		}
+
+		if span, ok := endsAt[previous]; ok && previous != -1 {
+			comment.Node, comment.Placement = span.node, ast.CommentPlacementAfter
+			p.document.Comments.AddComment(comment)
+			continue
+		}
+
+		// the comment is located somewhere inside a node, e.g. between a type name and its fields,
+		// so it becomes a leading comment of the innermost enclosing node
+		if span, ok := p.enclosingSpan(i); ok {
+			comment.Node, comment.Placement = span.node, ast.CommentPlacementLeading
+			p.document.Comments.AddComment(comment)
+			continue
+		}
+
+		if len(p.document.RootNodes) != 0 {
+			comment.Node, comment.Placement = p.document.RootNodes[len(p.document.RootNodes)-1], ast.CommentPlacementAfter
+			p.document.Comments.AddComment(comment)
+		}
+	}
+}
+
+// blankLineBefore returns true if the token is separated from the previous token by at least one blank line
+func (p *Parser) blankLineBefore(tokenIndex int) bool {
+	if tokenIndex <= 0 || tokenIndex >= p.tokenizer.maxTokens {
+		return false
+	}
+	return p.tokenizer.tokens[tokenIndex].TextPosition.LineStart > p.tokenizer.tokens[tokenIndex-1].TextPosition.LineEnd+1
+}
+
+func (p *Parser) nextNonCommentToken(tokenIndex int) int {
+	for i := tokenIndex + 1; i < p.tokenizer.maxTokens; i++ {
+		if p.tokenizer.tokens[i].Keyword != keyword.COMMENT {
+			return i
+		}
+	}
+	return p.tokenizer.maxTokens
+}
+
+// enclosingSpan returns the innermost node which contains the token
+func (p *Parser) enclosingSpan(tokenIndex int) (inner nodeSpan, ok bool) {
+	for _, span := range p.nodeSpans {
+		if span.start > tokenIndex || span.end < tokenIndex {
+			continue
+		}
+		if !ok || span.end-span.start < inner.end-inner.start {
+			inner, ok = span, true
+		}
+	}
+	return inner, ok
+}
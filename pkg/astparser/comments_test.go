@@ -0,0 +1,114 @@
+package astparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+func TestParser_ParseWithComments(t *testing.T) {
+	type expectedComment struct {
+		node            ast.Node
+		placement       ast.CommentPlacement
+		content         string
+		blankLineBefore bool
+	}
+
+	run := func(t *testing.T, input string, expected []expectedComment) ast.Document {
+		t.Helper()
+
+		doc, report := ParseGraphqlDocumentStringWithComments(input)
+		require.False(t, report.HasErrors(), report.Error())
+
+		actual := make([]expectedComment, 0, len(doc.Comments.Comments))
+		for ref, comment := range doc.Comments.Comments {
+			actual = append(actual, expectedComment{
+				node:            comment.Node,
+				placement:       comment.Placement,
+				content:         doc.CommentContentString(ref),
+				blankLineBefore: comment.BlankLineBefore,
+			})
+		}
+		assert.Equal(t, expected, actual)
+		return doc
+	}
+
+	t.Run("type system definitions", func(t *testing.T) {
+		doc := run(t, `
+			# header
+
+			# the query type
+			type Query {
+				# leading a
+				a: String # trailing a
+
+				"description of b"
+				b(
+					# leading x
+					x: Int
+				): Int
+				# after b
+			}
+			enum Episode { NEWHOPE # new hope
+			}
+			# end of document`,
+			[]expectedComment{
+				{node: ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: 0}, placement: ast.CommentPlacementLeading, content: "# header"},
+				{node: ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: 0}, placement: ast.CommentPlacementLeading, content: "# the query type", blankLineBefore: true},
+				{node: ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: 0}, placement: ast.CommentPlacementLeading, content: "# leading a"},
+				{node: ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: 0}, placement: ast.CommentPlacementTrailing, content: "# trailing a"},
+				{node: ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: 0}, placement: ast.CommentPlacementLeading, content: "# leading x"},
+				{node: ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: 1}, placement: ast.CommentPlacementAfter, content: "# after b"},
+				{node: ast.Node{Kind: ast.NodeKindEnumValueDefinition, Ref: 0}, placement: ast.CommentPlacementTrailing, content: "# new hope"},
+				{node: ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: 0}, placement: ast.CommentPlacementAfter, content: "# end of document"},
+			},
+		)
+
+		assert.False(t, doc.Comments.HasBlankLineBefore(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: 0}))
+		assert.True(t, doc.Comments.HasBlankLineBefore(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: 1}))
+	})
+
+	t.Run("executable definitions", func(t *testing.T) {
+		run(t, `
+			query Q {
+				# leading a
+				a # trailing a
+				... on Droid { # trailing inline fragment line
+					name
+				}
+				...Fields # trailing spread
+			}`,
+			[]expectedComment{
+				{node: ast.Node{Kind: ast.NodeKindField, Ref: 0}, placement: ast.CommentPlacementLeading, content: "# leading a"},
+				{node: ast.Node{Kind: ast.NodeKindField, Ref: 0}, placement: ast.CommentPlacementTrailing, content: "# trailing a"},
+				{node: ast.Node{Kind: ast.NodeKindField, Ref: 1}, placement: ast.CommentPlacementLeading, content: "# trailing inline fragment line"},
+				{node: ast.Node{Kind: ast.NodeKindFragmentSpread, Ref: 0}, placement: ast.CommentPlacementTrailing, content: "# trailing spread"},
+			},
+		)
+	})
+
+	t.Run("comment inside of a definition is attached to the enclosing node", func(t *testing.T) {
+		run(t, `
+			schema { # root operations
+				query: Query
+			}`,
+			[]expectedComment{
+				{node: ast.Node{Kind: ast.NodeKindSchemaDefinition, Ref: 0}, placement: ast.CommentPlacementLeading, content: "# root operations"},
+			},
+		)
+	})
+
+	t.Run("comments are skipped without comments enabled", func(t *testing.T) {
+		doc, report := ParseGraphqlDocumentString(`
+			# leading
+			# comments
+			type Query { a: String # trailing
+			}`)
+		require.False(t, report.HasErrors(), report.Error())
+		assert.False(t, doc.Comments.HasComments())
+		assert.Len(t, doc.FieldDefinitions, 1)
+	})
+}
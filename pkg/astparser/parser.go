@@ -45,6 +45,8 @@ type Parser struct {
 	tokenizer            *Tokenizer
 	shouldIndex          bool
 	reportInternalErrors bool
+	keepComments         bool
+	nodeSpans            []nodeSpan
 }
 
 // NewParser returns a new parser with all values properly initialized
@@ -67,17 +69,22 @@ func (p *Parser) PrepareImport(document *ast.Document, report *operationreport.R
 func (p *Parser) Parse(document *ast.Document, report *operationreport.Report) {
 	p.document = document
 	p.report = report
+	p.nodeSpans = p.nodeSpans[:0]
 	p.tokenize()
 	p.parse()
+	if p.keepComments && !report.HasErrors() {
+		p.attachComments()
+	}
 }
 
 func (p *Parser) tokenize() {
-	p.tokenizer.Tokenize(&p.document.Input)
+	p.tokenizer.tokenize(&p.document.Input, p.keepComments)
 }
 
 func (p *Parser) parse() {
 	for {
 		key, literalReference := p.peekLiteral()
+		start, rootNodes := p.startNode(), len(p.document.RootNodes)
 
 		switch key {
 		case keyword.EOF:
@@ -122,6 +129,10 @@ func (p *Parser) parse() {
 		if p.report.HasErrors() {
 			return
 		}
+
+		if len(p.document.RootNodes) > rootNodes {
+			p.endNode(p.document.RootNodes[len(p.document.RootNodes)-1], start)
+		}
 	}
 }
 
@@ -281,6 +292,7 @@ func (p *Parser) parseRootOperationTypeDefinitionList(list *ast.RootOperationTyp
 					TypeKind: ast.TypeKindNamed,
 					Name:     namedType.Literal,
 					OfType:   ast.InvalidRef,
+					Position: namedType.TextPosition,
 				},
 			}
 
@@ -452,6 +464,7 @@ func (p *Parser) ParseValue() (value ast.Value) {
 	case keyword.LBRACK:
 		value.Kind = ast.ValueKindList
 		value.Ref = p.parseValueList()
+		value.Position = p.document.ListValues[value.Ref].LBRACK
 	case keyword.LBRACE:
 		value.Kind = ast.ValueKindObject
 		value.Ref, value.Position = p.parseObjectValue()
@@ -803,6 +816,7 @@ func (p *Parser) parseFieldDefinitionList() (list ast.FieldDefinitionList) {
 func (p *Parser) parseFieldDefinition() int {
 
 	var fieldDefinition ast.FieldDefinition
+	start := p.startNode()
 
 	name := p.peek()
 	switch name {
@@ -834,7 +848,9 @@ func (p *Parser) parseFieldDefinition() int {
 	}
 
 	p.document.FieldDefinitions = append(p.document.FieldDefinitions, fieldDefinition)
-	return len(p.document.FieldDefinitions) - 1
+	ref := len(p.document.FieldDefinitions) - 1
+	p.endNode(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: ref}, start)
+	return ref
 }
 
 func (p *Parser) parseNamedType() (ref int) {
@@ -916,6 +932,7 @@ func (p *Parser) parseInputValueDefinitionList(closingKeyword keyword.Keyword) (
 func (p *Parser) parseInputValueDefinition() int {
 
 	var inputValueDefinition ast.InputValueDefinition
+	start := p.startNode()
 
 	name := p.peek()
 	switch name {
@@ -943,7 +960,9 @@ func (p *Parser) parseInputValueDefinition() int {
 	}
 
 	p.document.InputValueDefinitions = append(p.document.InputValueDefinitions, inputValueDefinition)
-	return len(p.document.InputValueDefinitions) - 1
+	ref := len(p.document.InputValueDefinitions) - 1
+	p.endNode(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: ref}, start)
+	return ref
 }
 
 func (p *Parser) parseInputObjectTypeDefinition(description *ast.Description) {
@@ -1159,6 +1178,7 @@ func (p *Parser) parseEnumValueDefinitionList() (list ast.EnumValueDefinitionLis
 
 func (p *Parser) parseEnumValueDefinition() int {
 	var enumValueDefinition ast.EnumValueDefinition
+	start := p.startNode()
 	next := p.peek()
 	switch next {
 	case keyword.STRING, keyword.BLOCKSTRING:
@@ -1177,7 +1197,9 @@ func (p *Parser) parseEnumValueDefinition() int {
 	}
 
 	p.document.EnumValueDefinitions = append(p.document.EnumValueDefinitions, enumValueDefinition)
-	return len(p.document.EnumValueDefinitions) - 1
+	ref := len(p.document.EnumValueDefinitions) - 1
+	p.endNode(ast.Node{Kind: ast.NodeKindEnumValueDefinition, Ref: ref}, start)
+	return ref
 }
 
 func (p *Parser) parseDirectiveDefinition(description *ast.Description) {
@@ -1308,17 +1330,27 @@ func (p *Parser) parseSelectionSet() (int, bool) {
 }
 
 func (p *Parser) parseSelection() int {
+	start := p.startNode()
 	next := p.peek()
 	switch next {
 	case keyword.IDENT:
+		ref := p.parseField()
 		p.document.Selections = append(p.document.Selections, ast.Selection{
 			Kind: ast.SelectionKindField,
-			Ref:  p.parseField(),
+			Ref:  ref,
 		})
+		p.endNode(ast.Node{Kind: ast.NodeKindField, Ref: ref}, start)
 		return len(p.document.Selections) - 1
 	case keyword.SPREAD:
 		spreadToken := p.read()
-		return p.parseFragmentSelection(spreadToken.TextPosition)
+		ref := p.parseFragmentSelection(spreadToken.TextPosition)
+		switch p.document.Selections[ref].Kind {
+		case ast.SelectionKindFragmentSpread:
+			p.endNode(ast.Node{Kind: ast.NodeKindFragmentSpread, Ref: p.document.Selections[ref].Ref}, start)
+		case ast.SelectionKindInlineFragment:
+			p.endNode(ast.Node{Kind: ast.NodeKindInlineFragment, Ref: p.document.Selections[ref].Ref}, start)
+		}
+		return ref
 	default:
 		nextToken := p.read()
 		p.errUnexpectedToken(nextToken, keyword.IDENT, keyword.SPREAD)
@@ -1610,7 +1642,10 @@ func (p *Parser) parseSchemaExtension(extend position.Position) {
 		schemaDefinition.Directives = p.parseDirectiveList()
 		schemaDefinition.HasDirectives = len(schemaDefinition.Directives.Refs) > 0
 	}
-	p.parseRootOperationTypeDefinitionList(&schemaDefinition.RootOperationTypeDefinitions)
+	// a schema extension may only extend the directives of the schema, e.g. extend schema @link(url: "...")
+	if !schemaDefinition.HasDirectives || p.peekEquals(keyword.LBRACE) {
+		p.parseRootOperationTypeDefinitionList(&schemaDefinition.RootOperationTypeDefinitions)
+	}
 
 	schemaExtension := ast.SchemaExtension{
 		ExtendLiteral:    extend,
@@ -435,6 +435,21 @@ func TestParser_Parse(t *testing.T) {
 					}
 				})
 		})
+		t.Run("with directives only", func(t *testing.T) {
+			run(`extend schema @link(url: "https://specs.apollo.dev/federation/v2.0")`, parse, false,
+				func(doc *ast.Document, extra interface{}) {
+					schema := doc.SchemaExtensions[0]
+					if !schema.HasDirectives || len(schema.Directives.Refs) != 1 {
+						panic("want directive")
+					}
+					if len(schema.RootOperationTypeDefinitions.Refs) != 0 {
+						panic("want no root operation type definitions")
+					}
+				})
+		})
+		t.Run("without directives and root operation types", func(t *testing.T) {
+			run(`extend schema`, parse, true)
+		})
 	})
 	t.Run("object type extension", func(t *testing.T) {
 		t.Run("complex", func(t *testing.T) {
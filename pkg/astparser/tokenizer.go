@@ -4,6 +4,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/token"
 )
 
@@ -26,6 +27,12 @@ func NewTokenizer() *Tokenizer {
 }
 
 func (t *Tokenizer) Tokenize(input *ast.Input) {
+	t.tokenize(input, false)
+}
+
+// tokenize reads all tokens of the input,
+// splitLines turns comments spanning multiple lines into one token per line
+func (t *Tokenizer) tokenize(input *ast.Input, splitLines bool) {
 	t.lexer.SetInput(input)
 	t.tokens = t.tokens[:0]
 
@@ -36,15 +43,72 @@ func (t *Tokenizer) Tokenize(input *ast.Input) {
 			t.currentToken = -1
 			return
 		}
+		if splitLines && next.Keyword == keyword.COMMENT {
+			t.appendCommentLines(input, next)
+			continue
+		}
 		t.tokens = append(t.tokens, next)
 	}
 }
 
+// appendCommentLines appends one token per non-empty line of a comment
+func (t *Tokenizer) appendCommentLines(input *ast.Input, comment token.Token) {
+	line := comment.TextPosition.LineStart
+	start := comment.Literal.Start
+	lineBegin := comment.Literal.Start - (comment.TextPosition.CharStart - 1)
+
+	appendLine := func(end uint32) {
+		for start < end && isCommentWhitespace(input.RawBytes[start]) {
+			start++
+		}
+		for end > start && isCommentWhitespace(input.RawBytes[end-1]) {
+			end--
+		}
+		if start == end {
+			return
+		}
+		t.tokens = append(t.tokens, token.Token{
+			Keyword: keyword.COMMENT,
+			Literal: ast.ByteSliceReference{Start: start, End: end},
+			TextPosition: position.Position{
+				LineStart: line,
+				LineEnd:   line,
+				CharStart: start - lineBegin + 1,
+				CharEnd:   end - lineBegin + 1,
+			},
+		})
+	}
+
+	for i := comment.Literal.Start; i < comment.Literal.End; i++ {
+		if input.RawBytes[i] != '\n' {
+			continue
+		}
+		appendLine(i)
+		start = i + 1
+		lineBegin = start
+		line++
+	}
+	appendLine(comment.Literal.End)
+}
+
+func isCommentWhitespace(r byte) bool {
+	return r == ' ' || r == '\t' || r == '\r'
+}
+
 // hasNextToken - checks that we haven't reached eof
 func (t *Tokenizer) hasNextToken(skip int) bool {
 	return t.currentToken+1+skip < t.maxTokens
 }
 
+// peekIndex - returns the index of the next token which is not a comment
+func (t *Tokenizer) peekIndex() int {
+	i := t.currentToken + 1
+	for i < t.maxTokens && t.tokens[i].Keyword == keyword.COMMENT {
+		i++
+	}
+	return i
+}
+
 // next - increments current token index if hasNextToken
 // otherwise returns current token
 func (t *Tokenizer) next() int {
@@ -58,7 +122,7 @@ func (t *Tokenizer) next() int {
 // otherwise returns keyword.EOF
 func (t *Tokenizer) Read() token.Token {
 	tok := t.read()
-	if t.skipComments && tok.Keyword == keyword.COMMENT {
+	for t.skipComments && tok.Keyword == keyword.COMMENT {
 		tok = t.read()
 	}
 
@@ -78,9 +142,11 @@ func (t *Tokenizer) read() token.Token {
 // Peek - returns token next to currentToken if hasNextToken
 // otherwise returns keyword.EOF
 func (t *Tokenizer) Peek() token.Token {
-	tok := t.peek(0)
-	if t.skipComments && tok.Keyword == keyword.COMMENT {
-		tok = t.peek(1)
+	skip := 0
+	tok := t.peek(skip)
+	for t.skipComments && tok.Keyword == keyword.COMMENT {
+		skip++
+		tok = t.peek(skip)
 	}
 
 	return tok
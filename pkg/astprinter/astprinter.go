@@ -25,6 +25,17 @@ func PrintIndent(document, definition *ast.Document, indent []byte, out io.Write
 	return printer.Print(document, definition, out)
 }
 
+// PrintIndentWithComments is the same as PrintIndent but additionally prints the comments of the document in place
+// and keeps the blank lines which group definitions, fields, arguments, enum values and selections.
+// Comments are only available if the document was parsed with astparser.NewParserWithComments.
+func PrintIndentWithComments(document, definition *ast.Document, indent []byte, out io.Writer) error {
+	printer := Printer{
+		indent:   indent,
+		comments: true,
+	}
+	return printer.Print(document, definition, out)
+}
+
 // PrintString is the same as Print but returns a string instead of writing to an io.Writer
 func PrintString(document, definition *ast.Document) (string, error) {
 	buff := &bytes.Buffer{}
@@ -41,9 +52,19 @@ func PrintStringIndent(document, definition *ast.Document, indent string) (strin
 	return out, err
 }
 
+// PrintStringIndentWithComments is the same as PrintIndentWithComments but returns a string instead of writing to an io.Writer
+func PrintStringIndentWithComments(document, definition *ast.Document, indent string) (string, error) {
+	buff := &bytes.Buffer{}
+	err := PrintIndentWithComments(document, definition, []byte(indent), buff)
+	out := buff.String()
+	return out, err
+}
+
 // Printer walks a GraphQL document and prints it as a string
 type Printer struct {
 	indent     []byte
+	comments   bool
+	format     FormatOptions
 	visitor    printVisitor
 	walker     astvisitor.SimpleWalker
 	registered bool
@@ -53,6 +74,8 @@ type Printer struct {
 // Keep a printer and re-use it in case you'd like to print ASTs in the hot path.
 func (p *Printer) Print(document, definition *ast.Document, out io.Writer) error {
 	p.visitor.indent = p.indent
+	p.visitor.comments = p.comments
+	p.visitor.format = p.format
 	p.visitor.err = nil
 	p.visitor.document = document
 	p.visitor.out = out
@@ -60,7 +83,15 @@ func (p *Printer) Print(document, definition *ast.Document, out io.Writer) error
 	if !p.registered {
 		p.walker.SetVisitor(&p.visitor)
 	}
-	return p.walker.Walk(p.visitor.document, definition)
+	if p.format.SortTypes != SortOrderNone || p.format.SortFields || p.format.SortArguments {
+		restore := p.sortDocument(document)
+		defer restore()
+	}
+	err := p.walker.Walk(p.visitor.document, definition)
+	if err == nil && p.format.TrailingNewline && len(document.RootNodes) != 0 {
+		_, err = out.Write(literal.LINETERMINATOR)
+	}
+	return err
 }
 
 type printVisitor struct {
@@ -74,6 +105,12 @@ type printVisitor struct {
 	inputValueDefinitionCloser []byte
 	isFirstDirectiveLocation   bool
 	isDirectiveRepeatable      bool
+	comments                   bool
+	multilineArguments         bool
+	wrapFieldArguments         bool
+	format                     FormatOptions
+	typeSystemIndent           []byte
+	line                       bytes.Buffer
 }
 
 func (p *printVisitor) write(data []byte) {
@@ -151,6 +188,7 @@ func (p *printVisitor) LeaveDirective(ref int) {
 		if p.document.FieldHasSelections(ancestor.Ref) {
 			p.write(literal.SPACE)
 		} else if len(p.SelectionsAfter) > 0 {
+			p.writeTrailingComments(ancestor, p.indentationDepth())
 			if p.indent != nil {
 				p.write(literal.LINETERMINATOR)
 			} else {
@@ -175,6 +213,11 @@ func (p *printVisitor) LeaveDirective(ref int) {
 		ast.NodeKindFieldDefinition,
 		ast.NodeKindInputValueDefinition:
 		return
+	case ast.NodeKindSchemaExtension:
+		if len(p.document.SchemaExtensions[ancestor.Ref].RootOperationTypeDefinitions.Refs) == 0 {
+			return
+		}
+		p.write(literal.SPACE)
 	default:
 		p.write(literal.SPACE)
 	}
@@ -213,22 +256,35 @@ func (p *printVisitor) LeaveVariableDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterArgument(ref int) {
-	if len(p.document.ArgumentsBefore(p.Ancestors[len(p.Ancestors)-1], ref)) == 0 {
+	ancestor := p.Ancestors[len(p.Ancestors)-1]
+	wrap := p.wrapFieldArguments && ancestor.Kind == ast.NodeKindField
+	if len(p.document.ArgumentsBefore(ancestor, ref)) == 0 {
 		p.write(literal.LPAREN)
-	} else {
+	} else if !wrap {
 		p.write(literal.COMMA)
 		p.write(literal.SPACE)
 	}
+	if wrap {
+		p.write(literal.LINETERMINATOR)
+		p.writeDepth(p.indentationDepth() + 2)
+	}
 	p.must(p.document.PrintArgument(ref, p.out))
 }
 
 func (p *printVisitor) LeaveArgument(ref int) {
-	if len(p.document.ArgumentsAfter(p.Ancestors[len(p.Ancestors)-1], ref)) == 0 {
+	ancestor := p.Ancestors[len(p.Ancestors)-1]
+	if len(p.document.ArgumentsAfter(ancestor, ref)) == 0 {
+		if p.wrapFieldArguments && ancestor.Kind == ast.NodeKindField {
+			p.write(literal.LINETERMINATOR)
+			p.writeDepth(p.indentationDepth())
+		}
 		p.write(literal.RPAREN)
 	}
 }
 
 func (p *printVisitor) EnterOperationDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindOperationDefinition, Ref: ref}, 0, true)
+	p.enterExecutableDefinition(p.document.OperationDefinitions[ref].SelectionSet)
 
 	hasName := p.document.OperationDefinitions[ref].Name.Length() > 0
 	hasVariables := p.document.OperationDefinitions[ref].HasVariableDefinitions
@@ -257,6 +313,8 @@ func (p *printVisitor) EnterOperationDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveOperationDefinition(ref int) {
+	p.leaveExecutableDefinition()
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindOperationDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindOperationDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -282,6 +340,8 @@ func (p *printVisitor) LeaveSelectionSet(ref int) {
 }
 
 func (p *printVisitor) EnterField(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindField, Ref: ref}, p.indentationDepth(), len(p.SelectionsBefore) == 0)
+	p.wrapFieldArguments = p.fieldExceedsLineWidth(ref)
 	if p.document.Fields[ref].Alias.IsDefined {
 		p.writeIndented(p.document.Input.ByteSlice(p.document.Fields[ref].Alias.Name))
 		p.write(literal.COLON)
@@ -296,6 +356,10 @@ func (p *printVisitor) EnterField(ref int) {
 }
 
 func (p *printVisitor) LeaveField(ref int) {
+	if !p.document.FieldHasDirectives(ref) || p.document.FieldHasSelections(ref) || len(p.SelectionsAfter) == 0 {
+		// otherwise the comments were already printed when leaving the last directive
+		p.writeTrailingComments(ast.Node{Kind: ast.NodeKindField, Ref: ref}, p.indentationDepth())
+	}
 	if !p.document.FieldHasDirectives(ref) && len(p.SelectionsAfter) != 0 {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -306,11 +370,13 @@ func (p *printVisitor) LeaveField(ref int) {
 }
 
 func (p *printVisitor) EnterFragmentSpread(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindFragmentSpread, Ref: ref}, p.indentationDepth(), len(p.SelectionsBefore) == 0)
 	p.writeIndented(literal.SPREAD)
 	p.write(p.document.Input.ByteSlice(p.document.FragmentSpreads[ref].FragmentName))
 }
 
 func (p *printVisitor) LeaveFragmentSpread(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindFragmentSpread, Ref: ref}, p.indentationDepth())
 	ancestor := p.Ancestors[len(p.Ancestors)-1]
 	if p.document.SelectionsAfterFragmentSpread(ref, ancestor) {
 		if p.indent != nil {
@@ -322,6 +388,7 @@ func (p *printVisitor) LeaveFragmentSpread(ref int) {
 }
 
 func (p *printVisitor) EnterInlineFragment(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindInlineFragment, Ref: ref}, p.indentationDepth(), len(p.SelectionsBefore) == 0)
 	p.writeIndented(literal.SPREAD)
 	if p.document.InlineFragments[ref].TypeCondition.Type != -1 {
 		p.write(literal.SPACE)
@@ -336,6 +403,7 @@ func (p *printVisitor) EnterInlineFragment(ref int) {
 }
 
 func (p *printVisitor) LeaveInlineFragment(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindInlineFragment, Ref: ref}, p.indentationDepth())
 	ancestor := p.Ancestors[len(p.Ancestors)-1]
 	if p.document.SelectionsAfterInlineFragment(ref, ancestor) {
 		if p.indent != nil {
@@ -347,6 +415,8 @@ func (p *printVisitor) LeaveInlineFragment(ref int) {
 }
 
 func (p *printVisitor) EnterFragmentDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindFragmentDefinition, Ref: ref}, 0, true)
+	p.enterExecutableDefinition(p.document.FragmentDefinitions[ref].SelectionSet)
 	p.write(literal.FRAGMENT)
 	p.write(literal.SPACE)
 	p.write(p.document.Input.ByteSlice(p.document.FragmentDefinitions[ref].Name))
@@ -359,6 +429,8 @@ func (p *printVisitor) EnterFragmentDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveFragmentDefinition(ref int) {
+	p.leaveExecutableDefinition()
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindFragmentDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindFragmentDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -370,9 +442,10 @@ func (p *printVisitor) LeaveFragmentDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterObjectTypeDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref}, 0, true)
 
 	if p.document.ObjectTypeDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.ObjectTypeDefinitions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.ObjectTypeDefinitions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -381,25 +454,31 @@ func (p *printVisitor) EnterObjectTypeDefinition(ref int) {
 	p.write(p.document.ObjectTypeDefinitionNameBytes(ref))
 	p.write(literal.SPACE)
 
-	if len(p.document.ObjectTypeDefinitions[ref].ImplementsInterfaces.Refs) != 0 {
-		p.write(literal.IMPLEMENTS)
-		p.write(literal.SPACE)
-		for i, j := range p.document.ObjectTypeDefinitions[ref].ImplementsInterfaces.Refs {
-			if i != 0 {
-				p.write(literal.SPACE)
-				p.write(literal.AND)
-				p.write(literal.SPACE)
-			}
-			p.must(p.document.PrintType(j, p.out))
-		}
-		p.write(literal.SPACE)
-	}
+	p.writeImplementsInterfaces(p.document.ObjectTypeDefinitions[ref].ImplementsInterfaces.Refs)
 
 	p.inputValueDefinitionOpener = literal.LPAREN
 	p.inputValueDefinitionCloser = literal.RPAREN
 }
 
+func (p *printVisitor) writeImplementsInterfaces(typeRefs []int) {
+	if len(typeRefs) == 0 {
+		return
+	}
+	p.write(literal.IMPLEMENTS)
+	p.write(literal.SPACE)
+	for i, j := range typeRefs {
+		if i != 0 {
+			p.write(literal.SPACE)
+			p.write(literal.AND)
+			p.write(literal.SPACE)
+		}
+		p.must(p.document.PrintType(j, p.out))
+	}
+	p.write(literal.SPACE)
+}
+
 func (p *printVisitor) LeaveObjectTypeDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -411,9 +490,10 @@ func (p *printVisitor) LeaveObjectTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterObjectTypeExtension(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref}, 0, true)
 
 	if p.document.ObjectTypeExtensions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.ObjectTypeExtensions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.ObjectTypeExtensions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -423,12 +503,14 @@ func (p *printVisitor) EnterObjectTypeExtension(ref int) {
 	p.write(literal.SPACE)
 	p.write(p.document.ObjectTypeExtensionNameBytes(ref))
 	p.write(literal.SPACE)
+	p.writeImplementsInterfaces(p.document.ObjectTypeExtensions[ref].ImplementsInterfaces.Refs)
 
 	p.inputValueDefinitionOpener = literal.LPAREN
 	p.inputValueDefinitionCloser = literal.RPAREN
 }
 
 func (p *printVisitor) LeaveObjectTypeExtension(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -440,14 +522,17 @@ func (p *printVisitor) LeaveObjectTypeExtension(ref int) {
 }
 
 func (p *printVisitor) EnterFieldDefinition(ref int) {
-	if p.document.FieldDefinitionIsFirst(ref, p.Ancestors[len(p.Ancestors)-1]) {
+	isFirst := p.document.FieldDefinitionIsFirst(ref, p.Ancestors[len(p.Ancestors)-1])
+	if isFirst {
 		p.write(literal.LBRACE)
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
 		}
 	}
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: ref}, p.indentationDepth(), isFirst)
+	p.multilineArguments = p.argumentsHaveComments(ref) || p.fieldDefinitionExceedsLineWidth(ref)
 	if p.document.FieldDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.FieldDefinitions[ref].Description, p.indent, p.indentationDepth(), p.out))
+		p.writeDescription(p.document.FieldDefinitions[ref].Description, p.indentationDepth())
 		p.write(literal.LINETERMINATOR)
 	}
 	p.writeIndented(p.document.FieldDefinitionNameBytes(ref))
@@ -457,6 +542,7 @@ func (p *printVisitor) LeaveFieldDefinition(ref int) {
 	if !p.document.FieldDefinitionHasDirectives(ref) {
 		p.writeFieldType(ref)
 	}
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: ref}, p.indentationDepth())
 
 	if p.document.FieldDefinitionIsLast(ref, p.Ancestors[len(p.Ancestors)-1]) {
 		if p.indent != nil {
@@ -473,23 +559,32 @@ func (p *printVisitor) LeaveFieldDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterInputValueDefinition(ref int) {
-	if p.document.InputValueDefinitionIsFirst(ref, p.Ancestors[len(p.Ancestors)-1]) {
+	isFirst := p.document.InputValueDefinitionIsFirst(ref, p.Ancestors[len(p.Ancestors)-1])
+	if isFirst {
 		p.write(p.inputValueDefinitionOpener)
 	}
 	if p.indent != nil {
 		switch p.Ancestors[len(p.Ancestors)-1].Kind {
 		case ast.NodeKindDirectiveDefinition, ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension:
 			p.write(literal.LINETERMINATOR)
+		case ast.NodeKindFieldDefinition:
+			if p.multilineArguments {
+				p.write(literal.LINETERMINATOR)
+			}
 		}
 	}
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: ref}, p.inputValueDefinitionDepth(), isFirst)
 	if p.document.InputValueDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.InputValueDefinitions[ref].Description, p.indent, p.indentationDepth(), p.out))
+		p.writeDescription(p.document.InputValueDefinitions[ref].Description, p.inputValueDefinitionDepth())
 		p.write(literal.LINETERMINATOR)
 	}
 	switch p.Ancestors[len(p.Ancestors)-1].Kind {
 	case ast.NodeKindDirectiveDefinition, ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension:
 		p.writeIndented(p.document.InputValueDefinitionNameBytes(ref))
 	default:
+		if p.multilineArguments {
+			p.writeDepth(p.inputValueDefinitionDepth())
+		}
 		p.write(p.document.InputValueDefinitionNameBytes(ref))
 	}
 	p.write(literal.COLON)
@@ -504,11 +599,17 @@ func (p *printVisitor) EnterInputValueDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveInputValueDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: ref}, p.inputValueDefinitionDepth())
 	if p.document.InputValueDefinitionIsLast(ref, p.Ancestors[len(p.Ancestors)-1]) {
 		if p.indent != nil {
 			switch p.Ancestors[len(p.Ancestors)-1].Kind {
 			case ast.NodeKindDirectiveDefinition, ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension:
 				p.write(literal.LINETERMINATOR)
+			case ast.NodeKindFieldDefinition:
+				if p.multilineArguments {
+					p.write(literal.LINETERMINATOR)
+					p.writeDepth(p.indentationDepth())
+				}
 			}
 		}
 		p.write(p.inputValueDefinitionCloser)
@@ -516,8 +617,11 @@ func (p *printVisitor) LeaveInputValueDefinition(ref int) {
 		if len(p.Ancestors) > 0 {
 			// check enclosing type kind
 			if p.Ancestors[len(p.Ancestors)-1].Kind == ast.NodeKindFieldDefinition {
-				p.write(literal.COMMA)
-				p.write(literal.SPACE)
+				// arguments on separate lines are separated by line terminators only
+				if !p.multilineArguments {
+					p.write(literal.COMMA)
+					p.write(literal.SPACE)
+				}
 			} else if len(p.indent) == 0 {
 				// add space between arguments when printing without indents
 				p.write(literal.SPACE)
@@ -526,10 +630,20 @@ func (p *printVisitor) LeaveInputValueDefinition(ref int) {
 	}
 }
 
+// inputValueDefinitionDepth returns the indentation depth of an input value definition,
+// arguments of field definitions are indented one level deeper when printed on separate lines
+func (p *printVisitor) inputValueDefinitionDepth() int {
+	if p.multilineArguments && p.Ancestors[len(p.Ancestors)-1].Kind == ast.NodeKindFieldDefinition {
+		return p.indentationDepth() + 2
+	}
+	return p.indentationDepth()
+}
+
 func (p *printVisitor) EnterInterfaceTypeDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref}, 0, true)
 
 	if p.document.InterfaceTypeDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.InterfaceTypeDefinitions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.InterfaceTypeDefinitions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -543,6 +657,7 @@ func (p *printVisitor) EnterInterfaceTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveInterfaceTypeDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -554,9 +669,10 @@ func (p *printVisitor) LeaveInterfaceTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterInterfaceTypeExtension(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref}, 0, true)
 
 	if p.document.InterfaceTypeExtensions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.InterfaceTypeExtensions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.InterfaceTypeExtensions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -572,6 +688,7 @@ func (p *printVisitor) EnterInterfaceTypeExtension(ref int) {
 }
 
 func (p *printVisitor) LeaveInterfaceTypeExtension(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -583,9 +700,10 @@ func (p *printVisitor) LeaveInterfaceTypeExtension(ref int) {
 }
 
 func (p *printVisitor) EnterScalarTypeDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindScalarTypeDefinition, Ref: ref}, 0, true)
 
 	if p.document.ScalarTypeDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.ScalarTypeDefinitions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.ScalarTypeDefinitions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -598,6 +716,7 @@ func (p *printVisitor) EnterScalarTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveScalarTypeDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindScalarTypeDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindScalarTypeDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -609,9 +728,10 @@ func (p *printVisitor) LeaveScalarTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterScalarTypeExtension(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindScalarTypeExtension, Ref: ref}, 0, true)
 
 	if p.document.ScalarTypeExtensions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.ScalarTypeExtensions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.ScalarTypeExtensions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -626,6 +746,7 @@ func (p *printVisitor) EnterScalarTypeExtension(ref int) {
 }
 
 func (p *printVisitor) LeaveScalarTypeExtension(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindScalarTypeExtension, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindScalarTypeExtension, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -637,9 +758,10 @@ func (p *printVisitor) LeaveScalarTypeExtension(ref int) {
 }
 
 func (p *printVisitor) EnterUnionTypeDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref}, 0, true)
 
 	if p.document.UnionTypeDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.UnionTypeDefinitions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.UnionTypeDefinitions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -652,6 +774,7 @@ func (p *printVisitor) EnterUnionTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveUnionTypeDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -663,9 +786,10 @@ func (p *printVisitor) LeaveUnionTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterUnionTypeExtension(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindUnionTypeExtension, Ref: ref}, 0, true)
 
 	if p.document.UnionTypeExtensions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.UnionTypeExtensions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.UnionTypeExtensions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -680,6 +804,7 @@ func (p *printVisitor) EnterUnionTypeExtension(ref int) {
 }
 
 func (p *printVisitor) LeaveUnionTypeExtension(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindUnionTypeExtension, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindUnionTypeExtension, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -709,9 +834,10 @@ func (p *printVisitor) LeaveUnionMemberType(ref int) {
 }
 
 func (p *printVisitor) EnterEnumTypeDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref}, 0, true)
 
 	if p.document.EnumTypeDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.EnumTypeDefinitions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.EnumTypeDefinitions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -724,6 +850,7 @@ func (p *printVisitor) EnterEnumTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveEnumTypeDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -735,9 +862,10 @@ func (p *printVisitor) LeaveEnumTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterEnumTypeExtension(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: ref}, 0, true)
 
 	if p.document.EnumTypeExtensions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.EnumTypeExtensions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.EnumTypeExtensions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -752,6 +880,7 @@ func (p *printVisitor) EnterEnumTypeExtension(ref int) {
 }
 
 func (p *printVisitor) LeaveEnumTypeExtension(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -763,21 +892,24 @@ func (p *printVisitor) LeaveEnumTypeExtension(ref int) {
 }
 
 func (p *printVisitor) EnterEnumValueDefinition(ref int) {
-	if p.document.EnumValueDefinitionIsFirst(ref, p.Ancestors[len(p.Ancestors)-1]) {
+	isFirst := p.document.EnumValueDefinitionIsFirst(ref, p.Ancestors[len(p.Ancestors)-1])
+	if isFirst {
 		p.write(literal.SPACE)
 		p.write(literal.LBRACE)
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
 		}
 	}
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindEnumValueDefinition, Ref: ref}, p.indentationDepth(), isFirst)
 	if p.document.EnumValueDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.EnumValueDefinitions[ref].Description, p.indent, p.indentationDepth(), p.out))
+		p.writeDescription(p.document.EnumValueDefinitions[ref].Description, p.indentationDepth())
 		p.write(literal.LINETERMINATOR)
 	}
 	p.writeIndented(p.document.EnumValueDefinitionNameBytes(ref))
 }
 
 func (p *printVisitor) LeaveEnumValueDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindEnumValueDefinition, Ref: ref}, p.indentationDepth())
 	if p.document.EnumValueDefinitionIsLast(ref, p.Ancestors[len(p.Ancestors)-1]) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -793,9 +925,10 @@ func (p *printVisitor) LeaveEnumValueDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterInputObjectTypeDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref}, 0, true)
 
 	if p.document.InputObjectTypeDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.InputObjectTypeDefinitions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.InputObjectTypeDefinitions[ref].Description, 0)
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
 		}
@@ -811,6 +944,7 @@ func (p *printVisitor) EnterInputObjectTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveInputObjectTypeDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -822,9 +956,10 @@ func (p *printVisitor) LeaveInputObjectTypeDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterInputObjectTypeExtension(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindInputObjectTypeExtension, Ref: ref}, 0, true)
 
 	if p.document.InputObjectTypeExtensions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.InputObjectTypeExtensions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.InputObjectTypeExtensions[ref].Description, 0)
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
 		}
@@ -842,6 +977,7 @@ func (p *printVisitor) EnterInputObjectTypeExtension(ref int) {
 }
 
 func (p *printVisitor) LeaveInputObjectTypeExtension(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindInputObjectTypeExtension, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindInputObjectTypeExtension, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -853,8 +989,9 @@ func (p *printVisitor) LeaveInputObjectTypeExtension(ref int) {
 }
 
 func (p *printVisitor) EnterDirectiveDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindDirectiveDefinition, Ref: ref}, 0, true)
 	if p.document.DirectiveDefinitions[ref].Description.IsDefined {
-		p.must(p.document.PrintDescription(p.document.DirectiveDefinitions[ref].Description, nil, 0, p.out))
+		p.writeDescription(p.document.DirectiveDefinitions[ref].Description, 0)
 		p.write(literal.LINETERMINATOR)
 	}
 
@@ -870,6 +1007,7 @@ func (p *printVisitor) EnterDirectiveDefinition(ref int) {
 }
 
 func (p *printVisitor) LeaveDirectiveDefinition(ref int) {
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindDirectiveDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindDirectiveDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -945,6 +1083,7 @@ func (p *printVisitor) LeaveDirectiveLocation(location ast.DirectiveLocation) {
 }
 
 func (p *printVisitor) EnterSchemaDefinition(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindSchemaDefinition, Ref: ref}, 0, true)
 	p.write(literal.SCHEMA)
 	p.write(literal.SPACE)
 }
@@ -954,6 +1093,7 @@ func (p *printVisitor) LeaveSchemaDefinition(ref int) {
 		p.write(literal.LINETERMINATOR)
 	}
 	p.write(literal.RBRACE)
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindSchemaDefinition, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindSchemaDefinition, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
@@ -965,6 +1105,7 @@ func (p *printVisitor) LeaveSchemaDefinition(ref int) {
 }
 
 func (p *printVisitor) EnterSchemaExtension(ref int) {
+	p.writeLeadingComments(ast.Node{Kind: ast.NodeKindSchemaExtension, Ref: ref}, 0, true)
 	p.write(literal.EXTEND)
 	p.write(literal.SPACE)
 	p.write(literal.SCHEMA)
@@ -972,10 +1113,13 @@ func (p *printVisitor) EnterSchemaExtension(ref int) {
 }
 
 func (p *printVisitor) LeaveSchemaExtension(ref int) {
-	if p.indent != nil {
-		p.write(literal.LINETERMINATOR)
+	if len(p.document.SchemaExtensions[ref].RootOperationTypeDefinitions.Refs) > 0 {
+		if p.indent != nil {
+			p.write(literal.LINETERMINATOR)
+		}
+		p.write(literal.RBRACE)
 	}
-	p.write(literal.RBRACE)
+	p.writeTrailingComments(ast.Node{Kind: ast.NodeKindSchemaExtension, Ref: ref}, 0)
 	if !p.document.NodeIsLastRootNode(ast.Node{Kind: ast.NodeKindSchemaExtension, Ref: ref}) {
 		if p.indent != nil {
 			p.write(literal.LINETERMINATOR)
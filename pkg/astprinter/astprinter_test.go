@@ -197,6 +197,11 @@ vary: [String]! = []) on QUERY directive @include(if: Boolean!) repeatable on FI
 					subscription: Subscription
 				}`, `extend schema @foo {query: Query mutation: Mutation subscription: Subscription}`)
 	})
+	t.Run("schema extension with directives only", func(t *testing.T) {
+		run(t, `
+				extend schema @link(url: "https://specs.apollo.dev/federation/v2.0") 
+				type Query {hello: String}`, `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0") type Query {hello: String}`)
+	})
 	t.Run("object type definition", func(t *testing.T) {
 		run(t, `
 				type Foo {
@@ -209,6 +214,12 @@ vary: [String]! = []) on QUERY directive @include(if: Boolean!) repeatable on FI
 					field: String
 				}`, `extend type Foo @foo {field: String}`)
 	})
+	t.Run("object type extension implementing interfaces", func(t *testing.T) {
+		run(t, `
+				extend type Foo implements Bar & Baz @foo {
+					field: String
+				}`, `extend type Foo implements Bar & Baz @foo {field: String}`)
+	})
 	t.Run("input object type definition", func(t *testing.T) {
 		run(t, `
 				input Foo {
@@ -0,0 +1,116 @@
+package astprinter
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+)
+
+func (p *printVisitor) printComments() bool {
+	return p.comments && p.indent != nil
+}
+
+func (p *printVisitor) writeDepth(depth int) {
+	for i := 0; i < depth; i++ {
+		p.write(p.indent)
+	}
+}
+
+// writeLeadingComments prints the comments on the lines before a node followed by a line terminator each.
+// Blank lines are only preserved between nodes of the same parent,
+// the first node of a parent as well as root nodes already get separated by the enclosing printer logic.
+func (p *printVisitor) writeLeadingComments(node ast.Node, depth int, isFirst bool) {
+	if !p.printComments() {
+		return
+	}
+
+	refs := p.document.Comments.NodeComments(node, ast.CommentPlacementLeading)
+	for i, ref := range refs {
+		if p.document.Comments.Comments[ref].BlankLineBefore && (i != 0 || !isFirst) {
+			p.write(literal.LINETERMINATOR)
+		}
+		p.writeDepth(depth)
+		p.write(p.document.CommentContentBytes(ref))
+		p.write(literal.LINETERMINATOR)
+	}
+
+	if p.document.Comments.HasBlankLineBefore(node) && (len(refs) != 0 || !isFirst) {
+		p.write(literal.LINETERMINATOR)
+	}
+}
+
+// writeTrailingComments prints the comment on the same line after a node
+// as well as the comments on the lines after a node which don't belong to another node.
+func (p *printVisitor) writeTrailingComments(node ast.Node, depth int) {
+	if !p.printComments() {
+		return
+	}
+
+	for _, ref := range p.document.Comments.NodeComments(node, ast.CommentPlacementTrailing) {
+		p.write(literal.SPACE)
+		p.write(p.document.CommentContentBytes(ref))
+	}
+
+	for _, ref := range p.document.Comments.NodeComments(node, ast.CommentPlacementAfter) {
+		p.write(literal.LINETERMINATOR)
+		if p.document.Comments.Comments[ref].BlankLineBefore {
+			p.write(literal.LINETERMINATOR)
+		}
+		p.writeDepth(depth)
+		p.write(p.document.CommentContentBytes(ref))
+	}
+}
+
+// argumentsHaveComments returns true if comments are attached to any argument definition of a field definition,
+// in this case the arguments get printed on separate lines.
+func (p *printVisitor) argumentsHaveComments(fieldDefinition int) bool {
+	if !p.printComments() {
+		return false
+	}
+
+	for _, ref := range p.document.FieldDefinitions[fieldDefinition].ArgumentsDefinition.Refs {
+		if p.nodeHasComments(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: ref}) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectionSetHasComments returns true if comments are attached to any selection of the selection set or its nested selection sets
+func (p *printVisitor) selectionSetHasComments(selectionSet int) bool {
+	if !p.printComments() {
+		return false
+	}
+
+	for _, ref := range p.document.SelectionSets[selectionSet].SelectionRefs {
+		selection := p.document.Selections[ref]
+		var node ast.Node
+		nestedSelectionSet := ast.InvalidRef
+		switch selection.Kind {
+		case ast.SelectionKindField:
+			node = ast.Node{Kind: ast.NodeKindField, Ref: selection.Ref}
+			if p.document.Fields[selection.Ref].HasSelections {
+				nestedSelectionSet = p.document.Fields[selection.Ref].SelectionSet
+			}
+		case ast.SelectionKindFragmentSpread:
+			node = ast.Node{Kind: ast.NodeKindFragmentSpread, Ref: selection.Ref}
+		case ast.SelectionKindInlineFragment:
+			node = ast.Node{Kind: ast.NodeKindInlineFragment, Ref: selection.Ref}
+			nestedSelectionSet = p.document.InlineFragments[selection.Ref].SelectionSet
+		default:
+			continue
+		}
+		if p.nodeHasComments(node) {
+			return true
+		}
+		if nestedSelectionSet != ast.InvalidRef && p.selectionSetHasComments(nestedSelectionSet) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *printVisitor) nodeHasComments(node ast.Node) bool {
+	return len(p.document.Comments.NodeComments(node, ast.CommentPlacementLeading)) != 0 ||
+		len(p.document.Comments.NodeComments(node, ast.CommentPlacementTrailing)) != 0 ||
+		len(p.document.Comments.NodeComments(node, ast.CommentPlacementAfter)) != 0
+}
@@ -0,0 +1,122 @@
+package astprinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+)
+
+func TestPrintIndentWithComments(t *testing.T) {
+	run := func(t *testing.T, raw string, expected string) {
+		t.Helper()
+
+		doc, report := astparser.ParseGraphqlDocumentStringWithComments(raw)
+		require.False(t, report.HasErrors(), report.Error())
+
+		actual, err := PrintStringIndentWithComments(&doc, nil, " ")
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		reparsed, report := astparser.ParseGraphqlDocumentStringWithComments(actual)
+		require.False(t, report.HasErrors(), report.Error())
+
+		reprinted, err := PrintStringIndentWithComments(&reparsed, nil, " ")
+		require.NoError(t, err)
+		assert.Equal(t, actual, reprinted, "printing must be stable")
+	}
+
+	t.Run("type system definitions", func(t *testing.T) {
+		run(t, `
+# header
+
+# the query type
+type Query {
+	# leading a
+	a: String   # trailing a
+
+
+	"description of b"
+	b(
+		# leading x
+		x: Int
+		y: Int # trailing y
+	): Int
+	# after b
+}
+enum Episode { NEWHOPE # new hope
+	EMPIRE }
+# end of document`,
+			`# header
+
+# the query type
+type Query {
+  # leading a
+  a: String # trailing a
+
+  "description of b"
+  b(
+    # leading x
+    x: Int
+    y: Int # trailing y
+  ): Int
+  # after b
+}
+
+enum Episode {
+  NEWHOPE # new hope
+  EMPIRE
+}
+# end of document`)
+	})
+
+	t.Run("executable definitions", func(t *testing.T) {
+		run(t, `
+query Q($id: ID!) {
+	# leading a
+	a # trailing a
+
+	b @include(if: true) # trailing b
+	c
+	... on Droid {
+		# name
+		name
+	}
+	...Fields # trailing spread
+}`,
+			`query Q($id: ID!){
+  # leading a
+  a # trailing a
+
+  b @include(if: true) # trailing b
+  c
+  ... on Droid {
+    # name
+    name
+  }
+  ...Fields # trailing spread
+}`)
+	})
+
+	t.Run("comments are not printed without comments enabled", func(t *testing.T) {
+		doc, report := astparser.ParseGraphqlDocumentStringWithComments(`
+# the query type
+type Query {
+	a(x: Int # trailing x
+	): String # trailing a
+}`)
+		require.False(t, report.HasErrors(), report.Error())
+
+		printed, err := PrintStringIndent(&doc, nil, " ")
+		require.NoError(t, err)
+
+		withoutCommentsDoc := unsafeparser.ParseGraphqlDocumentString(`type Query { a(x: Int): String }`)
+		withoutComments, err := PrintStringIndent(&withoutCommentsDoc, nil, " ")
+		require.NoError(t, err)
+
+		assert.Equal(t, withoutComments, printed)
+	})
+}
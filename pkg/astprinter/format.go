@@ -0,0 +1,371 @@
+package astprinter
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// SortOrder defines how the root nodes of a document get ordered when printing
+type SortOrder int
+
+const (
+	// SortOrderNone keeps the order of the source document
+	SortOrderNone SortOrder = iota
+	// SortOrderAlphabetical orders root nodes by name, schema definitions and extensions come first
+	SortOrderAlphabetical
+	// SortOrderKind groups root nodes by kind and orders them by name within each group:
+	// schema, directives, scalars, interfaces, objects, unions, enums, input objects, operations, fragments.
+	// Extensions follow the definitions of the same kind and name.
+	SortOrderKind
+)
+
+// DescriptionStyle defines how descriptions get printed
+type DescriptionStyle int
+
+const (
+	// DescriptionStylePreserve prints descriptions the way they are defined in the source document
+	DescriptionStylePreserve DescriptionStyle = iota
+	// DescriptionStyleBlock prints all descriptions as block strings, e.g. """description"""
+	DescriptionStyleBlock
+	// DescriptionStyleSingleLine prints descriptions as single line strings, e.g. "description",
+	// descriptions spanning multiple lines or containing quotes are printed as block strings.
+	DescriptionStyleSingleLine
+)
+
+// FormatOptions configure the layout of the printed document
+type FormatOptions struct {
+	// Indent is written twice per nesting level, same as the indent of PrintIndent,
+	// e.g. a single space results in two spaces per level. An empty Indent prints the document on a single line.
+	Indent string
+	// MaxLineWidth wraps the arguments of field definitions and fields onto separate lines
+	// if the line of the field would exceed the given number of characters, zero disables wrapping.
+	MaxLineWidth int
+	// SortTypes orders the root nodes of the document
+	SortTypes SortOrder
+	// SortFields orders the fields of object types, interfaces and input objects alphabetically
+	SortFields bool
+	// SortArguments orders the arguments of field and directive definitions alphabetically
+	SortArguments bool
+	// DescriptionStyle defines if descriptions get printed as block or single line strings
+	DescriptionStyle DescriptionStyle
+	// TrailingNewline terminates the printed document with a line terminator
+	TrailingNewline bool
+	// CompactOperations prints operations and fragments on a single line while type system definitions stay indented.
+	// If Comments is enabled, operations and fragments with comments inside their selection set stay indented.
+	CompactOperations bool
+	// Comments prints the comments of the document in place, see PrintIndentWithComments.
+	Comments bool
+}
+
+// DefaultFormatOptions returns the options used by Format,
+// the document is indented by two spaces per level and keeps its comments, order and description style.
+func DefaultFormatOptions() FormatOptions {
+	return FormatOptions{
+		Indent:          " ",
+		MaxLineWidth:    80,
+		TrailingNewline: true,
+		Comments:        true,
+	}
+}
+
+// Format parses a GraphQL document and prints it using DefaultFormatOptions.
+// It's the equivalent of gofmt for .graphql files.
+func Format(source []byte) ([]byte, error) {
+	return FormatWithOptions(source, DefaultFormatOptions())
+}
+
+// FormatWithOptions is the same as Format but accepts custom options
+func FormatWithOptions(source []byte, options FormatOptions) ([]byte, error) {
+	var (
+		document ast.Document
+		report   operationreport.Report
+	)
+	if options.Comments {
+		document, report = astparser.ParseGraphqlDocumentBytesWithComments(source)
+	} else {
+		document, report = astparser.ParseGraphqlDocumentBytes(source)
+	}
+	if report.HasErrors() {
+		return nil, report
+	}
+
+	buff := &bytes.Buffer{}
+	if err := PrintWithOptions(&document, nil, options, buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// PrintWithOptions is the same as Print but lays out the document according to the options.
+// Sorting doesn't modify the document, the original order is restored after printing.
+func PrintWithOptions(document, definition *ast.Document, options FormatOptions, out io.Writer) error {
+	printer := Printer{
+		comments: options.Comments,
+		format:   options,
+	}
+	if options.Indent != "" {
+		printer.indent = []byte(options.Indent)
+	}
+	return printer.Print(document, definition, out)
+}
+
+// PrintStringWithOptions is the same as PrintWithOptions but returns a string instead of writing to an io.Writer
+func PrintStringWithOptions(document, definition *ast.Document, options FormatOptions) (string, error) {
+	buff := &bytes.Buffer{}
+	err := PrintWithOptions(document, definition, options, buff)
+	out := buff.String()
+	return out, err
+}
+
+// sortedRefs holds a list of refs replaced by a sorted copy to restore it after printing
+type sortedRefs struct {
+	refs     *[]int
+	original []int
+}
+
+// sortDocument replaces root nodes, fields and arguments of the document with sorted copies
+// and returns a function restoring the original order
+func (p *Printer) sortDocument(document *ast.Document) (restore func()) {
+	rootNodes := document.RootNodes
+	var replaced []sortedRefs
+
+	sortRefs := func(refs *[]int, less func(i, j int) bool) {
+		if len(*refs) < 2 {
+			return
+		}
+		replaced = append(replaced, sortedRefs{refs: refs, original: *refs})
+		sorted := make([]int, len(*refs))
+		copy(sorted, *refs)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return less(sorted[i], sorted[j])
+		})
+		*refs = sorted
+	}
+
+	if p.format.SortTypes != SortOrderNone {
+		document.RootNodes = make([]ast.Node, len(rootNodes))
+		copy(document.RootNodes, rootNodes)
+		sort.SliceStable(document.RootNodes, func(i, j int) bool {
+			return p.rootNodeLess(document, document.RootNodes[i], document.RootNodes[j])
+		})
+	}
+
+	if p.format.SortFields {
+		fieldDefinitionLess := func(i, j int) bool {
+			return bytes.Compare(document.FieldDefinitionNameBytes(i), document.FieldDefinitionNameBytes(j)) < 0
+		}
+		inputValueDefinitionLess := func(i, j int) bool {
+			return bytes.Compare(document.InputValueDefinitionNameBytes(i), document.InputValueDefinitionNameBytes(j)) < 0
+		}
+		for i := range document.ObjectTypeDefinitions {
+			sortRefs(&document.ObjectTypeDefinitions[i].FieldsDefinition.Refs, fieldDefinitionLess)
+		}
+		for i := range document.ObjectTypeExtensions {
+			sortRefs(&document.ObjectTypeExtensions[i].FieldsDefinition.Refs, fieldDefinitionLess)
+		}
+		for i := range document.InterfaceTypeDefinitions {
+			sortRefs(&document.InterfaceTypeDefinitions[i].FieldsDefinition.Refs, fieldDefinitionLess)
+		}
+		for i := range document.InterfaceTypeExtensions {
+			sortRefs(&document.InterfaceTypeExtensions[i].FieldsDefinition.Refs, fieldDefinitionLess)
+		}
+		for i := range document.InputObjectTypeDefinitions {
+			sortRefs(&document.InputObjectTypeDefinitions[i].InputFieldsDefinition.Refs, inputValueDefinitionLess)
+		}
+		for i := range document.InputObjectTypeExtensions {
+			sortRefs(&document.InputObjectTypeExtensions[i].InputFieldsDefinition.Refs, inputValueDefinitionLess)
+		}
+	}
+
+	if p.format.SortArguments {
+		inputValueDefinitionLess := func(i, j int) bool {
+			return bytes.Compare(document.InputValueDefinitionNameBytes(i), document.InputValueDefinitionNameBytes(j)) < 0
+		}
+		for i := range document.FieldDefinitions {
+			sortRefs(&document.FieldDefinitions[i].ArgumentsDefinition.Refs, inputValueDefinitionLess)
+		}
+		for i := range document.DirectiveDefinitions {
+			sortRefs(&document.DirectiveDefinitions[i].ArgumentsDefinition.Refs, inputValueDefinitionLess)
+		}
+	}
+
+	return func() {
+		document.RootNodes = rootNodes
+		for i := range replaced {
+			*replaced[i].refs = replaced[i].original
+		}
+	}
+}
+
+func (p *Printer) rootNodeLess(document *ast.Document, left, right ast.Node) bool {
+	if p.format.SortTypes == SortOrderKind {
+		if leftRank, rightRank := rootNodeKindRank(left.Kind), rootNodeKindRank(right.Kind); leftRank != rightRank {
+			return leftRank < rightRank
+		}
+	}
+	if compared := bytes.Compare(rootNodeNameBytes(document, left), rootNodeNameBytes(document, right)); compared != 0 {
+		return compared < 0
+	}
+	return rootNodeKindRank(left.Kind) < rootNodeKindRank(right.Kind)
+}
+
+func rootNodeNameBytes(document *ast.Document, node ast.Node) ast.ByteSlice {
+	switch node.Kind {
+	case ast.NodeKindOperationDefinition:
+		return document.Input.ByteSlice(document.OperationDefinitions[node.Ref].Name)
+	case ast.NodeKindFragmentDefinition:
+		return document.Input.ByteSlice(document.FragmentDefinitions[node.Ref].Name)
+	default:
+		return document.NodeNameBytes(node)
+	}
+}
+
+func rootNodeKindRank(kind ast.NodeKind) int {
+	switch kind {
+	case ast.NodeKindSchemaDefinition:
+		return 0
+	case ast.NodeKindSchemaExtension:
+		return 1
+	case ast.NodeKindDirectiveDefinition:
+		return 2
+	case ast.NodeKindScalarTypeDefinition:
+		return 3
+	case ast.NodeKindScalarTypeExtension:
+		return 4
+	case ast.NodeKindInterfaceTypeDefinition:
+		return 5
+	case ast.NodeKindInterfaceTypeExtension:
+		return 6
+	case ast.NodeKindObjectTypeDefinition:
+		return 7
+	case ast.NodeKindObjectTypeExtension:
+		return 8
+	case ast.NodeKindUnionTypeDefinition:
+		return 9
+	case ast.NodeKindUnionTypeExtension:
+		return 10
+	case ast.NodeKindEnumTypeDefinition:
+		return 11
+	case ast.NodeKindEnumTypeExtension:
+		return 12
+	case ast.NodeKindInputObjectTypeDefinition:
+		return 13
+	case ast.NodeKindInputObjectTypeExtension:
+		return 14
+	case ast.NodeKindOperationDefinition:
+		return 15
+	default:
+		return 16
+	}
+}
+
+// writeDescription prints a description in the configured style
+func (p *printVisitor) writeDescription(description ast.Description, depth int) {
+	content := p.document.Input.ByteSlice(description.Content)
+	switch p.format.DescriptionStyle {
+	case DescriptionStyleBlock:
+		// escape sequences of single line strings have no meaning in block strings
+		if !bytes.ContainsRune(content, '\\') {
+			description.IsBlockString = true
+		}
+	case DescriptionStyleSingleLine:
+		if !bytes.ContainsAny(content, "\n\"\\") {
+			description.IsBlockString = false
+		}
+	}
+	p.must(p.document.PrintDescription(description, p.indent, depth, p.out))
+}
+
+// enterExecutableDefinition disables indentation for operations and fragments in compact mode.
+// Definitions with comments inside their selection set stay indented as comments can't be printed on a single line.
+func (p *printVisitor) enterExecutableDefinition(selectionSet int) {
+	if p.format.CompactOperations {
+		p.typeSystemIndent = p.indent
+		if !p.selectionSetHasComments(selectionSet) {
+			p.indent = nil
+		}
+	}
+}
+
+// leaveExecutableDefinition restores the indentation after printing an operation or fragment in compact mode
+func (p *printVisitor) leaveExecutableDefinition() {
+	if p.format.CompactOperations {
+		p.indent = p.typeSystemIndent
+	}
+}
+
+// fieldDefinitionExceedsLineWidth returns true if the field definition printed on a single line would exceed the max line width
+func (p *printVisitor) fieldDefinitionExceedsLineWidth(ref int) bool {
+	if p.format.MaxLineWidth <= 0 || p.indent == nil || !p.document.FieldDefinitionHasArgumentsDefinitions(ref) {
+		return false
+	}
+
+	p.line.Reset()
+	p.writeLineIndent(p.indentationDepth())
+	p.line.Write(p.document.FieldDefinitionNameBytes(ref))
+	p.line.Write(literal.LPAREN)
+	for i, arg := range p.document.FieldDefinitions[ref].ArgumentsDefinition.Refs {
+		if i != 0 {
+			p.line.Write(literal.COMMA)
+			p.line.Write(literal.SPACE)
+		}
+		p.line.Write(p.document.InputValueDefinitionNameBytes(arg))
+		p.line.Write(literal.COLON)
+		p.line.Write(literal.SPACE)
+		p.must(p.document.PrintType(p.document.InputValueDefinitionType(arg), &p.line))
+		if p.document.InputValueDefinitionHasDefaultValue(arg) {
+			p.line.Write(literal.SPACE)
+			p.line.Write(literal.EQUALS)
+			p.line.Write(literal.SPACE)
+			p.must(p.document.PrintValue(p.document.InputValueDefinitionDefaultValue(arg), &p.line))
+		}
+		p.writeLineDirectives(p.document.InputValueDefinitions[arg].Directives.Refs)
+	}
+	p.line.Write(literal.RPAREN)
+	p.line.Write(literal.COLON)
+	p.line.Write(literal.SPACE)
+	p.must(p.document.PrintType(p.document.FieldDefinitionType(ref), &p.line))
+	p.writeLineDirectives(p.document.FieldDefinitions[ref].Directives.Refs)
+
+	return utf8.RuneCount(p.line.Bytes()) > p.format.MaxLineWidth
+}
+
+// fieldExceedsLineWidth returns true if the field of a selection set printed on a single line would exceed the max line width
+func (p *printVisitor) fieldExceedsLineWidth(ref int) bool {
+	if p.format.MaxLineWidth <= 0 || p.indent == nil || !p.document.FieldHasArguments(ref) {
+		return false
+	}
+
+	p.line.Reset()
+	p.writeLineIndent(p.indentationDepth())
+	if p.document.Fields[ref].Alias.IsDefined {
+		p.line.Write(p.document.Input.ByteSlice(p.document.Fields[ref].Alias.Name))
+		p.line.Write(literal.COLON)
+		p.line.Write(literal.SPACE)
+	}
+	p.line.Write(p.document.Input.ByteSlice(p.document.Fields[ref].Name))
+	p.must(p.document.PrintArguments(p.document.Fields[ref].Arguments.Refs, &p.line))
+	p.writeLineDirectives(p.document.Fields[ref].Directives.Refs)
+
+	return utf8.RuneCount(p.line.Bytes()) > p.format.MaxLineWidth
+}
+
+func (p *printVisitor) writeLineIndent(depth int) {
+	for i := 0; i < depth; i++ {
+		p.line.Write(p.indent)
+	}
+}
+
+func (p *printVisitor) writeLineDirectives(refs []int) {
+	for _, ref := range refs {
+		p.line.Write(literal.SPACE)
+		p.must(p.document.PrintDirective(ref, &p.line))
+	}
+}
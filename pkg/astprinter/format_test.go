@@ -0,0 +1,317 @@
+package astprinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("formats a document and keeps comments", func(t *testing.T) {
+		formatted, err := Format([]byte(`
+# the query type
+type Query { droid(id: ID!): Droid # a droid
+}
+"a droid" type Droid { name: String }`))
+		require.NoError(t, err)
+		assert.Equal(t, `# the query type
+type Query {
+  droid(id: ID!): Droid # a droid
+}
+
+"a droid"
+type Droid {
+  name: String
+}
+`, string(formatted))
+
+		formattedTwice, err := Format(formatted)
+		require.NoError(t, err)
+		assert.Equal(t, string(formatted), string(formattedTwice))
+	})
+
+	t.Run("returns the report on invalid documents", func(t *testing.T) {
+		_, err := Format([]byte(`type Query {`))
+		assert.Error(t, err)
+	})
+
+	t.Run("keeps comments of compact operations", func(t *testing.T) {
+		options := DefaultFormatOptions()
+		options.CompactOperations = true
+		formatted, err := FormatWithOptions([]byte(`
+# the droid query
+query Q {
+	droid {
+		# the name
+		name
+		id # the id
+	}
+}
+query WithoutComments { droid { name } }
+fragment DroidFields on Droid { ... on Droid { name # the name
+} }`), options)
+		require.NoError(t, err)
+		assert.Equal(t, `# the droid query
+query Q {
+  droid {
+    # the name
+    name
+    id # the id
+  }
+}
+
+query WithoutComments {droid {name}}
+
+fragment DroidFields on Droid {
+  ... on Droid {
+    name # the name
+  }
+}
+`, string(formatted))
+
+		options.Comments = false
+		formatted, err = FormatWithOptions([]byte(`
+query Q {
+	droid {
+		# the name
+		name
+	}
+}`), options)
+		require.NoError(t, err)
+		assert.Equal(t, "query Q {droid {name}}\n", string(formatted))
+	})
+}
+
+func TestPrintWithOptions(t *testing.T) {
+	run := func(t *testing.T, raw string, options FormatOptions, expected string) {
+		t.Helper()
+
+		formatted, err := FormatWithOptions([]byte(raw), options)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(formatted))
+	}
+
+	indented := FormatOptions{Indent: " "}
+
+	t.Run("max line width wraps field definition arguments", func(t *testing.T) {
+		options := indented
+		options.MaxLineWidth = 40
+		run(t, `
+			type Query {
+				droid(id: ID!): Droid
+				search(term: String!, first: Int = 10, after: String): [Droid!]!
+			}`, options,
+			`type Query {
+  droid(id: ID!): Droid
+  search(
+    term: String!
+    first: Int = 10
+    after: String
+  ): [Droid!]!
+}`)
+	})
+
+	t.Run("max line width wraps field arguments", func(t *testing.T) {
+		options := indented
+		options.MaxLineWidth = 40
+		run(t, `
+			query Q($term: String!) {
+				search(term: $term, first: 100, after: "cursor") { name }
+				droid(id: 1) { name }
+			}`, options,
+			`query Q($term: String!){
+  search(
+    term: $term
+    first: 100
+    after: "cursor"
+  ){
+    name
+  }
+  droid(id: 1){
+    name
+  }
+}`)
+	})
+
+	t.Run("sort types alphabetically", func(t *testing.T) {
+		options := indented
+		options.SortTypes = SortOrderAlphabetical
+		run(t, `
+			type Query { b: String }
+			extend type Droid { primaryFunction: String }
+			enum Episode { NEWHOPE }
+			type Droid { name: String }
+			schema { query: Query }`, options,
+			`schema {
+  query: Query
+}
+
+type Droid {
+  name: String
+}
+
+extend type Droid {
+  primaryFunction: String
+}
+
+enum Episode {
+  NEWHOPE
+}
+
+type Query {
+  b: String
+}`)
+	})
+
+	t.Run("sort types by kind", func(t *testing.T) {
+		options := indented
+		options.SortTypes = SortOrderKind
+		run(t, `
+			type Query { b: String }
+			enum Episode { NEWHOPE }
+			scalar Date
+			interface Node { id: ID! }
+			directive @auth on FIELD_DEFINITION
+			input Filter { a: String }
+			type Droid { name: String }`, options,
+			`directive @auth on FIELD_DEFINITION
+
+scalar Date
+
+interface Node {
+  id: ID!
+}
+
+type Droid {
+  name: String
+}
+
+type Query {
+  b: String
+}
+
+enum Episode {
+  NEWHOPE
+}
+
+input Filter {
+  a: String
+}`)
+	})
+
+	t.Run("sort fields and arguments", func(t *testing.T) {
+		options := indented
+		options.SortFields = true
+		options.SortArguments = true
+		run(t, `
+			type Query { search(term: String, first: Int): String droid: String }
+			input Filter { name: String, age: Int }
+			directive @auth(role: String, level: Int) on FIELD_DEFINITION`, options,
+			`type Query {
+  droid: String
+  search(first: Int, term: String): String
+}
+
+input Filter {
+  age: Int
+  name: String
+}
+
+directive @auth(
+  level: Int
+  role: String
+) on FIELD_DEFINITION`)
+	})
+
+	t.Run("description styles", func(t *testing.T) {
+		raw := `
+"""
+a droid
+"""
+type Droid {
+  "the name"
+  name: String
+  """
+  multiple
+  lines
+  """
+  friends: [Droid]
+  "an \"escaped\" quote"
+  id: ID
+}`
+
+		options := indented
+		options.DescriptionStyle = DescriptionStyleSingleLine
+		run(t, raw, options, `"a droid"
+type Droid {
+  "the name"
+  name: String
+  """
+  multiple
+  lines
+  """
+  friends: [Droid]
+  "an \"escaped\" quote"
+  id: ID
+}`)
+
+		options.DescriptionStyle = DescriptionStyleBlock
+		run(t, raw, options, `"""
+a droid
+"""
+type Droid {
+  """
+  the name
+  """
+  name: String
+  """
+  multiple
+  lines
+  """
+  friends: [Droid]
+  "an \"escaped\" quote"
+  id: ID
+}`)
+	})
+
+	t.Run("trailing newline", func(t *testing.T) {
+		options := indented
+		options.TrailingNewline = true
+		run(t, `scalar Date`, options, "scalar Date\n")
+		run(t, ``, options, "")
+	})
+
+	t.Run("compact operations", func(t *testing.T) {
+		options := indented
+		options.CompactOperations = true
+		run(t, `
+			type Query { droid: Droid }
+			query Q { droid { ...DroidFields } }
+			fragment DroidFields on Droid { name id }`, options,
+			`type Query {
+  droid: Droid
+}
+
+query Q {droid {...DroidFields}}
+
+fragment DroidFields on Droid {name id}`)
+	})
+
+	t.Run("sorting does not modify the document", func(t *testing.T) {
+		doc := unsafeparser.ParseGraphqlDocumentString(`type Query { b(y: Int, x: Int): String a: String } scalar A`)
+		options := indented
+		options.SortTypes = SortOrderAlphabetical
+		options.SortFields = true
+		options.SortArguments = true
+
+		_, err := PrintStringWithOptions(&doc, nil, options)
+		require.NoError(t, err)
+
+		printed, err := PrintString(&doc, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `type Query {b(y: Int, x: Int): String a: String} scalar A`, printed)
+	})
+}
@@ -164,7 +164,7 @@ directive @deprecated(
     [Markdown](https://daringfireball.net/projects/markdown/).
     """
     reason: String = "No longer supported"
-) on FIELD_DEFINITION | ENUM_VALUE
+) on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
 
 """
 The @removeNullVariables directive allows you to remove variables with null value from your GraphQL Query or Mutation Operations.
@@ -185,6 +185,15 @@ So upstream will receive the following variables:
 """
 directive @removeNullVariables on QUERY | MUTATION
 
+"Indicates exactly one field must be supplied and this field must not be 'null'."
+directive @oneOf on INPUT_OBJECT
+
+"Exposes a URL that specifies the behaviour of this scalar."
+directive @specifiedBy(
+    "The URL that specifies the behaviour of this scalar."
+    url: String!
+) on SCALAR
+
 """
 A Directive provides a way to describe alternate runtime execution and type validation behavior in a GraphQL document.
 In some cases, you need to provide options to alter GraphQL's execution behavior
@@ -196,7 +205,7 @@ type __Directive {
     name: String!
     description: String
     locations: [__DirectiveLocation!]!
-    args: [__InputValue!]!
+    args(includeDeprecated: Boolean = false): [__InputValue!]!
     isRepeatable: Boolean!
 }
 
@@ -261,7 +270,7 @@ a name, potentially a list of arguments, and a return type.
 type __Field {
     name: String!
     description: String
-    args: [__InputValue!]!
+    args(includeDeprecated: Boolean = false): [__InputValue!]!
     type: __Type!
     isDeprecated: Boolean!
     deprecationReason: String
@@ -277,6 +286,8 @@ type __InputValue {
     type: __Type!
     "A GraphQL-formatted string representing the default value for this input value."
     defaultValue: String
+    isDeprecated: Boolean!
+    deprecationReason: String
 }
 
 """
@@ -315,8 +326,12 @@ type __Type {
     interfaces: [__Type!]
     possibleTypes: [__Type!]
     enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
-    inputFields: [__InputValue!]
+    inputFields(includeDeprecated: Boolean = false): [__InputValue!]
     ofType: __Type
+    "A URL pointing to the specification of a custom scalar, null for all other kinds."
+    specifiedByURL: String
+    "Indicates whether an 'INPUT_OBJECT' is a OneOf Input Object, null for all other kinds."
+    isOneOf: Boolean
 }
 
 "An enum describing what kind of type a given '__Type' is."
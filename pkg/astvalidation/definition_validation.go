@@ -3,6 +3,7 @@ package astvalidation
 import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -19,6 +20,14 @@ func DefaultDefinitionValidator() *DefinitionValidator {
 		ImplementTransitiveInterfaces(),
 		ImplementingTypesAreSupersets(),
 		DirectivesAreUniquePerLocation(),
+		UniqueDirectiveNames(),
+		DirectivesAreInValidLocations(),
+		UniqueArgumentDefinitionNames(),
+		NoInputObjectCircularReferences(),
+		RootOperationTypesAreObjectTypes(),
+		ValidTypeReferences(),
+		NamesAreNotReserved(),
+		OneOfInputFieldsAreNullable(),
 	)
 }
 
@@ -54,3 +63,9 @@ func (d *DefinitionValidator) Validate(definition *ast.Document, report *operati
 	}
 	return Valid
 }
+
+// withLocation sets the location of a definition validation error to the position of the invalid definition
+func withLocation(err operationreport.ExternalError, pos position.Position) operationreport.ExternalError {
+	err.Locations = operationreport.LocationsFromPosition(pos)
+	return err
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
 )
 
 func runDefinitionValidation(t *testing.T, definitionInput string, expectation ValidationState, rules ...Rule) {
@@ -25,3 +26,57 @@ func runDefinitionValidation(t *testing.T, definitionInput string, expectation V
 	result := validator.Validate(&definition, &report)
 	assert.Equal(t, expectation, result)
 }
+
+func TestDefinitionValidator_ErrorLocations(t *testing.T) {
+	run := func(definitionInput string, rule Rule, expectedLocations ...graphqlerrors.Location) func(t *testing.T) {
+		return func(t *testing.T) {
+			definition, report := astparser.ParseGraphqlDocumentString(definitionInput)
+			require.False(t, report.HasErrors())
+
+			validator := &DefinitionValidator{}
+			validator.RegisterRule(rule)
+			validator.Validate(&definition, &report)
+
+			var locations []graphqlerrors.Location
+			for _, externalError := range report.ExternalErrors {
+				locations = append(locations, externalError.Locations...)
+			}
+			assert.Equal(t, expectedLocations, locations)
+		}
+	}
+
+	t.Run("unique type names", run(`
+type Query { hello: String }
+scalar Query`, UniqueTypeNames(), graphqlerrors.Location{Line: 3, Column: 8}))
+
+	t.Run("unique field definition names", run(`
+type Query {
+	hello: String
+	hello: Int
+}`, UniqueFieldDefinitionNames(), graphqlerrors.Location{Line: 4, Column: 2}))
+
+	t.Run("known type names", run(`
+type Query {
+	hello: Unknown
+}`, KnownTypeNames(), graphqlerrors.Location{Line: 3, Column: 9}))
+
+	t.Run("populated type bodies", run(`
+type Query { hello: String }
+enum Color`, PopulatedTypeBodies(), graphqlerrors.Location{Line: 3, Column: 6}))
+
+	t.Run("unique operation types", run(`
+schema {
+	query: Query
+	query: Query
+}
+type Query { hello: String }`, UniqueOperationTypes(), graphqlerrors.Location{Line: 4, Column: 9}))
+
+	t.Run("names are not reserved", run(`
+type Query {
+	hello(__name: String): String
+}`, NamesAreNotReserved(), graphqlerrors.Location{Line: 3, Column: 8}))
+
+	t.Run("implementing types are supersets", run(`
+interface Node { id: ID! }
+type User implements Node { name: String }`, ImplementingTypesAreSupersets(), graphqlerrors.Location{Line: 3, Column: 6}))
+}
@@ -0,0 +1,230 @@
+package astvalidation
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// AllVariableUsagesAreAllowed validates if all variables are used in positions which allow their types:
+// as argument values, as list items and as input object fields, including usages inside of fragments
+func AllVariableUsagesAreAllowed() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := allVariableUsagesAreAllowedVisitor{
+			Walker: walker,
+		}
+		walker.RegisterEnterDocumentVisitor(&visitor)
+		walker.RegisterEnterArgumentVisitor(&visitor)
+		walker.RegisterEnterFragmentSpreadVisitor(&visitor)
+		walker.RegisterLeaveDocumentVisitor(&visitor)
+	}
+}
+
+type variableUsage struct {
+	value                   ast.Value
+	locationTypeRef         int // type ref of the definition document
+	hasLocationDefaultValue bool
+}
+
+type allVariableUsagesAreAllowedVisitor struct {
+	*astvisitor.Walker
+	operation, definition *ast.Document
+	// usages and fragmentSpreads are keyed by the operation or fragment definition which contains them
+	usages          map[ast.Node][]variableUsage
+	fragmentSpreads map[ast.Node][]ast.ByteSlice
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) EnterDocument(operation, definition *ast.Document) {
+	a.operation = operation
+	a.definition = definition
+	a.usages = map[ast.Node][]variableUsage{}
+	a.fragmentSpreads = map[ast.Node][]ast.ByteSlice{}
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) EnterFragmentSpread(ref int) {
+	owner := a.Ancestors[0]
+	a.fragmentSpreads[owner] = append(a.fragmentSpreads[owner], a.operation.FragmentSpreadNameBytes(ref))
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) EnterArgument(ref int) {
+	inputValueDefinitionRef, exists := a.ArgumentInputValueDefinition(ref)
+	if !exists {
+		return
+	}
+
+	a.collectVariableUsages(
+		a.Ancestors[0],
+		a.operation.ArgumentValue(ref),
+		a.definition.InputValueDefinitionType(inputValueDefinitionRef),
+		a.definition.InputValueDefinitionHasDefaultValue(inputValueDefinitionRef),
+	)
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) collectVariableUsages(owner ast.Node, value ast.Value, locationTypeRef int, hasLocationDefaultValue bool) {
+	switch value.Kind {
+	case ast.ValueKindVariable:
+		a.usages[owner] = append(a.usages[owner], variableUsage{
+			value:                   value,
+			locationTypeRef:         locationTypeRef,
+			hasLocationDefaultValue: hasLocationDefaultValue,
+		})
+	case ast.ValueKindList:
+		listTypeRef := locationTypeRef
+		if a.definition.TypeIsNonNull(listTypeRef) {
+			listTypeRef = a.definition.Types[listTypeRef].OfType
+		}
+		if a.definition.Types[listTypeRef].TypeKind != ast.TypeKindList {
+			// a list in a non list position is reported by Values
+			return
+		}
+		for _, itemRef := range a.operation.ListValues[value.Ref].Refs {
+			a.collectVariableUsages(owner, a.operation.Value(itemRef), a.definition.Types[listTypeRef].OfType, false)
+		}
+	case ast.ValueKindObject:
+		typeName := a.definition.ResolveTypeNameBytes(locationTypeRef)
+		node, exists := a.definition.Index.FirstNodeByNameBytes(typeName)
+		if !exists || node.Kind != ast.NodeKindInputObjectTypeDefinition {
+			return
+		}
+		for _, objectFieldRef := range a.operation.ObjectValues[value.Ref].Refs {
+			fieldName := a.operation.ObjectFieldNameBytes(objectFieldRef)
+			inputValueDefinitionRef := a.definition.InputObjectTypeDefinitionInputValueDefinitionByName(node.Ref, fieldName)
+			if inputValueDefinitionRef == ast.InvalidRef {
+				continue
+			}
+			a.collectVariableUsages(
+				owner,
+				a.operation.ObjectFieldValue(objectFieldRef),
+				a.definition.InputValueDefinitionType(inputValueDefinitionRef),
+				a.definition.InputValueDefinitionHasDefaultValue(inputValueDefinitionRef),
+			)
+		}
+	}
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) LeaveDocument(operation, definition *ast.Document) {
+	for _, node := range operation.RootNodes {
+		if node.Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		for _, usage := range a.recursiveVariableUsages(node) {
+			a.validateVariableUsage(node.Ref, usage)
+		}
+	}
+}
+
+// recursiveVariableUsages returns the variable usages of the operation and of all fragments it spreads directly or indirectly
+func (a *allVariableUsagesAreAllowedVisitor) recursiveVariableUsages(operationNode ast.Node) (usages []variableUsage) {
+	visited := map[int]struct{}{}
+	nodes := []ast.Node{operationNode}
+
+	for len(nodes) != 0 {
+		node := nodes[0]
+		nodes = nodes[1:]
+
+		usages = append(usages, a.usages[node]...)
+
+		for _, fragmentName := range a.fragmentSpreads[node] {
+			fragmentRef, exists := a.operation.FragmentDefinitionRef(fragmentName)
+			if !exists {
+				continue
+			}
+			if _, ok := visited[fragmentRef]; ok {
+				continue
+			}
+			visited[fragmentRef] = struct{}{}
+			nodes = append(nodes, ast.Node{Kind: ast.NodeKindFragmentDefinition, Ref: fragmentRef})
+		}
+	}
+
+	return usages
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) validateVariableUsage(operationRef int, usage variableUsage) {
+	variableName := a.operation.VariableValueNameBytes(usage.value.Ref)
+	variableDefinitionRef, exists := a.operation.VariableDefinitionByNameAndOperation(operationRef, variableName)
+	if !exists {
+		// undefined variables are reported by AllVariableUsesDefined
+		return
+	}
+
+	if a.variableUsageIsAllowed(variableDefinitionRef, usage) {
+		return
+	}
+
+	printedValue, err := a.operation.PrintValueBytes(usage.value, nil)
+	if a.HandleInternalErr(err) {
+		return
+	}
+
+	variableTypeName, err := a.operation.PrintTypeBytes(a.operation.VariableDefinitions[variableDefinitionRef].Type, nil)
+	if a.HandleInternalErr(err) {
+		return
+	}
+
+	locationTypeName, err := a.definition.PrintTypeBytes(usage.locationTypeRef, nil)
+	if a.HandleInternalErr(err) {
+		return
+	}
+
+	a.Report.AddExternalError(operationreport.ErrVariableTypeDoesntSatisfyInputValueDefinition(
+		printedValue,
+		variableTypeName,
+		locationTypeName,
+		usage.value.Position,
+		a.operation.VariableDefinitions[variableDefinitionRef].VariableValue.Position,
+	))
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) variableUsageIsAllowed(variableDefinitionRef int, usage variableUsage) bool {
+	variableTypeRef := a.operation.VariableDefinitions[variableDefinitionRef].Type
+	locationTypeRef := usage.locationTypeRef
+
+	// A nullable variable is allowed in a non-null position if either the variable or the location
+	// provides a default value, which is used in place of an omitted variable.
+	if a.definition.TypeIsNonNull(locationTypeRef) && !a.operation.TypeIsNonNull(variableTypeRef) {
+		defaultValue := a.operation.VariableDefinitions[variableDefinitionRef].DefaultValue
+		hasNonNullVariableDefaultValue := defaultValue.IsDefined && defaultValue.Value.Kind != ast.ValueKindNull
+		if !hasNonNullVariableDefaultValue && !usage.hasLocationDefaultValue {
+			return false
+		}
+		locationTypeRef = a.definition.Types[locationTypeRef].OfType
+	}
+
+	return a.typesAreCompatible(variableTypeRef, locationTypeRef)
+}
+
+func (a *allVariableUsagesAreAllowedVisitor) typesAreCompatible(variableTypeRef, locationTypeRef int) bool {
+	for {
+		if variableTypeRef == ast.InvalidRef || locationTypeRef == ast.InvalidRef {
+			return false
+		}
+
+		variableTypeKind := a.operation.Types[variableTypeRef].TypeKind
+		locationTypeKind := a.definition.Types[locationTypeRef].TypeKind
+
+		switch {
+		case locationTypeKind == ast.TypeKindNonNull:
+			if variableTypeKind != ast.TypeKindNonNull {
+				return false
+			}
+			variableTypeRef = a.operation.Types[variableTypeRef].OfType
+			locationTypeRef = a.definition.Types[locationTypeRef].OfType
+		case variableTypeKind == ast.TypeKindNonNull:
+			// a non-null variable is allowed in a nullable position
+			variableTypeRef = a.operation.Types[variableTypeRef].OfType
+		case locationTypeKind == ast.TypeKindList:
+			if variableTypeKind != ast.TypeKindList {
+				return false
+			}
+			variableTypeRef = a.operation.Types[variableTypeRef].OfType
+			locationTypeRef = a.definition.Types[locationTypeRef].OfType
+		case variableTypeKind == ast.TypeKindList:
+			return false
+		default:
+			return bytes.Equal(a.operation.TypeNameBytes(variableTypeRef), a.definition.TypeNameBytes(locationTypeRef))
+		}
+	}
+}
@@ -38,6 +38,14 @@ func (d *directivesAreInValidLocationsVisitor) EnterDirective(ref int) {
 
 	ancestor := d.Ancestors[len(d.Ancestors)-1]
 
+	if ancestor.Kind == ast.NodeKindInputValueDefinition {
+		// the location of an input value definition depends on whether it is an argument or an input field
+		if !d.directiveDefinitionContainsInputValueDefinitionLocation(definition.Ref) {
+			d.StopWithExternalErr(operationreport.ErrDirectiveNotAllowedOnNode(directiveName, d.operation.NodeKindNameBytes(ancestor)))
+		}
+		return
+	}
+
 	if !d.directiveDefinitionContainsNodeLocation(definition.Ref, ancestor) {
 		ancestorKindName := d.operation.NodeKindNameBytes(ancestor)
 		d.StopWithExternalErr(operationreport.ErrDirectiveNotAllowedOnNode(directiveName, ancestorKindName))
@@ -54,3 +62,14 @@ func (d *directivesAreInValidLocationsVisitor) directiveDefinitionContainsNodeLo
 
 	return d.definition.DirectiveDefinitions[definition].DirectiveLocations.Get(nodeDirectiveLocation)
 }
+
+func (d *directivesAreInValidLocationsVisitor) directiveDefinitionContainsInputValueDefinitionLocation(definition int) bool {
+	location := ast.TypeSystemDirectiveLocationArgumentDefinition
+
+	switch d.Ancestors[len(d.Ancestors)-2].Kind {
+	case ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension:
+		location = ast.TypeSystemDirectiveLocationInputFieldDefinition
+	}
+
+	return d.definition.DirectiveDefinitions[definition].DirectiveLocations.Get(location)
+}
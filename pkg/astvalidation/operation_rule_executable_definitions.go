@@ -0,0 +1,86 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// ExecutableDefinitions validates if the document contains only executable definitions: operations and fragments
+func ExecutableDefinitions() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &executableDefinitionsVisitor{
+			Walker: walker,
+		}
+		walker.RegisterEnterDocumentVisitor(visitor)
+	}
+}
+
+type executableDefinitionsVisitor struct {
+	*astvisitor.Walker
+}
+
+func (e *executableDefinitionsVisitor) EnterDocument(operation, definition *ast.Document) {
+	executable := true
+	for _, node := range operation.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindOperationDefinition, ast.NodeKindFragmentDefinition, ast.NodeKindUnknown:
+			// unknown nodes are definitions removed during normalization
+			continue
+		case ast.NodeKindSchemaDefinition:
+			executable = false
+			e.Report.AddExternalError(operationreport.ErrSchemaDefinitionIsNotExecutable(operation.SchemaDefinitions[node.Ref].SchemaLiteral))
+		case ast.NodeKindSchemaExtension:
+			executable = false
+			e.Report.AddExternalError(operationreport.ErrSchemaDefinitionIsNotExecutable(operation.SchemaExtensions[node.Ref].ExtendLiteral))
+		default:
+			executable = false
+			e.Report.AddExternalError(operationreport.ErrDefinitionIsNotExecutable(operation.NodeNameBytes(node), e.definitionPosition(operation, node)))
+		}
+	}
+
+	if !executable {
+		// the remaining rules only apply to executable documents
+		e.Stop()
+	}
+}
+
+func (e *executableDefinitionsVisitor) definitionPosition(operation *ast.Document, node ast.Node) position.Position {
+	var description ast.Description
+	var pos position.Position
+
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		description, pos = operation.ObjectTypeDefinitions[node.Ref].Description, operation.ObjectTypeDefinitions[node.Ref].TypeLiteral
+	case ast.NodeKindInterfaceTypeDefinition:
+		description, pos = operation.InterfaceTypeDefinitions[node.Ref].Description, operation.InterfaceTypeDefinitions[node.Ref].InterfaceLiteral
+	case ast.NodeKindInputObjectTypeDefinition:
+		description, pos = operation.InputObjectTypeDefinitions[node.Ref].Description, operation.InputObjectTypeDefinitions[node.Ref].InputLiteral
+	case ast.NodeKindUnionTypeDefinition:
+		description, pos = operation.UnionTypeDefinitions[node.Ref].Description, operation.UnionTypeDefinitions[node.Ref].UnionLiteral
+	case ast.NodeKindEnumTypeDefinition:
+		description, pos = operation.EnumTypeDefinitions[node.Ref].Description, operation.EnumTypeDefinitions[node.Ref].EnumLiteral
+	case ast.NodeKindScalarTypeDefinition:
+		description, pos = operation.ScalarTypeDefinitions[node.Ref].Description, operation.ScalarTypeDefinitions[node.Ref].ScalarLiteral
+	case ast.NodeKindDirectiveDefinition:
+		description, pos = operation.DirectiveDefinitions[node.Ref].Description, operation.DirectiveDefinitions[node.Ref].DirectiveLiteral
+	case ast.NodeKindObjectTypeExtension:
+		pos = operation.ObjectTypeExtensions[node.Ref].ExtendLiteral
+	case ast.NodeKindInterfaceTypeExtension:
+		pos = operation.InterfaceTypeExtensions[node.Ref].ExtendLiteral
+	case ast.NodeKindInputObjectTypeExtension:
+		pos = operation.InputObjectTypeExtensions[node.Ref].ExtendLiteral
+	case ast.NodeKindUnionTypeExtension:
+		pos = operation.UnionTypeExtensions[node.Ref].ExtendLiteral
+	case ast.NodeKindEnumTypeExtension:
+		pos = operation.EnumTypeExtensions[node.Ref].ExtendLiteral
+	case ast.NodeKindScalarTypeExtension:
+		pos = operation.ScalarTypeExtensions[node.Ref].ExtendLiteral
+	}
+
+	if description.IsDefined {
+		return description.Position
+	}
+	return pos
+}
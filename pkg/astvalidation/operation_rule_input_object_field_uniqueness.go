@@ -0,0 +1,63 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// InputObjectFieldUniqueness validates if all input object values contain each field only once
+func InputObjectFieldUniqueness() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := inputObjectFieldUniquenessVisitor{
+			Walker: walker,
+		}
+		walker.RegisterEnterDocumentVisitor(&visitor)
+		walker.RegisterEnterArgumentVisitor(&visitor)
+		walker.RegisterEnterVariableDefinitionVisitor(&visitor)
+	}
+}
+
+type inputObjectFieldUniquenessVisitor struct {
+	*astvisitor.Walker
+	operation *ast.Document
+}
+
+func (i *inputObjectFieldUniquenessVisitor) EnterDocument(operation, definition *ast.Document) {
+	i.operation = operation
+}
+
+func (i *inputObjectFieldUniquenessVisitor) EnterArgument(ref int) {
+	i.validateValue(i.operation.ArgumentValue(ref))
+}
+
+func (i *inputObjectFieldUniquenessVisitor) EnterVariableDefinition(ref int) {
+	if !i.operation.VariableDefinitionHasDefaultValue(ref) {
+		return
+	}
+	i.validateValue(i.operation.VariableDefinitions[ref].DefaultValue.Value)
+}
+
+func (i *inputObjectFieldUniquenessVisitor) validateValue(value ast.Value) {
+	switch value.Kind {
+	case ast.ValueKindList:
+		for _, itemRef := range i.operation.ListValues[value.Ref].Refs {
+			i.validateValue(i.operation.Value(itemRef))
+		}
+	case ast.ValueKindObject:
+		fieldPositions := make(map[string]position.Position, len(i.operation.ObjectValues[value.Ref].Refs))
+		for _, objectFieldRef := range i.operation.ObjectValues[value.Ref].Refs {
+			fieldName := i.operation.ObjectFieldNameBytes(objectFieldRef)
+			fieldPosition := i.operation.ObjectField(objectFieldRef).Position
+
+			if firstPosition, exists := fieldPositions[string(fieldName)]; exists {
+				i.Report.AddExternalError(operationreport.ErrDuplicatedFieldInputObject(fieldName, firstPosition, fieldPosition))
+			} else {
+				fieldPositions[string(fieldName)] = fieldPosition
+			}
+
+			i.validateValue(i.operation.ObjectFieldValue(objectFieldRef))
+		}
+	}
+}
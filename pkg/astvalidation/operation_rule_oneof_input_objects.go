@@ -0,0 +1,155 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// OneOfInputObjects validates if literal values of OneOf Input Objects specify exactly one non-null field
+// and if variables used as the field of a OneOf Input Object are of a non-null type
+func OneOfInputObjects() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := oneOfInputObjectsVisitor{
+			Walker: walker,
+		}
+		walker.RegisterEnterDocumentVisitor(&visitor)
+		walker.RegisterEnterArgumentVisitor(&visitor)
+		walker.RegisterEnterVariableDefinitionVisitor(&visitor)
+	}
+}
+
+type oneOfInputObjectsVisitor struct {
+	*astvisitor.Walker
+	operation, definition *ast.Document
+}
+
+func (o *oneOfInputObjectsVisitor) EnterDocument(operation, definition *ast.Document) {
+	o.operation = operation
+	o.definition = definition
+}
+
+func (o *oneOfInputObjectsVisitor) EnterArgument(ref int) {
+	inputValueDefinitionRef, exists := o.ArgumentInputValueDefinition(ref)
+	if !exists {
+		return
+	}
+
+	o.validateValue(o.operation.ArgumentValue(ref), o.definition.InputValueDefinitionType(inputValueDefinitionRef))
+}
+
+func (o *oneOfInputObjectsVisitor) EnterVariableDefinition(ref int) {
+	if !o.operation.VariableDefinitionHasDefaultValue(ref) {
+		return
+	}
+
+	typeName := o.operation.ResolveTypeNameBytes(o.operation.VariableDefinitions[ref].Type)
+	node, exists := o.definition.Index.FirstNodeByNameBytes(typeName)
+	if !exists || node.Kind != ast.NodeKindInputObjectTypeDefinition {
+		return
+	}
+
+	o.validateDefaultValue(o.operation.VariableDefinitions[ref].DefaultValue.Value, node.Ref)
+}
+
+// validateDefaultValue validates a variable default value, whose type is declared in the operation
+func (o *oneOfInputObjectsVisitor) validateDefaultValue(value ast.Value, inputObjectRef int) {
+	switch value.Kind {
+	case ast.ValueKindList:
+		for _, itemRef := range o.operation.ListValues[value.Ref].Refs {
+			o.validateDefaultValue(o.operation.Value(itemRef), inputObjectRef)
+		}
+	case ast.ValueKindObject:
+		o.validateObjectValue(value, inputObjectRef)
+	}
+}
+
+// validateValue validates a value against a type of the definition
+func (o *oneOfInputObjectsVisitor) validateValue(value ast.Value, typeRef int) {
+	if o.definition.TypeIsNonNull(typeRef) {
+		typeRef = o.definition.Types[typeRef].OfType
+	}
+
+	switch value.Kind {
+	case ast.ValueKindList:
+		if o.definition.Types[typeRef].TypeKind != ast.TypeKindList {
+			// a list in a non list position is reported by Values
+			return
+		}
+		for _, itemRef := range o.operation.ListValues[value.Ref].Refs {
+			o.validateValue(o.operation.Value(itemRef), o.definition.Types[typeRef].OfType)
+		}
+	case ast.ValueKindObject:
+		node, exists := o.definition.Index.FirstNodeByNameBytes(o.definition.ResolveTypeNameBytes(typeRef))
+		if !exists || node.Kind != ast.NodeKindInputObjectTypeDefinition {
+			return
+		}
+		o.validateObjectValue(value, node.Ref)
+	}
+}
+
+func (o *oneOfInputObjectsVisitor) validateObjectValue(value ast.Value, inputObjectRef int) {
+	objectFieldRefs := o.operation.ObjectValues[value.Ref].Refs
+
+	if o.definition.InputObjectTypeDefinitionIsOneOf(inputObjectRef) {
+		o.validateOneOfObjectValue(value, inputObjectRef)
+	}
+
+	for _, objectFieldRef := range objectFieldRefs {
+		inputValueDefinitionRef := o.definition.InputObjectTypeDefinitionInputValueDefinitionByName(inputObjectRef, o.operation.ObjectFieldNameBytes(objectFieldRef))
+		if inputValueDefinitionRef == ast.InvalidRef {
+			// unknown fields are reported by Values
+			continue
+		}
+		o.validateValue(o.operation.ObjectFieldValue(objectFieldRef), o.definition.InputValueDefinitionType(inputValueDefinitionRef))
+	}
+}
+
+func (o *oneOfInputObjectsVisitor) validateOneOfObjectValue(value ast.Value, inputObjectRef int) {
+	typeName := o.definition.InputObjectTypeDefinitionNameBytes(inputObjectRef)
+	objectFieldRefs := o.operation.ObjectValues[value.Ref].Refs
+
+	if len(objectFieldRefs) != 1 {
+		o.Report.AddExternalError(operationreport.ErrOneOfInputObjectKeyCount(typeName, value.Position))
+		return
+	}
+
+	fieldValue := o.operation.ObjectFieldValue(objectFieldRefs[0])
+	switch fieldValue.Kind {
+	case ast.ValueKindNull:
+		o.Report.AddExternalError(operationreport.ErrOneOfInputObjectNullField(typeName, o.operation.ObjectFieldNameBytes(objectFieldRefs[0]), fieldValue.Position))
+	case ast.ValueKindVariable:
+		variableName := o.operation.VariableValueNameBytes(fieldValue.Ref)
+		if !o.variableIsNonNull(variableName) {
+			o.Report.AddExternalError(operationreport.ErrOneOfInputObjectNullableVariable(variableName, typeName, fieldValue.Position))
+		}
+	}
+}
+
+// variableIsNonNull checks the variable definition of the enclosing operation.
+// Fragments may be spread into any operation, so all operations defining the variable have to declare it non-null.
+func (o *oneOfInputObjectsVisitor) variableIsNonNull(variableName ast.ByteSlice) bool {
+	if len(o.Ancestors) == 0 {
+		return true
+	}
+
+	if o.Ancestors[0].Kind == ast.NodeKindOperationDefinition {
+		return o.operationVariableIsNonNull(o.Ancestors[0].Ref, variableName)
+	}
+
+	for operationRef := range o.operation.OperationDefinitions {
+		if !o.operationVariableIsNonNull(operationRef, variableName) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *oneOfInputObjectsVisitor) operationVariableIsNonNull(operationRef int, variableName ast.ByteSlice) bool {
+	variableDefinitionRef, exists := o.operation.VariableDefinitionByNameAndOperation(operationRef, variableName)
+	if !exists {
+		// undefined variables are reported by AllVariableUsesDefined
+		return true
+	}
+	return o.operation.TypeIsNonNull(o.operation.VariableDefinitions[variableDefinitionRef].Type)
+}
@@ -10,7 +10,9 @@ import (
 )
 
 // ValidArguments validates if arguments are valid: values and variables has compatible types
-// deep variables comparison is handled by Values
+//
+// Deprecated: ValidArguments only compares variables used directly as argument values,
+// use AllVariableUsagesAreAllowed which also covers list items and input object fields.
 func ValidArguments() Rule {
 	return func(walker *astvisitor.Walker) {
 		visitor := validArgumentsVisitor{
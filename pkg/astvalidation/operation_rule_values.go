@@ -10,8 +10,26 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
-// Values validates if values are used properly
+// Values validates if values are used properly, including the types of variables and duplicated input object fields
+//
+// Deprecated: the variable checks of Values don't follow the rules of the spec for allowed variable usages,
+// use ValuesOfCorrectType together with AllVariableUsagesAreAllowed and InputObjectFieldUniqueness.
 func Values() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := valuesVisitor{
+			Walker:         walker,
+			validateUsages: true,
+			validateFields: true,
+		}
+		walker.RegisterEnterDocumentVisitor(&visitor)
+		walker.RegisterEnterArgumentVisitor(&visitor)
+		walker.RegisterEnterVariableDefinitionVisitor(&visitor)
+	}
+}
+
+// ValuesOfCorrectType validates if literal values satisfy the type of their input value definition
+// Variable usages are validated by AllVariableUsagesAreAllowed, duplicated input object fields by InputObjectFieldUniqueness
+func ValuesOfCorrectType() Rule {
 	return func(walker *astvisitor.Walker) {
 		visitor := valuesVisitor{
 			Walker: walker,
@@ -26,6 +44,10 @@ type valuesVisitor struct {
 	*astvisitor.Walker
 	operation, definition *ast.Document
 	importer              astimport.Importer
+	// validateUsages enables comparing the types of variables with the types of the positions they are used in
+	validateUsages bool
+	// validateFields enables reporting duplicated fields of input objects
+	validateFields bool
 }
 
 func (v *valuesVisitor) EnterDocument(operation, definition *ast.Document) {
@@ -57,7 +79,7 @@ func (v *valuesVisitor) EnterArgument(ref int) {
 			v.StopWithExternalErr(operationreport.ErrVariableNotDefinedOnOperation(variableName, operationName))
 			return
 		}
-		if !v.operation.VariableDefinitions[variableDefinition].DefaultValue.IsDefined {
+		if !v.validateUsages || !v.operation.VariableDefinitions[variableDefinition].DefaultValue.IsDefined {
 			return // variable has no default value, deep type check not required
 		}
 		value = v.operation.VariableDefinitions[variableDefinition].DefaultValue.Value
@@ -171,6 +193,9 @@ func (v *valuesVisitor) valuesSatisfiesNonNullType(value ast.Value, definitionTy
 		v.handleUnexpectedNullError(value, definitionTypeRef)
 		return false
 	case ast.ValueKindVariable:
+		if !v.validateUsages {
+			return true
+		}
 		variableDefinitionRef, variableTypeRef, _, ok := v.operationVariableType(value.Ref)
 		if !ok {
 			v.handleTypeError(value, definitionTypeRef)
@@ -210,6 +235,9 @@ func (v *valuesVisitor) valuesSatisfiesNamedType(value ast.Value, definitionType
 func (v *valuesVisitor) valueSatisfiesListType(value ast.Value, definitionTypeRef int, listItemType int) bool {
 
 	if value.Kind == ast.ValueKindVariable {
+		if !v.validateUsages {
+			return true
+		}
 		variableDefinitionRef, actualType, _, ok := v.operationVariableType(value.Ref)
 		if !ok {
 			v.handleTypeError(value, definitionTypeRef)
@@ -444,7 +472,7 @@ func (v *valuesVisitor) valueSatisfiesInputObjectTypeDefinition(value ast.Value,
 		return false
 	}
 
-	if v.objectValueHasDuplicateFields(value.Ref) {
+	if v.validateFields && v.objectValueHasDuplicateFields(value.Ref) {
 		return false
 	}
 
@@ -514,6 +542,10 @@ func (v *valuesVisitor) objectValueSatisfiesInputValueDefinition(objectValue ast
 }
 
 func (v *valuesVisitor) variableValueHasMatchingTypeName(value ast.Value, definitionTypeRef int, expectedTypeName []byte) bool {
+	if !v.validateUsages {
+		return true
+	}
+
 	variableDefinitionRef, _, actualTypeName, ok := v.operationVariableType(value.Ref)
 	if !ok {
 		v.handleVariableHasIncompatibleTypeError(value, definitionTypeRef)
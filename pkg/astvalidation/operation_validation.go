@@ -14,6 +14,7 @@ func DefaultOperationValidator() *OperationValidator {
 		walker: astvisitor.NewWalker(48),
 	}
 
+	validator.RegisterRule(ExecutableDefinitions())
 	validator.RegisterRule(DocumentContainsExecutableOperation())
 	validator.RegisterRule(OperationNameUniqueness())
 	validator.RegisterRule(LoneAnonymousOperation())
@@ -21,8 +22,9 @@ func DefaultOperationValidator() *OperationValidator {
 	validator.RegisterRule(FieldSelections())
 	validator.RegisterRule(FieldSelectionMerging())
 	validator.RegisterRule(KnownArguments())
-	validator.RegisterRule(ValidArguments())
-	validator.RegisterRule(Values())
+	validator.RegisterRule(ValuesOfCorrectType())
+	validator.RegisterRule(InputObjectFieldUniqueness())
+	validator.RegisterRule(OneOfInputObjects())
 	validator.RegisterRule(ArgumentUniqueness())
 	validator.RegisterRule(RequiredArguments())
 	validator.RegisterRule(Fragments())
@@ -33,6 +35,7 @@ func DefaultOperationValidator() *OperationValidator {
 	validator.RegisterRule(VariablesAreInputTypes())
 	validator.RegisterRule(AllVariableUsesDefined())
 	validator.RegisterRule(AllVariablesUsed())
+	validator.RegisterRule(AllVariableUsagesAreAllowed())
 
 	return &validator
 }
@@ -125,10 +125,54 @@ func TestExecutionValidation(t *testing.T) {
 		runWithDefinition(t, testDefinition, operationInput, rule, expectation, opts...)
 	}
 
-	// 5.1 Documents
-	// 5.1.1 Executable Definitions
-	// -> won't be addressed as the parser will only parse operation- and fragment definitions
-	// when parsing executable definitions
+	t.Run("5.1 Documents", func(t *testing.T) {
+		t.Run("5.1.1 Executable Definitions", func(t *testing.T) {
+			t.Run("with operation and fragment", func(t *testing.T) {
+				run(t, `
+							query getDogName {
+								dog {
+									...dogFields
+								}
+							}
+							fragment dogFields on Dog {
+								name
+							}`,
+					ExecutableDefinitions(), Valid, withDisableNormalization())
+			})
+			t.Run("with type definition", func(t *testing.T) {
+				run(t, `
+							query getDogName {
+								dog {
+									name
+								}
+							}
+							type Cow {
+								name: String
+							}
+							extend type Dog {
+								color: String
+							}`,
+					ExecutableDefinitions(), Invalid, withDisableNormalization(),
+					withValidationErrors(
+						`The "Cow" definition is not executable.`,
+						`The "Dog" definition is not executable.`,
+					))
+			})
+			t.Run("with schema definition", func(t *testing.T) {
+				run(t, `
+							schema {
+								query: Query
+							}
+							query getDogName {
+								dog {
+									name
+								}
+							}`,
+					ExecutableDefinitions(), Invalid, withDisableNormalization(),
+					withValidationErrors(`The schema definition is not executable.`))
+			})
+		})
+	})
 
 	t.Run("5.2 Operations", func(t *testing.T) {
 		t.Run("5.2.1 Named Operation Definitions", func(t *testing.T) {
@@ -2894,6 +2938,20 @@ func TestExecutionValidation(t *testing.T) {
 							}`,
 					Values(), Invalid, withValidationErrors(`Variable "$name" of type "String" used in position expecting type "String!"`))
 			})
+			t.Run("145 variant variable non null into required field with AllVariableUsagesAreAllowed", func(t *testing.T) {
+				run(t, `
+							query goodComplexDefaultValue($name: String ) {
+								findDogNonOptional(complex: { name: $name })
+							}`,
+					AllVariableUsagesAreAllowed(), Invalid, withValidationErrors(`Variable "$name" of type "String" used in position expecting type "String!"`))
+			})
+			t.Run("145 variant variable non null into required field with ValuesOfCorrectType", func(t *testing.T) {
+				run(t, `
+							query goodComplexDefaultValue($name: String ) {
+								findDogNonOptional(complex: { name: $name })
+							}`,
+					ValuesOfCorrectType(), Valid)
+			})
 			t.Run("145 variant", func(t *testing.T) {
 				run(t, `
 							query goodComplexDefaultValue($search: ComplexInput = { name: 123 }) {
@@ -2995,6 +3053,105 @@ func TestExecutionValidation(t *testing.T) {
 								}`,
 					Values(), Invalid, withValidationErrors(`There can be only one input field named "name"`))
 			})
+			t.Run("149 with InputObjectFieldUniqueness", func(t *testing.T) {
+				run(t, `{
+									findDog(complex: { name: "Fido", name: "Goofy"})
+								}`,
+					InputObjectFieldUniqueness(), Invalid, withValidationErrors(`There can be only one input field named "name"`))
+			})
+			t.Run("149 variant nested input object", func(t *testing.T) {
+				run(t, `{
+									findNestedDog(complex: { complex: { name: "Fido", name: "Goofy" } })
+								}`,
+					InputObjectFieldUniqueness(), Invalid, withValidationErrors(`There can be only one input field named "name"`))
+			})
+			t.Run("149 variant input object in list", func(t *testing.T) {
+				run(t, `{
+									nested(input: { requiredString: "str", optionalListOfNestedInput: [{ requiredString: "a", requiredString: "b" }] })
+								}`,
+					InputObjectFieldUniqueness(), Invalid, withValidationErrors(`There can be only one input field named "requiredString"`))
+			})
+			t.Run("149 variant variable default value", func(t *testing.T) {
+				run(t, `query findDog($complex: ComplexInput = { name: "Fido", name: "Goofy" }) {
+									findDog(complex: $complex)
+								}`,
+					InputObjectFieldUniqueness(), Invalid, withDisableNormalization(), withValidationErrors(`There can be only one input field named "name"`))
+			})
+			t.Run("149 variant same field in sibling objects", func(t *testing.T) {
+				run(t, `{
+									findNestedDog(complex: { complex: { name: "Fido" } })
+									findDog(complex: { name: "Fido" })
+								}`,
+					InputObjectFieldUniqueness(), Valid)
+			})
+		})
+		t.Run("5.6.x OneOf Input Objects", func(t *testing.T) {
+			t.Run("exactly one field", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `{
+									pet(by: { name: "Fido" })
+								}`,
+					OneOfInputObjects(), Valid)
+			})
+			t.Run("no fields", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `{
+									pet(by: {})
+								}`,
+					OneOfInputObjects(), Invalid, withValidationErrors(`OneOf Input Object "PetBy" must specify exactly one key.`))
+			})
+			t.Run("more than one field", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `{
+									pet(by: { id: 1, name: "Fido" })
+								}`,
+					OneOfInputObjects(), Invalid, withValidationErrors(`OneOf Input Object "PetBy" must specify exactly one key.`))
+			})
+			t.Run("null field", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `{
+									pet(by: { name: null })
+								}`,
+					OneOfInputObjects(), Invalid, withValidationErrors(`Field "PetBy.name" must be non-null.`))
+			})
+			t.Run("non-null variable field", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `query ($name: String!) {
+									pet(by: { name: $name })
+								}`,
+					OneOfInputObjects(), Valid)
+			})
+			t.Run("nullable variable field", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `query ($name: String) {
+									pet(by: { name: $name })
+								}`,
+					OneOfInputObjects(), Invalid, withValidationErrors(`Variable "$name" must be non-nullable to be used for OneOf Input Object "PetBy".`))
+			})
+			t.Run("nullable variable field in fragment", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `
+								query ($name: String) { ...petFragment }
+								fragment petFragment on Query { pet(by: { name: $name }) }`,
+					OneOfInputObjects(), Invalid, withDisableNormalization(), withValidationErrors(`Variable "$name" must be non-nullable to be used for OneOf Input Object "PetBy".`))
+			})
+			t.Run("variable for the whole input object", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `query ($by: PetBy!) {
+									pet(by: $by)
+								}`,
+					OneOfInputObjects(), Valid)
+			})
+			t.Run("nested in list and input object", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `{
+									pets(filter: { by: [{ id: 1 }, { id: 2, name: "Fido" }] })
+								}`,
+					OneOfInputObjects(), Invalid, withValidationErrors(`OneOf Input Object "PetBy" must specify exactly one key.`))
+			})
+			t.Run("variable default value", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `query ($by: PetBy = { id: 1, name: "Fido" }) {
+									pet(by: $by)
+								}`,
+					OneOfInputObjects(), Invalid, withDisableNormalization(), withValidationErrors(`OneOf Input Object "PetBy" must specify exactly one key.`))
+			})
+			t.Run("regular input object", func(t *testing.T) {
+				runWithDefinition(t, oneOfDefinition, `{
+									pets(filter: { by: [], limit: 1 })
+								}`,
+					OneOfInputObjects(), Valid)
+			})
 		})
 		t.Run("5.6.4 Input Object Required Fields", func(t *testing.T) {
 			t.Run("145 variant", func(t *testing.T) {
@@ -3857,6 +4014,21 @@ func TestExecutionValidation(t *testing.T) {
 						`Variable "$b" of type "Boolean" used in position expecting type "String"`,
 					))
 			})
+			t.Run("with variables inside an input object with AllVariableUsagesAreAllowed", func(t *testing.T) {
+				runWithDefinition(t, wundergraphSchema, `
+					query QueryWithNestedBooleanClause($a: String, $b: Boolean) {
+						findFirstnodepool(
+							where: { id: { equals: $b }, AND: { shared: { equals: $a } } }
+						) {
+							id
+						}
+					}
+					`, AllVariableUsagesAreAllowed(), Invalid,
+					withValidationErrors(
+						`Variable "$a" of type "String" used in position expecting type "Boolean"`,
+						`Variable "$b" of type "Boolean" used in position expecting type "String"`,
+					))
+			})
 
 			t.Run("with variables inside an input object", func(t *testing.T) {
 				run(t, `
@@ -3870,6 +4042,76 @@ func TestExecutionValidation(t *testing.T) {
 						`Variable "$a" of type "Boolean" used in position expecting type "[String]"`,
 					))
 			})
+			t.Run("with variables inside an input object with AllVariableUsagesAreAllowed", func(t *testing.T) {
+				run(t, `
+					query booleanIntoStringList($a: Boolean) {
+						findDog(complex: {optionalListOfOptionalStrings: $a}) {
+							id
+						}
+					}
+					`, AllVariableUsagesAreAllowed(), Invalid,
+					withValidationErrors(
+						`Variable "$a" of type "Boolean" used in position expecting type "[String]"`,
+					))
+			})
+			t.Run("nullable variable in non-null list item position", func(t *testing.T) {
+				run(t, `
+					query booleanIntoListOfNonNull($a: Boolean) {
+						arguments {
+							listOfNonNullBooleanArgField(listOfNonNullBooleanArg: [true, $a])
+						}
+					}
+					`, AllVariableUsagesAreAllowed(), Invalid,
+					withValidationErrors(
+						`Variable "$a" of type "Boolean" used in position expecting type "Boolean!"`,
+					))
+			})
+			t.Run("non-null variable in non-null list item position", func(t *testing.T) {
+				run(t, `
+					query booleanIntoListOfNonNull($a: Boolean!) {
+						arguments {
+							listOfNonNullBooleanArgField(listOfNonNullBooleanArg: [true, $a])
+						}
+					}
+					`, AllVariableUsagesAreAllowed(), Valid)
+			})
+			t.Run("nullable variable in non-null input field with default value", func(t *testing.T) {
+				run(t, `
+					query stringIntoFieldWithDefault($s: String) {
+						nested(input: {requiredString: "str", requiredStringWithDefault: $s, requiredListOfOptionalStrings: [], requiredListOfRequiredStrings: []})
+					}
+					`, AllVariableUsagesAreAllowed(), Valid)
+			})
+			t.Run("nullable variable in non-null input field", func(t *testing.T) {
+				run(t, `
+					query stringIntoRequiredField($s: String) {
+						nested(input: {requiredString: $s, requiredListOfOptionalStrings: [], requiredListOfRequiredStrings: []})
+					}
+					`, AllVariableUsagesAreAllowed(), Invalid,
+					withValidationErrors(
+						`Variable "$s" of type "String" used in position expecting type "String!"`,
+					))
+			})
+			t.Run("variable usage within fragment is validated per operation", func(t *testing.T) {
+				run(t, `
+					query nonNullBoolean($a: Boolean!) {
+						arguments {
+							...nonNullBooleanArgFragment
+						}
+					}
+					query nullableBoolean($a: Boolean) {
+						arguments {
+							...nonNullBooleanArgFragment
+						}
+					}
+					fragment nonNullBooleanArgFragment on ValidArguments {
+						nonNullBooleanArgField(nonNullBooleanArg: $a)
+					}
+					`, AllVariableUsagesAreAllowed(), Invalid, withDisableNormalization(),
+					withValidationErrors(
+						`Variable "$a" of type "Boolean" used in position expecting type "Boolean!"`,
+					))
+			})
 		})
 	})
 }
@@ -4782,6 +5024,31 @@ enum __TypeKind {
     NON_NULL
 }`
 
+const oneOfDefinition = `
+scalar ID
+scalar Int
+scalar String
+directive @oneOf on INPUT_OBJECT
+
+schema {
+	query: Query
+}
+
+type Query {
+	pet(by: PetBy!): String
+	pets(filter: PetFilter): [String]
+}
+
+input PetBy @oneOf {
+	id: ID
+	name: String
+}
+
+input PetFilter {
+	by: [PetBy!]!
+	limit: Int
+}`
+
 const boxDefinition = `
 scalar String
 scalar ID
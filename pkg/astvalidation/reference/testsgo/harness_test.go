@@ -62,7 +62,7 @@ const (
 )
 
 var rulesMap = map[string][]astvalidation.Rule{
-	ExecutableDefinitionsRule:                 {astvalidation.DocumentContainsExecutableOperation()},
+	ExecutableDefinitionsRule:                 {astvalidation.ExecutableDefinitions()},
 	FieldsOnCorrectTypeRule:                   {astvalidation.FieldSelections()},
 	KnownArgumentNamesRule:                    {astvalidation.KnownArguments()},
 	KnownArgumentNamesOnDirectivesRule:        {},
@@ -83,10 +83,10 @@ var rulesMap = map[string][]astvalidation.Rule{
 	UniqueOperationTypesRule:                  {astvalidation.UniqueOperationTypes()},
 	UniqueTypeNamesRule:                       {astvalidation.UniqueTypeNames()},
 	UniqueVariableNamesRule:                   {astvalidation.VariableUniqueness()},
-	ValuesOfCorrectTypeRule:                   {astvalidation.Values()},
+	ValuesOfCorrectTypeRule:                   {astvalidation.ValuesOfCorrectType()},
 	VariablesAreInputTypesRule:                {astvalidation.VariablesAreInputTypes()},
 	KnownTypeNamesOperationRule:               {astvalidation.VariablesAreInputTypes(), astvalidation.Fragments()},
-	VariablesInAllowedPositionRule:            {astvalidation.ValidArguments(), astvalidation.Values()},
+	VariablesInAllowedPositionRule:            {astvalidation.AllVariableUsagesAreAllowed()},
 
 	// fragments rules
 	FragmentsOnCompositeTypesRule: {astvalidation.Fragments()},
@@ -96,10 +96,12 @@ var rulesMap = map[string][]astvalidation.Rule{
 	PossibleFragmentSpreadsRule:   {astvalidation.Fragments()},
 	UniqueFragmentNamesRule:       {astvalidation.Fragments()},
 
+	UniqueInputFieldNamesRule: {astvalidation.InputObjectFieldUniqueness()},
+
+	UniqueDirectiveNamesRule: {astvalidation.UniqueDirectiveNames()},
+
 	// not mapped rules
 
-	UniqueInputFieldNamesRule:  {astvalidation.Values()},
-	UniqueDirectiveNamesRule:   {},
 	LoneSchemaDefinitionRule:   {},
 	ScalarLeafsRule:            {},
 	PossibleTypeExtensionsRule: {},
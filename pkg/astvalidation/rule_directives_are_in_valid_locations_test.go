@@ -0,0 +1,69 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestDirectivesAreInValidLocations(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("directives in valid locations", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @onSchema on SCHEMA
+					directive @onObject on OBJECT
+					directive @onField on FIELD_DEFINITION
+					directive @onArgument on ARGUMENT_DEFINITION
+					directive @onScalar on SCALAR
+					directive @onEnumValue on ENUM_VALUE
+					directive @onInputField on INPUT_FIELD_DEFINITION
+
+					schema @onSchema { query: Query }
+					type Query @onObject {
+						foo(bar: String @onArgument): String @onField
+					}
+					scalar Date @onScalar
+					extend scalar Date @onScalar
+					enum Foo { BAR @onEnumValue }
+					input Baz { foo: String @onInputField }
+				`, Valid, DirectivesAreInValidLocations(),
+			)
+		})
+
+		t.Run("undefined directive", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query @undefined { foo: String }
+				`, Valid, DirectivesAreInValidLocations(),
+			)
+		})
+
+		t.Run("field directive on an object", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @onField on FIELD_DEFINITION
+					type Query @onField { foo: String }
+				`, Invalid, DirectivesAreInValidLocations(),
+			)
+		})
+
+		t.Run("input field directive on an argument", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @onInputField on INPUT_FIELD_DEFINITION
+					type Query { foo(bar: String @onInputField): String }
+				`, Invalid, DirectivesAreInValidLocations(),
+			)
+		})
+
+		t.Run("argument directive on an input field", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @onArgument on ARGUMENT_DEFINITION
+					input Foo { bar: String @onArgument }
+				`, Invalid, DirectivesAreInValidLocations(),
+			)
+		})
+
+		t.Run("executable directive on a field definition", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { foo: String @skip(if: true) }
+				`, Invalid, DirectivesAreInValidLocations(),
+			)
+		})
+	})
+}
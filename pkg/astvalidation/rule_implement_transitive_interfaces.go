@@ -56,7 +56,8 @@ func (v *implementTransitiveInterfacesVisitor) LeaveDocument(operation, definiti
 			for j := 0; j < len(v.typesImplementingInterfaces[implementedInterfaceName]); j++ {
 				transitiveInterfaceName := v.typesImplementingInterfaces[implementedInterfaceName][j]
 				if _, ok := interfaceNamesLookupList[transitiveInterfaceName]; !ok {
-					v.Report.AddExternalError(operationreport.ErrTransitiveInterfaceNotImplemented([]byte(typeName), []byte(transitiveInterfaceName)))
+					typeNode, _ := v.definition.Index.FirstNodeByNameStr(typeName)
+					v.Report.AddExternalError(withLocation(operationreport.ErrTransitiveInterfaceNotImplemented([]byte(typeName), []byte(transitiveInterfaceName)), v.definition.NodePosition(typeNode)))
 				}
 			}
 		}
@@ -82,7 +83,7 @@ func (v *implementTransitiveInterfacesVisitor) EnterInterfaceTypeExtension(ref i
 	interfaceName := v.definition.InterfaceTypeExtensionNameString(ref)
 	fieldDefinitionRefs := v.definition.InterfaceTypeExtensions[ref].FieldsDefinition.Refs
 	if len(fieldDefinitionRefs) == 0 {
-		v.Report.AddExternalError(operationreport.ErrTransitiveInterfaceExtensionImplementingWithoutBody([]byte(interfaceName)))
+		v.Report.AddExternalError(withLocation(operationreport.ErrTransitiveInterfaceExtensionImplementingWithoutBody([]byte(interfaceName)), v.definition.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref})))
 	}
 	v.collectImplementedInterfaces(interfaceName, v.definition.InterfaceTypeExtensions[ref].ImplementsInterfaces.Refs)
 }
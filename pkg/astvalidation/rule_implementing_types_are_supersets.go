@@ -54,6 +54,9 @@ func (v *implementingTypesAreSupersetsVisitor) LeaveDocument(operation, definiti
 			typeNameHasFields = false
 		}
 
+		typeNode, _ := v.definition.Index.FirstNodeByNameStr(typeName)
+		typePosition := v.definition.NodePosition(typeNode)
+
 		typeNameFieldsLookupMap := map[string]bool{}
 		for i := 0; i < len(typeNameFields); i++ {
 			typeNameFieldsLookupMap[typeNameFields[i]] = true
@@ -78,18 +81,14 @@ func (v *implementingTypesAreSupersetsVisitor) LeaveDocument(operation, definiti
 			}
 
 			if !typeNameHasFields && len(interfaceFieldRefs) > 0 {
-				v.Report.AddExternalError(operationreport.ErrImplementingTypeDoesNotHaveFields([]byte(typeName)))
+				v.Report.AddExternalError(withLocation(operationreport.ErrImplementingTypeDoesNotHaveFields([]byte(typeName)), typePosition))
 				continue
 			}
 
 			for j := 0; j < len(interfaceFieldRefs); j++ {
 				interfaceFieldName := v.definition.FieldDefinitionNameString(interfaceFieldRefs[j])
 				if existsOnType := typeNameFieldsLookupMap[interfaceFieldName]; !existsOnType {
-					v.Report.AddExternalError(operationreport.ErrTypeDoesNotImplementFieldFromInterface(
-						[]byte(typeName),
-						[]byte(interfacesNames[i]),
-						[]byte(interfaceFieldName),
-					))
+					v.Report.AddExternalError(withLocation(operationreport.ErrTypeDoesNotImplementFieldFromInterface([]byte(typeName), []byte(interfacesNames[i]), []byte(interfaceFieldName)), typePosition))
 				}
 			}
 		}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -33,19 +34,25 @@ type knownTypeNamesVisitor struct {
 	*astvisitor.Walker
 	definition           *ast.Document
 	definedTypeNameHashs map[uint64]bool
-	referencedTypeNames  map[uint64][]byte
+	referencedTypeNames  map[uint64]referencedTypeName
+}
+
+// referencedTypeName is the name of a referenced type and the position of its first reference
+type referencedTypeName struct {
+	name     ast.ByteSlice
+	position position.Position
 }
 
 func (u *knownTypeNamesVisitor) EnterDocument(operation, _ *ast.Document) {
 	u.definition = operation
 	u.definedTypeNameHashs = make(map[uint64]bool)
-	u.referencedTypeNames = make(map[uint64][]byte)
+	u.referencedTypeNames = make(map[uint64]referencedTypeName)
 }
 
 func (u *knownTypeNamesVisitor) LeaveDocument(_, _ *ast.Document) {
 	for referencedTypeNameHash, referencedTypeName := range u.referencedTypeNames {
 		if !u.definedTypeNameHashs[referencedTypeNameHash] {
-			u.Report.AddExternalError(operationreport.ErrTypeUndefined(referencedTypeName))
+			u.Report.AddExternalError(withLocation(operationreport.ErrTypeUndefined(referencedTypeName.name), referencedTypeName.position))
 			continue
 		}
 	}
@@ -53,25 +60,25 @@ func (u *knownTypeNamesVisitor) LeaveDocument(_, _ *ast.Document) {
 }
 
 func (u *knownTypeNamesVisitor) EnterRootOperationTypeDefinition(ref int) {
-	referencedTypeName := u.definition.Input.ByteSlice(u.definition.RootOperationTypeDefinitions[ref].NamedType.Name)
-	u.saveReferencedTypeName(referencedTypeName)
+	namedType := u.definition.RootOperationTypeDefinitions[ref].NamedType
+	u.saveReferencedTypeName(u.definition.Input.ByteSlice(namedType.Name), namedType.Position)
 }
 
 func (u *knownTypeNamesVisitor) EnterFieldDefinition(ref int) {
 	referencedTypeRef := u.definition.ResolveUnderlyingType(u.definition.FieldDefinitions[ref].Type)
 	referencedTypeName := u.definition.TypeNameBytes(referencedTypeRef)
-	u.saveReferencedTypeName(referencedTypeName)
+	u.saveReferencedTypeName(referencedTypeName, u.definition.Types[referencedTypeRef].Position)
 }
 
 func (u *knownTypeNamesVisitor) EnterUnionMemberType(ref int) {
 	referencedTypeName := u.definition.TypeNameBytes(ref)
-	u.saveReferencedTypeName(referencedTypeName)
+	u.saveReferencedTypeName(referencedTypeName, u.definition.Types[ref].Position)
 }
 
 func (u *knownTypeNamesVisitor) EnterInputValueDefinition(ref int) {
 	referencedTypeRef := u.definition.InputValueDefinitions[ref].Type
 	referencedTypeName := u.definition.TypeNameBytes(referencedTypeRef)
-	u.saveReferencedTypeName(referencedTypeName)
+	u.saveReferencedTypeName(referencedTypeName, u.definition.Types[referencedTypeRef].Position)
 }
 
 func (u *knownTypeNamesVisitor) EnterObjectTypeDefinition(ref int) {
@@ -113,9 +120,13 @@ func (u *knownTypeNamesVisitor) saveTypeName(typeName ast.ByteSlice) {
 	u.definedTypeNameHashs[xxhash.Sum64(typeName)] = true
 }
 
-func (u *knownTypeNamesVisitor) saveReferencedTypeName(referencedTypeName ast.ByteSlice) {
-	if len(referencedTypeName) == 0 {
+func (u *knownTypeNamesVisitor) saveReferencedTypeName(name ast.ByteSlice, position position.Position) {
+	if len(name) == 0 {
+		return
+	}
+	hash := xxhash.Sum64(name)
+	if _, exists := u.referencedTypeNames[hash]; exists {
 		return
 	}
-	u.referencedTypeNames[xxhash.Sum64(referencedTypeName)] = referencedTypeName
+	u.referencedTypeNames[hash] = referencedTypeName{name: name, position: position}
 }
@@ -0,0 +1,139 @@
+package astvalidation
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+var (
+	reservedNamePrefix = []byte("__")
+
+	// introspectionTypeNames and introspectionFieldNames are defined by the base schema and may use the reserved prefix
+	introspectionTypeNames = [][]byte{
+		[]byte("__Schema"),
+		[]byte("__Type"),
+		[]byte("__TypeKind"),
+		[]byte("__Field"),
+		[]byte("__InputValue"),
+		[]byte("__EnumValue"),
+		[]byte("__DirectiveLocation"),
+		[]byte("__Directive"),
+	}
+	introspectionFieldNames = [][]byte{
+		[]byte("__typename"),
+		[]byte("__schema"),
+		[]byte("__type"),
+	}
+	reservedEnumValueNames = [][]byte{
+		[]byte("true"),
+		[]byte("false"),
+		[]byte("null"),
+	}
+)
+
+// NamesAreNotReserved validates if no type, field, argument, input field, enum value or directive name
+// begins with '__', which is reserved for introspection, and if no enum value is named true, false or null
+func NamesAreNotReserved() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &namesAreNotReservedVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterInterfaceTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterUnionTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterScalarTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterEnumTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterInputObjectTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterDirectiveDefinitionVisitor(visitor)
+		walker.RegisterEnterFieldDefinitionVisitor(visitor)
+		walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+		walker.RegisterEnterEnumValueDefinitionVisitor(visitor)
+	}
+}
+
+type namesAreNotReservedVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (n *namesAreNotReservedVisitor) EnterDocument(operation, _ *ast.Document) {
+	n.definition = operation
+}
+
+func (n *namesAreNotReservedVisitor) EnterObjectTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.ObjectTypeDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterInterfaceTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.InterfaceTypeDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterUnionTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.UnionTypeDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterScalarTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.ScalarTypeDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindScalarTypeDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterEnumTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.EnumTypeDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterInputObjectTypeDefinition(ref int) {
+	n.checkTypeName(n.definition.InputObjectTypeDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterDirectiveDefinition(ref int) {
+	n.checkName(n.definition.DirectiveDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindDirectiveDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterFieldDefinition(ref int) {
+	fieldName := n.definition.FieldDefinitionNameBytes(ref)
+	if containsName(introspectionFieldNames, fieldName) {
+		return
+	}
+	n.checkName(fieldName, ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterInputValueDefinition(ref int) {
+	n.checkName(n.definition.InputValueDefinitionNameBytes(ref), ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: ref})
+}
+
+func (n *namesAreNotReservedVisitor) EnterEnumValueDefinition(ref int) {
+	enumValueName := n.definition.EnumValueDefinitionNameBytes(ref)
+	enumValue := ast.Node{Kind: ast.NodeKindEnumValueDefinition, Ref: ref}
+	if containsName(reservedEnumValueNames, enumValueName) {
+		enumName := n.definition.NodeNameBytes(n.Ancestors[len(n.Ancestors)-1])
+		n.Report.AddExternalError(withLocation(operationreport.ErrEnumValueNameIsReserved(enumName, enumValueName), n.definition.NodePosition(enumValue)))
+		return
+	}
+	n.checkName(enumValueName, enumValue)
+}
+
+func (n *namesAreNotReservedVisitor) checkTypeName(typeName ast.ByteSlice, node ast.Node) {
+	if containsName(introspectionTypeNames, typeName) {
+		return
+	}
+	n.checkName(typeName, node)
+}
+
+func (n *namesAreNotReservedVisitor) checkName(name ast.ByteSlice, node ast.Node) {
+	if bytes.HasPrefix(name, reservedNamePrefix) {
+		n.Report.AddExternalError(withLocation(operationreport.ErrNameIsReserved(name), n.definition.NodePosition(node)))
+	}
+}
+
+func containsName(names [][]byte, name []byte) bool {
+	for i := range names {
+		if bytes.Equal(names[i], name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestNamesAreNotReserved(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("names without reserved prefix", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						foo_(_bar: String): Foo
+					}
+					enum Foo { TRUE False _null }
+					input Bar { _foo: String }
+					directive @_foo on FIELD_DEFINITION
+				`, Valid, NamesAreNotReserved(),
+			)
+		})
+
+		t.Run("introspection types and fields of the base schema", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						foo: String
+					}
+				`, Valid, NamesAreNotReserved(),
+			)
+		})
+
+		t.Run("reserved type name", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type __Foo { foo: String }
+				`, Invalid, NamesAreNotReserved(),
+			)
+		})
+
+		t.Run("reserved field name", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { __foo: String }
+				`, Invalid, NamesAreNotReserved(),
+			)
+		})
+
+		t.Run("reserved argument name", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { foo(__bar: String): String }
+				`, Invalid, NamesAreNotReserved(),
+			)
+		})
+
+		t.Run("reserved input field name", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo { __bar: String }
+				`, Invalid, NamesAreNotReserved(),
+			)
+		})
+
+		t.Run("reserved enum value name", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					enum Foo { __BAR }
+				`, Invalid, NamesAreNotReserved(),
+			)
+		})
+
+		t.Run("reserved directive name", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @__foo on FIELD_DEFINITION
+				`, Invalid, NamesAreNotReserved(),
+			)
+		})
+
+		for _, enumValue := range []string{"true", "false", "null"} {
+			t.Run("enum value named "+enumValue, func(t *testing.T) {
+				runDefinitionValidation(t, `
+					enum Foo { `+enumValue+` }
+				`, Invalid, NamesAreNotReserved(),
+				)
+			})
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package astvalidation
+
+import (
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// NoInputObjectCircularReferences validates if no input object references itself through a chain of non-null fields,
+// because it would be impossible to provide a finite value for it
+func NoInputObjectCircularReferences() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &noInputObjectCircularReferencesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+	}
+}
+
+type noInputObjectCircularReferencesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+	// inputFields contains the input field refs of all input object definitions and extensions by type name
+	inputFields          map[string][]int
+	visitedTypeNames     map[string]bool
+	fieldPath            []int
+	fieldPathIndexByName map[string]int
+}
+
+func (n *noInputObjectCircularReferencesVisitor) EnterDocument(operation, _ *ast.Document) {
+	n.definition = operation
+	n.inputFields = make(map[string][]int)
+	n.visitedTypeNames = make(map[string]bool)
+	n.fieldPath = n.fieldPath[:0]
+	n.fieldPathIndexByName = make(map[string]int)
+
+	var typeNames []string
+	for _, node := range operation.RootNodes {
+		var typeName string
+		var fieldRefs []int
+
+		switch node.Kind {
+		case ast.NodeKindInputObjectTypeDefinition:
+			typeName = operation.InputObjectTypeDefinitionNameString(node.Ref)
+			fieldRefs = operation.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs
+			typeNames = append(typeNames, typeName)
+		case ast.NodeKindInputObjectTypeExtension:
+			typeName = operation.InputObjectTypeExtensionNameString(node.Ref)
+			fieldRefs = operation.InputObjectTypeExtensions[node.Ref].InputFieldsDefinition.Refs
+		default:
+			continue
+		}
+
+		n.inputFields[typeName] = append(n.inputFields[typeName], fieldRefs...)
+	}
+
+	for _, typeName := range typeNames {
+		n.detectCycle(typeName)
+	}
+}
+
+// detectCycle performs a depth first search over the non-null input fields and reports every cycle once
+func (n *noInputObjectCircularReferencesVisitor) detectCycle(typeName string) {
+	if n.visitedTypeNames[typeName] {
+		return
+	}
+
+	n.visitedTypeNames[typeName] = true
+	n.fieldPathIndexByName[typeName] = len(n.fieldPath)
+
+	for _, fieldRef := range n.inputFields[typeName] {
+		typeRef := n.definition.InputValueDefinitionType(fieldRef)
+		if !n.definition.TypeIsNonNull(typeRef) {
+			continue
+		}
+
+		typeRef = n.definition.Types[typeRef].OfType
+		if n.definition.Types[typeRef].TypeKind != ast.TypeKindNamed {
+			// lists may be empty, so they break the cycle
+			continue
+		}
+
+		fieldTypeName := n.definition.TypeNameString(typeRef)
+		if _, isInputObject := n.inputFields[fieldTypeName]; !isInputObject {
+			continue
+		}
+
+		n.fieldPath = append(n.fieldPath, fieldRef)
+
+		cycleIndex, inPath := n.fieldPathIndexByName[fieldTypeName]
+		if !inPath {
+			n.detectCycle(fieldTypeName)
+		} else {
+			n.reportCycle(fieldTypeName, n.fieldPath[cycleIndex:])
+		}
+
+		n.fieldPath = n.fieldPath[:len(n.fieldPath)-1]
+	}
+
+	delete(n.fieldPathIndexByName, typeName)
+}
+
+func (n *noInputObjectCircularReferencesVisitor) reportCycle(typeName string, cycle []int) {
+	fieldNames := make([]string, 0, len(cycle))
+	for _, fieldRef := range cycle {
+		fieldNames = append(fieldNames, n.definition.InputValueDefinitionNameString(fieldRef))
+	}
+
+	n.Report.AddExternalError(withLocation(operationreport.ErrInputObjectCircularReference(ast.ByteSlice(typeName), strings.Join(fieldNames, ".")), n.definition.NodePosition(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: cycle[0]})))
+}
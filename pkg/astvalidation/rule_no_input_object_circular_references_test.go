@@ -0,0 +1,75 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestNoInputObjectCircularReferences(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("nullable self reference", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo {
+						foo: Foo
+					}
+				`, Valid, NoInputObjectCircularReferences(),
+			)
+		})
+
+		t.Run("non-null list self reference", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo {
+						foos: [Foo!]!
+					}
+				`, Valid, NoInputObjectCircularReferences(),
+			)
+		})
+
+		t.Run("cycle broken by a nullable field", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo {
+						bar: Bar!
+					}
+					input Bar {
+						foo: Foo
+					}
+				`, Valid, NoInputObjectCircularReferences(),
+			)
+		})
+
+		t.Run("non-null self reference", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo {
+						foo: Foo!
+					}
+				`, Invalid, NoInputObjectCircularReferences(),
+			)
+		})
+
+		t.Run("non-null cycle through many input objects", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo {
+						bar: Bar!
+					}
+					input Bar {
+						baz: Baz!
+					}
+					input Baz {
+						foo: Foo!
+					}
+				`, Invalid, NoInputObjectCircularReferences(),
+			)
+		})
+
+		t.Run("non-null self reference added by an extension", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo {
+						name: String
+					}
+					extend input Foo {
+						foo: Foo!
+					}
+				`, Invalid, NoInputObjectCircularReferences(),
+			)
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// OneOfInputFieldsAreNullable validates if all fields of OneOf Input Objects are nullable and have no default value,
+// otherwise it would be impossible to provide exactly one of them
+func OneOfInputFieldsAreNullable() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &oneOfInputFieldsAreNullableVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterInputObjectTypeDefinitionVisitor(visitor)
+	}
+}
+
+type oneOfInputFieldsAreNullableVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (o *oneOfInputFieldsAreNullableVisitor) EnterDocument(operation, _ *ast.Document) {
+	o.definition = operation
+}
+
+func (o *oneOfInputFieldsAreNullableVisitor) EnterInputObjectTypeDefinition(ref int) {
+	if !o.definition.InputObjectTypeDefinitionIsOneOf(ref) {
+		return
+	}
+
+	typeName := o.definition.InputObjectTypeDefinitionNameBytes(ref)
+	for _, fieldRef := range o.definition.InputObjectTypeDefinitions[ref].InputFieldsDefinition.Refs {
+		fieldName := o.definition.InputValueDefinitionNameBytes(fieldRef)
+		fieldPosition := o.definition.NodePosition(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: fieldRef})
+		if o.definition.TypeIsNonNull(o.definition.InputValueDefinitionType(fieldRef)) {
+			o.Report.AddExternalError(withLocation(operationreport.ErrOneOfInputFieldMustBeNullable(typeName, fieldName), fieldPosition))
+		}
+		if o.definition.InputValueDefinitionHasDefaultValue(fieldRef) {
+			o.Report.AddExternalError(withLocation(operationreport.ErrOneOfInputFieldMustNotHaveDefaultValue(typeName, fieldName), fieldPosition))
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestOneOfInputFieldsAreNullable(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("nullable fields", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input PetBy @oneOf {
+						id: ID
+						name: String
+					}
+				`, Valid, OneOfInputFieldsAreNullable(),
+			)
+		})
+
+		t.Run("non-null field of a regular input object", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input PetBy {
+						id: ID!
+						name: String = "Fido"
+					}
+				`, Valid, OneOfInputFieldsAreNullable(),
+			)
+		})
+
+		t.Run("non-null field", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input PetBy @oneOf {
+						id: ID!
+						name: String
+					}
+				`, Invalid, OneOfInputFieldsAreNullable(),
+			)
+		})
+
+		t.Run("field with default value", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input PetBy @oneOf {
+						id: ID
+						name: String = "Fido"
+					}
+				`, Invalid, OneOfInputFieldsAreNullable(),
+			)
+		})
+	})
+}
@@ -37,28 +37,28 @@ func (p *populatedTypeBodiesVisitor) EnterDocument(operation, _ *ast.Document) {
 
 func (p populatedTypeBodiesVisitor) EnterEnumTypeDefinition(ref int) {
 	if !p.definition.EnumTypeDefinitions[ref].HasEnumValuesDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("enum", p.definition.EnumTypeDefinitionNameString(ref)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("enum", p.definition.EnumTypeDefinitionNameString(ref)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref})))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterEnumTypeExtension(ref int) {
 	if !p.definition.EnumTypeExtensions[ref].HasEnumValuesDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("enum extension", p.definition.EnumTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("enum extension", p.definition.EnumTypeExtensionNameString(ref)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: ref})))
 		return
 	}
 }
 
 func (p populatedTypeBodiesVisitor) EnterInputObjectTypeDefinition(ref int) {
 	if !p.definition.InputObjectTypeDefinitions[ref].HasInputFieldsDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("input", p.definition.InputObjectTypeDefinitionNameString(ref)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("input", p.definition.InputObjectTypeDefinitionNameString(ref)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref})))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterInputObjectTypeExtension(ref int) {
 	if !p.definition.InputObjectTypeExtensions[ref].HasInputFieldsDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("input extension", p.definition.InputObjectTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("input extension", p.definition.InputObjectTypeExtensionNameString(ref)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindInputObjectTypeExtension, Ref: ref})))
 		return
 	}
 }
@@ -71,14 +71,14 @@ func (p populatedTypeBodiesVisitor) EnterInterfaceTypeDefinition(ref int) {
 		}
 		fallthrough
 	case false:
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("interface", p.definition.InterfaceTypeDefinitionNameString(ref)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("interface", p.definition.InterfaceTypeDefinitionNameString(ref)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref})))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterInterfaceTypeExtension(ref int) {
 	if !p.definition.InterfaceTypeExtensions[ref].HasFieldDefinitions {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("interface extension", p.definition.InterfaceTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("interface extension", p.definition.InterfaceTypeExtensionNameString(ref)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref})))
 		return
 	}
 }
@@ -93,14 +93,14 @@ func (p populatedTypeBodiesVisitor) EnterObjectTypeDefinition(ref int) {
 		}
 		fallthrough
 	case false:
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("object", string(nameBytes)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("object", string(nameBytes)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref})))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterObjectTypeExtension(ref int) {
 	if !p.definition.ObjectTypeExtensions[ref].HasFieldDefinitions {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("object extension", p.definition.ObjectTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(withLocation(operationreport.ErrTypeBodyMustNotBeEmpty("object extension", p.definition.ObjectTypeExtensionNameString(ref)), p.definition.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref})))
 		return
 	}
 }
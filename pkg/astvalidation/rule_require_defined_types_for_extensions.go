@@ -34,42 +34,42 @@ func (r *requireDefinedTypesForExtensionsVisitor) EnterDocument(operation, defin
 func (r *requireDefinedTypesForExtensionsVisitor) EnterScalarTypeExtension(ref int) {
 	name := r.definition.ScalarTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindScalarTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrScalarTypeUndefined(name))
+		r.Report.AddExternalError(withLocation(operationreport.ErrScalarTypeUndefined(name), r.definition.NodePosition(ast.Node{Kind: ast.NodeKindScalarTypeExtension, Ref: ref})))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterObjectTypeExtension(ref int) {
 	name := r.definition.ObjectTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindObjectTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrTypeUndefined(name))
+		r.Report.AddExternalError(withLocation(operationreport.ErrTypeUndefined(name), r.definition.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref})))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterInterfaceTypeExtension(ref int) {
 	name := r.definition.InterfaceTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindInterfaceTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrInterfaceTypeUndefined(name))
+		r.Report.AddExternalError(withLocation(operationreport.ErrInterfaceTypeUndefined(name), r.definition.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref})))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterUnionTypeExtension(ref int) {
 	name := r.definition.UnionTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindUnionTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrUnionTypeUndefined(name))
+		r.Report.AddExternalError(withLocation(operationreport.ErrUnionTypeUndefined(name), r.definition.NodePosition(ast.Node{Kind: ast.NodeKindUnionTypeExtension, Ref: ref})))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterEnumTypeExtension(ref int) {
 	name := r.definition.EnumTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindEnumTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrEnumTypeUndefined(name))
+		r.Report.AddExternalError(withLocation(operationreport.ErrEnumTypeUndefined(name), r.definition.NodePosition(ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: ref})))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterInputObjectTypeExtension(ref int) {
 	name := r.definition.InputObjectTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindInputObjectTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrInputObjectTypeUndefined(name))
+		r.Report.AddExternalError(withLocation(operationreport.ErrInputObjectTypeUndefined(name), r.definition.NodePosition(ast.Node{Kind: ast.NodeKindInputObjectTypeExtension, Ref: ref})))
 	}
 }
 
@@ -0,0 +1,46 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// RootOperationTypesAreObjectTypes validates if the query, mutation and subscription root types are object types
+func RootOperationTypesAreObjectTypes() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &rootOperationTypesAreObjectTypesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterRootOperationTypeDefinitionVisitor(visitor)
+	}
+}
+
+type rootOperationTypesAreObjectTypesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (r *rootOperationTypesAreObjectTypesVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.definition = operation
+}
+
+func (r *rootOperationTypesAreObjectTypesVisitor) EnterRootOperationTypeDefinition(ref int) {
+	rootOperationType := r.definition.RootOperationTypeDefinitions[ref]
+	typeName := r.definition.Input.ByteSlice(rootOperationType.NamedType.Name)
+
+	node, exists := r.definition.Index.FirstNodeByNameBytes(typeName)
+	if !exists {
+		// undefined types are reported by KnownTypeNames
+		return
+	}
+
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition, ast.NodeKindObjectTypeExtension:
+		return
+	}
+
+	r.Report.AddExternalError(withLocation(operationreport.ErrRootOperationTypeMustBeObjectType(rootOperationType.OperationType.Name(), typeName), rootOperationType.NamedType.Position))
+}
@@ -0,0 +1,66 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestRootOperationTypesAreObjectTypes(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("object root types", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					schema {
+						query: Query
+						mutation: Mutation
+					}
+					type Query {
+						foo: String
+					}
+					type Mutation {
+						foo: String
+					}
+				`, Valid, RootOperationTypesAreObjectTypes(),
+			)
+		})
+
+		t.Run("interface query root type", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					schema {
+						query: Query
+					}
+					interface Query {
+						foo: String
+					}
+				`, Invalid, RootOperationTypesAreObjectTypes(),
+			)
+		})
+
+		t.Run("input object mutation root type", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					schema {
+						query: Query
+						mutation: Mutation
+					}
+					type Query {
+						foo: String
+					}
+					input Mutation {
+						foo: String
+					}
+				`, Invalid, RootOperationTypesAreObjectTypes(),
+			)
+		})
+
+		t.Run("scalar subscription root type", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					schema {
+						query: Query
+						subscription: String
+					}
+					type Query {
+						foo: String
+					}
+				`, Invalid, RootOperationTypesAreObjectTypes(),
+			)
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package astvalidation
+
+import (
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// UniqueArgumentDefinitionNames validates if the arguments of every field and directive definition have unique names
+func UniqueArgumentDefinitionNames() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &uniqueArgumentDefinitionNamesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterFieldDefinitionVisitor(visitor)
+		walker.RegisterEnterDirectiveDefinitionVisitor(visitor)
+	}
+}
+
+type uniqueArgumentDefinitionNamesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (u *uniqueArgumentDefinitionNamesVisitor) EnterDocument(operation, _ *ast.Document) {
+	u.definition = operation
+}
+
+func (u *uniqueArgumentDefinitionNamesVisitor) EnterFieldDefinition(ref int) {
+	if !u.definition.FieldDefinitions[ref].HasArgumentsDefinitions {
+		return
+	}
+
+	parentName := make(ast.ByteSlice, 0, 32)
+	if len(u.Ancestors) > 0 {
+		parentName = append(parentName, u.definition.NodeNameBytes(u.Ancestors[len(u.Ancestors)-1])...)
+		parentName = append(parentName, '.')
+	}
+	parentName = append(parentName, u.definition.FieldDefinitionNameBytes(ref)...)
+
+	u.checkArgumentNames(parentName, u.definition.FieldDefinitions[ref].ArgumentsDefinition.Refs)
+}
+
+func (u *uniqueArgumentDefinitionNamesVisitor) EnterDirectiveDefinition(ref int) {
+	if !u.definition.DirectiveDefinitions[ref].HasArgumentsDefinitions {
+		return
+	}
+
+	parentName := append(ast.ByteSlice("@"), u.definition.DirectiveDefinitionNameBytes(ref)...)
+	u.checkArgumentNames(parentName, u.definition.DirectiveDefinitions[ref].ArgumentsDefinition.Refs)
+}
+
+func (u *uniqueArgumentDefinitionNamesVisitor) checkArgumentNames(parentName ast.ByteSlice, argumentRefs []int) {
+	usedArgumentNamesAsHash := make(map[uint64]bool, len(argumentRefs))
+	for _, argumentRef := range argumentRefs {
+		argumentName := u.definition.InputValueDefinitionNameBytes(argumentRef)
+		hashedArgumentName := xxhash.Sum64(argumentName)
+		if usedArgumentNamesAsHash[hashedArgumentName] {
+			u.Report.AddExternalError(withLocation(operationreport.ErrArgumentDefinitionMustBeUnique(parentName, argumentName), u.definition.NodePosition(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: argumentRef})))
+			continue
+		}
+		usedArgumentNamesAsHash[hashedArgumentName] = true
+	}
+}
@@ -0,0 +1,67 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestUniqueArgumentDefinitionNames(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("no arguments", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						foo: String
+					}
+					directive @foo on FIELD_DEFINITION
+				`, Valid, UniqueArgumentDefinitionNames(),
+			)
+		})
+
+		t.Run("unique field arguments", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						foo(a: String, b: String): String
+						bar(a: String, b: String): String
+					}
+					interface Foo {
+						foo(a: String, b: String): String
+					}
+					extend type Query {
+						baz(a: String, b: String): String
+					}
+				`, Valid, UniqueArgumentDefinitionNames(),
+			)
+		})
+
+		t.Run("unique directive arguments", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @foo(a: String, b: String) on FIELD_DEFINITION
+				`, Valid, UniqueArgumentDefinitionNames(),
+			)
+		})
+
+		t.Run("duplicate field arguments", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						foo(a: String, b: String, a: Int): String
+					}
+				`, Invalid, UniqueArgumentDefinitionNames(),
+			)
+		})
+
+		t.Run("duplicate interface field arguments", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					interface Foo {
+						foo(a: String, a: String): String
+					}
+				`, Invalid, UniqueArgumentDefinitionNames(),
+			)
+		})
+
+		t.Run("duplicate directive arguments", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @foo(a: String, a: String) on FIELD_DEFINITION
+				`, Invalid, UniqueArgumentDefinitionNames(),
+			)
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package astvalidation
+
+import (
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// UniqueDirectiveNames validates if every directive is defined only once
+func UniqueDirectiveNames() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &uniqueDirectiveNamesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterDirectiveDefinitionVisitor(visitor)
+	}
+}
+
+type uniqueDirectiveNamesVisitor struct {
+	*astvisitor.Walker
+	definition               *ast.Document
+	usedDirectiveNamesAsHash map[uint64]bool
+}
+
+func (u *uniqueDirectiveNamesVisitor) EnterDocument(operation, _ *ast.Document) {
+	u.definition = operation
+	u.usedDirectiveNamesAsHash = make(map[uint64]bool)
+}
+
+func (u *uniqueDirectiveNamesVisitor) EnterDirectiveDefinition(ref int) {
+	directiveName := u.definition.DirectiveDefinitionNameBytes(ref)
+	hashedDirectiveName := xxhash.Sum64(directiveName)
+	if u.usedDirectiveNamesAsHash[hashedDirectiveName] {
+		u.Report.AddExternalError(withLocation(operationreport.ErrDirectiveNameMustBeUnique(directiveName), u.definition.NodePosition(ast.Node{Kind: ast.NodeKindDirectiveDefinition, Ref: ref})))
+		return
+	}
+	u.usedDirectiveNamesAsHash[hashedDirectiveName] = true
+}
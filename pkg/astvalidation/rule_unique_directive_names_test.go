@@ -0,0 +1,48 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestUniqueDirectiveNames(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("one directive", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @foo on SCHEMA
+				`, Valid, UniqueDirectiveNames(),
+			)
+		})
+
+		t.Run("many directives", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @foo on SCHEMA
+					directive @bar on SCHEMA
+					directive @baz on SCHEMA
+				`, Valid, UniqueDirectiveNames(),
+			)
+		})
+
+		t.Run("directive and non-directive definitions named the same", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type foo
+					directive @foo on SCHEMA
+				`, Valid, UniqueDirectiveNames(),
+			)
+		})
+
+		t.Run("directives named the same", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @foo on SCHEMA
+					directive @foo on SCHEMA
+				`, Invalid, UniqueDirectiveNames(),
+			)
+		})
+
+		t.Run("directive named like a base schema directive", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					directive @skip on SCHEMA
+				`, Invalid, UniqueDirectiveNames(),
+			)
+		})
+	})
+}
@@ -34,20 +34,21 @@ func (u *uniqueOperationTypesVisitor) EnterDocument(operation, definition *ast.D
 
 func (u *uniqueOperationTypesVisitor) EnterRootOperationTypeDefinition(ref int) {
 	operationType := u.definition.RootOperationTypeDefinitions[ref].OperationType
+	typePosition := u.definition.RootOperationTypeDefinitions[ref].NamedType.Position
 	switch operationType {
 	case ast.OperationTypeQuery:
 		if u.queryIsDefined {
-			u.Report.AddExternalError(operationreport.ErrOnlyOneQueryTypeAllowed())
+			u.Report.AddExternalError(withLocation(operationreport.ErrOnlyOneQueryTypeAllowed(), typePosition))
 		}
 		u.queryIsDefined = true
 	case ast.OperationTypeMutation:
 		if u.mutationIsDefined {
-			u.Report.AddExternalError(operationreport.ErrOnlyOneMutationTypeAllowed())
+			u.Report.AddExternalError(withLocation(operationreport.ErrOnlyOneMutationTypeAllowed(), typePosition))
 		}
 		u.mutationIsDefined = true
 	case ast.OperationTypeSubscription:
 		if u.subscriptionIsDefined {
-			u.Report.AddExternalError(operationreport.ErrOnlyOneSubscriptionTypeAllowed())
+			u.Report.AddExternalError(withLocation(operationreport.ErrOnlyOneSubscriptionTypeAllowed(), typePosition))
 		}
 		u.subscriptionIsDefined = true
 	}
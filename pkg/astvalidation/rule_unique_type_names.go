@@ -37,38 +37,38 @@ func (u *uniqueTypeNamesVisitor) EnterDocument(operation, definition *ast.Docume
 
 func (u *uniqueTypeNamesVisitor) EnterObjectTypeDefinition(ref int) {
 	typeName := u.definition.ObjectTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref})
 }
 
 func (u *uniqueTypeNamesVisitor) EnterScalarTypeDefinition(ref int) {
 	typeName := u.definition.ScalarTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, ast.Node{Kind: ast.NodeKindScalarTypeDefinition, Ref: ref})
 }
 
 func (u *uniqueTypeNamesVisitor) EnterInterfaceTypeDefinition(ref int) {
 	typeName := u.definition.InterfaceTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref})
 }
 
 func (u *uniqueTypeNamesVisitor) EnterUnionTypeDefinition(ref int) {
 	typeName := u.definition.UnionTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref})
 }
 
 func (u *uniqueTypeNamesVisitor) EnterEnumTypeDefinition(ref int) {
 	typeName := u.definition.EnumTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref})
 }
 
 func (u *uniqueTypeNamesVisitor) EnterInputObjectTypeDefinition(ref int) {
 	typeName := u.definition.InputObjectTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref})
 }
 
-func (u *uniqueTypeNamesVisitor) checkTypeName(typeName ast.ByteSlice) {
+func (u *uniqueTypeNamesVisitor) checkTypeName(typeName ast.ByteSlice, node ast.Node) {
 	hashedTypeName := xxhash.Sum64(typeName)
 	if u.usedTypeNamesAsHash[hashedTypeName] {
-		u.Report.AddExternalError(operationreport.ErrTypeNameMustBeUnique(typeName))
+		u.Report.AddExternalError(withLocation(operationreport.ErrTypeNameMustBeUnique(typeName), u.definition.NodePosition(node)))
 		return
 	}
 	u.usedTypeNamesAsHash[hashedTypeName] = true
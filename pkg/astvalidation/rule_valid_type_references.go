@@ -0,0 +1,152 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// ValidTypeReferences validates if referenced types are of the kind required by their position:
+// union members must be object types, implemented types must be interfaces,
+// fields must have output types and arguments and input fields must have input types
+func ValidTypeReferences() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &validTypeReferencesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterUnionMemberTypeVisitor(visitor)
+		walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterObjectTypeExtensionVisitor(visitor)
+		walker.RegisterEnterInterfaceTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterInterfaceTypeExtensionVisitor(visitor)
+		walker.RegisterEnterFieldDefinitionVisitor(visitor)
+		walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+	}
+}
+
+type validTypeReferencesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (v *validTypeReferencesVisitor) EnterDocument(operation, _ *ast.Document) {
+	v.definition = operation
+}
+
+func (v *validTypeReferencesVisitor) EnterUnionMemberType(ref int) {
+	memberName := v.definition.TypeNameBytes(ref)
+	kind, exists := v.typeKind(memberName)
+	if !exists || kind == ast.NodeKindObjectTypeDefinition {
+		return
+	}
+
+	unionName := v.definition.NodeNameBytes(v.Ancestors[len(v.Ancestors)-1])
+	v.Report.AddExternalError(withLocation(operationreport.ErrUnionMemberMustBeObjectType(unionName, memberName), v.definition.Types[ref].Position))
+}
+
+func (v *validTypeReferencesVisitor) EnterObjectTypeDefinition(ref int) {
+	v.checkImplementedInterfaces(v.definition.ObjectTypeDefinitionNameBytes(ref), v.definition.ObjectTypeDefinitions[ref].ImplementsInterfaces.Refs)
+}
+
+func (v *validTypeReferencesVisitor) EnterObjectTypeExtension(ref int) {
+	v.checkImplementedInterfaces(v.definition.ObjectTypeExtensionNameBytes(ref), v.definition.ObjectTypeExtensions[ref].ImplementsInterfaces.Refs)
+}
+
+func (v *validTypeReferencesVisitor) EnterInterfaceTypeDefinition(ref int) {
+	v.checkImplementedInterfaces(v.definition.InterfaceTypeDefinitionNameBytes(ref), v.definition.InterfaceTypeDefinitions[ref].ImplementsInterfaces.Refs)
+}
+
+func (v *validTypeReferencesVisitor) EnterInterfaceTypeExtension(ref int) {
+	v.checkImplementedInterfaces(v.definition.InterfaceTypeExtensionNameBytes(ref), v.definition.InterfaceTypeExtensions[ref].ImplementsInterfaces.Refs)
+}
+
+func (v *validTypeReferencesVisitor) EnterFieldDefinition(ref int) {
+	typeName := v.definition.ResolveTypeNameBytes(v.definition.FieldDefinitionType(ref))
+	kind, exists := v.typeKind(typeName)
+	if !exists {
+		return
+	}
+
+	switch kind {
+	case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition, ast.NodeKindUnionTypeDefinition,
+		ast.NodeKindScalarTypeDefinition, ast.NodeKindEnumTypeDefinition:
+		return
+	}
+
+	fieldCoordinate := v.parentName() + "." + v.definition.FieldDefinitionNameString(ref)
+	v.Report.AddExternalError(withLocation(operationreport.ErrFieldTypeMustBeOutputType(fieldCoordinate, typeName), v.definition.NodePosition(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: ref})))
+}
+
+func (v *validTypeReferencesVisitor) EnterInputValueDefinition(ref int) {
+	typeName := v.definition.ResolveTypeNameBytes(v.definition.InputValueDefinitionType(ref))
+	kind, exists := v.typeKind(typeName)
+	if !exists {
+		return
+	}
+
+	switch kind {
+	case ast.NodeKindInputObjectTypeDefinition, ast.NodeKindScalarTypeDefinition, ast.NodeKindEnumTypeDefinition:
+		return
+	}
+
+	v.Report.AddExternalError(withLocation(operationreport.ErrInputValueTypeMustBeInputType(v.inputValueCoordinate(ref), typeName), v.definition.NodePosition(ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: ref})))
+}
+
+func (v *validTypeReferencesVisitor) checkImplementedInterfaces(typeName ast.ByteSlice, implementedTypeRefs []int) {
+	for _, implementedTypeRef := range implementedTypeRefs {
+		implementedTypeName := v.definition.TypeNameBytes(implementedTypeRef)
+		kind, exists := v.typeKind(implementedTypeName)
+		if !exists || kind == ast.NodeKindInterfaceTypeDefinition {
+			continue
+		}
+
+		v.Report.AddExternalError(withLocation(operationreport.ErrImplementedTypeMustBeInterface(typeName, implementedTypeName), v.definition.Types[implementedTypeRef].Position))
+	}
+}
+
+// typeKind returns the definition kind of a named type, extensions are reported as their definition kind
+// exists is false for unknown types, which are reported by KnownTypeNames
+func (v *validTypeReferencesVisitor) typeKind(typeName ast.ByteSlice) (kind ast.NodeKind, exists bool) {
+	node, exists := v.definition.Index.FirstNodeByNameBytes(typeName)
+	if !exists {
+		return ast.NodeKindUnknown, false
+	}
+
+	switch node.Kind {
+	case ast.NodeKindObjectTypeExtension:
+		return ast.NodeKindObjectTypeDefinition, true
+	case ast.NodeKindInterfaceTypeExtension:
+		return ast.NodeKindInterfaceTypeDefinition, true
+	case ast.NodeKindUnionTypeExtension:
+		return ast.NodeKindUnionTypeDefinition, true
+	case ast.NodeKindScalarTypeExtension:
+		return ast.NodeKindScalarTypeDefinition, true
+	case ast.NodeKindEnumTypeExtension:
+		return ast.NodeKindEnumTypeDefinition, true
+	case ast.NodeKindInputObjectTypeExtension:
+		return ast.NodeKindInputObjectTypeDefinition, true
+	}
+
+	return node.Kind, true
+}
+
+func (v *validTypeReferencesVisitor) parentName() string {
+	return string(v.definition.NodeNameBytes(v.Ancestors[len(v.Ancestors)-1]))
+}
+
+func (v *validTypeReferencesVisitor) inputValueCoordinate(ref int) string {
+	inputValueName := v.definition.InputValueDefinitionNameString(ref)
+	parent := v.Ancestors[len(v.Ancestors)-1]
+
+	switch parent.Kind {
+	case ast.NodeKindFieldDefinition:
+		typeName := v.definition.NodeNameBytes(v.Ancestors[len(v.Ancestors)-2])
+		return string(typeName) + "." + v.definition.FieldDefinitionNameString(parent.Ref) + "(" + inputValueName + ":)"
+	case ast.NodeKindDirectiveDefinition:
+		return "@" + v.definition.DirectiveDefinitionNameString(parent.Ref) + "(" + inputValueName + ":)"
+	default:
+		return v.parentName() + "." + inputValueName
+	}
+}
@@ -0,0 +1,102 @@
+package astvalidation
+
+import (
+	"testing"
+)
+
+func TestValidTypeReferences(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("valid references", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					interface Node {
+						id: ID!
+					}
+					interface Named implements Node {
+						id: ID!
+						name: String
+					}
+					type User implements Node & Named {
+						id: ID!
+						name: String
+						friends(filter: Filter, kind: Kind = FRIEND): [User!]
+					}
+					union Search = User
+					enum Kind { FRIEND }
+					input Filter {
+						name: String
+						kind: Kind
+						nested: Filter
+					}
+					directive @foo(filter: Filter) on FIELD_DEFINITION
+				`, Valid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("union with a non-object member", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Foo { foo: String }
+					interface Bar { bar: String }
+					union Search = Foo | Bar
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("union extension with a non-object member", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Foo { foo: String }
+					union Search = Foo
+					extend union Search = String
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("object implementing an object", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Foo { foo: String }
+					type Bar implements Foo { foo: String }
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("interface implementing an input object", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo { foo: String }
+					interface Bar implements Foo { foo: String }
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("field with an input object type", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Foo { foo: String }
+					type Query { foo: [Foo!] }
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("argument with an object type", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Foo { foo: String }
+					type Query { foo(foo: Foo): String }
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("directive argument with a union type", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Foo { foo: String }
+					union Search = Foo
+					directive @foo(search: Search) on FIELD_DEFINITION
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+
+		t.Run("input field with an interface type", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					interface Foo { foo: String }
+					input Bar { foo: Foo! }
+				`, Invalid, ValidTypeReferences(),
+			)
+		})
+	})
+}
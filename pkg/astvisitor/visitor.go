@@ -6,6 +6,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -1324,10 +1325,13 @@ func (w *Walker) appendAncestor(ref int, kind ast.NodeKind) {
 	})
 
 	var typeName ast.ByteSlice
+	// typeNamePosition locates the node the type name was derived from if the type is undefined
+	var typeNamePosition position.Position
 
 	switch kind {
 	case ast.NodeKindOperationDefinition:
 		operationType := w.document.OperationDefinitions[ref].OperationType
+		typeNamePosition = w.document.OperationDefinitions[ref].OperationTypeLiteral
 		switch operationType {
 		case ast.OperationTypeQuery:
 			typeName = w.definition.Index.QueryTypeName
@@ -1360,8 +1364,10 @@ func (w *Walker) appendAncestor(ref int, kind ast.NodeKind) {
 			return
 		}
 		typeName = w.document.InlineFragmentTypeConditionName(ref)
+		typeNamePosition = w.document.Types[w.document.InlineFragments[ref].TypeCondition.Type].Position
 	case ast.NodeKindFragmentDefinition:
 		typeName = w.document.FragmentDefinitionTypeName(ref)
+		typeNamePosition = w.document.Types[w.document.FragmentDefinitions[ref].TypeCondition.Type].Position
 		w.Path = append(w.Path, ast.PathItem{
 			Kind:       ast.FieldName,
 			ArrayIndex: 0,
@@ -1369,6 +1375,7 @@ func (w *Walker) appendAncestor(ref int, kind ast.NodeKind) {
 		})
 	case ast.NodeKindField:
 		fieldName := w.document.FieldNameBytes(ref)
+		typeNamePosition = w.document.Fields[ref].Position
 		w.Path = append(w.Path, ast.PathItem{
 			Kind:       ast.FieldName,
 			ArrayIndex: 0,
@@ -1402,7 +1409,9 @@ func (w *Walker) appendAncestor(ref int, kind ast.NodeKind) {
 	var exists bool
 	w.EnclosingTypeDefinition, exists = w.definition.Index.FirstNonExtensionNodeByNameBytes(typeName)
 	if !exists {
-		w.StopWithExternalErr(operationreport.ErrTypeUndefined(typeName))
+		err := operationreport.ErrTypeUndefined(typeName)
+		err.Locations = operationreport.LocationsFromPosition(typeNamePosition)
+		w.StopWithExternalErr(err)
 		return
 	}
 
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/buger/jsonparser"
 	"github.com/tidwall/sjson"
@@ -249,12 +252,42 @@ type FetchConfiguration struct {
 	URL    string
 	Method string
 	Header http.Header
+	// UseWebSocket executes queries and mutations over a websocket connection (subscribe -> next -> complete)
+	// instead of HTTP. Connections to the same origin are shared with subscriptions.
+	UseWebSocket bool
+	// WebSocketURL is the websocket endpoint for queries and mutations.
+	// It defaults to the subscription URL or, if not set, to URL with a websocket scheme.
+	WebSocketURL string
 }
 
 func (c *Configuration) ApplyDefaults() {
 	if c.Fetch.Method == "" {
 		c.Fetch.Method = "POST"
 	}
+	if c.Fetch.UseWebSocket && c.Fetch.WebSocketURL == "" {
+		c.Fetch.WebSocketURL = c.Subscription.URL
+	}
+	if c.Fetch.UseWebSocket && c.Fetch.WebSocketURL == "" {
+		c.Fetch.WebSocketURL = webSocketURL(c.Fetch.URL)
+	}
+}
+
+// webSocketURL maps the scheme of a http(s) URL to the corresponding websocket scheme.
+// URLs which can't be parsed or use another scheme are returned unchanged.
+func webSocketURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	switch parsed.Scheme {
+	case "http":
+		parsed.Scheme = "ws"
+	case "https":
+		parsed.Scheme = "wss"
+	default:
+		return rawURL
+	}
+	return parsed.String()
 }
 
 func (p *Planner) Register(visitor *plan.Visitor, configuration plan.DataSourceConfiguration, isNested bool) error {
@@ -294,8 +327,19 @@ func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
 		input = httpclient.SetInputHeader(input, header)
 	}
 
-	input = httpclient.SetInputURL(input, []byte(p.config.Fetch.URL))
-	input = httpclient.SetInputMethod(input, []byte(p.config.Fetch.Method))
+	var dataSource resolve.DataSource = &Source{
+		httpClient: p.fetchClient,
+	}
+
+	if p.config.Fetch.UseWebSocket {
+		input = httpclient.SetInputURL(input, []byte(p.config.Fetch.WebSocketURL))
+		dataSource = &WebSocketSource{
+			client: p.subscriptionClient,
+		}
+	} else {
+		input = httpclient.SetInputURL(input, []byte(p.config.Fetch.URL))
+		input = httpclient.SetInputMethod(input, []byte(p.config.Fetch.Method))
+	}
 
 	var batchConfig plan.BatchConfig
 	// Allow batch query for fetching entities.
@@ -307,10 +351,8 @@ func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
 	}
 
 	return plan.FetchConfiguration{
-		Input: string(input),
-		DataSource: &Source{
-			httpClient: p.fetchClient,
-		},
+		Input:                string(input),
+		DataSource:           dataSource,
 		Variables:            p.variables,
 		DisallowSingleFlight: p.disallowSingleFlight,
 		ProcessResponseConfig: resolve.ProcessResponseConfig{
@@ -527,7 +569,7 @@ func (p *Planner) EnterField(ref int) {
 	// Note: federated fields always have a field configuration because at
 	// least the federation key for the type the field lives on is required
 	// (and required fields are specified in the configuration).
-	p.handleFederation(fieldConfiguration)
+	p.handleFederation(ref, fieldConfiguration)
 	p.addField(ref)
 
 	upstreamFieldRef := p.nodes[len(p.nodes)-1].Ref
@@ -623,7 +665,7 @@ func (p *Planner) EnterDocument(_, _ *ast.Document) {
 func (p *Planner) LeaveDocument(_, _ *ast.Document) {
 }
 
-func (p *Planner) handleFederation(fieldConfig *plan.FieldConfiguration) {
+func (p *Planner) handleFederation(fieldRef int, fieldConfig *plan.FieldConfiguration) {
 	if !p.config.Federation.Enabled { // federation must be enabled
 		return
 	}
@@ -642,19 +684,19 @@ func (p *Planner) handleFederation(fieldConfig *plan.FieldConfiguration) {
 		// LeaveDocument, but ConfigureFetch is called before this visitor's
 		// LeaveDocument is called. (Updating the visitor logic to call
 		// LeaveDocument in reverse registration order would fix this issue.)
-		p.updateRepresentationsVariable(fieldConfig)
+		p.updateRepresentationsVariable(fieldRef, fieldConfig)
 		return
 	}
 	p.hasFederationRoot = true
 	p.federationDepth = p.visitor.Walker.Depth
 	// query($representations: [_Any!]!){_entities(representations: $representations){... on Product
-	p.addRepresentationsVariableDefinition()     // $representations: [_Any!]!
-	p.addEntitiesSelectionSet()                  // {_entities(representations: $representations)
-	p.addOnTypeInlineFragment()                  // ... on Product
-	p.updateRepresentationsVariable(fieldConfig) // "variables\":{\"representations\":[{\"upc\":\"$$0$$\",\"__typename\":\"Product\"}]}}
+	p.addRepresentationsVariableDefinition()               // $representations: [_Any!]!
+	p.addEntitiesSelectionSet()                            // {_entities(representations: $representations)
+	p.addOnTypeInlineFragment()                            // ... on Product
+	p.updateRepresentationsVariable(fieldRef, fieldConfig) // "variables\":{\"representations\":[{\"upc\":\"$$0$$\",\"__typename\":\"Product\"}]}}
 }
 
-func (p *Planner) updateRepresentationsVariable(fieldConfig *plan.FieldConfiguration) {
+func (p *Planner) updateRepresentationsVariable(fieldRef int, fieldConfig *plan.FieldConfiguration) {
 	if p.visitor.Walker.Depth != p.federationDepth {
 		// given that this field has a different depth than the federation root, we skip this field
 		// this is because we only have to handle federated fields that are part of the "current" federated request
@@ -665,19 +707,11 @@ func (p *Planner) updateRepresentationsVariable(fieldConfig *plan.FieldConfigura
 	}
 
 	// "variables\":{\"representations\":[{\"upc\":\$$0$$\,\"__typename\":\"Product\"}]}}
-	parser := astparser.NewParser()
-	doc := ast.NewDocument()
-	doc.Input.ResetInputString(p.config.Federation.ServiceSDL)
-	report := &operationreport.Report{}
-	parser.Parse(doc, report)
-	if report.HasErrors() {
-		p.visitor.Walker.StopWithInternalErr(fmt.Errorf("GraphQL Planner: failed parsing Federation SDL"))
-		return
-	}
 
 	// RequiresFields includes `@requires` fields as well as federation keys
 	// for the type containing the field currently being visited.
-	fields := fieldConfig.RequiresFields
+	// Nested fields are dot delimited paths, e.g. "organization.id", and become nested objects in the representation.
+	fields := p.visitor.RequiredFields(fieldRef, fieldConfig)
 	if len(fields) == 0 {
 		return
 	}
@@ -701,9 +735,9 @@ func (p *Planner) updateRepresentationsVariable(fieldConfig *plan.FieldConfigura
 
 	for i := range fields {
 		objectVariable := &resolve.ObjectVariable{
-			Path: []string{fields[i]},
+			Path: strings.Split(fields[i], "."),
 		}
-		fieldDef := p.fieldDefinition(fields[i], p.lastFieldEnclosingTypeName)
+		fieldDef := p.nestedFieldDefinition(objectVariable.Path, p.lastFieldEnclosingTypeName)
 		if fieldDef == nil {
 			continue
 		}
@@ -735,6 +769,21 @@ func (p *Planner) fieldDefinition(fieldName, typeName string) *ast.FieldDefiniti
 	return &p.visitor.Definition.FieldDefinitions[definition]
 }
 
+// nestedFieldDefinition returns the definition of the last field of the path starting at the given type
+func (p *Planner) nestedFieldDefinition(path []string, typeName string) *ast.FieldDefinition {
+	var fieldDef *ast.FieldDefinition
+	for i := range path {
+		if fieldDef != nil {
+			typeName = p.visitor.Definition.ResolveTypeNameString(fieldDef.Type)
+		}
+		fieldDef = p.fieldDefinition(path[i], typeName)
+		if fieldDef == nil {
+			return nil
+		}
+	}
+	return fieldDef
+}
+
 func (p *Planner) addOnTypeInlineFragment() {
 	selectionSet := p.upstreamOperation.AddSelectionSet()
 	p.addTypenameToSelectionSet(p.nodes[len(p.nodes)-1].Ref)
@@ -1420,6 +1469,44 @@ func (s *Source) Load(ctx context.Context, input []byte, writer io.Writer) (err
 	return httpclient.Do(s.httpClient, ctx, input, writer)
 }
 
+var errWebSocketOperationWithoutResponse = errors.New("websocket operation completed without a response")
+
+// WebSocketSource executes queries and mutations over a websocket connection of the GraphQLSubscriptionClient.
+// The operation is started like a subscription and the first result, which may be an error, is used as response.
+// Afterwards the operation is stopped, so origins which don't complete the operation don't block it.
+type WebSocketSource struct {
+	Source
+	client GraphQLSubscriptionClient
+}
+
+func (s *WebSocketSource) Load(ctx context.Context, input []byte, writer io.Writer) (err error) {
+	input = s.compactAndUnNullVariables(input)
+
+	var options GraphQLSubscriptionOptions
+	if err = json.Unmarshal(input, &options); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	next := make(chan []byte)
+	if err = s.client.Subscribe(ctx, options, next); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case data, ok := <-next:
+		if !ok {
+			return errWebSocketOperationWithoutResponse
+		}
+		_, err = writer.Write(data)
+		return err
+	}
+}
+
 type GraphQLSubscriptionClient interface {
 	Subscribe(ctx context.Context, options GraphQLSubscriptionOptions, next chan<- []byte) error
 }
@@ -9,12 +9,15 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/httpclient"
 	. "github.com/wundergraph/graphql-go-tools/pkg/engine/datasourcetesting"
@@ -2377,6 +2380,104 @@ func TestGraphQLDataSource(t *testing.T) {
 		},
 	))
 
+	t.Run("simple mutation over websocket", RunTest(`
+		type Mutation {
+			addFriend(name: String!):Friend!
+		}
+		type Friend {
+			id: ID!
+			name: String!
+		}
+	`,
+		`mutation AddFriend($name: String!){ addFriend(name: $name){ id name } }`,
+		"AddFriend",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:   0,
+						Input:      `{"url":"wss://service.one/ws","body":{"query":"mutation($name: String!){addFriend(name: $name){id name}}","variables":{"name":$$0$$}}}`,
+						DataSource: &WebSocketSource{},
+						Variables: resolve.NewVariables(
+							&resolve.ContextVariable{
+								Path:     []string{"name"},
+								Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string"]}`),
+							},
+						),
+						DisallowSingleFlight:  true,
+						DataSourceIdentifier:  []byte("graphql_datasource.WebSocketSource"),
+						ProcessResponseConfig: resolve.ProcessResponseConfig{ExtractGraphqlResponse: true},
+					},
+					Fields: []*resolve.Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("addFriend"),
+							Value: &resolve.Object{
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("id"),
+										Value: &resolve.String{
+											Path: []string{"id"},
+										},
+									},
+									{
+										Name: []byte("name"),
+										Value: &resolve.String{
+											Path: []string{"name"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "Mutation",
+							FieldNames: []string{"addFriend"},
+						},
+					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "Friend",
+							FieldNames: []string{"id", "name"},
+						},
+					},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL:          "https://service.one",
+							UseWebSocket: true,
+						},
+						Subscription: SubscriptionConfiguration{
+							URL: "wss://service.one/ws",
+						},
+					}),
+					Factory: &Factory{},
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:              "Mutation",
+					FieldName:             "addFriend",
+					DisableDefaultMapping: true,
+					Arguments: []plan.ArgumentConfiguration{
+						{
+							Name:       "name",
+							SourceType: plan.FieldArgumentSource,
+						},
+					},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		},
+	))
+
 	t.Run("nested resolvers of same upstream", RunTest(`
 		type Query {
 			foo(bar: String):Baz
@@ -4003,7 +4104,416 @@ func TestGraphQLDataSource(t *testing.T) {
 					ChildNodes: []plan.TypeField{
 						{
 							TypeName:   "User",
-							FieldNames: []string{"id", "username"},
+							FieldNames: []string{"id", "username"},
+						},
+					},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://user.service",
+						},
+						Federation: FederationConfiguration{
+							Enabled:    true,
+							ServiceSDL: "extend type Query {me: User} type User @key(fields: \"id\"){ id: ID! username: String!}",
+						},
+					}),
+					Factory: federationFactory,
+				},
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "Query",
+							FieldNames: []string{"topProducts"},
+						},
+						{
+							TypeName:   "Subscription",
+							FieldNames: []string{"updatedPrice"},
+						},
+						{
+							TypeName:   "Product",
+							FieldNames: []string{"upc", "name", "price"},
+						},
+					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "Product",
+							FieldNames: []string{"upc", "name", "price"},
+						},
+					},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://product.service",
+						},
+						Subscription: SubscriptionConfiguration{
+							URL: "ws://product.service",
+						},
+						Federation: FederationConfiguration{
+							Enabled:    true,
+							ServiceSDL: "extend type Query {topProducts(first: Int = 5): [Product]} type Product @key(fields: \"upc\") {upc: String! price: Int!}",
+						},
+					}),
+					Factory: federationFactory,
+				},
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "User",
+							FieldNames: []string{"reviews"},
+						},
+						{
+							TypeName:   "Product",
+							FieldNames: []string{"reviews"},
+						},
+					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "Review",
+							FieldNames: []string{"body", "author", "product"},
+						},
+						{
+							TypeName:   "User",
+							FieldNames: []string{"id", "username"},
+						},
+						{
+							TypeName:   "Product",
+							FieldNames: []string{"upc"},
+						},
+					},
+					Factory: federationFactory,
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://review.service",
+						},
+						Federation: FederationConfiguration{
+							Enabled:    true,
+							ServiceSDL: "type Review { body: String! author: User! @provides(fields: \"username\") product: Product! } extend type User @key(fields: \"id\") { id: ID! @external reviews: [Review] } extend type Product @key(fields: \"upc\") { upc: String! @external reviews: [Review] }",
+						},
+					}),
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:  "Query",
+					FieldName: "topProducts",
+					Arguments: []plan.ArgumentConfiguration{
+						{
+							Name:       "first",
+							SourceType: plan.FieldArgumentSource,
+						},
+					},
+				},
+				{
+					TypeName:       "User",
+					FieldName:      "reviews",
+					RequiresFields: []string{"id"},
+				},
+				{
+					TypeName:       "Product",
+					FieldName:      "name",
+					RequiresFields: []string{"upc"},
+				},
+				{
+					TypeName:       "Product",
+					FieldName:      "price",
+					RequiresFields: []string{"upc"},
+				},
+				{
+					TypeName:       "Product",
+					FieldName:      "reviews",
+					RequiresFields: []string{"upc"},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		}))
+
+	t.Run("federation with provided fields", RunTest(federationTestSchema,
+		`	query MyReviews {
+						me {
+							reviews {
+								body
+								author {
+									username
+								}
+							}
+						}
+					}`,
+		"MyReviews",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:              0,
+						Input:                 `{"method":"POST","url":"http://user.service","body":{"query":"{me {id}}"}}`,
+						DataSource:            &Source{},
+						DataSourceIdentifier:  []byte("graphql_datasource.Source"),
+						ProcessResponseConfig: resolve.ProcessResponseConfig{ExtractGraphqlResponse: true},
+					},
+					Fields: []*resolve.Field{
+						{
+							HasBuffer: true,
+							BufferID:  0,
+							Name:      []byte("me"),
+							Value: &resolve.Object{
+								Fetch: &resolve.BatchFetch{
+									Fetch: &resolve.SingleFetch{
+										BufferId: 1,
+										Input:    `{"method":"POST","url":"http://review.service","body":{"query":"query($representations: [_Any!]!){_entities(representations: $representations){__typename ... on User {reviews {body author {username id}}}}}","variables":{"representations":[{"id":$$0$$,"__typename":"User"}]}}}`,
+										Variables: resolve.NewVariables(
+											&resolve.ObjectVariable{
+												Path:     []string{"id"},
+												Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string","integer"]}`),
+											},
+										),
+										DataSource:           &Source{},
+										DataSourceIdentifier: []byte("graphql_datasource.Source"),
+										ProcessResponseConfig: resolve.ProcessResponseConfig{
+											ExtractGraphqlResponse:    true,
+											ExtractFederationEntities: true,
+										},
+										SetTemplateOutputToNullOnVariableNull: true,
+									},
+									BatchFactory: batchFactory,
+								},
+								Path:     []string{"me"},
+								Nullable: true,
+								Fields: []*resolve.Field{
+									{
+										HasBuffer: true,
+										BufferID:  1,
+										Name:      []byte("reviews"),
+										Value: &resolve.Array{
+											Path:     []string{"reviews"},
+											Nullable: true,
+											Item: &resolve.Object{
+												Nullable: true,
+												Fields: []*resolve.Field{
+													{
+														Name: []byte("body"),
+														Value: &resolve.String{
+															Path: []string{"body"},
+														},
+													},
+													{
+														Name: []byte("author"),
+														Value: &resolve.Object{
+															Path: []string{"author"},
+															Fields: []*resolve.Field{
+																{
+																	Name: []byte("username"),
+																	Value: &resolve.String{
+																		Path: []string{"username"},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "Query",
+							FieldNames: []string{"me"},
+						},
+						{
+							TypeName:   "User",
+							FieldNames: []string{"id", "username"},
+						},
+					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "User",
+							FieldNames: []string{"id", "username"},
+						},
+					},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://user.service",
+						},
+						Federation: FederationConfiguration{
+							Enabled:    true,
+							ServiceSDL: "extend type Query {me: User} type User @key(fields: \"id\"){ id: ID! username: String!}",
+						},
+					}),
+					Factory: federationFactory,
+				},
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "User",
+							FieldNames: []string{"reviews"},
+						},
+					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "Review",
+							FieldNames: []string{"body", "author"},
+						},
+						{
+							TypeName:   "User",
+							FieldNames: []string{"id"},
+						},
+					},
+					Provides: []plan.ProvidesConfiguration{
+						{
+							TypeName:   "Review",
+							FieldName:  "author",
+							FieldPaths: []string{"username"},
+						},
+					},
+					Factory: federationFactory,
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://review.service",
+						},
+						Federation: FederationConfiguration{
+							Enabled:    true,
+							ServiceSDL: "type Review { body: String! author: User! @provides(fields: \"username\") } extend type User @key(fields: \"id\") { id: ID! @external reviews: [Review] }",
+						},
+					}),
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:       "User",
+					FieldName:      "reviews",
+					RequiresFields: []string{"id"},
+				},
+				{
+					TypeName:       "User",
+					FieldName:      "username",
+					RequiresFields: []string{"id"},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		}))
+
+	t.Run("federation with nested composite key", RunTest(`
+		type Query {
+			me: User
+		}
+		type User {
+			id: ID!
+			organization: Organization!
+			username: String!
+			reviews: [Review]
+		}
+		type Organization {
+			id: ID!
+		}
+		type Review {
+			body: String!
+		}
+	`,
+		`	query MyReviews {
+						me {
+							reviews {
+								body
+							}
+						}
+					}`,
+		"MyReviews",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:              0,
+						Input:                 `{"method":"POST","url":"http://user.service","body":{"query":"{me {id organization {id}}}"}}`,
+						DataSource:            &Source{},
+						DataSourceIdentifier:  []byte("graphql_datasource.Source"),
+						ProcessResponseConfig: resolve.ProcessResponseConfig{ExtractGraphqlResponse: true},
+					},
+					Fields: []*resolve.Field{
+						{
+							HasBuffer: true,
+							BufferID:  0,
+							Name:      []byte("me"),
+							Value: &resolve.Object{
+								Fetch: &resolve.BatchFetch{
+									Fetch: &resolve.SingleFetch{
+										BufferId: 1,
+										Input:    `{"method":"POST","url":"http://review.service","body":{"query":"query($representations: [_Any!]!){_entities(representations: $representations){__typename ... on User {reviews {body}}}}","variables":{"representations":[{"organization":{"id":$$1$$},"id":$$0$$,"__typename":"User"}]}}}`,
+										Variables: resolve.NewVariables(
+											&resolve.ObjectVariable{
+												Path:     []string{"id"},
+												Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string","integer"]}`),
+											},
+											&resolve.ObjectVariable{
+												Path:     []string{"organization", "id"},
+												Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string","integer"]}`),
+											},
+										),
+										DataSource:           &Source{},
+										DataSourceIdentifier: []byte("graphql_datasource.Source"),
+										ProcessResponseConfig: resolve.ProcessResponseConfig{
+											ExtractGraphqlResponse:    true,
+											ExtractFederationEntities: true,
+										},
+										SetTemplateOutputToNullOnVariableNull: true,
+									},
+									BatchFactory: batchFactory,
+								},
+								Path:     []string{"me"},
+								Nullable: true,
+								Fields: []*resolve.Field{
+									{
+										HasBuffer: true,
+										BufferID:  1,
+										Name:      []byte("reviews"),
+										Value: &resolve.Array{
+											Path:     []string{"reviews"},
+											Nullable: true,
+											Item: &resolve.Object{
+												Nullable: true,
+												Fields: []*resolve.Field{
+													{
+														Name: []byte("body"),
+														Value: &resolve.String{
+															Path: []string{"body"},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "Query",
+							FieldNames: []string{"me"},
+						},
+						{
+							TypeName:   "User",
+							FieldNames: []string{"id", "organization", "username"},
+						},
+					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "User",
+							FieldNames: []string{"id", "organization", "username"},
+						},
+						{
+							TypeName:   "Organization",
+							FieldNames: []string{"id"},
 						},
 					},
 					Custom: ConfigJson(Configuration{
@@ -4012,7 +4522,7 @@ func TestGraphQLDataSource(t *testing.T) {
 						},
 						Federation: FederationConfiguration{
 							Enabled:    true,
-							ServiceSDL: "extend type Query {me: User} type User @key(fields: \"id\"){ id: ID! username: String!}",
+							ServiceSDL: "extend type Query {me: User} type User @key(fields: \"id organization { id }\"){ id: ID! organization: Organization! username: String!} type Organization { id: ID! }",
 						},
 					}),
 					Factory: federationFactory,
@@ -4020,105 +4530,196 @@ func TestGraphQLDataSource(t *testing.T) {
 				{
 					RootNodes: []plan.TypeField{
 						{
-							TypeName:   "Query",
-							FieldNames: []string{"topProducts"},
-						},
-						{
-							TypeName:   "Subscription",
-							FieldNames: []string{"updatedPrice"},
-						},
-						{
-							TypeName:   "Product",
-							FieldNames: []string{"upc", "name", "price"},
+							TypeName:   "User",
+							FieldNames: []string{"reviews"},
 						},
 					},
 					ChildNodes: []plan.TypeField{
 						{
-							TypeName:   "Product",
-							FieldNames: []string{"upc", "name", "price"},
+							TypeName:   "Review",
+							FieldNames: []string{"body"},
 						},
 					},
+					Factory: federationFactory,
 					Custom: ConfigJson(Configuration{
 						Fetch: FetchConfiguration{
-							URL: "http://product.service",
-						},
-						Subscription: SubscriptionConfiguration{
-							URL: "ws://product.service",
+							URL: "http://review.service",
 						},
 						Federation: FederationConfiguration{
 							Enabled:    true,
-							ServiceSDL: "extend type Query {topProducts(first: Int = 5): [Product]} type Product @key(fields: \"upc\") {upc: String! price: Int!}",
+							ServiceSDL: "type Review { body: String! } extend type User @key(fields: \"id organization { id }\") { id: ID! @external organization: Organization! @external reviews: [Review] } type Organization { id: ID! }",
 						},
 					}),
-					Factory: federationFactory,
 				},
+			},
+			Fields: []plan.FieldConfiguration{
 				{
-					RootNodes: []plan.TypeField{
+					TypeName:       "User",
+					FieldName:      "reviews",
+					RequiresFields: []string{"id", "organization.id"},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		}))
+
+	t.Run("federation with multiple keys", RunTest(`
+		type Query {
+			topReviews: [Review]
+		}
+		type Review {
+			body: String!
+			product: Product!
+		}
+		type Product {
+			upc: String!
+			sku: String!
+			name: String!
+		}
+	`,
+		`	query TopReviews {
+						topReviews {
+							product {
+								name
+							}
+						}
+					}`,
+		"TopReviews",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:              0,
+						Input:                 `{"method":"POST","url":"http://review.service","body":{"query":"{topReviews {product {sku}}}"}}`,
+						DataSource:            &Source{},
+						DataSourceIdentifier:  []byte("graphql_datasource.Source"),
+						ProcessResponseConfig: resolve.ProcessResponseConfig{ExtractGraphqlResponse: true},
+					},
+					Fields: []*resolve.Field{
 						{
-							TypeName:   "User",
-							FieldNames: []string{"reviews"},
+							HasBuffer: true,
+							BufferID:  0,
+							Name:      []byte("topReviews"),
+							Value: &resolve.Array{
+								Path:     []string{"topReviews"},
+								Nullable: true,
+								Item: &resolve.Object{
+									Nullable: true,
+									Fields: []*resolve.Field{
+										{
+											Name: []byte("product"),
+											Value: &resolve.Object{
+												Path: []string{"product"},
+												Fetch: &resolve.BatchFetch{
+													Fetch: &resolve.SingleFetch{
+														BufferId: 1,
+														Input:    `{"method":"POST","url":"http://product.service","body":{"query":"query($representations: [_Any!]!){_entities(representations: $representations){__typename ... on Product {name}}}","variables":{"representations":[{"sku":$$0$$,"__typename":"Product"}]}}}`,
+														Variables: resolve.NewVariables(
+															&resolve.ObjectVariable{
+																Path:     []string{"sku"},
+																Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string"]}`),
+															},
+														),
+														DataSource:           &Source{},
+														DataSourceIdentifier: []byte("graphql_datasource.Source"),
+														ProcessResponseConfig: resolve.ProcessResponseConfig{
+															ExtractGraphqlResponse:    true,
+															ExtractFederationEntities: true,
+														},
+														SetTemplateOutputToNullOnVariableNull: true,
+													},
+													BatchFactory: batchFactory,
+												},
+												Fields: []*resolve.Field{
+													{
+														HasBuffer: true,
+														BufferID:  1,
+														Name:      []byte("name"),
+														Value: &resolve.String{
+															Path: []string{"name"},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
 						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
 						{
-							TypeName:   "Product",
-							FieldNames: []string{"reviews"},
+							TypeName:   "Query",
+							FieldNames: []string{"topReviews"},
 						},
 					},
 					ChildNodes: []plan.TypeField{
 						{
 							TypeName:   "Review",
-							FieldNames: []string{"body", "author", "product"},
-						},
-						{
-							TypeName:   "User",
-							FieldNames: []string{"id", "username"},
+							FieldNames: []string{"body", "product"},
 						},
 						{
 							TypeName:   "Product",
-							FieldNames: []string{"upc"},
+							FieldNames: []string{"sku"},
 						},
 					},
-					Factory: federationFactory,
 					Custom: ConfigJson(Configuration{
 						Fetch: FetchConfiguration{
 							URL: "http://review.service",
 						},
 						Federation: FederationConfiguration{
 							Enabled:    true,
-							ServiceSDL: "type Review { body: String! author: User! @provides(fields: \"username\") product: Product! } extend type User @key(fields: \"id\") { id: ID! @external reviews: [Review] } extend type Product @key(fields: \"upc\") { upc: String! @external reviews: [Review] }",
+							ServiceSDL: "extend type Query {topReviews: [Review]} type Review { body: String! product: Product! } extend type Product @key(fields: \"sku\") { sku: String! @external }",
 						},
 					}),
+					Factory: federationFactory,
 				},
-			},
-			Fields: []plan.FieldConfiguration{
 				{
-					TypeName:  "Query",
-					FieldName: "topProducts",
-					Arguments: []plan.ArgumentConfiguration{
+					RootNodes: []plan.TypeField{
 						{
-							Name:       "first",
-							SourceType: plan.FieldArgumentSource,
+							TypeName:   "Product",
+							FieldNames: []string{"upc", "sku", "name"},
 						},
 					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "Product",
+							FieldNames: []string{"upc", "sku", "name"},
+						},
+					},
+					Factory: federationFactory,
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://product.service",
+						},
+						Federation: FederationConfiguration{
+							Enabled:    true,
+							ServiceSDL: "type Product @key(fields: \"upc\") @key(fields: \"sku\") { upc: String! sku: String! name: String! }",
+						},
+					}),
 				},
-				{
-					TypeName:       "User",
-					FieldName:      "reviews",
-					RequiresFields: []string{"id"},
-				},
+			},
+			Fields: []plan.FieldConfiguration{
 				{
 					TypeName:       "Product",
-					FieldName:      "name",
-					RequiresFields: []string{"upc"},
+					FieldName:      "upc",
+					RequiresFields: []string{"sku"},
 				},
 				{
 					TypeName:       "Product",
-					FieldName:      "price",
+					FieldName:      "sku",
 					RequiresFields: []string{"upc"},
 				},
 				{
-					TypeName:       "Product",
-					FieldName:      "reviews",
-					RequiresFields: []string{"upc"},
+					TypeName:                   "Product",
+					FieldName:                  "name",
+					RequiresFields:             []string{"upc"},
+					RequiresFieldsAlternatives: [][]string{{"sku"}},
 				},
 			},
 			DisableResolveFieldPositions: true,
@@ -8171,6 +8772,160 @@ func TestSource_Load(t *testing.T) {
 	})
 }
 
+func TestWebSocketSource_Load(t *testing.T) {
+	t.Run("should run operations over a shared connection and correlate responses by id", func(t *testing.T) {
+		var connections int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&connections, 1)
+			conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{ProtocolGraphQLTWS}})
+			require.NoError(t, err)
+			defer conn.Close(websocket.StatusNormalClosure, "")
+
+			ctx := context.Background()
+			_, data, err := conn.Read(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, `{"type":"connection_init"}`, string(data))
+			require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte(`{"type":"connection_ack"}`)))
+
+			for i := 0; i < 2; i++ {
+				_, data, err = conn.Read(ctx)
+				require.NoError(t, err)
+				assert.Contains(t, string(data), `"type":"subscribe"`)
+			}
+
+			// respond in reverse order
+			for _, message := range []string{
+				`{"id":"2","type":"next","payload":{"data":{"id":2}}}`,
+				`{"id":"2","type":"complete"}`,
+				`{"id":"1","type":"next","payload":{"data":{"id":1}}}`,
+				`{"id":"1","type":"complete"}`,
+			} {
+				require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte(message)))
+			}
+
+			_, _, _ = conn.Read(ctx)
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		source := &WebSocketSource{
+			client: NewGraphQLSubscriptionClient(http.DefaultClient, http.DefaultClient, ctx, WithReadTimeout(time.Millisecond)),
+		}
+
+		input := func(query string) []byte {
+			var input []byte
+			input = httpclient.SetInputBodyWithPath(input, []byte(`"`+query+`"`), "query")
+			return httpclient.SetInputURL(input, []byte(strings.Replace(server.URL, "http", "ws", 1)))
+		}
+
+		results := make([]*bytes.Buffer, 2)
+		wg := &sync.WaitGroup{}
+		for i := range results {
+			results[i] = &bytes.Buffer{}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				assert.NoError(t, source.Load(ctx, input(fmt.Sprintf("{id%d}", i)), results[i]))
+			}(i)
+			// ensure the operations are started in order
+			time.Sleep(50 * time.Millisecond)
+		}
+		wg.Wait()
+
+		assert.Equal(t, `{"data":{"id":1}}`, results[0].String())
+		assert.Equal(t, `{"data":{"id":2}}`, results[1].String())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+	})
+
+	t.Run("should run mutation with chat example", func(t *testing.T) {
+		chatServer := httptest.NewServer(subscriptiontesting.ChatGraphQLEndpointHandler())
+		defer chatServer.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		source := &WebSocketSource{
+			client: NewGraphQLSubscriptionClient(http.DefaultClient, http.DefaultClient, ctx, WithWSSubProtocol(ProtocolGraphQLTWS)),
+		}
+
+		var input []byte
+		input = httpclient.SetInputBodyWithPath(input, []byte(`"mutation { post(roomName: \"#test\", username: \"myuser\", text: \"hello\") { text createdBy } }"`), "query")
+		input = httpclient.SetInputURL(input, []byte(chatServer.URL))
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.Load(ctx, input, buf))
+		assert.Equal(t, `{"data":{"post":{"text":"hello","createdBy":"myuser"}}}`, buf.String())
+	})
+
+	t.Run("should return the errors of an origin which doesn't complete the operation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{ProtocolGraphQLTWS}})
+			require.NoError(t, err)
+			defer conn.Close(websocket.StatusNormalClosure, "")
+
+			ctx := context.Background()
+			_, _, err = conn.Read(ctx)
+			require.NoError(t, err)
+			require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte(`{"type":"connection_ack"}`)))
+
+			_, data, err := conn.Read(ctx)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `"type":"subscribe"`)
+
+			// error is a terminal message, no complete message follows
+			require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte(`{"id":"1","type":"error","payload":[{"message":"Cannot query field \"unknown\" on type \"Query\"."}]}`)))
+
+			_, _, _ = conn.Read(ctx)
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		source := &WebSocketSource{
+			client: NewGraphQLSubscriptionClient(http.DefaultClient, http.DefaultClient, ctx, WithReadTimeout(time.Millisecond)),
+		}
+
+		var input []byte
+		input = httpclient.SetInputBodyWithPath(input, []byte(`"{unknown}"`), "query")
+		input = httpclient.SetInputURL(input, []byte(server.URL))
+
+		loadCtx, loadCancel := context.WithTimeout(ctx, 5*time.Second)
+		defer loadCancel()
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.Load(loadCtx, input, buf))
+		assert.Equal(t, `{"errors":[{"message":"Cannot query field \"unknown\" on type \"Query\"."}]}`, buf.String())
+	})
+
+	t.Run("should return error when subscription client fails", func(t *testing.T) {
+		source := &WebSocketSource{client: FailingSubscriptionClient{}}
+		err := source.Load(context.Background(), []byte(`{"url":"ws://localhost","body":{"query":"{a}"}}`), &bytes.Buffer{})
+		assert.Equal(t, errSubscriptionClientFail, err)
+	})
+}
+
+func TestConfiguration_ApplyDefaults(t *testing.T) {
+	run := func(fetchURL, subscriptionURL, expectedWebSocketURL string) func(t *testing.T) {
+		return func(t *testing.T) {
+			config := Configuration{
+				Fetch:        FetchConfiguration{URL: fetchURL, UseWebSocket: true},
+				Subscription: SubscriptionConfiguration{URL: subscriptionURL},
+			}
+			config.ApplyDefaults()
+			assert.Equal(t, expectedWebSocketURL, config.Fetch.WebSocketURL)
+		}
+	}
+
+	t.Run("http", run("http://example.com/graphql", "", "ws://example.com/graphql"))
+	t.Run("https", run("https://example.com/graphql", "", "wss://example.com/graphql"))
+	t.Run("host containing http", run("http://http-gateway.local/graphql", "", "ws://http-gateway.local/graphql"))
+	t.Run("websocket scheme", run("ws://example.com/graphql", "", "ws://example.com/graphql"))
+	t.Run("subscription url", run("https://example.com/graphql", "wss://example.com/ws", "wss://example.com/ws"))
+}
+
 func TestUnNullVariables(t *testing.T) {
 	t.Run("should not unnull variables if not enabled", func(t *testing.T) {
 		t.Run("two variables, one null", func(t *testing.T) {
@@ -25,7 +25,7 @@ type SubscriptionClient struct {
 	engineCtx                  context.Context
 	log                        abstractlogger.Logger
 	hashPool                   sync.Pool
-	handlers                   map[uint64]ConnectionHandler
+	handlers                   map[uint64]*connectionHandlerEntry
 	handlersMu                 sync.Mutex
 	wsSubProtocol              string
 	onWsConnectionInitCallback *OnWsConnectionInitCallback
@@ -90,7 +90,7 @@ func NewGraphQLSubscriptionClient(httpClient, streamingClient *http.Client, engi
 		httpClient:      httpClient,
 		streamingClient: streamingClient,
 		engineCtx:       engineCtx,
-		handlers:        make(map[uint64]ConnectionHandler),
+		handlers:        make(map[uint64]*connectionHandlerEntry),
 		log:             op.log,
 		readTimeout:     op.readTimeout,
 		hashPool: sync.Pool{
@@ -152,30 +152,49 @@ func (c *SubscriptionClient) subscribeWS(reqCtx context.Context, options GraphQL
 		return err
 	}
 
-	c.handlersMu.Lock()
-	defer c.handlersMu.Unlock()
-	handler, exists := c.handlers[handlerID]
-	if exists {
+	for {
+		c.handlersMu.Lock()
+		entry, exists := c.handlers[handlerID]
+		if !exists {
+			defer c.handlersMu.Unlock()
+			return c.startWSConnectionHandler(reqCtx, handlerID, sub)
+		}
+		c.handlersMu.Unlock()
+
 		select {
-		case handler.SubscribeCH() <- sub:
+		case entry.handler.SubscribeCH() <- sub:
+			return nil
+		case <-entry.done:
+			// the connection terminated in the meantime, so we have to establish a new one
+			continue
 		case <-reqCtx.Done():
+			return nil
 		}
-		return nil
 	}
+}
 
-	handler, err = c.newWSConnectionHandler(reqCtx, options)
+// startWSConnectionHandler establishes a new WS connection for the given Subscription, handlersMu must be held by the caller
+func (c *SubscriptionClient) startWSConnectionHandler(reqCtx context.Context, handlerID uint64, sub Subscription) error {
+	handler, err := c.newWSConnectionHandler(reqCtx, sub.options)
 	if err != nil {
 		return err
 	}
 
-	c.handlers[handlerID] = handler
+	entry := &connectionHandlerEntry{
+		handler: handler,
+		done:    make(chan struct{}),
+	}
+	c.handlers[handlerID] = entry
 
-	go func(handlerID uint64) {
+	go func() {
 		handler.StartBlocking(sub)
+		close(entry.done)
 		c.handlersMu.Lock()
-		delete(c.handlers, handlerID)
+		if c.handlers[handlerID] == entry {
+			delete(c.handlers, handlerID)
+		}
 		c.handlersMu.Unlock()
-	}(handlerID)
+	}()
 
 	return nil
 }
@@ -281,6 +300,13 @@ type ConnectionHandler interface {
 	SubscribeCH() chan<- Subscription
 }
 
+// connectionHandlerEntry tracks a running ConnectionHandler,
+// done is closed as soon as the handler stopped accepting new subscriptions
+type connectionHandlerEntry struct {
+	handler ConnectionHandler
+	done    chan struct{}
+}
+
 type Subscription struct {
 	ctx     context.Context
 	options GraphQLSubscriptionOptions
@@ -163,6 +163,8 @@ func (h *gqlTWSConnectionHandler) handleMessageTypeComplete(data []byte) {
 	delete(h.subscriptions, id)
 }
 
+// handleMessageTypeError forwards the errors to the subscription.
+// In the graphql-transport-ws protocol error is a terminal message, so the subscription is done afterwards.
 func (h *gqlTWSConnectionHandler) handleMessageTypeError(data []byte) {
 	id, err := jsonparser.GetString(data, "id")
 	if err != nil {
@@ -172,34 +174,40 @@ func (h *gqlTWSConnectionHandler) handleMessageTypeError(data []byte) {
 	if !ok {
 		return
 	}
+	defer func() {
+		close(sub.next)
+		delete(h.subscriptions, id)
+	}()
 
+	response := []byte(internalError)
 	value, valueType, _, err := jsonparser.Get(data, "payload")
-	if err != nil {
+	switch {
+	case err != nil:
 		h.log.Error(
 			"failed to get payload from error message",
 			log.Error(err),
 			log.ByteString("raw message", data),
 		)
-		sub.next <- []byte(internalError)
-		return
-	}
-
-	switch valueType {
-	case jsonparser.Array:
-		response := []byte(`{}`)
-		response, err = jsonparser.Set(response, value, "errors")
+	case valueType == jsonparser.Array:
+		errorsResponse, err := jsonparser.Set([]byte(`{}`), value, "errors")
 		if err != nil {
 			h.log.Error(
 				"failed to set errors response",
 				log.Error(err),
 				log.ByteString("raw message", value),
 			)
-			sub.next <- []byte(internalError)
-			return
+			break
 		}
-		sub.next <- response
-	default:
-		sub.next <- []byte(internalError)
+		response = errorsResponse
+	}
+
+	ctx, cancel := context.WithTimeout(h.ctx, time.Second*5)
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+	case sub.next <- response:
+	case <-sub.ctx.Done():
 	}
 }
 
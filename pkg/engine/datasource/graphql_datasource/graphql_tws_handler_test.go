@@ -209,9 +209,10 @@ func TestWebsocketSubscriptionClientError_GQLTWS(t *testing.T) {
 	message := <-next
 	assert.Equal(t, `{"errors":[{"message":"Unexpected Name \"wrongQuery\"","locations":[{"line":1,"column":1}],"extensions":{"code":"GRAPHQL_PARSE_FAILED"}}]}`, string(message))
 
-	clientCancel()
+	// error is a terminal message, so the subscription is done without cancelling it
 	_, ok := <-next
 	assert.False(t, ok)
+	clientCancel()
 
 	serverCancel()
 	assert.Eventuallyf(t, func() bool {
@@ -27,6 +27,9 @@ func NewIntrospectionConfigFactory(schema *ast.Document) (*IntrospectionConfigFa
 	return &IntrospectionConfigFactory{introspectionData: &data}, nil
 }
 
+// BuildFieldConfigurations returns the __Type fields and the args of __Field and __Directive
+// which are resolved by a separate fetch in order to apply the includeDeprecated argument.
+// The args aren't scoped to a type, so they are passed to the fetch from the parent data.
 func (f *IntrospectionConfigFactory) BuildFieldConfigurations() (planFields plan.FieldConfigurations) {
 	return plan.FieldConfigurations{
 		{
@@ -49,6 +52,21 @@ func (f *IntrospectionConfigFactory) BuildFieldConfigurations() (planFields plan
 			FieldName:             "enumValues",
 			DisableDefaultMapping: true,
 		},
+		{
+			TypeName:              "__Type",
+			FieldName:             "inputFields",
+			DisableDefaultMapping: true,
+		},
+		{
+			TypeName:              "__Field",
+			FieldName:             "args",
+			DisableDefaultMapping: true,
+		},
+		{
+			TypeName:              "__Directive",
+			FieldName:             "args",
+			DisableDefaultMapping: true,
+		},
 	}
 }
 
@@ -61,7 +79,15 @@ func (f *IntrospectionConfigFactory) BuildDataSourceConfiguration() plan.DataSou
 			},
 			{
 				TypeName:   "__Type",
-				FieldNames: []string{"fields", "enumValues"},
+				FieldNames: []string{"fields", "enumValues", "inputFields"},
+			},
+			{
+				TypeName:   "__Field",
+				FieldNames: []string{"args"},
+			},
+			{
+				TypeName:   "__Directive",
+				FieldNames: []string{"args"},
 			},
 		},
 		Factory: NewFactory(f.introspectionData),
@@ -2,6 +2,7 @@ package introspection_datasource
 
 import (
 	"bytes"
+	"encoding/json"
 	"strconv"
 )
 
@@ -12,13 +13,17 @@ const (
 	TypeRequestType
 	TypeFieldsRequestType
 	TypeEnumValuesRequestType
+	TypeInputFieldsRequestType
+	ArgsRequestType
 )
 
 const (
-	schemaFieldName     = "__schema"
-	typeFieldName       = "__type"
-	fieldsFieldName     = "fields"
-	enumValuesFieldName = "enumValues"
+	schemaFieldName      = "__schema"
+	typeFieldName        = "__type"
+	fieldsFieldName      = "fields"
+	enumValuesFieldName  = "enumValues"
+	inputFieldsFieldName = "inputFields"
+	argsFieldName        = "args"
 )
 
 type introspectionInput struct {
@@ -26,6 +31,8 @@ type introspectionInput struct {
 	OnTypeName        *string     `json:"on_type_name"`
 	TypeName          *string     `json:"type_name"`
 	IncludeDeprecated bool        `json:"include_deprecated"`
+	// Args are the arguments of a __Field or __Directive, which aren't scoped to a type
+	Args json.RawMessage `json:"args"`
 }
 
 var (
@@ -36,6 +43,7 @@ var (
 	onTypeField            = []byte(`"on_type_name":"{{ .object.name }}"`)
 	typeNameField          = []byte(`"type_name":"{{ .arguments.name }}"`)
 	includeDeprecatedField = []byte(`"include_deprecated":{{ .arguments.includeDeprecated }}`)
+	argsField              = []byte(`"args":{{ .object.args }}`)
 )
 
 func buildInput(fieldName string) string {
@@ -53,6 +61,15 @@ func buildInput(fieldName string) string {
 	case enumValuesFieldName:
 		writeRequestTypeField(buf, TypeEnumValuesRequestType)
 		writeOnTypeFields(buf)
+	case inputFieldsFieldName:
+		writeRequestTypeField(buf, TypeInputFieldsRequestType)
+		writeOnTypeFields(buf)
+	case argsFieldName:
+		writeRequestTypeField(buf, ArgsRequestType)
+		buf.Write(comma)
+		buf.Write(argsField)
+		buf.Write(comma)
+		buf.Write(includeDeprecatedField)
 	default:
 		writeRequestTypeField(buf, SchemaRequestType)
 	}
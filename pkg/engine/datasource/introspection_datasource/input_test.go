@@ -21,6 +21,8 @@ func TestBuildInput(t *testing.T) {
 	t.Run("type introspection", run(typeFieldName, `{"request_type":2,"type_name":"{{ .arguments.name }}"}`))
 	t.Run("type fields", run(fieldsFieldName, `{"request_type":3,"on_type_name":"{{ .object.name }}","include_deprecated":{{ .arguments.includeDeprecated }}}`))
 	t.Run("type enum values", run(enumValuesFieldName, `{"request_type":4,"on_type_name":"{{ .object.name }}","include_deprecated":{{ .arguments.includeDeprecated }}}`))
+	t.Run("type input fields", run(inputFieldsFieldName, `{"request_type":5,"on_type_name":"{{ .object.name }}","include_deprecated":{{ .arguments.includeDeprecated }}}`))
+	t.Run("args", run(argsFieldName, `{"request_type":6,"args":{{ .object.args }},"include_deprecated":{{ .arguments.includeDeprecated }}}`))
 }
 
 func TestUnmarshalIntrospectionInput(t *testing.T) {
@@ -39,4 +41,6 @@ func TestUnmarshalIntrospectionInput(t *testing.T) {
 	t.Run("type introspection", run(`{"request_type":2,"type_name":"Foo"}`, introspectionInput{RequestType: TypeRequestType, TypeName: &foo}))
 	t.Run("type fields", run(`{"request_type":3,"on_type_name":"Foo","include_deprecated":true}`, introspectionInput{RequestType: TypeFieldsRequestType, OnTypeName: &foo, IncludeDeprecated: true}))
 	t.Run("type enum values", run(`{"request_type":4,"on_type_name":"Foo","include_deprecated":false}`, introspectionInput{RequestType: TypeEnumValuesRequestType, OnTypeName: &foo, IncludeDeprecated: false}))
+	t.Run("type input fields", run(`{"request_type":5,"on_type_name":"Foo","include_deprecated":true}`, introspectionInput{RequestType: TypeInputFieldsRequestType, OnTypeName: &foo, IncludeDeprecated: true}))
+	t.Run("args", run(`{"request_type":6,"args":[{"name":"id"}],"include_deprecated":true}`, introspectionInput{RequestType: ArgsRequestType, Args: json.RawMessage(`[{"name":"id"}]`), IncludeDeprecated: true}))
 }
@@ -1,6 +1,7 @@
 package introspection_datasource
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
@@ -29,6 +30,10 @@ func (s *Source) Load(ctx context.Context, input []byte, w io.Writer) (err error
 		return s.enumValuesForType(w, req.OnTypeName, req.IncludeDeprecated)
 	case TypeFieldsRequestType:
 		return s.fieldsForType(w, req.OnTypeName, req.IncludeDeprecated)
+	case TypeInputFieldsRequestType:
+		return s.inputFieldsForType(w, req.OnTypeName, req.IncludeDeprecated)
+	case ArgsRequestType:
+		return s.args(w, req.Args, req.IncludeDeprecated)
 	}
 
 	return json.NewEncoder(w).Encode(s.introspectionData.Schema)
@@ -100,3 +105,55 @@ func (s *Source) enumValuesForType(w io.Writer, typeName *string, includeDepreca
 
 	return json.NewEncoder(w).Encode(enumValues)
 }
+
+func (s *Source) inputFieldsForType(w io.Writer, typeName *string, includeDeprecated bool) error {
+	typeInfo := s.typeInfo(typeName)
+	if typeInfo == nil {
+		return s.writeNull(w)
+	}
+
+	if includeDeprecated {
+		return json.NewEncoder(w).Encode(typeInfo.InputFields)
+	}
+
+	inputFields := make([]introspection.InputValue, 0, len(typeInfo.InputFields))
+	for _, inputField := range typeInfo.InputFields {
+		if !inputField.IsDeprecated {
+			inputFields = append(inputFields, inputField)
+		}
+	}
+
+	return json.NewEncoder(w).Encode(inputFields)
+}
+
+// args filters the arguments of a __Field or __Directive, which are passed in from the parent data
+func (s *Source) args(w io.Writer, rawArgs json.RawMessage, includeDeprecated bool) error {
+	if len(rawArgs) == 0 || bytes.Equal(rawArgs, null) {
+		return s.writeNull(w)
+	}
+
+	if includeDeprecated {
+		_, err := w.Write(rawArgs)
+		return err
+	}
+
+	var args []introspection.InputValue
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return err
+	}
+
+	filteredArgs := make([]introspection.InputValue, 0, len(args))
+	for _, arg := range args {
+		if !arg.IsDeprecated {
+			filteredArgs = append(filteredArgs, arg)
+		}
+	}
+
+	// args are non-nullable lists, so empty args must be written as plain [] without a trailing newline
+	data, err := json.Marshal(filteredArgs)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
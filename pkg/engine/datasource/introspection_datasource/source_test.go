@@ -57,6 +57,26 @@ func TestSource_Load(t *testing.T) {
 
 		t.Run("of not existing type", run(testSchema, `{"request_type":4,"on_type_name":"NotExisting","include_deprecated":true}`, `not_existing_type`))
 	})
+
+	t.Run("type input fields", func(t *testing.T) {
+		t.Run("include deprecated", run(testSchema, `{"request_type":5,"on_type_name":"DroidFilter","include_deprecated":true}`, `input_fields_with_deprecated`))
+
+		t.Run("no deprecated", run(testSchema, `{"request_type":5,"on_type_name":"DroidFilter","include_deprecated":false}`, `input_fields_without_deprecated`))
+
+		t.Run("of not existing type", run(testSchema, `{"request_type":5,"on_type_name":"NotExisting","include_deprecated":true}`, `not_existing_type`))
+	})
+
+	t.Run("args", func(t *testing.T) {
+		args := `[{"name":"id","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"ID","ofType":null}},"defaultValue":null,"isDeprecated":false,"deprecationReason":null},{"name":"name","description":"","type":{"kind":"SCALAR","name":"String","ofType":null},"defaultValue":null,"isDeprecated":true,"deprecationReason":"use id"}]`
+
+		t.Run("include deprecated", run(testSchema, `{"request_type":6,"args":`+args+`,"include_deprecated":true}`, `args_with_deprecated`))
+
+		t.Run("no deprecated", run(testSchema, `{"request_type":6,"args":`+args+`,"include_deprecated":false}`, `args_without_deprecated`))
+
+		t.Run("empty args", run(testSchema, `{"request_type":6,"args":[],"include_deprecated":false}`, `empty_args`))
+
+		t.Run("without args", run(testSchema, `{"request_type":6,"args":null,"include_deprecated":false}`, `not_existing_type`))
+	})
 }
 
 const testSchema = `
@@ -67,6 +87,7 @@ schema {
 type Query {
     me: Droid @deprecated
     droid(id: ID!): Droid
+    droids(filter: DroidFilter): [Droid]
 }
 
 enum Episode {
@@ -78,6 +99,11 @@ enum Episode {
 type Droid {
     name: String!
 }
+
+input DroidFilter {
+    name: String
+    serial: String @deprecated(reason: "use name")
+}
 `
 
 const testSchemaWithCustomRootOperationTypes = `
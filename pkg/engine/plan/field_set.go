@@ -0,0 +1,79 @@
+package plan
+
+import (
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+)
+
+// fieldSetPaths returns the dot delimited paths of all fields in a field set, e.g.
+// "name address { city }" results in "name", "address" and "address.city"
+func fieldSetPaths(fieldSet string) []string {
+	return parseFieldSet(fieldSet, false)
+}
+
+// fieldSetLeafPaths returns the dot delimited paths of the fields in a field set which have no selections, e.g.
+// "id organization { id }" results in "id" and "organization.id"
+func fieldSetLeafPaths(fieldSet string) []string {
+	return parseFieldSet(fieldSet, true)
+}
+
+// FieldSetFieldNames returns the distinct names of the top level fields of a field set, e.g.
+// "id organization { id }" results in "id" and "organization"
+func FieldSetFieldNames(fieldSet string) []string {
+	return fieldSetTopLevelFieldNames(fieldSetPaths(fieldSet))
+}
+
+// fieldSetTopLevelFieldNames returns the distinct names of the top level fields of the given paths, e.g.
+// "id", "organization.id" and "organization.name" result in "id" and "organization"
+func fieldSetTopLevelFieldNames(paths []string) []string {
+	fieldNames := make([]string, 0, len(paths))
+	seen := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		fieldName := strings.SplitN(path, ".", 2)[0]
+		if _, ok := seen[fieldName]; ok {
+			continue
+		}
+		seen[fieldName] = struct{}{}
+		fieldNames = append(fieldNames, fieldName)
+	}
+	return fieldNames
+}
+
+// parseFieldSet parses a field set of a federation directive like @key, @requires or @provides as a selection set.
+// The field set may be wrapped in curly braces. Field sets which can't be parsed result in nil.
+func parseFieldSet(fieldSet string, leavesOnly bool) []string {
+	selectionSet := strings.TrimSpace(fieldSet)
+	if !strings.HasPrefix(selectionSet, "{") {
+		selectionSet = "{" + selectionSet + "}"
+	}
+	document, report := astparser.ParseGraphqlDocumentString(selectionSet)
+	if report.HasErrors() || len(document.OperationDefinitions) == 0 {
+		return nil
+	}
+
+	var paths []string
+	var collect func(selectionSetRef int, prefix string)
+	collect = func(selectionSetRef int, prefix string) {
+		for _, selectionRef := range document.SelectionSets[selectionSetRef].SelectionRefs {
+			selection := document.Selections[selectionRef]
+			switch selection.Kind {
+			case ast.SelectionKindField:
+				path := prefix + document.FieldNameString(selection.Ref)
+				hasSelections := document.Fields[selection.Ref].HasSelections
+				if !leavesOnly || !hasSelections {
+					paths = append(paths, path)
+				}
+				if hasSelections {
+					collect(document.Fields[selection.Ref].SelectionSet, path+".")
+				}
+			case ast.SelectionKindInlineFragment:
+				collect(document.InlineFragments[selection.Ref].SelectionSet, prefix)
+			}
+		}
+	}
+	collect(document.OperationDefinitions[0].SelectionSet, "")
+
+	return paths
+}
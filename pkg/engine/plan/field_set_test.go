@@ -0,0 +1,28 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldSet(t *testing.T) {
+	t.Run("paths", func(t *testing.T) {
+		assert.Equal(t, []string{"id"}, fieldSetPaths("id"))
+		assert.Equal(t, []string{"id", "organization", "organization.id"}, fieldSetPaths("id organization { id }"))
+		assert.Equal(t, []string{"lastname"}, fieldSetPaths("{ lastname }"))
+		assert.Nil(t, fieldSetPaths("organization {"))
+	})
+	t.Run("leaf paths", func(t *testing.T) {
+		assert.Equal(t, []string{"id", "sku"}, fieldSetLeafPaths("id sku"))
+		assert.Equal(t, []string{"id", "organization.id", "organization.owner.id"}, fieldSetLeafPaths("id organization { id owner { id } }"))
+	})
+	t.Run("top level field names", func(t *testing.T) {
+		assert.Equal(t, []string{"id", "organization"}, fieldSetTopLevelFieldNames([]string{"id", "organization.id", "organization.name"}))
+	})
+	t.Run("field names", func(t *testing.T) {
+		assert.Equal(t, []string{"id", "sku"}, FieldSetFieldNames("id sku"))
+		assert.Equal(t, []string{"id", "organization", "name"}, FieldSetFieldNames("id organization { id owner { id } } name"))
+		assert.Equal(t, []string{"organization"}, FieldSetFieldNames("organization{id}"))
+	})
+}
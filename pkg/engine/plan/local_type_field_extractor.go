@@ -235,7 +235,7 @@ func (e *LocalTypeFieldExtractor) collectFieldDefinitions(node ast.Node, nodeInf
 		}
 
 		requiredFields := requiredFieldsByRequiresDirective(e.document, ref)
-		for _, field := range requiredFields {
+		for _, field := range fieldSetTopLevelFieldNames(requiredFields) {
 			nodeInfo.requiredFields[field] = struct{}{}
 		}
 	}
@@ -114,9 +114,15 @@ type FieldConfiguration struct {
 	// DisableDefaultMapping - instructs planner whether to use path mapping coming from Path field
 	DisableDefaultMapping bool
 	// Path - represents a json path to lookup for a field value in response json
-	Path           []string
-	Arguments      ArgumentsConfigurations
+	Path      []string
+	Arguments ArgumentsConfigurations
+	// RequiresFields - fields which have to be resolved before the field can be resolved, e.g. the federation key
+	// and the fields of the @requires directive. Nested fields are expressed as dot delimited paths, e.g. "organization.id"
 	RequiresFields []string
+	// RequiresFieldsAlternatives - further sets of fields which can be used instead of RequiresFields,
+	// e.g. for federation entities with multiple keys. The planner uses the first set of fields
+	// the DataSource of the enclosing field is able to resolve.
+	RequiresFieldsAlternatives [][]string
 	// UnescapeResponseJson set to true will allow fields (String,List,Object)
 	// to be resolved from an escaped JSON string
 	// e.g. {"response":"{\"foo\":\"bar\"}"} will be returned as {"foo":"bar"} when path is "response"
@@ -164,6 +170,10 @@ type DataSourceConfiguration struct {
 	// They are always required for the Graphql datasources cause each field could have it's own datasource
 	// For any single point datasource like HTTP/REST or GRPC we could not request less fields, as we always get a full response
 	ChildNodes []TypeField
+	// Provides - describes fields which the DataSource is only able to resolve when they are selected through a specific field
+	// This is the case for fields of federation entities which are provided by a field using the @provides directive
+	// Inside the selection set of such a field the provided fields are treated like child nodes
+	Provides   []ProvidesConfiguration
 	Directives DirectiveConfigurations
 	Factory    PlannerFactory
 	Custom     json.RawMessage
@@ -183,6 +193,30 @@ func (d *DataSourceConfiguration) HasRootNode(typeName, fieldName string) bool {
 	return false
 }
 
+func (d *DataSourceConfiguration) HasChildNode(typeName, fieldName string) bool {
+	for i := range d.ChildNodes {
+		if typeName != d.ChildNodes[i].TypeName {
+			continue
+		}
+		for j := range d.ChildNodes[i].FieldNames {
+			if fieldName == d.ChildNodes[i].FieldNames[j] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canResolveFields returns true if the top level fields of all paths are root or child nodes of the type
+func (d *DataSourceConfiguration) canResolveFields(typeName string, fieldPaths []string) bool {
+	for _, fieldName := range fieldSetTopLevelFieldNames(fieldPaths) {
+		if !d.HasRootNode(typeName, fieldName) && !d.HasChildNode(typeName, fieldName) {
+			return false
+		}
+	}
+	return true
+}
+
 type PlannerFactory interface {
 	// Planner should return the DataSourcePlanner
 	// closer is the closing channel for all stateful DataSources
@@ -198,6 +232,14 @@ type TypeField struct {
 	FieldNames []string
 }
 
+// ProvidesConfiguration describes the fields a DataSource resolves along with the field FieldName of the type TypeName
+type ProvidesConfiguration struct {
+	TypeName  string
+	FieldName string
+	// FieldPaths are the dot delimited paths of the provided fields relative to the field, e.g. "name" or "address.city"
+	FieldPaths []string
+}
+
 type FieldMapping struct {
 	TypeName              string
 	FieldName             string
@@ -225,6 +267,7 @@ func NewPlanner(ctx context.Context, config Configuration) *Planner {
 	requiredFieldsWalker.RegisterEnterDocumentVisitor(requiredFieldsV)
 	requiredFieldsWalker.RegisterEnterOperationVisitor(requiredFieldsV)
 	requiredFieldsWalker.RegisterEnterFieldVisitor(requiredFieldsV)
+	requiredFieldsWalker.RegisterSelectionSetVisitor(requiredFieldsV)
 	requiredFieldsWalker.RegisterLeaveDocumentVisitor(requiredFieldsV)
 
 	// configuration
@@ -295,6 +338,7 @@ func (p *Planner) Plan(operation, definition *ast.Document, operationName string
 	p.planningVisitor.fetchConfigurations = p.configurationVisitor.fetches
 	p.planningVisitor.fieldBuffers = p.configurationVisitor.fieldBuffers
 	p.planningVisitor.skipFieldPaths = p.requiredFieldsVisitor.skipFieldPaths
+	p.planningVisitor.requiredFields = p.requiredFieldsVisitor.requiredFields
 
 	p.planningWalker.ResetVisitors()
 	p.planningWalker.SetVisitorFilter(p.planningVisitor)
@@ -380,6 +424,7 @@ type Visitor struct {
 	fetchConfigurations          []objectFetchConfiguration
 	fieldBuffers                 map[int]int
 	skipFieldPaths               []string
+	requiredFields               map[int][]string
 	fieldConfigs                 map[int]*FieldConfiguration
 	exportedVariables            map[string]struct{}
 	skipIncludeFields            map[int]skipIncludeField
@@ -684,6 +729,9 @@ func (v *Visitor) resolveOnTypeNames() [][]byte {
 }
 
 func (v *Visitor) LeaveField(ref int) {
+	if v.skipField(ref) {
+		return
+	}
 	if v.currentFields[len(v.currentFields)-1].popOnField == ref {
 		v.currentFields = v.currentFields[:len(v.currentFields)-1]
 	}
@@ -701,13 +749,22 @@ func (v *Visitor) LeaveField(ref int) {
 func (v *Visitor) skipField(ref int) bool {
 	fullPath := v.Walker.Path.DotDelimitedString() + "." + v.Operation.FieldAliasOrNameString(ref)
 	for i := range v.skipFieldPaths {
-		if v.skipFieldPaths[i] == fullPath {
+		if v.skipFieldPaths[i] == fullPath || strings.HasPrefix(fullPath, v.skipFieldPaths[i]+".") {
 			return true
 		}
 	}
 	return false
 }
 
+// RequiredFields returns the fields required to resolve the field.
+// For fields with RequiresFieldsAlternatives this is the set of fields chosen by the planner, e.g. one of multiple keys.
+func (v *Visitor) RequiredFields(fieldRef int, fieldConfig *FieldConfiguration) []string {
+	if requiredFields, ok := v.requiredFields[fieldRef]; ok {
+		return requiredFields
+	}
+	return fieldConfig.RequiresFields
+}
+
 func (v *Visitor) resolveFieldValue(fieldRef, typeRef int, nullable bool, path []string) resolve.Node {
 	ofType := v.Definition.Types[typeRef].OfType
 
@@ -1522,26 +1579,20 @@ func (p *plannerConfiguration) hasParent(parent string) bool {
 }
 
 func (p *plannerConfiguration) hasChildNode(typeName, fieldName string) bool {
-	for i := range p.dataSourceConfiguration.ChildNodes {
-		if typeName != p.dataSourceConfiguration.ChildNodes[i].TypeName {
-			continue
-		}
-		for j := range p.dataSourceConfiguration.ChildNodes[i].FieldNames {
-			if fieldName == p.dataSourceConfiguration.ChildNodes[i].FieldNames[j] {
-				return true
-			}
-		}
-	}
-	return false
+	return p.dataSourceConfiguration.HasChildNode(typeName, fieldName)
 }
 
 func (p *plannerConfiguration) hasRootNode(typeName, fieldName string) bool {
-	for i := range p.dataSourceConfiguration.RootNodes {
-		if typeName != p.dataSourceConfiguration.RootNodes[i].TypeName {
+	return p.dataSourceConfiguration.HasRootNode(typeName, fieldName)
+}
+
+func (p *plannerConfiguration) providesField(typeName, fieldName, fieldPath string) bool {
+	for i := range p.dataSourceConfiguration.Provides {
+		if typeName != p.dataSourceConfiguration.Provides[i].TypeName || fieldName != p.dataSourceConfiguration.Provides[i].FieldName {
 			continue
 		}
-		for j := range p.dataSourceConfiguration.RootNodes[i].FieldNames {
-			if fieldName == p.dataSourceConfiguration.RootNodes[i].FieldNames[j] {
+		for j := range p.dataSourceConfiguration.Provides[i].FieldPaths {
+			if fieldPath == p.dataSourceConfiguration.Provides[i].FieldPaths[j] {
 				return true
 			}
 		}
@@ -1592,7 +1643,7 @@ func (c *configurationVisitor) EnterField(ref int) {
 			return
 		}
 		if plannerConfig.hasPath(parentPath) {
-			if plannerConfig.hasChildNode(typeName, fieldName) {
+			if plannerConfig.hasChildNode(typeName, fieldName) || c.isProvidedField(&plannerConfig, fieldName) {
 				// has parent path + has child node = child
 				c.planners[i].paths = append(c.planners[i].paths, pathConfiguration{path: currentPath, shouldWalkFields: true})
 				return
@@ -1700,6 +1751,37 @@ func (c *configurationVisitor) addPlannerPathForChildOfAbstractParent(
 	return true
 }
 
+// isProvidedField returns true if one of the enclosing fields planned by the planner provides the current field,
+// e.g. when the current field is "username" and the enclosing field is declared as `author: User! @provides(fields: "username")`
+func (c *configurationVisitor) isProvidedField(plannerConfig *plannerConfiguration, fieldName string) bool {
+	if len(plannerConfig.dataSourceConfiguration.Provides) == 0 {
+		return false
+	}
+	fieldPath := fieldName
+	path := c.walker.Path
+	// the last parent type node is the enclosing type of the current field
+	parentTypeNodeIndex := len(c.parentTypeNodes) - 1
+	for i := len(c.walker.Ancestors) - 1; i >= 0; i-- {
+		ancestor := c.walker.Ancestors[i]
+		switch ancestor.Kind {
+		case ast.NodeKindSelectionSet:
+			parentTypeNodeIndex--
+		case ast.NodeKindField:
+			if parentTypeNodeIndex < 0 || !plannerConfig.hasPath(path.DotDelimitedString()) {
+				return false
+			}
+			enclosingTypeName := c.parentTypeNodes[parentTypeNodeIndex].NameString(c.definition)
+			ancestorFieldName := c.operation.FieldNameString(ancestor.Ref)
+			if plannerConfig.providesField(enclosingTypeName, ancestorFieldName, fieldPath) {
+				return true
+			}
+			fieldPath = ancestorFieldName + "." + fieldPath
+			path = path[:len(path)-1]
+		}
+	}
+	return false
+}
+
 func (c *configurationVisitor) isParentTypeNodeAbstractType() bool {
 	if len(c.parentTypeNodes) < 2 {
 		return false
@@ -1764,6 +1846,9 @@ type requiredFieldsVisitor struct {
 	config                *Configuration
 	operationName         string
 	skipFieldPaths        []string
+	// requiredFields are the required fields chosen for fields with RequiresFieldsAlternatives by field ref
+	requiredFields  map[int][]string
+	parentTypeNodes []ast.Node
 	// selectedFieldPaths is a set of all explicitly selected field paths
 	selectedFieldPaths map[string]struct{}
 	// skipFieldDataPaths is to prevent appending duplicate skipFieldData to potentialSkipFieldDatas
@@ -1775,6 +1860,8 @@ type requiredFieldsVisitor struct {
 
 func (r *requiredFieldsVisitor) EnterDocument(_, _ *ast.Document) {
 	r.skipFieldPaths = r.skipFieldPaths[:0]
+	r.requiredFields = make(map[int][]string)
+	r.parentTypeNodes = r.parentTypeNodes[:0]
 	r.selectedFieldPaths = make(map[string]struct{})
 	r.potentialSkipFieldDatas = make([]*skipFieldData, 0)
 }
@@ -1798,7 +1885,12 @@ func (r *requiredFieldsVisitor) EnterField(ref int) {
 	if selectionSet.Kind != ast.NodeKindSelectionSet {
 		return
 	}
-	for _, requiredField := range fieldConfig.RequiresFields {
+	requiredFields := fieldConfig.RequiresFields
+	if len(fieldConfig.RequiresFieldsAlternatives) != 0 {
+		requiredFields = r.chooseRequiredFields(typeName, fieldConfig)
+		r.requiredFields[ref] = requiredFields
+	}
+	for _, requiredField := range requiredFields {
 		requiredFieldPath := fmt.Sprintf("%s.%s", path, requiredField)
 		// Prevent adding duplicates to the slice (order is necessary; hence, a separate map)
 		if _, ok := r.skipFieldDataPaths[requiredFieldPath]; ok {
@@ -1815,31 +1907,120 @@ func (r *requiredFieldsVisitor) EnterField(ref int) {
 	}
 }
 
-func (r *requiredFieldsVisitor) handleRequiredField(selectionSet int, requiredFieldName, fullFieldPath string) {
+func (r *requiredFieldsVisitor) EnterSelectionSet(_ int) {
+	r.parentTypeNodes = append(r.parentTypeNodes, r.walker.EnclosingTypeDefinition)
+}
+
+func (r *requiredFieldsVisitor) LeaveSelectionSet(_ int) {
+	r.parentTypeNodes = r.parentTypeNodes[:len(r.parentTypeNodes)-1]
+}
+
+// chooseRequiredFields returns the first set of required fields of the field configuration
+// which can be resolved by a DataSource resolving the enclosing field, e.g. one of multiple keys of an entity.
+// If no DataSource is able to resolve any of the sets, RequiresFields is returned.
+func (r *requiredFieldsVisitor) chooseRequiredFields(typeName string, fieldConfig *FieldConfiguration) []string {
+	enclosingTypeName, enclosingFieldName, ok := r.enclosingField()
+	if !ok {
+		return fieldConfig.RequiresFields
+	}
+
+	candidates := make([][]string, 0, len(fieldConfig.RequiresFieldsAlternatives)+1)
+	candidates = append(candidates, fieldConfig.RequiresFields)
+	candidates = append(candidates, fieldConfig.RequiresFieldsAlternatives...)
+
+	// DataSources with the enclosing field as a root node are preferred over DataSources with a matching child node
+	for _, isRootNode := range []bool{true, false} {
+		for i := range r.config.DataSources {
+			dataSource := &r.config.DataSources[i]
+			if isRootNode && !dataSource.HasRootNode(enclosingTypeName, enclosingFieldName) ||
+				!isRootNode && !dataSource.HasChildNode(enclosingTypeName, enclosingFieldName) {
+				continue
+			}
+			for _, candidate := range candidates {
+				if dataSource.canResolveFields(typeName, candidate) {
+					return candidate
+				}
+			}
+		}
+	}
+
+	return fieldConfig.RequiresFields
+}
+
+// enclosingField returns the type and field name of the field enclosing the current field
+func (r *requiredFieldsVisitor) enclosingField() (typeName, fieldName string, ok bool) {
+	// the last parent type node is the enclosing type of the current field
+	parentTypeNodeIndex := len(r.parentTypeNodes) - 1
+	for i := len(r.walker.Ancestors) - 1; i >= 0; i-- {
+		ancestor := r.walker.Ancestors[i]
+		switch ancestor.Kind {
+		case ast.NodeKindSelectionSet:
+			parentTypeNodeIndex--
+		case ast.NodeKindField:
+			if parentTypeNodeIndex < 0 {
+				return "", "", false
+			}
+			return r.parentTypeNodes[parentTypeNodeIndex].NameString(r.definition), r.operation.FieldNameString(ancestor.Ref), true
+		}
+	}
+	return "", "", false
+}
+
+// handleRequiredField adds the required field to the selection set unless it's already selected.
+// Nested required fields like "organization.id" are added to the selection set of their parent field.
+func (r *requiredFieldsVisitor) handleRequiredField(selectionSet int, requiredField, fullFieldPath string) {
+	fieldNames := strings.Split(requiredField, ".")
+	fieldPath := strings.TrimSuffix(fullFieldPath, requiredField)
+	for i, fieldName := range fieldNames {
+		fieldPath += fieldName
+		fieldRef, exists := r.selectedField(selectionSet, fieldName)
+		if !exists {
+			r.addRequiredField(fieldNames[i:], selectionSet, fieldPath)
+			return
+		}
+		if !r.operation.Fields[fieldRef].HasSelections {
+			// already exists
+			return
+		}
+		selectionSet = r.operation.Fields[fieldRef].SelectionSet
+		fieldPath += "."
+	}
+}
+
+func (r *requiredFieldsVisitor) selectedField(selectionSet int, fieldName string) (fieldRef int, exists bool) {
 	for _, ref := range r.operation.SelectionSets[selectionSet].SelectionRefs {
 		selection := r.operation.Selections[ref]
 		if selection.Kind != ast.SelectionKindField {
 			continue
 		}
 		name := r.operation.FieldAliasOrNameString(selection.Ref)
-		if name == requiredFieldName {
-			// already exists
-			return
+		if name == fieldName {
+			return selection.Ref, true
 		}
 	}
-	r.addRequiredField(requiredFieldName, selectionSet, fullFieldPath)
+	return -1, false
 }
 
-func (r *requiredFieldsVisitor) addRequiredField(fieldName string, selectionSet int, fullFieldPath string) {
-	field := ast.Field{
-		Name: r.operation.Input.AppendInputString(fieldName),
-	}
-	addedField := r.operation.AddField(field)
-	selection := ast.Selection{
-		Kind: ast.SelectionKindField,
-		Ref:  addedField.Ref,
+// addRequiredField adds the field with the first of the field names to the selection set.
+// Each further field name is added as a nested field of the previous one.
+func (r *requiredFieldsVisitor) addRequiredField(fieldNames []string, selectionSet int, fullFieldPath string) {
+	for i, fieldName := range fieldNames {
+		field := ast.Field{
+			Name: r.operation.Input.AppendInputString(fieldName),
+		}
+		if i < len(fieldNames)-1 {
+			field.HasSelections = true
+			field.SelectionSet = r.operation.AddSelectionSet().Ref
+		}
+		addedField := r.operation.AddField(field)
+		selection := ast.Selection{
+			Kind: ast.SelectionKindField,
+			Ref:  addedField.Ref,
+		}
+		r.operation.AddSelection(selectionSet, selection)
+		selectionSet = field.SelectionSet
 	}
-	r.operation.AddSelection(selectionSet, selection)
+	// skipping the outermost added field skips the nested fields as well
 	r.skipFieldPaths = append(r.skipFieldPaths, fullFieldPath)
 }
 
@@ -0,0 +1,67 @@
+package plan
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+const federationProvidesDirectiveName = "provides"
+
+// ProvidedFieldExtractor extracts the fields provided by fields with the @provides directive
+// from an ast.Document containing a parsed federation subgraph SDL
+type ProvidedFieldExtractor struct {
+	document *ast.Document
+}
+
+func NewProvidedFieldExtractor(document *ast.Document) *ProvidedFieldExtractor {
+	return &ProvidedFieldExtractor{
+		document: document,
+	}
+}
+
+// GetAllProvidedFields returns a ProvidesConfiguration for each field with the @provides directive.
+// Field sets which can't be parsed are ignored.
+func (f *ProvidedFieldExtractor) GetAllProvidedFields() []ProvidesConfiguration {
+	var provides []ProvidesConfiguration
+
+	for _, node := range f.document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindObjectTypeExtension,
+			ast.NodeKindInterfaceTypeDefinition, ast.NodeKindInterfaceTypeExtension:
+		default:
+			continue
+		}
+
+		typeName := f.document.NodeNameString(node)
+		for _, fieldDefinitionRef := range f.document.NodeFieldDefinitions(node) {
+			fieldPaths := f.providedFieldPaths(fieldDefinitionRef)
+			if len(fieldPaths) == 0 {
+				continue
+			}
+
+			provides = append(provides, ProvidesConfiguration{
+				TypeName:   typeName,
+				FieldName:  f.document.FieldDefinitionNameString(fieldDefinitionRef),
+				FieldPaths: fieldPaths,
+			})
+		}
+	}
+
+	return provides
+}
+
+func (f *ProvidedFieldExtractor) providedFieldPaths(fieldDefinitionRef int) []string {
+	for _, directiveRef := range f.document.FieldDefinitions[fieldDefinitionRef].Directives.Refs {
+		if f.document.DirectiveNameString(directiveRef) != federationProvidesDirectiveName {
+			continue
+		}
+
+		value, exists := f.document.DirectiveArgumentValueByName(directiveRef, fieldsArgumentNameBytes)
+		if !exists || value.Kind != ast.ValueKindString {
+			continue
+		}
+
+		return fieldSetPaths(f.document.StringValueContentString(value.Ref))
+	}
+
+	return nil
+}
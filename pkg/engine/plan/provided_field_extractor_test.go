@@ -0,0 +1,54 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+)
+
+func TestProvidedFieldExtractor_GetAllProvidedFields(t *testing.T) {
+	run := func(t *testing.T, SDL string, expected []ProvidesConfiguration) {
+		document := unsafeparser.ParseGraphqlDocumentString(SDL)
+		extractor := NewProvidedFieldExtractor(&document)
+		got := extractor.GetAllProvidedFields()
+		assert.Equal(t, expected, got)
+	}
+
+	t.Run("no provides directive", func(t *testing.T) {
+		run(t, `
+		type Review {
+			body: String!
+			author: User!
+		}
+		`, nil)
+	})
+	t.Run("single provided field", func(t *testing.T) {
+		run(t, `
+		type Review {
+			body: String!
+			author: User! @provides(fields: "username")
+		}
+		`, []ProvidesConfiguration{
+			{TypeName: "Review", FieldName: "author", FieldPaths: []string{"username"}},
+		})
+	})
+	t.Run("multiple and nested provided fields on type extension", func(t *testing.T) {
+		run(t, `
+		extend type User @key(fields: "id") {
+			id: ID! @external
+			reviews: [Review] @provides(fields: "body product { upc name ... on Book { pages } }")
+		}
+		`, []ProvidesConfiguration{
+			{TypeName: "User", FieldName: "reviews", FieldPaths: []string{"body", "product", "product.upc", "product.name", "product.pages"}},
+		})
+	})
+	t.Run("invalid field set", func(t *testing.T) {
+		run(t, `
+		type Review {
+			author: User! @provides(fields: "username {")
+		}
+		`, nil)
+	})
+}
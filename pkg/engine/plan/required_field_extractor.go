@@ -1,8 +1,6 @@
 package plan
 
 import (
-	"strings"
-
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 )
 
@@ -36,7 +34,7 @@ func (f *RequiredFieldExtractor) addFieldsForObjectExtensionDefinitions(fieldReq
 		objectType := objectTypeExt.ObjectTypeDefinition
 		typeName := f.document.Input.ByteSliceString(objectType.Name)
 
-		primaryKeys, exists := f.primaryKeyFieldsIfObjectTypeIsEntity(objectType)
+		keys, exists := f.keyFieldsIfObjectTypeIsEntity(objectType)
 		if !exists {
 			continue
 		}
@@ -47,18 +45,14 @@ func (f *RequiredFieldExtractor) addFieldsForObjectExtensionDefinitions(fieldReq
 			}
 
 			fieldName := f.document.FieldDefinitionNameString(fieldDefinitionRef)
-
-			requiredFields := make([]string, len(primaryKeys))
-			copy(requiredFields, primaryKeys)
-
 			requiredFieldsByRequiresDirective := requiredFieldsByRequiresDirective(f.document, fieldDefinitionRef)
-			requiredFields = append(requiredFields, requiredFieldsByRequiresDirective...)
 
-			*fieldRequires = append(*fieldRequires, FieldConfiguration{
-				TypeName:       typeName,
-				FieldName:      fieldName,
-				RequiresFields: requiredFields,
-			})
+			requiredFields := make([][]string, 0, len(keys))
+			for _, keyFields := range keys {
+				requiredFields = append(requiredFields, appendRequiredFields(keyFields, requiredFieldsByRequiresDirective))
+			}
+
+			*fieldRequires = append(*fieldRequires, newRequiredFieldConfiguration(typeName, fieldName, requiredFields))
 		}
 	}
 }
@@ -67,34 +61,66 @@ func (f *RequiredFieldExtractor) addFieldsForObjectDefinitions(fieldRequires *Fi
 	for _, objectType := range f.document.ObjectTypeDefinitions {
 		typeName := f.document.Input.ByteSliceString(objectType.Name)
 
-		primaryKeys, exists := f.primaryKeyFieldsIfObjectTypeIsEntity(objectType)
+		keys, exists := f.keyFieldsIfObjectTypeIsEntity(objectType)
 		if !exists {
 			continue
 		}
 
-		primaryKeysSet := make(map[string]struct{}, len(primaryKeys))
-		for _, val := range primaryKeys {
-			primaryKeysSet[val] = struct{}{}
-		}
-
 		for _, fieldRef := range objectType.FieldsDefinition.Refs {
+			if f.document.FieldDefinitionHasNamedDirective(fieldRef, federationExternalDirectiveName) {
+				continue
+			}
+
 			fieldName := f.document.FieldDefinitionNameString(fieldRef)
-			if _, exists := primaryKeysSet[fieldName]; exists { // Field is part of primary key, it couldn't have any required fields
+			requiredFieldsByRequiresDirective := requiredFieldsByRequiresDirective(f.document, fieldRef)
+
+			requiredFields := make([][]string, 0, len(keys))
+			for _, keyFields := range keys {
+				if keyContainsField(keyFields, fieldName) { // Field is part of the key, it couldn't be required to resolve it
+					continue
+				}
+				requiredFields = append(requiredFields, appendRequiredFields(keyFields, requiredFieldsByRequiresDirective))
+			}
+			if len(requiredFields) == 0 {
 				continue
 			}
 
-			requiredFields := make([]string, len(primaryKeys))
-			copy(requiredFields, primaryKeys)
+			*fieldRequires = append(*fieldRequires, newRequiredFieldConfiguration(typeName, fieldName, requiredFields))
+		}
+	}
+}
 
-			*fieldRequires = append(*fieldRequires, FieldConfiguration{
-				TypeName:       typeName,
-				FieldName:      fieldName,
-				RequiresFields: requiredFields,
-			})
+// newRequiredFieldConfiguration creates a FieldConfiguration which requires the first set of required fields.
+// All further sets, e.g. resulting from multiple keys, are added as alternatives.
+func newRequiredFieldConfiguration(typeName, fieldName string, requiredFields [][]string) FieldConfiguration {
+	configuration := FieldConfiguration{
+		TypeName:       typeName,
+		FieldName:      fieldName,
+		RequiresFields: requiredFields[0],
+	}
+	if len(requiredFields) > 1 {
+		configuration.RequiresFieldsAlternatives = requiredFields[1:]
+	}
+	return configuration
+}
+
+func appendRequiredFields(keyFields, requiredFieldsByRequiresDirective []string) []string {
+	requiredFields := make([]string, 0, len(keyFields)+len(requiredFieldsByRequiresDirective))
+	requiredFields = append(requiredFields, keyFields...)
+	return append(requiredFields, requiredFieldsByRequiresDirective...)
+}
+
+func keyContainsField(keyFields []string, fieldName string) bool {
+	for _, keyFieldName := range fieldSetTopLevelFieldNames(keyFields) {
+		if keyFieldName == fieldName {
+			return true
 		}
 	}
+	return false
 }
 
+// requiredFieldsByRequiresDirective returns the paths of the fields selected by the @requires directive.
+// Nested fields are returned as dot delimited paths, e.g. "author.name".
 func requiredFieldsByRequiresDirective(document *ast.Document, fieldDefinitionRef int) []string {
 	for _, directiveRef := range document.FieldDefinitions[fieldDefinitionRef].Directives.Refs {
 		if directiveName := document.DirectiveNameString(directiveRef); directiveName != federationRequireDirectiveName {
@@ -111,13 +137,15 @@ func requiredFieldsByRequiresDirective(document *ast.Document, fieldDefinitionRe
 
 		fieldsStr := document.StringValueContentString(value.Ref)
 
-		return strings.Split(fieldsStr, " ")
+		return fieldSetLeafPaths(fieldsStr)
 	}
 
 	return nil
 }
 
-func (f *RequiredFieldExtractor) primaryKeyFieldsIfObjectTypeIsEntity(objectType ast.ObjectTypeDefinition) (keyFields []string, ok bool) {
+// keyFieldsIfObjectTypeIsEntity returns the paths of the fields of each @key directive of the object type.
+// Nested key fields are returned as dot delimited paths, e.g. "organization.id".
+func (f *RequiredFieldExtractor) keyFieldsIfObjectTypeIsEntity(objectType ast.ObjectTypeDefinition) (keys [][]string, ok bool) {
 	for _, directiveRef := range objectType.Directives.Refs {
 		if directiveName := f.document.DirectiveNameString(directiveRef); directiveName != FederationKeyDirectiveName {
 			continue
@@ -131,10 +159,13 @@ func (f *RequiredFieldExtractor) primaryKeyFieldsIfObjectTypeIsEntity(objectType
 			continue
 		}
 
-		fieldsStr := f.document.StringValueContentString(value.Ref)
+		keyFields := fieldSetLeafPaths(f.document.StringValueContentString(value.Ref))
+		if len(keyFields) == 0 {
+			continue
+		}
 
-		return strings.Split(fieldsStr, " "), true
+		keys = append(keys, keyFields)
 	}
 
-	return nil, false
+	return keys, len(keys) > 0
 }
@@ -83,4 +83,58 @@ func TestRequiredFieldExtractor_GetAllFieldRequires(t *testing.T) {
 			{TypeName: "Review", FieldName: "slug", RequiresFields: []string{"id", "title", "author"}},
 		})
 	})
+	t.Run("Entity with nested composite key", func(t *testing.T) {
+		run(t, `
+		type User @key(fields: "id organization { id }"){
+			id: ID!
+			organization: Organization!
+			name: String!
+		}
+		`, FieldConfigurations{
+			{TypeName: "User", FieldName: "name", RequiresFields: []string{"id", "organization.id"}},
+		})
+	})
+	t.Run("Entity with multiple keys", func(t *testing.T) {
+		run(t, `
+		type Product @key(fields: "upc") @key(fields: "sku"){
+			upc: String!
+			sku: String!
+			name: String!
+		}
+		`, FieldConfigurations{
+			{TypeName: "Product", FieldName: "upc", RequiresFields: []string{"sku"}},
+			{TypeName: "Product", FieldName: "sku", RequiresFields: []string{"upc"}},
+			{TypeName: "Product", FieldName: "name", RequiresFields: []string{"upc"}, RequiresFieldsAlternatives: [][]string{{"sku"}}},
+		})
+	})
+	t.Run("Entity object extension with multiple keys and nested \"requires\" directive", func(t *testing.T) {
+		run(t, `
+		extend type Product @key(fields: "upc") @key(fields: "sku"){
+			upc: String! @external
+			sku: String! @external
+			dimensions: Dimensions @external
+			shippingEstimate: Int @requires(fields: "dimensions { size weight }")
+		}
+		`, FieldConfigurations{
+			{
+				TypeName:                   "Product",
+				FieldName:                  "shippingEstimate",
+				RequiresFields:             []string{"upc", "dimensions.size", "dimensions.weight"},
+				RequiresFieldsAlternatives: [][]string{{"sku", "dimensions.size", "dimensions.weight"}},
+			},
+		})
+	})
+	t.Run("Entity definition with \"requires\" directive and external fields", func(t *testing.T) {
+		run(t, `
+		type User @key(fields: "id"){
+			id: ID!
+			secret: String! @external
+			name: String!
+			greeting: String! @requires(fields: "secret")
+		}
+		`, FieldConfigurations{
+			{TypeName: "User", FieldName: "name", RequiresFields: []string{"id"}},
+			{TypeName: "User", FieldName: "greeting", RequiresFields: []string{"id", "secret"}},
+		})
+	})
 }
@@ -133,6 +133,9 @@ type Context struct {
 	afterFetchHook   AfterFetchHook
 	position         Position
 	RenameTypeNames  []RenameTypeName
+	// resultSet is the result set of the closest object with a fetch,
+	// fields can read the buffers of fetches which have been hoisted into an ancestor object from it
+	resultSet *resultSet
 }
 
 type Request struct {
@@ -205,6 +208,7 @@ func (c *Context) clone() Context {
 		beforeFetchHook: c.beforeFetchHook,
 		afterFetchHook:  c.afterFetchHook,
 		position:        c.position,
+		resultSet:       c.resultSet,
 	}
 }
 
@@ -226,6 +230,7 @@ func (c *Context) Free() {
 	c.position = Position{}
 	c.dataLoader = nil
 	c.RenameTypeNames = nil
+	c.resultSet = nil
 }
 
 func (c *Context) SetBeforeFetchHook(hook BeforeFetchHook) {
@@ -1160,6 +1165,11 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 		for i := range set.buffers {
 			r.MergeBufPairErrors(set.buffers[i], objectBuf)
 		}
+		set.parent = ctx.resultSet
+		ctx.resultSet = set
+		defer func() {
+			ctx.resultSet = set.parent
+		}()
 	}
 
 	fieldBuf := r.getBufPair()
@@ -1189,8 +1199,17 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 		}
 
 		var fieldData []byte
-		if set != nil && object.Fields[i].HasBuffer {
-			buffer, ok := set.buffers[object.Fields[i].BufferID]
+		if (set != nil || object.HoistedFetch) && object.Fields[i].HasBuffer {
+			var (
+				buffer *BufPair
+				ok     bool
+			)
+			if set != nil {
+				buffer, ok = set.buffers[object.Fields[i].BufferID]
+			} else {
+				// the fetch of the object has been hoisted into an ancestor
+				buffer, ok = ctx.resultSet.buffer(object.Fields[i].BufferID)
+			}
 			if ok {
 				fieldData = buffer.Data.Bytes()
 				ctx.resetResponsePathElements()
@@ -1202,6 +1221,11 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 
 		if object.Fields[i].OnTypeNames != nil {
 			typeName, _, _, _ := jsonparser.Get(fieldData, "__typename")
+			if typeName == nil && object.Fields[i].HasBuffer {
+				// the response of the fetch of the field might not contain the __typename of the object,
+				// e.g. entities resolved by a subgraph, as the fetch belongs to this object its __typename applies
+				typeName, _, _, _ = jsonparser.Get(data, "__typename")
+			}
 			hasMatch := false
 			for _, onTypeName := range object.Fields[i].OnTypeNames {
 				if bytes.Equal(typeName, onTypeName) {
@@ -1287,7 +1311,7 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 // if one sibling has no data (null), we have to "pop" the null result (generated by the batch resolver) from the cache
 // this is because the "null" sibling will not trigger a fetch by itself, as it has no data and will not resolve any fields
 func (r *Resolver) recursivelySkipBatchResults(ctx *Context, object *Object, data []byte) {
-	if object.Fetch != nil && object.Fetch.FetchKind() == FetchKindBatch {
+	if object.Fetch != nil && hasBatchFetch(object.Fetch) {
 		set := r.getResultSet()
 		defer r.freeResultSet(set)
 		_ = r.resolveFetch(ctx, object.Fetch, data, set)
@@ -1306,12 +1330,28 @@ func (r *Resolver) recursivelySkipBatchResults(ctx *Context, object *Object, dat
 	}
 }
 
+// hasBatchFetch returns true if the fetch is a batch fetch or a parallel fetch containing one
+func hasBatchFetch(fetch Fetch) bool {
+	switch f := fetch.(type) {
+	case *BatchFetch:
+		return true
+	case *ParallelFetch:
+		for i := range f.Fetches {
+			if hasBatchFetch(f.Fetches[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *Resolver) freeResultSet(set *resultSet) {
 	for i := range set.buffers {
 		set.buffers[i].Reset()
 		r.bufPairPool.Put(set.buffers[i])
 		delete(set.buffers, i)
 	}
+	set.parent = nil
 	r.resultSetPool.Put(set)
 }
 
@@ -1425,6 +1465,9 @@ type Object struct {
 	Fields               []*Field
 	Fetch                Fetch
 	UnescapeResponseJson bool `json:"unescape_response_json,omitempty"`
+	// HoistedFetch is true if the fetch of the object has been moved into the fetch of an ancestor object.
+	// Fields with a buffer read it from the result set of the ancestor.
+	HoistedFetch bool `json:"hoisted_fetch,omitempty"`
 }
 
 func (_ *Object) NodeKind() NodeKind {
@@ -1484,6 +1527,17 @@ func (_ *Null) NodeKind() NodeKind {
 
 type resultSet struct {
 	buffers map[int]*BufPair
+	parent  *resultSet
+}
+
+// buffer looks up the buffer of a fetch in the result set and its parents
+func (r *resultSet) buffer(id int) (*BufPair, bool) {
+	for set := r; set != nil; set = set.parent {
+		if buffer, ok := set.buffers[id]; ok {
+			return buffer, true
+		}
+	}
+	return nil, false
 }
 
 type SingleFetch struct {
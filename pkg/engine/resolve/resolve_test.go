@@ -1275,6 +1275,78 @@ func TestResolver_ResolveNode(t *testing.T) {
 			}, Context{ctx: context.Background()},
 			`{"pets":[{"name":"Woofie"}]}`
 	}))
+	t.Run("object reads the buffer of its fetch hoisted into an ancestor", testFn(false, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
+		return &Object{
+				Fetch: &SingleFetch{
+					BufferId:   0,
+					DataSource: FakeDataSource(`{"me":{"review":{"product":{"upc":"top-1"}}}}`),
+				},
+				Fields: []*Field{
+					{
+						BufferID:  0,
+						HasBuffer: true,
+						Name:      []byte("me"),
+						Value: &Object{
+							Path: []string{"me"},
+							Fetch: &ParallelFetch{
+								Fetches: []Fetch{
+									&SingleFetch{
+										BufferId:   1,
+										DataSource: FakeDataSource(`{"name":"Me"}`),
+									},
+									&SingleFetch{
+										BufferId:   2,
+										DataSource: FakeDataSource(`{"name":"Trilby"}`),
+									},
+								},
+							},
+							Fields: []*Field{
+								{
+									BufferID:  1,
+									HasBuffer: true,
+									Name:      []byte("name"),
+									Value: &String{
+										Path: []string{"name"},
+									},
+								},
+								{
+									Name: []byte("review"),
+									Value: &Object{
+										Path: []string{"review"},
+										Fields: []*Field{
+											{
+												Name: []byte("product"),
+												Value: &Object{
+													Path:         []string{"product"},
+													HoistedFetch: true,
+													Fields: []*Field{
+														{
+															Name: []byte("upc"),
+															Value: &String{
+																Path: []string{"upc"},
+															},
+														},
+														{
+															BufferID:  2,
+															HasBuffer: true,
+															Name:      []byte("name"),
+															Value: &String{
+																Path: []string{"name"},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}, Context{ctx: context.Background()},
+			`{"me":{"name":"Me","review":{"product":{"upc":"top-1","name":"Trilby"}}}}`
+	}))
 	t.Run("non null object with field condition can be null", testFn(false, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
 		return &Object{
 				Fetch: &SingleFetch{
@@ -1489,6 +1561,67 @@ func TestResolver_ResolveNode(t *testing.T) {
 			}, Context{ctx: context.Background()},
 			`{"pets":[{"name":"Woofie"}]}`
 	}))
+	// petWithFetchedTypeConditions resolves the fields of the pet by __typename where the fields are resolved by a fetch of the pet
+	petWithFetchedTypeConditions := func(petData, petFetchData string) *Object {
+		return &Object{
+			Fetch: &SingleFetch{
+				BufferId:   0,
+				DataSource: FakeDataSource(`{"pet":` + petData + `}`),
+			},
+			Fields: []*Field{
+				{
+					BufferID:  0,
+					HasBuffer: true,
+					Name:      []byte("pet"),
+					Value: &Object{
+						Path: []string{"pet"},
+						Fetch: &SingleFetch{
+							BufferId:   1,
+							DataSource: FakeDataSource(petFetchData),
+						},
+						Fields: []*Field{
+							{
+								Name: []byte("id"),
+								Value: &String{
+									Path: []string{"id"},
+								},
+							},
+							{
+								BufferID:    1,
+								HasBuffer:   true,
+								OnTypeNames: [][]byte{[]byte("Dog")},
+								Name:        []byte("woof"),
+								Value: &String{
+									Path: []string{"sound"},
+								},
+							},
+							{
+								BufferID:    1,
+								HasBuffer:   true,
+								OnTypeNames: [][]byte{[]byte("Cat")},
+								Name:        []byte("meow"),
+								Value: &String{
+									Path: []string{"sound"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	t.Run("resolve fieldsets based on __typename of the object when the fetch of the field has none", testFn(false, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
+		return petWithFetchedTypeConditions(`{"__typename":"Cat","id":"1"}`, `{"sound":"Meow"}`), Context{ctx: context.Background()},
+			`{"pet":{"id":"1","meow":"Meow"}}`
+	}))
+	t.Run("resolve fieldsets based on __typename of the fetch of the field before the one of the object", testFn(false, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
+		return petWithFetchedTypeConditions(`{"__typename":"Cat","id":"1"}`, `{"__typename":"Dog","sound":"Woof"}`), Context{ctx: context.Background()},
+			`{"pet":{"id":"1","woof":"Woof"}}`
+	}))
+	t.Run("skip fieldsets when neither the object nor the fetch of the field has a __typename", testFn(false, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
+		return petWithFetchedTypeConditions(`{"id":"1"}`, `{"sound":"Woof"}`), Context{ctx: context.Background()},
+			`{"pet":{"id":"1"}}`
+	}))
 	t.Run("parent object variables", testFn(true, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
 		mockDataSource := NewMockDataSource(ctrl)
 		mockDataSource.EXPECT().
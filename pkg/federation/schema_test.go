@@ -2,6 +2,7 @@ package federation
 
 import (
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/goldie"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +49,33 @@ type User {
   reviews: [Review]
 }
 `
+
+func TestBuildSubgraphSchema(t *testing.T) {
+	t.Run("subgraph with entities", func(t *testing.T) {
+		actual, err := BuildSubgraphSchema(subgraphSDL)
+		assert.NoError(t, err)
+		goldie.Assert(t, "subgraph_schema", []byte(actual))
+	})
+
+	t.Run("subgraph without entities only serves _service", func(t *testing.T) {
+		actual, err := BuildSubgraphSchema(`type Query { hello: String }`)
+		assert.NoError(t, err)
+		assert.Contains(t, actual, "_service: _Service!")
+		assert.NotContains(t, actual, "_entities")
+		assert.NotContains(t, actual, "_Entity")
+	})
+
+	t.Run("federation definitions of the subgraph are kept", func(t *testing.T) {
+		actual, err := BuildSubgraphSchema(`scalar _Any type Query { hello: String } directive @key(fields: String!) repeatable on OBJECT type User @key(fields: "id") { id: ID! }`)
+		assert.NoError(t, err)
+		assert.Contains(t, actual, "directive @key(\n    fields: String!\n) repeatable on OBJECT")
+		assert.Equal(t, 1, strings.Count(actual, "scalar _Any"))
+		assert.Equal(t, 1, strings.Count(actual, "directive @key"))
+	})
+}
+
+func TestEntityTypes(t *testing.T) {
+	assert.Equal(t, []string{"Review", "User"}, EntityTypes(subgraphSDL))
+}
+
+const subgraphSDL = `extend type Query { me: User } type Review @key(fields: "id") { id: ID! body: String! author: User! @provides(fields: "username") } extend type User @key(fields: "id") { id: ID! @external username: String! @external reviews: [Review] }`
@@ -4,6 +4,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -33,22 +34,26 @@ func (c *collectEntitiesVisitor) EnterDocument(operation, _ *ast.Document) {
 func (c *collectEntitiesVisitor) EnterInterfaceTypeDefinition(ref int) {
 	interfaceType := c.document.InterfaceTypeDefinitions[ref]
 	name := c.document.InterfaceTypeDefinitionNameString(ref)
-	if err := c.resolvePotentialEntity(name, interfaceType.Directives.Refs); err != nil {
-		c.StopWithExternalErr(*err)
+	if err := c.resolvePotentialEntity(name, interfaceType.Directives.Refs, c.document.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref})); err != nil {
+		reportCompositionError(c.Walker, *err)
 	}
 }
 
 func (c *collectEntitiesVisitor) EnterObjectTypeDefinition(ref int) {
 	objectType := c.document.ObjectTypeDefinitions[ref]
+	if hasDirective(c.document, objectType.Directives.Refs, interfaceObjectDirectiveName) {
+		// an @interfaceObject is merged into the interface entity of the same name
+		return
+	}
 	name := c.document.ObjectTypeDefinitionNameString(ref)
-	if err := c.resolvePotentialEntity(name, objectType.Directives.Refs); err != nil {
-		c.StopWithExternalErr(*err)
+	if err := c.resolvePotentialEntity(name, objectType.Directives.Refs, c.document.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref})); err != nil {
+		reportCompositionError(c.Walker, *err)
 	}
 }
 
-func (c *collectEntitiesVisitor) resolvePotentialEntity(name string, directiveRefs []int) *operationreport.ExternalError {
+func (c *collectEntitiesVisitor) resolvePotentialEntity(name string, directiveRefs []int, pos position.Position) *operationreport.ExternalError {
 	if _, exists := c.collectedEntities[name]; exists {
-		err := operationreport.ErrEntitiesMustNotBeDuplicated(name)
+		err := withLocations(withCode(operationreport.ErrEntitiesMustNotBeDuplicated(name), ErrCodeEntityDuplicated), pos)
 		return &err
 	}
 	for _, directiveRef := range directiveRefs {
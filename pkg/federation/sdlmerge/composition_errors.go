@@ -0,0 +1,370 @@
+package sdlmerge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+const (
+	// ErrCodeInvalidGraphQL is the code of errors raised while parsing, validating or normalizing a single subgraph
+	ErrCodeInvalidGraphQL = "INVALID_GRAPHQL"
+	// ErrCodeCompositionFailed is the code of composition errors which are not classified any further
+	ErrCodeCompositionFailed = "COMPOSITION_FAILED"
+
+	// the codes of the errors raised by the composition rules
+	ErrCodeSharedTypeMismatch              = "SHARED_TYPE_MISMATCH"
+	ErrCodeEntityDuplicated                = "ENTITY_DUPLICATED"
+	ErrCodeSharedTypeExtended              = "SHARED_TYPE_EXTENDED"
+	ErrCodeExtensionOrphan                 = "EXTENSION_ORPHAN"
+	ErrCodeExtensionMissingKey             = "EXTENSION_MISSING_KEY"
+	ErrCodeExtensionWithKeyNotEntity       = "EXTENSION_WITH_KEY_NOT_ENTITY"
+	ErrCodeFieldTypeMismatch               = "FIELD_TYPE_MISMATCH"
+	ErrCodeInvalidFieldSharing             = "INVALID_FIELD_SHARING"
+	ErrCodeOverrideCollision               = "OVERRIDE_COLLISION"
+	ErrCodeInterfaceObjectWithoutInterface = "INTERFACE_OBJECT_WITHOUT_INTERFACE"
+	ErrCodeReferencedInaccessible          = "REFERENCED_INACCESSIBLE"
+	ErrCodeUnknownLinkImport               = "UNKNOWN_LINK_IMPORT"
+)
+
+// withCode classifies an error raised during composition by one of the ErrCode constants
+func withCode(err operationreport.ExternalError, code string) operationreport.ExternalError {
+	err.Code = code
+	return err
+}
+
+// withLocations locates an error raised during composition at the positions of the offending nodes of the composed document
+func withLocations(err operationreport.ExternalError, positions ...position.Position) operationreport.ExternalError {
+	err.Locations = operationreport.LocationsFromPosition(positions...)
+	return err
+}
+
+// SubgraphLocation is a line and column in the SDL of a subgraph.
+type SubgraphLocation struct {
+	Subgraph string `json:"subgraph"`
+	Line     uint32 `json:"line"`
+	Column   uint32 `json:"column"`
+}
+
+func (s SubgraphLocation) String() string {
+	return fmt.Sprintf("%s:%d:%d", s.Subgraph, s.Line, s.Column)
+}
+
+// CompositionError describes why a set of subgraphs could not be composed.
+type CompositionError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Coordinate is the schema coordinate of the offending type or field, e.g. "User" or "User.name"
+	Coordinate string `json:"coordinate,omitempty"`
+	// Subgraphs are the names of the subgraphs which are involved in the error
+	Subgraphs []string           `json:"subgraphs,omitempty"`
+	Locations []SubgraphLocation `json:"locations,omitempty"`
+}
+
+func (c CompositionError) Error() string {
+	out := fmt.Sprintf("%s: %s", c.Code, c.Message)
+	for i := range c.Locations {
+		if i == 0 {
+			out += " at "
+		} else {
+			out += ", "
+		}
+		out += c.Locations[i].String()
+	}
+	return out
+}
+
+// CompositionErrors is returned by MergeSubgraphs if the subgraphs could not be composed.
+// It holds every error of the first failing step of the composition, e.g. the validation errors of all subgraphs
+// or all violations of the composition rules. The steps after a failing step are skipped.
+type CompositionErrors []CompositionError
+
+func (c CompositionErrors) Error() string {
+	messages := make([]string, len(c))
+	for i := range c {
+		messages[i] = c[i].Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// MergeSubgraphs composes the subgraphs like MergeSDLs does.
+// If the composition fails, the returned error is of type CompositionErrors
+// and reports the offending subgraphs with the locations in their SDLs.
+func MergeSubgraphs(subgraphs ...Subgraph) (string, error) {
+	SDLs := make([]string, len(subgraphs))
+	for i := range subgraphs {
+		SDLs[i] = subgraphs[i].SDL
+	}
+
+	out, err := MergeSDLs(SDLs...)
+	if err != nil {
+		return "", newCompositionErrors(subgraphs, err)
+	}
+	return out, nil
+}
+
+// subgraphError marks an error which is caused by the subgraph at index.
+// sdl is the SDL the error was raised for, it differs from the original SDL if the subgraph has been prepared before.
+type subgraphError struct {
+	index int
+	sdl   string
+	err   error
+}
+
+func newSubgraphError(index int, sdl string, err error) error {
+	return subgraphError{index: index, sdl: sdl, err: err}
+}
+
+func (s subgraphError) Error() string {
+	return s.err.Error()
+}
+
+func (s subgraphError) Unwrap() error {
+	return s.err
+}
+
+// subgraphErrors collects the errors of all subgraphs which failed the same step of the composition.
+type subgraphErrors []subgraphError
+
+func (s subgraphErrors) Error() string {
+	messages := make([]string, len(s))
+	for i := range s {
+		messages[i] = s[i].Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// add records err as an error of the subgraph at index
+func (s *subgraphErrors) add(index int, sdl string, err error) {
+	*s = append(*s, subgraphError{index: index, sdl: sdl, err: err})
+}
+
+// errorOrNil returns nil if no subgraph failed and the error of the subgraph if a single one failed
+func (s subgraphErrors) errorOrNil() error {
+	switch len(s) {
+	case 0:
+		return nil
+	case 1:
+		return s[0]
+	default:
+		return s
+	}
+}
+
+// reportCompositionError reports the violation of a composition rule. Unlike StopWithExternalErr
+// the walker keeps walking, so that all violations found by the walker are reported at once.
+func reportCompositionError(walker *astvisitor.Walker, err operationreport.ExternalError) {
+	err.Path = walker.Path
+	walker.Report.AddExternalError(err)
+}
+
+func newCompositionErrors(subgraphs []Subgraph, err error) CompositionErrors {
+	locator := newCoordinateLocator(subgraphs)
+
+	var errs subgraphErrors
+	if errors.As(err, &errs) {
+		var compositionErrors CompositionErrors
+		for i := range errs {
+			compositionErrors = append(compositionErrors, locator.compositionErrors(errs[i], &errs[i])...)
+		}
+		return compositionErrors
+	}
+
+	var subgraphErr subgraphError
+	if errors.As(err, &subgraphErr) {
+		return locator.compositionErrors(err, &subgraphErr)
+	}
+	return locator.compositionErrors(err, nil)
+}
+
+// compositionErrors turns err into composition errors. If subgraphErr is not nil, err has been caused by a single subgraph.
+func (c *coordinateLocator) compositionErrors(err error, subgraphErr *subgraphError) CompositionErrors {
+	subgraphs := c.subgraphs
+
+	var report operationreport.Report
+	if !errors.As(err, &report) || len(report.ExternalErrors) == 0 {
+		compositionError := CompositionError{
+			Code:    ErrCodeCompositionFailed,
+			Message: operationreport.UnwrappedErrorMessage(err),
+		}
+		if subgraphErr != nil {
+			compositionError.Code = ErrCodeInvalidGraphQL
+			compositionError.Subgraphs = []string{subgraphs[subgraphErr.index].Name}
+		}
+		return CompositionErrors{compositionError}
+	}
+
+	compositionErrors := make(CompositionErrors, 0, len(report.ExternalErrors))
+	for _, externalError := range report.ExternalErrors {
+		compositionError := CompositionError{
+			Code:       externalError.Code,
+			Message:    externalError.Message,
+			Coordinate: externalError.Coordinate,
+		}
+
+		switch {
+		case subgraphErr != nil:
+			if compositionError.Code == "" {
+				compositionError.Code = ErrCodeInvalidGraphQL
+			}
+			subgraph := subgraphs[subgraphErr.index]
+			compositionError.Subgraphs = []string{subgraph.Name}
+			// the locations can only be mapped if they refer to the original SDL of the subgraph
+			if len(externalError.Locations) > 0 && subgraphErr.sdl == subgraph.SDL {
+				for _, location := range externalError.Locations {
+					compositionError.Locations = append(compositionError.Locations, SubgraphLocation{
+						Subgraph: subgraph.Name,
+						Line:     location.Line,
+						Column:   location.Column,
+					})
+				}
+			} else {
+				compositionError.Locations = c.locate(subgraphErr.index, externalError.Coordinate)
+			}
+		default:
+			if compositionError.Code == "" {
+				compositionError.Code = ErrCodeCompositionFailed
+			}
+			for i := range subgraphs {
+				locations := c.locate(i, externalError.Coordinate)
+				if len(locations) == 0 {
+					continue
+				}
+				compositionError.Subgraphs = append(compositionError.Subgraphs, subgraphs[i].Name)
+				compositionError.Locations = append(compositionError.Locations, locations...)
+			}
+		}
+
+		compositionErrors = append(compositionErrors, compositionError)
+	}
+
+	return compositionErrors
+}
+
+// coordinateLocator finds the definitions of schema coordinates in the original SDLs of the subgraphs.
+type coordinateLocator struct {
+	subgraphs []Subgraph
+	documents []*ast.Document
+}
+
+func newCoordinateLocator(subgraphs []Subgraph) *coordinateLocator {
+	return &coordinateLocator{
+		subgraphs: subgraphs,
+		documents: make([]*ast.Document, len(subgraphs)),
+	}
+}
+
+func (c *coordinateLocator) document(index int) *ast.Document {
+	if c.documents[index] == nil {
+		doc, report := astparser.ParseGraphqlDocumentString(c.subgraphs[index].SDL)
+		if report.HasErrors() {
+			// a subgraph which cannot be parsed has no locations to offer
+			doc = *ast.NewDocument()
+		}
+		c.documents[index] = &doc
+	}
+	return c.documents[index]
+}
+
+// locate returns the locations of all definitions and extensions matching the coordinate in the subgraph at index
+func (c *coordinateLocator) locate(index int, coordinate string) (locations []SubgraphLocation) {
+	if coordinate == "" {
+		return nil
+	}
+	segments := strings.Split(coordinate, ".")
+	if len(segments) > 3 {
+		return nil
+	}
+	document := c.document(index)
+	addLocation := func(name ast.ByteSliceReference) {
+		position := document.Input.Position(name)
+		locations = append(locations, SubgraphLocation{
+			Subgraph: c.subgraphs[index].Name,
+			Line:     position.LineStart,
+			Column:   position.CharStart,
+		})
+	}
+
+	for _, node := range document.RootNodes {
+		name, ok := typeNameReference(document, node)
+		if !ok || document.Input.ByteSliceString(name) != segments[0] {
+			continue
+		}
+		if len(segments) == 1 {
+			addLocation(name)
+			continue
+		}
+		for _, fieldRef := range document.NodeFieldDefinitions(node) {
+			if document.FieldDefinitionNameString(fieldRef) != segments[1] {
+				continue
+			}
+			if len(segments) == 2 {
+				addLocation(document.FieldDefinitions[fieldRef].Name)
+				continue
+			}
+			for _, argumentRef := range document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs {
+				if document.InputValueDefinitionNameString(argumentRef) == segments[2] {
+					addLocation(document.InputValueDefinitions[argumentRef].Name)
+				}
+			}
+		}
+		if len(segments) != 2 {
+			continue
+		}
+		for _, inputValueRef := range inputFieldRefs(document, node) {
+			if document.InputValueDefinitionNameString(inputValueRef) == segments[1] {
+				addLocation(document.InputValueDefinitions[inputValueRef].Name)
+			}
+		}
+	}
+
+	return locations
+}
+
+func typeNameReference(document *ast.Document, node ast.Node) (ast.ByteSliceReference, bool) {
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return document.ObjectTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindObjectTypeExtension:
+		return document.ObjectTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindInterfaceTypeDefinition:
+		return document.InterfaceTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindInterfaceTypeExtension:
+		return document.InterfaceTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindUnionTypeDefinition:
+		return document.UnionTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindUnionTypeExtension:
+		return document.UnionTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindEnumTypeDefinition:
+		return document.EnumTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindEnumTypeExtension:
+		return document.EnumTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindInputObjectTypeDefinition:
+		return document.InputObjectTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindInputObjectTypeExtension:
+		return document.InputObjectTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindScalarTypeDefinition:
+		return document.ScalarTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindScalarTypeExtension:
+		return document.ScalarTypeExtensions[node.Ref].Name, true
+	default:
+		return ast.ByteSliceReference{}, false
+	}
+}
+
+func inputFieldRefs(document *ast.Document, node ast.Node) []int {
+	switch node.Kind {
+	case ast.NodeKindInputObjectTypeDefinition:
+		return document.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs
+	case ast.NodeKindInputObjectTypeExtension:
+		return document.InputObjectTypeExtensions[node.Ref].InputFieldsDefinition.Refs
+	default:
+		return nil
+	}
+}
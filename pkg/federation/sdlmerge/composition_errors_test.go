@@ -0,0 +1,276 @@
+package sdlmerge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestMergeSubgraphs(t *testing.T) {
+	runMergeSubgraphsErrorTest := func(expectedErrors CompositionErrors, subgraphs ...Subgraph) func(t *testing.T) {
+		return func(t *testing.T) {
+			_, err := MergeSubgraphs(subgraphs...)
+			require.Error(t, err)
+
+			var compositionErrors CompositionErrors
+			require.ErrorAs(t, err, &compositionErrors)
+			assert.Equal(t, expectedErrors, compositionErrors)
+		}
+	}
+
+	t.Run("composes valid subgraphs", func(t *testing.T) {
+		got, err := MergeSubgraphs(
+			Subgraph{Name: "accounts", SDL: `type Query { me: User } type User @key(fields: "id") { id: ID! }`},
+			Subgraph{Name: "reviews", SDL: `extend type User @key(fields: "id") { id: ID! @external reviews: [String] }`},
+		)
+		require.NoError(t, err)
+
+		want, err := MergeSDLs(
+			`type Query { me: User } type User @key(fields: "id") { id: ID! }`,
+			`extend type User @key(fields: "id") { id: ID! @external reviews: [String] }`,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("shared type mismatch reports every subgraph defining the type", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:       ErrCodeSharedTypeMismatch,
+				Message:    operationreport.ErrSharedTypesMustBeIdenticalToFederate("Trainer").Message,
+				Coordinate: "Trainer",
+				Subgraphs:  []string{"trainers", "pokemon"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "trainers", Line: 5, Column: 6},
+					{Subgraph: "pokemon", Line: 2, Column: 6},
+				},
+			},
+		},
+		Subgraph{Name: "trainers", SDL: `type Query {
+	trainer: Trainer
+}
+
+type Trainer {
+	name: String!
+}`},
+		Subgraph{Name: "pokemon", SDL: `
+type Trainer {
+	name: String!
+	age: Int!
+}`},
+	))
+
+	t.Run("field type mismatch reports the field in each subgraph", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:       ErrCodeFieldTypeMismatch,
+				Message:    operationreport.ErrDuplicateFieldsMustBeIdentical("age", "User", "Int", "String").Message,
+				Coordinate: "User.age",
+				Subgraphs:  []string{"accounts", "profiles"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "accounts", Line: 4, Column: 5},
+					{Subgraph: "profiles", Line: 4, Column: 5},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `
+type User @key(fields: "id") {
+    id: ID!
+    age: Int
+}`},
+		Subgraph{Name: "profiles", SDL: `
+extend type User @key(fields: "id") {
+    id: ID! @external
+    age: String
+}`},
+	))
+
+	t.Run("extension orphan", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:       ErrCodeExtensionOrphan,
+				Message:    operationreport.ErrExtensionOrphansMustResolveInSupergraph([]byte("Color")).Message,
+				Coordinate: "Color",
+				Subgraphs:  []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 1, Column: 13},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `type Query { me: String }`},
+		Subgraph{Name: "products", SDL: `extend enum Color { RED }`},
+	))
+
+	t.Run("syntax errors keep the location in the subgraph", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:      ErrCodeInvalidGraphQL,
+				Message:   "unexpected token - got: IDENT want one of: [COLON]",
+				Subgraphs: []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 3, Column: 7},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `type Query { me: String }`},
+		Subgraph{Name: "products", SDL: `type Product {
+	upc: String!
+	name String
+}`},
+	))
+
+	t.Run("validation errors keep the location in the subgraph", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:      ErrCodeInvalidGraphQL,
+				Message:   operationreport.ErrTypeUndefined([]byte("Unknown")).Message,
+				Subgraphs: []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 3, Column: 9},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `type Query { me: String }`},
+		Subgraph{Name: "products", SDL: `type Product {
+	upc: String!
+	price: Unknown
+}`},
+	))
+
+	t.Run("validation errors keep the location in federation v2 subgraphs", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:      ErrCodeInvalidGraphQL,
+				Message:   operationreport.ErrTypeUndefined([]byte("Unknown")).Message,
+				Subgraphs: []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 4, Column: 9},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `type Query { me: String }`},
+		Subgraph{Name: "products", SDL: `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@shareable"])
+type Product @key(fields: "upc") {
+	upc: String! @shareable
+	price: Unknown
+}`},
+	))
+
+	t.Run("unknown federation v2 import", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:      ErrCodeUnknownLinkImport,
+				Message:   operationreport.ErrUnknownFederationDirectiveImport("@unknown").Message,
+				Subgraphs: []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 1, Column: 79},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `type Query { me: String }`},
+		Subgraph{Name: "products", SDL: `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@unknown"])
+type Product {
+	upc: String!
+}`},
+	))
+
+	t.Run("field sharing in federation v2 subgraphs", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:       ErrCodeInvalidFieldSharing,
+				Message:    operationreport.ErrFieldMustBeShareable("name", "Product").Message,
+				Coordinate: "Product.name",
+				Subgraphs:  []string{"products", "inventory"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 5, Column: 2},
+					{Subgraph: "inventory", Line: 5, Column: 2},
+				},
+			},
+		},
+		Subgraph{Name: "products", SDL: `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+type Query { product: Product }
+type Product @key(fields: "upc") {
+	upc: String!
+	name: String!
+}`},
+		Subgraph{Name: "inventory", SDL: `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+type Product @key(fields: "upc") {
+	upc: String!
+	inStock: Boolean!
+	name: String!
+}`},
+	))
+
+	t.Run("reports the validation errors of every subgraph", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:      ErrCodeInvalidGraphQL,
+				Message:   operationreport.ErrTypeUndefined([]byte("Unknown")).Message,
+				Subgraphs: []string{"accounts"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "accounts", Line: 1, Column: 18},
+				},
+			},
+			{
+				Code:      ErrCodeInvalidGraphQL,
+				Message:   operationreport.ErrTypeUndefined([]byte("Missing")).Message,
+				Subgraphs: []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 3, Column: 9},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `type Query { me: Unknown }`},
+		Subgraph{Name: "products", SDL: `type Product {
+	upc: String!
+	price: Missing
+}`},
+	))
+
+	t.Run("reports every violated composition rule", runMergeSubgraphsErrorTest(
+		CompositionErrors{
+			{
+				Code:       ErrCodeExtensionOrphan,
+				Message:    operationreport.ErrExtensionOrphansMustResolveInSupergraph([]byte("Color")).Message,
+				Coordinate: "Color",
+				Subgraphs:  []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 1, Column: 13},
+				},
+			},
+			{
+				Code:       ErrCodeExtensionOrphan,
+				Message:    operationreport.ErrExtensionOrphansMustResolveInSupergraph([]byte("Size")).Message,
+				Coordinate: "Size",
+				Subgraphs:  []string{"products"},
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 2, Column: 13},
+				},
+			},
+		},
+		Subgraph{Name: "accounts", SDL: `type Query { me: String }`},
+		Subgraph{Name: "products", SDL: `extend enum Color { RED }
+extend enum Size { SMALL }`},
+	))
+
+	t.Run("composition errors are readable", func(t *testing.T) {
+		err := CompositionErrors{
+			{
+				Code:    ErrCodeExtensionOrphan,
+				Message: "the extension orphan named 'Color' was never resolved in the supergraph",
+				Locations: []SubgraphLocation{
+					{Subgraph: "products", Line: 1, Column: 13},
+					{Subgraph: "inventory", Line: 3, Column: 13},
+				},
+			},
+			{
+				Code:    ErrCodeCompositionFailed,
+				Message: "failed",
+			},
+		}
+		assert.Equal(t, "EXTENSION_ORPHAN: the extension orphan named 'Color' was never resolved in the supergraph at products:1:13, inventory:3:13\nCOMPOSITION_FAILED: failed", err.Error())
+	})
+}
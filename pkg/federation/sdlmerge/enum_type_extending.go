@@ -37,7 +37,8 @@ func (e *extendEnumTypeDefinitionVisitor) EnterEnumTypeExtension(ref int) {
 			continue
 		}
 		if hasExtended {
-			e.StopWithExternalErr(operationreport.ErrSharedTypesMustNotBeExtended(e.document.EnumTypeExtensionNameString(ref)))
+			reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustNotBeExtended(e.document.EnumTypeExtensionNameString(ref)), ErrCodeSharedTypeExtended),
+				e.document.NodePosition(ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: ref})))
 			return
 		}
 		e.document.ExtendEnumTypeDefinitionByEnumTypeExtension(nodes[i].Ref, ref)
@@ -45,6 +46,7 @@ func (e *extendEnumTypeDefinitionVisitor) EnterEnumTypeExtension(ref int) {
 	}
 
 	if !hasExtended {
-		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.EnumTypeExtensionNameBytes(ref)))
+		reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.EnumTypeExtensionNameBytes(ref)), ErrCodeExtensionOrphan),
+			e.document.NodePosition(ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: ref})))
 	}
 }
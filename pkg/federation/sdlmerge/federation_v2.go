@@ -0,0 +1,402 @@
+package sdlmerge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+const (
+	federationV2SpecURLPrefix = "https://specs.apollo.dev/federation/v2"
+
+	linkDirectiveName            = "link"
+	externalDirectiveName        = "external"
+	extendsDirectiveName         = "extends"
+	requiresDirectiveName        = "requires"
+	providesDirectiveName        = "provides"
+	shareableDirectiveName       = "shareable"
+	overrideDirectiveName        = "override"
+	inaccessibleDirectiveName    = "inaccessible"
+	tagDirectiveName             = "tag"
+	interfaceObjectDirectiveName = "interfaceObject"
+)
+
+// federationV2DirectiveNames are the directives which can be imported from the federation v2 spec by @link.
+var federationV2DirectiveNames = map[string]struct{}{
+	plan.FederationKeyDirectiveName: {},
+	externalDirectiveName:           {},
+	extendsDirectiveName:            {},
+	requiresDirectiveName:           {},
+	providesDirectiveName:           {},
+	shareableDirectiveName:          {},
+	overrideDirectiveName:           {},
+	inaccessibleDirectiveName:       {},
+	tagDirectiveName:                {},
+	interfaceObjectDirectiveName:    {},
+	"composeDirective":              {},
+}
+
+// federationV2CompositionDirectiveNames are removed from the composed schema.
+var federationV2CompositionDirectiveNames = []string{
+	shareableDirectiveName,
+	overrideDirectiveName,
+	inaccessibleDirectiveName,
+	tagDirectiveName,
+	interfaceObjectDirectiveName,
+}
+
+// subgraphDocument is a parsed subgraph that gets prepared for composition.
+type subgraphDocument struct {
+	document      *ast.Document
+	federationV2  bool
+	directiveName map[string]string
+}
+
+// prepareFederationV2Subgraphs rewrites the subgraphs in place so that subgraphs written against
+// federation v2 can be composed by the merge visitors:
+//
+//   - federation directives imported by @link are renamed to their canonical names and @link is removed
+//   - type and field definitions are no longer distinguished from extensions, the first occurrence
+//     of an object type becomes the definition and all further occurrences extend it
+//   - the fields of @shareable types and key fields are marked @shareable, fields of federation v1
+//     subgraphs are considered @shareable like Apollo does when upgrading them
+//
+// If none of the subgraphs links the federation v2 spec, the subgraphs are left untouched and false is returned.
+func prepareFederationV2Subgraphs(subgraphs []string) (isFederationV2 bool, err error) {
+	documents := make([]subgraphDocument, 0, len(subgraphs))
+	var errs subgraphErrors
+	for i, subgraph := range subgraphs {
+		doc, report := astparser.ParseGraphqlDocumentString(subgraph)
+		if report.HasErrors() {
+			errs.add(i, subgraph, fmt.Errorf(parseDocumentError, report))
+			continue
+		}
+		document := subgraphDocument{document: &doc}
+		if err := document.resolveFederationV2Link(); err != nil {
+			errs.add(i, subgraph, err)
+			continue
+		}
+		if document.federationV2 {
+			isFederationV2 = true
+		}
+		documents = append(documents, document)
+	}
+	if err := errs.errorOrNil(); err != nil {
+		return false, err
+	}
+
+	if !isFederationV2 {
+		return false, nil
+	}
+
+	entityKeys := make(map[string]string)
+	for i := range documents {
+		documents[i].renameDirectives()
+		documents[i].collectEntityKeys(entityKeys)
+	}
+
+	seenObjectTypes := make(map[string]struct{})
+	for i := range documents {
+		documents[i].markShareableFields()
+		documents[i].unifyObjectTypeExtensions(seenObjectTypes, entityKeys)
+
+		out, err := astprinter.PrintString(documents[i].document, nil)
+		if err != nil {
+			return false, fmt.Errorf("stringify schema: %w", err)
+		}
+		subgraphs[i] = out
+	}
+
+	return true, nil
+}
+
+// resolveFederationV2Link looks for a @link to the federation v2 spec on the schema definition or extension,
+// removes it and records how the imported directives are named within the subgraph.
+func (s *subgraphDocument) resolveFederationV2Link() error {
+	for _, node := range s.document.RootNodes {
+		var schema *ast.SchemaDefinition
+		switch node.Kind {
+		case ast.NodeKindSchemaDefinition:
+			schema = &s.document.SchemaDefinitions[node.Ref]
+		case ast.NodeKindSchemaExtension:
+			schema = &s.document.SchemaExtensions[node.Ref].SchemaDefinition
+		default:
+			continue
+		}
+
+		directiveRefs := make([]int, 0, len(schema.Directives.Refs))
+		for _, directiveRef := range schema.Directives.Refs {
+			if s.document.DirectiveNameString(directiveRef) != linkDirectiveName {
+				directiveRefs = append(directiveRefs, directiveRef)
+				continue
+			}
+			url, ok := s.directiveArgumentString(directiveRef, "url")
+			if !ok || !strings.HasPrefix(url, federationV2SpecURLPrefix) {
+				continue
+			}
+			if err := s.resolveImports(directiveRef); err != nil {
+				return err
+			}
+			s.federationV2 = true
+		}
+		schema.Directives.Refs = directiveRefs
+		schema.HasDirectives = len(directiveRefs) > 0
+	}
+
+	s.removeEmptySchemaExtensions()
+	return nil
+}
+
+func (s *subgraphDocument) resolveImports(linkDirectiveRef int) error {
+	namespace := "federation"
+	if as, ok := s.directiveArgumentString(linkDirectiveRef, "as"); ok {
+		namespace = as
+	}
+
+	s.directiveName = make(map[string]string, len(federationV2DirectiveNames))
+	for name := range federationV2DirectiveNames {
+		s.directiveName[namespace+"__"+name] = name
+	}
+
+	imports, ok := s.document.DirectiveArgumentValueByName(linkDirectiveRef, []byte("import"))
+	if !ok || imports.Kind != ast.ValueKindList {
+		return nil
+	}
+
+	for _, valueRef := range s.document.ListValues[imports.Ref].Refs {
+		value := s.document.Value(valueRef)
+		var name, as string
+		switch value.Kind {
+		case ast.ValueKindString:
+			name = s.document.StringValueContentString(value.Ref)
+			as = name
+		case ast.ValueKindObject:
+			for _, fieldRef := range s.document.ObjectValues[value.Ref].Refs {
+				fieldValue := s.document.ObjectFields[fieldRef].Value
+				if fieldValue.Kind != ast.ValueKindString {
+					continue
+				}
+				switch s.document.ObjectFieldNameString(fieldRef) {
+				case "name":
+					name = s.document.StringValueContentString(fieldValue.Ref)
+				case "as":
+					as = s.document.StringValueContentString(fieldValue.Ref)
+				}
+			}
+			if as == "" {
+				as = name
+			}
+		default:
+			continue
+		}
+
+		// imports without the @ prefix are types like FieldSet
+		if !strings.HasPrefix(name, "@") {
+			continue
+		}
+		if _, ok := federationV2DirectiveNames[name[1:]]; !ok {
+			report := operationreport.Report{}
+			err := withCode(operationreport.ErrUnknownFederationDirectiveImport(name), ErrCodeUnknownLinkImport)
+			err.Locations = operationreport.LocationsFromPosition(value.Position)
+			report.AddExternalError(err)
+			return fmt.Errorf("resolve federation imports: %w", report)
+		}
+		s.directiveName[strings.TrimPrefix(as, "@")] = name[1:]
+	}
+
+	return nil
+}
+
+func (s *subgraphDocument) removeEmptySchemaExtensions() {
+	rootNodes := s.document.RootNodes[:0]
+	for _, node := range s.document.RootNodes {
+		if node.Kind == ast.NodeKindSchemaExtension {
+			extension := s.document.SchemaExtensions[node.Ref]
+			if !extension.HasDirectives && len(extension.RootOperationTypeDefinitions.Refs) == 0 {
+				continue
+			}
+		}
+		rootNodes = append(rootNodes, node)
+	}
+	s.document.RootNodes = rootNodes
+}
+
+// renameDirectives renames aliased and namespaced federation directives to their canonical names.
+func (s *subgraphDocument) renameDirectives() {
+	if len(s.directiveName) == 0 {
+		return
+	}
+	for i := range s.document.Directives {
+		if name, ok := s.directiveName[s.document.DirectiveNameString(i)]; ok {
+			s.document.Directives[i].Name = s.document.Input.AppendInputString(name)
+		}
+	}
+}
+
+func (s *subgraphDocument) collectEntityKeys(entityKeys map[string]string) {
+	for _, node := range s.document.RootNodes {
+		objectType, ok := s.objectType(node)
+		if !ok {
+			continue
+		}
+		name := s.document.Input.ByteSliceString(objectType.Name)
+		if _, exists := entityKeys[name]; exists {
+			continue
+		}
+		for _, fields := range s.keyFieldSets(objectType.Directives.Refs) {
+			entityKeys[name] = fields
+			break
+		}
+	}
+}
+
+// markShareableFields moves @shareable from types to their fields and marks key fields as shareable.
+// The fields of federation v1 subgraphs are all considered to be shareable.
+func (s *subgraphDocument) markShareableFields() {
+	for _, node := range s.document.RootNodes {
+		objectType, ok := s.objectType(node)
+		if !ok {
+			continue
+		}
+
+		isRootType := ast.IsRootType(s.document.Input.ByteSlice(objectType.Name))
+		typeIsShareable := !s.federationV2 && !isRootType
+		shareableFields := make(map[string]struct{})
+
+		directiveRefs := make([]int, 0, len(objectType.Directives.Refs))
+		for _, directiveRef := range objectType.Directives.Refs {
+			switch s.document.DirectiveNameString(directiveRef) {
+			case shareableDirectiveName:
+				typeIsShareable = true
+				continue
+			case extendsDirectiveName:
+				// extensions and definitions are treated alike
+				continue
+			}
+			directiveRefs = append(directiveRefs, directiveRef)
+		}
+		objectType.Directives.Refs = directiveRefs
+		objectType.HasDirectives = len(directiveRefs) > 0
+
+		for _, fields := range s.keyFieldSets(objectType.Directives.Refs) {
+			for _, fieldName := range plan.FieldSetFieldNames(fields) {
+				shareableFields[fieldName] = struct{}{}
+			}
+		}
+
+		for _, fieldRef := range objectType.FieldsDefinition.Refs {
+			if s.document.FieldDefinitionHasNamedDirective(fieldRef, shareableDirectiveName) {
+				continue
+			}
+			if _, isKeyField := shareableFields[s.document.FieldDefinitionNameString(fieldRef)]; !typeIsShareable && !isKeyField {
+				continue
+			}
+			directiveRef := s.document.ImportDirective(shareableDirectiveName, nil)
+			s.document.FieldDefinitions[fieldRef].Directives.Refs = append(s.document.FieldDefinitions[fieldRef].Directives.Refs, directiveRef)
+			s.document.FieldDefinitions[fieldRef].HasDirectives = true
+		}
+	}
+}
+
+// unifyObjectTypeExtensions turns the first occurrence of an object type across all subgraphs into its definition
+// and all further occurrences into extensions. Occurrences of entities without a key get the key of the entity.
+func (s *subgraphDocument) unifyObjectTypeExtensions(seenObjectTypes map[string]struct{}, entityKeys map[string]string) {
+	for i, node := range s.document.RootNodes {
+		objectType, ok := s.objectType(node)
+		if !ok {
+			continue
+		}
+		nameBytes := s.document.Input.ByteSlice(objectType.Name)
+		if ast.IsRootType(nameBytes) {
+			continue
+		}
+		if s.hasDirective(objectType.Directives.Refs, interfaceObjectDirectiveName) {
+			// the interface is the entity, the @interfaceObject gets merged into it
+			s.removeDirective(objectType, plan.FederationKeyDirectiveName)
+			continue
+		}
+		name := string(nameBytes)
+
+		if fields, isEntity := entityKeys[name]; isEntity && len(s.keyFieldSets(objectType.Directives.Refs)) == 0 {
+			s.addKeyDirective(objectType, fields)
+		}
+
+		_, seen := seenObjectTypes[name]
+		seenObjectTypes[name] = struct{}{}
+
+		switch {
+		case seen && node.Kind == ast.NodeKindObjectTypeDefinition:
+			ref := s.document.AddObjectTypeDefinitionExtension(ast.ObjectTypeExtension{
+				ObjectTypeDefinition: *objectType,
+			})
+			s.document.RootNodes[i] = ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref}
+		case !seen && node.Kind == ast.NodeKindObjectTypeExtension:
+			ref := s.document.AddObjectTypeDefinition(*objectType)
+			s.document.RootNodes[i] = ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref}
+		}
+	}
+}
+
+func (s *subgraphDocument) removeDirective(objectType *ast.ObjectTypeDefinition, name string) {
+	directiveRefs := make([]int, 0, len(objectType.Directives.Refs))
+	for _, directiveRef := range objectType.Directives.Refs {
+		if s.document.DirectiveNameString(directiveRef) != name {
+			directiveRefs = append(directiveRefs, directiveRef)
+		}
+	}
+	objectType.Directives.Refs = directiveRefs
+	objectType.HasDirectives = len(directiveRefs) > 0
+}
+
+func (s *subgraphDocument) addKeyDirective(objectType *ast.ObjectTypeDefinition, fields string) {
+	valueRef := s.document.ImportStringValue([]byte(fields), false)
+	argumentRef := s.document.ImportArgument("fields", ast.Value{Kind: ast.ValueKindString, Ref: valueRef})
+	directiveRef := s.document.ImportDirective(plan.FederationKeyDirectiveName, []int{argumentRef})
+	objectType.Directives.Refs = append(objectType.Directives.Refs, directiveRef)
+	objectType.HasDirectives = true
+}
+
+func (s *subgraphDocument) objectType(node ast.Node) (*ast.ObjectTypeDefinition, bool) {
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return &s.document.ObjectTypeDefinitions[node.Ref], true
+	case ast.NodeKindObjectTypeExtension:
+		return &s.document.ObjectTypeExtensions[node.Ref].ObjectTypeDefinition, true
+	default:
+		return nil, false
+	}
+}
+
+func (s *subgraphDocument) keyFieldSets(directiveRefs []int) (fieldSets []string) {
+	for _, directiveRef := range directiveRefs {
+		if s.document.DirectiveNameString(directiveRef) != plan.FederationKeyDirectiveName {
+			continue
+		}
+		if fields, ok := s.directiveArgumentString(directiveRef, "fields"); ok {
+			fieldSets = append(fieldSets, fields)
+		}
+	}
+	return fieldSets
+}
+
+func (s *subgraphDocument) hasDirective(directiveRefs []int, name string) bool {
+	return hasDirective(s.document, directiveRefs, name)
+}
+
+func (s *subgraphDocument) directiveArgumentString(directiveRef int, argumentName string) (string, bool) {
+	return directiveArgumentString(s.document, directiveRef, argumentName)
+}
+
+func hasDirective(document *ast.Document, directiveRefs []int, name string) bool {
+	for _, directiveRef := range directiveRefs {
+		if document.DirectiveNameString(directiveRef) == name {
+			return true
+		}
+	}
+	return false
+}
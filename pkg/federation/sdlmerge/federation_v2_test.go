@@ -0,0 +1,379 @@
+package sdlmerge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestMergeSDLs_FederationV2(t *testing.T) {
+	runMergeTest := func(expectedSchema string, sdls ...string) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+
+			got, err := MergeSDLs(sdls...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedOutputDocument := unsafeparser.ParseGraphqlDocumentString(expectedSchema)
+			want := mustString(astprinter.PrintString(&expectedOutputDocument, nil))
+
+			assert.Equal(t, want, got)
+		}
+	}
+
+	runMergeTestAndExpectError := func(expectedError string, sdls ...string) func(t *testing.T) {
+		return func(t *testing.T) {
+			_, err := MergeSDLs(sdls...)
+			actual, _ := operationreport.ExternalErrorMessage(err, testFormatExternalErrorMessage)
+			assert.Equal(t, expectedError, actual)
+		}
+	}
+
+	t.Run("should merge entities and shareable value types", runMergeTest(
+		`
+			type Query {
+				me: User
+				topReviews: [Review!]!
+			}
+
+			type User {
+				id: ID!
+				name: String!
+				reviews: [Review!]!
+			}
+
+			type Position {
+				x: Int!
+				y: Int!
+				z: Int
+			}
+
+			type Review {
+				body: String!
+				author: User!
+				position: Position
+			}
+		`,
+		v2AccountsSchema, v2ReviewsSchema,
+	))
+
+	t.Run("should resolve namespaced federation directives", runMergeTest(
+		`
+			type Query {
+				me: User
+				user: User
+			}
+
+			type User {
+				id: ID!
+				name: String!
+			}
+
+			type Position {
+				x: Int!
+				y: Int!
+			}
+		`,
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+
+			type Query {
+				user: User
+			}
+
+			type User @key(fields: "id") {
+				id: ID!
+				name: String! @federation__shareable
+			}
+		`,
+	))
+
+	t.Run("should compose with federation v1 subgraphs", runMergeTest(
+		`
+			type Query {
+				me: User
+				products: [Product!]!
+			}
+
+			type User {
+				id: ID!
+				name: String!
+			}
+
+			type Position {
+				x: Int!
+				y: Int!
+			}
+
+			type Product {
+				upc: String!
+				position: Position
+			}
+		`,
+		v2AccountsSchema, `
+			extend type Query {
+				products: [Product!]!
+			}
+
+			type Product @key(fields: "upc") {
+				upc: String!
+				position: Position
+			}
+
+			type Position {
+				x: Int!
+				y: Int!
+			}
+		`,
+	))
+
+	t.Run("should let @override take over a field", runMergeTest(
+		`
+			type Query {
+				me: User
+			}
+
+			type User {
+				id: ID!
+				name: String!
+			}
+
+			type Position {
+				x: Int!
+				y: Int!
+			}
+		`,
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@override"])
+
+			type User @key(fields: "id") {
+				id: ID!
+				name: String! @override(from: "accounts")
+			}
+		`,
+	))
+
+	t.Run("should remove @inaccessible elements from the composed schema", runMergeTest(
+		`
+			type Query {
+				me: User
+			}
+
+			type User {
+				id: ID!
+				name: String!
+				role(scope: String): Role
+			}
+
+			type Position {
+				x: Int!
+				y: Int!
+			}
+
+			enum Role {
+				ADMIN
+			}
+		`,
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@inaccessible", "@tag"])
+
+			type User @key(fields: "id") {
+				id: ID!
+				role(scope: String, internal: Internal @inaccessible): Role @tag(name: "public")
+				internal: Boolean @inaccessible
+			}
+
+			enum Role {
+				ADMIN
+				SYSTEM @inaccessible
+			}
+
+			input Internal @inaccessible {
+				debug: Boolean
+			}
+		`,
+	))
+
+	t.Run("should merge @interfaceObject into the interface and its implementations", runMergeTest(
+		`
+			type Query {
+				media: [Media!]!
+			}
+
+			interface Media {
+				id: ID!
+				title: String!
+				reviews: [String!]!
+			}
+
+			type Book implements Media {
+				id: ID!
+				title: String!
+				reviews: [String!]!
+			}
+		`,
+		`
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key"])
+
+			type Query {
+				media: [Media!]!
+			}
+
+			interface Media @key(fields: "id") {
+				id: ID!
+				title: String!
+			}
+
+			type Book implements Media @key(fields: "id") {
+				id: ID!
+				title: String!
+			}
+		`, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key", "@interfaceObject"])
+
+			type Media @key(fields: "id") @interfaceObject {
+				id: ID!
+				reviews: [String!]!
+			}
+		`,
+	))
+
+	t.Run("should return an error for non-shareable fields resolved by multiple subgraphs", runMergeTestAndExpectError(
+		nonShareableFieldErrorMessage("name", "User"),
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+
+			type User @key(fields: "id") {
+				id: ID!
+				name: String!
+			}
+		`,
+	))
+
+	t.Run("should return an error for non-shareable root fields resolved by multiple subgraphs", runMergeTestAndExpectError(
+		nonShareableFieldErrorMessage("me", "Query"),
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+
+			type Query {
+				me: User
+			}
+
+			type User @key(fields: "id") {
+				id: ID!
+			}
+		`,
+	))
+
+	t.Run("should return an error for a field overridden by multiple subgraphs", runMergeTestAndExpectError(
+		"field 'name' on type 'User' is marked @override in more than one subgraph",
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@override"])
+
+			type User @key(fields: "id") {
+				id: ID!
+				name: String! @override(from: "accounts")
+			}
+		`, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@override"])
+
+			type User @key(fields: "id") {
+				id: ID!
+				name: String! @override(from: "accounts")
+			}
+		`,
+	))
+
+	t.Run("should return an error for accessible fields referencing @inaccessible types", runMergeTestAndExpectError(
+		"the type named 'Internal' is @inaccessible but it is referenced by 'User.internal' which is not @inaccessible",
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@inaccessible"])
+
+			type User @key(fields: "id") {
+				id: ID!
+				internal: Internal
+			}
+
+			type Internal @inaccessible {
+				debug: Boolean
+			}
+		`,
+	))
+
+	t.Run("should return an error for @interfaceObject without interface", runMergeTestAndExpectError(
+		"the type named 'Media' is marked @interfaceObject but there is no interface of the same name",
+		v2AccountsSchema, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key", "@interfaceObject"])
+
+			type Media @key(fields: "id") @interfaceObject {
+				id: ID!
+			}
+		`,
+	))
+
+	t.Run("should return an error for unknown directive imports", runMergeTestAndExpectError(
+		"the directive '@unknown' imported by @link is not a federation directive",
+		`
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@unknown"])
+
+			type Query {
+				hello: String
+			}
+		`,
+	))
+}
+
+func nonShareableFieldErrorMessage(fieldName, typeName string) string {
+	return operationreport.ErrFieldMustBeShareable(fieldName, typeName).Message
+}
+
+const (
+	v2AccountsSchema = `
+		extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@shareable"])
+
+		type Query {
+			me: User
+		}
+
+		type User @key(fields: "id") {
+			id: ID!
+			name: String! @shareable
+		}
+
+		type Position @shareable {
+			x: Int!
+			y: Int!
+		}
+	`
+	v2ReviewsSchema = `
+		extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key", { name: "@shareable", as: "@share" }])
+
+		type Query {
+			topReviews: [Review!]!
+		}
+
+		type Review {
+			body: String!
+			author: User!
+			position: Position
+		}
+
+		type User @key(fields: "id") {
+			id: ID!
+			name: String! @share
+			reviews: [Review!]!
+		}
+
+		type Position @share {
+			x: Int!
+			y: Int!
+			z: Int
+		}
+	`
+)
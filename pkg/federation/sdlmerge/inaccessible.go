@@ -0,0 +1,128 @@
+package sdlmerge
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// removeInaccessibleVisitor removes types, fields, arguments, input fields and enum values marked @inaccessible
+// from the composed schema.
+// Accessible fields, arguments and input fields must not reference an @inaccessible type.
+type removeInaccessibleVisitor struct {
+	*astvisitor.Walker
+	document          *ast.Document
+	inaccessibleTypes map[string]struct{}
+}
+
+func newRemoveInaccessibleVisitor() *removeInaccessibleVisitor {
+	return &removeInaccessibleVisitor{}
+}
+
+func (r *removeInaccessibleVisitor) Register(walker *astvisitor.Walker) {
+	r.Walker = walker
+	walker.RegisterEnterDocumentVisitor(r)
+	walker.RegisterLeaveDocumentVisitor(r)
+}
+
+func (r *removeInaccessibleVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.document = operation
+	r.inaccessibleTypes = make(map[string]struct{})
+}
+
+func (r *removeInaccessibleVisitor) LeaveDocument(_, _ *ast.Document) {
+	var rootNodesToRemove []ast.Node
+	for _, node := range r.document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition,
+			ast.NodeKindInterfaceTypeDefinition,
+			ast.NodeKindUnionTypeDefinition,
+			ast.NodeKindEnumTypeDefinition,
+			ast.NodeKindInputObjectTypeDefinition,
+			ast.NodeKindScalarTypeDefinition:
+			if r.isInaccessible(r.document.NodeDirectives(node)) {
+				r.inaccessibleTypes[r.document.NodeNameString(node)] = struct{}{}
+				rootNodesToRemove = append(rootNodesToRemove, node)
+			}
+		}
+	}
+	r.document.DeleteRootNodes(rootNodesToRemove)
+
+	for _, node := range r.document.RootNodes {
+		typeName := r.document.NodeNameString(node)
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition:
+			fields := &r.document.ObjectTypeDefinitions[node.Ref].FieldsDefinition.Refs
+			r.removeInaccessibleFields(typeName, fields)
+			r.document.ObjectTypeDefinitions[node.Ref].HasFieldDefinitions = len(*fields) > 0
+		case ast.NodeKindInterfaceTypeDefinition:
+			fields := &r.document.InterfaceTypeDefinitions[node.Ref].FieldsDefinition.Refs
+			r.removeInaccessibleFields(typeName, fields)
+			r.document.InterfaceTypeDefinitions[node.Ref].HasFieldDefinitions = len(*fields) > 0
+		case ast.NodeKindInputObjectTypeDefinition:
+			inputFields := &r.document.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs
+			r.removeInaccessibleInputValues(typeName, inputFields)
+			r.document.InputObjectTypeDefinitions[node.Ref].HasInputFieldsDefinition = len(*inputFields) > 0
+		case ast.NodeKindEnumTypeDefinition:
+			values := &r.document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs
+			*values = r.filter(*values, func(ref int) bool {
+				return !r.isInaccessible(r.document.EnumValueDefinitions[ref].Directives.Refs)
+			})
+			r.document.EnumTypeDefinitions[node.Ref].HasEnumValuesDefinition = len(*values) > 0
+		case ast.NodeKindUnionTypeDefinition:
+			members := &r.document.UnionTypeDefinitions[node.Ref].UnionMemberTypes.Refs
+			*members = r.filter(*members, func(ref int) bool {
+				_, isInaccessible := r.inaccessibleTypes[r.document.TypeNameString(ref)]
+				return !isInaccessible
+			})
+			r.document.UnionTypeDefinitions[node.Ref].HasUnionMemberTypes = len(*members) > 0
+		}
+	}
+}
+
+func (r *removeInaccessibleVisitor) removeInaccessibleFields(typeName string, fieldRefs *[]int) {
+	*fieldRefs = r.filter(*fieldRefs, func(ref int) bool {
+		return !r.isInaccessible(r.document.FieldDefinitions[ref].Directives.Refs)
+	})
+
+	for _, fieldRef := range *fieldRefs {
+		coordinate := typeName + "." + r.document.FieldDefinitionNameString(fieldRef)
+		r.checkTypeIsAccessible(r.document.FieldDefinitions[fieldRef].Type, coordinate)
+		arguments := &r.document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs
+		r.removeInaccessibleInputValues(coordinate, arguments)
+		r.document.FieldDefinitions[fieldRef].HasArgumentsDefinitions = len(*arguments) > 0
+	}
+}
+
+func (r *removeInaccessibleVisitor) removeInaccessibleInputValues(parentCoordinate string, inputValueRefs *[]int) {
+	*inputValueRefs = r.filter(*inputValueRefs, func(ref int) bool {
+		return !r.isInaccessible(r.document.InputValueDefinitions[ref].Directives.Refs)
+	})
+
+	for _, inputValueRef := range *inputValueRefs {
+		coordinate := parentCoordinate + "." + r.document.InputValueDefinitionNameString(inputValueRef)
+		r.checkTypeIsAccessible(r.document.InputValueDefinitions[inputValueRef].Type, coordinate)
+	}
+}
+
+func (r *removeInaccessibleVisitor) checkTypeIsAccessible(typeRef int, coordinate string) {
+	typeName := r.document.ResolveTypeNameString(typeRef)
+	if _, isInaccessible := r.inaccessibleTypes[typeName]; isInaccessible {
+		reportCompositionError(r.Walker, withLocations(withCode(operationreport.ErrInaccessibleTypeMustNotBeReferenced(typeName, coordinate), ErrCodeReferencedInaccessible),
+			r.document.Types[typeRef].Position))
+	}
+}
+
+func (r *removeInaccessibleVisitor) isInaccessible(directiveRefs []int) bool {
+	return hasDirective(r.document, directiveRefs, inaccessibleDirectiveName)
+}
+
+func (r *removeInaccessibleVisitor) filter(refs []int, keep func(ref int) bool) []int {
+	out := refs[:0]
+	for _, ref := range refs {
+		if keep(ref) {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
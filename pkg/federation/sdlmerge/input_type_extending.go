@@ -37,7 +37,8 @@ func (e *extendInputObjectTypeDefinitionVisitor) EnterInputObjectTypeExtension(r
 			continue
 		}
 		if hasExtended {
-			e.StopWithExternalErr(operationreport.ErrSharedTypesMustNotBeExtended(e.document.InputObjectTypeExtensionNameString(ref)))
+			reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustNotBeExtended(e.document.InputObjectTypeExtensionNameString(ref)), ErrCodeSharedTypeExtended),
+				e.document.NodePosition(ast.Node{Kind: ast.NodeKindInputObjectTypeExtension, Ref: ref})))
 			return
 		}
 		e.document.ExtendInputObjectTypeDefinitionByInputObjectTypeExtension(nodes[i].Ref, ref)
@@ -45,6 +46,7 @@ func (e *extendInputObjectTypeDefinitionVisitor) EnterInputObjectTypeExtension(r
 	}
 
 	if !hasExtended {
-		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.InputObjectTypeExtensionNameBytes(ref)))
+		reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.InputObjectTypeExtensionNameBytes(ref)), ErrCodeExtensionOrphan),
+			e.document.NodePosition(ast.Node{Kind: ast.NodeKindInputObjectTypeExtension, Ref: ref})))
 	}
 }
@@ -0,0 +1,100 @@
+package sdlmerge
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// interfaceObjectVisitor merges object types marked @interfaceObject into the interface of the same name.
+// The fields contributed by the @interfaceObject are added to the interface and to all of its implementations.
+type interfaceObjectVisitor struct {
+	*astvisitor.Walker
+	document *ast.Document
+}
+
+func newInterfaceObjectVisitor() *interfaceObjectVisitor {
+	return &interfaceObjectVisitor{}
+}
+
+func (i *interfaceObjectVisitor) Register(walker *astvisitor.Walker) {
+	i.Walker = walker
+	walker.RegisterEnterDocumentVisitor(i)
+	walker.RegisterLeaveDocumentVisitor(i)
+}
+
+func (i *interfaceObjectVisitor) EnterDocument(operation, _ *ast.Document) {
+	i.document = operation
+}
+
+func (i *interfaceObjectVisitor) LeaveDocument(_, _ *ast.Document) {
+	var rootNodesToRemove []ast.Node
+	for _, node := range i.document.RootNodes {
+		if node.Kind != ast.NodeKindObjectTypeDefinition {
+			continue
+		}
+		objectType := i.document.ObjectTypeDefinitions[node.Ref]
+		if !hasDirective(i.document, objectType.Directives.Refs, interfaceObjectDirectiveName) {
+			continue
+		}
+
+		nameBytes := i.document.ObjectTypeDefinitionNameBytes(node.Ref)
+		interfaceRef, ok := i.interfaceTypeDefinitionByName(nameBytes)
+		if !ok {
+			reportCompositionError(i.Walker, withLocations(withCode(operationreport.ErrInterfaceObjectMustHaveInterface(string(nameBytes)), ErrCodeInterfaceObjectWithoutInterface),
+				i.document.NodePosition(node)))
+			continue
+		}
+
+		interfaceType := &i.document.InterfaceTypeDefinitions[interfaceRef]
+		interfaceType.FieldsDefinition.Refs = i.addMissingFields(interfaceType.FieldsDefinition.Refs, objectType.FieldsDefinition.Refs)
+		interfaceType.HasFieldDefinitions = len(interfaceType.FieldsDefinition.Refs) > 0
+
+		for _, implementingNode := range i.document.RootNodes {
+			if implementingNode.Kind != ast.NodeKindObjectTypeDefinition || implementingNode.Ref == node.Ref {
+				continue
+			}
+			if !i.document.ObjectTypeDefinitionImplementsInterface(implementingNode.Ref, nameBytes) {
+				continue
+			}
+			implementingType := &i.document.ObjectTypeDefinitions[implementingNode.Ref]
+			implementingType.FieldsDefinition.Refs = i.addMissingFields(implementingType.FieldsDefinition.Refs, objectType.FieldsDefinition.Refs)
+			implementingType.HasFieldDefinitions = len(implementingType.FieldsDefinition.Refs) > 0
+		}
+
+		rootNodesToRemove = append(rootNodesToRemove, node)
+	}
+
+	i.document.DeleteRootNodes(rootNodesToRemove)
+}
+
+func (i *interfaceObjectVisitor) interfaceTypeDefinitionByName(name ast.ByteSlice) (int, bool) {
+	for _, node := range i.document.RootNodes {
+		if node.Kind == ast.NodeKindInterfaceTypeDefinition && string(i.document.InterfaceTypeDefinitionNameBytes(node.Ref)) == string(name) {
+			return node.Ref, true
+		}
+	}
+	return ast.InvalidRef, false
+}
+
+// addMissingFields adds copies of the fields which are not yet part of the target fields.
+func (i *interfaceObjectVisitor) addMissingFields(targetFieldRefs, fieldRefs []int) []int {
+	existingFields := make(map[string]struct{}, len(targetFieldRefs))
+	for _, fieldRef := range targetFieldRefs {
+		existingFields[i.document.FieldDefinitionNameString(fieldRef)] = struct{}{}
+	}
+
+	for _, fieldRef := range fieldRefs {
+		if _, exists := existingFields[i.document.FieldDefinitionNameString(fieldRef)]; exists {
+			continue
+		}
+		if i.document.FieldDefinitionHasNamedDirective(fieldRef, externalDirectiveName) {
+			continue
+		}
+		field := i.document.FieldDefinitions[fieldRef]
+		field.Directives.Refs = append([]int(nil), field.Directives.Refs...)
+		targetFieldRefs = append(targetFieldRefs, i.document.AddFieldDefinition(field))
+	}
+
+	return targetFieldRefs
+}
@@ -42,20 +42,21 @@ func (e *extendInterfaceTypeDefinitionVisitor) EnterInterfaceTypeExtension(ref i
 			continue
 		}
 		if nodeToExtend != nil {
-			e.StopWithExternalErr(*multipleExtensionError(isEntity, nameBytes))
+			reportCompositionError(e.Walker, *multipleExtensionError(isEntity, nameBytes, e.document.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref})))
 			return
 		}
 		var err *operationreport.ExternalError
 		extension := e.document.InterfaceTypeExtensions[ref]
 		if isEntity, err = e.collectedEntities.isExtensionForEntity(nameBytes, extension.Directives.Refs, e.document); err != nil {
-			e.StopWithExternalErr(*err)
+			reportCompositionError(e.Walker, withLocations(*err, e.document.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref})))
 			return
 		}
 		nodeToExtend = &nodes[i]
 	}
 
 	if nodeToExtend == nil {
-		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.InterfaceTypeExtensionNameBytes(ref)))
+		reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.InterfaceTypeExtensionNameBytes(ref)), ErrCodeExtensionOrphan),
+			e.document.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref})))
 		return
 	}
 
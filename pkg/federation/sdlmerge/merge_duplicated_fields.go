@@ -49,10 +49,10 @@ func (m *mergeDuplicatedFieldsVisitor) LeaveObjectTypeDefinition(ref int) {
 				m.Walker.StopWithInternalErr(err)
 				return
 			}
-			m.Walker.StopWithExternalErr(operationreport.ErrDuplicateFieldsMustBeIdentical(
+			reportCompositionError(m.Walker, withLocations(withCode(operationreport.ErrDuplicateFieldsMustBeIdentical(
 				fieldName, m.document.ObjectTypeDefinitionNameString(ref), string(oldFieldTypeNameBytes), string(newFieldTypeNameBytes),
-			))
-			return
+			), ErrCodeFieldTypeMismatch), m.document.NodePosition(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: fieldRef})))
+			continue
 		}
 
 		fieldByTypeRefSet[fieldName] = newTypeRef
@@ -42,13 +42,13 @@ func (e *extendObjectTypeDefinitionVisitor) EnterObjectTypeExtension(ref int) {
 			continue
 		}
 		if nodeToExtend != nil {
-			e.StopWithExternalErr(*multipleExtensionError(isEntity, nameBytes))
+			reportCompositionError(e.Walker, *multipleExtensionError(isEntity, nameBytes, e.document.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref})))
 			return
 		}
 		var err *operationreport.ExternalError
 		extension := e.document.ObjectTypeExtensions[ref]
 		if isEntity, err = e.collectedEntities.isExtensionForEntity(nameBytes, extension.Directives.Refs, e.document); err != nil {
-			e.StopWithExternalErr(*err)
+			reportCompositionError(e.Walker, withLocations(*err, e.document.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref})))
 			return
 		}
 		nodeToExtend = &nodes[i]
@@ -58,7 +58,8 @@ func (e *extendObjectTypeDefinitionVisitor) EnterObjectTypeExtension(ref int) {
 	}
 
 	if nodeToExtend == nil {
-		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(nameBytes))
+		reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrExtensionOrphansMustResolveInSupergraph(nameBytes), ErrCodeExtensionOrphan),
+			e.document.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref})))
 		return
 	}
 
@@ -0,0 +1,96 @@
+package sdlmerge
+
+import (
+	"fmt"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+)
+
+// PlanningSDLs returns the SDLs of the subgraphs as they are used to plan which subgraph resolves a field.
+// Federation directives of federation v2 subgraphs which are renamed by @link are resolved to their canonical names
+// and a field taken over by a subgraph with @override is removed from all other subgraphs, unless it is @external there.
+// If none of the subgraphs links the federation v2 spec, the SDLs are returned as they are.
+func PlanningSDLs(SDLs ...string) ([]string, error) {
+	documents := make([]subgraphDocument, 0, len(SDLs))
+	isFederationV2 := false
+	for i, SDL := range SDLs {
+		doc, report := astparser.ParseGraphqlDocumentString(SDL)
+		if report.HasErrors() {
+			return nil, newSubgraphError(i, SDL, fmt.Errorf(parseDocumentError, report))
+		}
+		document := subgraphDocument{document: &doc}
+		if err := document.resolveFederationV2Link(); err != nil {
+			return nil, newSubgraphError(i, SDL, err)
+		}
+		document.renameDirectives()
+		if document.federationV2 {
+			isFederationV2 = true
+		}
+		documents = append(documents, document)
+	}
+
+	planningSDLs := make([]string, len(SDLs))
+	copy(planningSDLs, SDLs)
+	if !isFederationV2 {
+		return planningSDLs, nil
+	}
+
+	overridingSubgraphs := make(map[string]int)
+	for i := range documents {
+		documents[i].collectOverriddenFields(i, overridingSubgraphs)
+	}
+
+	for i := range documents {
+		removed := documents[i].removeOverriddenFields(i, overridingSubgraphs)
+		if !documents[i].federationV2 && !removed {
+			continue
+		}
+		out, err := astprinter.PrintString(documents[i].document, nil)
+		if err != nil {
+			return nil, fmt.Errorf("stringify schema: %w", err)
+		}
+		planningSDLs[i] = out
+	}
+
+	return planningSDLs, nil
+}
+
+// collectOverriddenFields records the subgraph at index as the subgraph resolving its fields marked @override by coordinate
+func (s *subgraphDocument) collectOverriddenFields(index int, overridingSubgraphs map[string]int) {
+	for _, node := range s.document.RootNodes {
+		objectType, ok := s.objectType(node)
+		if !ok {
+			continue
+		}
+		typeName := s.document.Input.ByteSliceString(objectType.Name)
+		for _, fieldRef := range objectType.FieldsDefinition.Refs {
+			if s.document.FieldDefinitionHasNamedDirective(fieldRef, overrideDirectiveName) {
+				overridingSubgraphs[typeName+"."+s.document.FieldDefinitionNameString(fieldRef)] = index
+			}
+		}
+	}
+}
+
+// removeOverriddenFields removes the fields which are resolved by another subgraph because of @override
+func (s *subgraphDocument) removeOverriddenFields(index int, overridingSubgraphs map[string]int) (removed bool) {
+	for _, node := range s.document.RootNodes {
+		objectType, ok := s.objectType(node)
+		if !ok {
+			continue
+		}
+		typeName := s.document.Input.ByteSliceString(objectType.Name)
+		fieldRefs := make([]int, 0, len(objectType.FieldsDefinition.Refs))
+		for _, fieldRef := range objectType.FieldsDefinition.Refs {
+			overridingSubgraph, isOverridden := overridingSubgraphs[typeName+"."+s.document.FieldDefinitionNameString(fieldRef)]
+			if isOverridden && overridingSubgraph != index && !s.document.FieldDefinitionHasNamedDirective(fieldRef, externalDirectiveName) {
+				removed = true
+				continue
+			}
+			fieldRefs = append(fieldRefs, fieldRef)
+		}
+		objectType.FieldsDefinition.Refs = fieldRefs
+		objectType.HasFieldDefinitions = len(fieldRefs) > 0
+	}
+	return removed
+}
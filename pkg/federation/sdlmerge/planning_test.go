@@ -0,0 +1,61 @@
+package sdlmerge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeprinter"
+)
+
+func TestPlanningSDLs(t *testing.T) {
+	t.Run("federation v1 subgraphs are kept as they are", func(t *testing.T) {
+		SDLs := []string{
+			`type Query { me: User } type User @key(fields: "id") { id: ID! name: String }`,
+			`extend type User @key(fields: "id") { id: ID! @external reviews: [String] }`,
+		}
+		planningSDLs, err := PlanningSDLs(SDLs...)
+		require.NoError(t, err)
+		assert.Equal(t, SDLs, planningSDLs)
+	})
+
+	t.Run("overridden fields are removed from the other subgraphs", func(t *testing.T) {
+		planningSDLs, err := PlanningSDLs(`
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+			type Query { me: User }
+			type User @key(fields: "id") { id: ID! name: String }
+		`, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@override"])
+			type User @key(fields: "id") { id: ID! name: String @override(from: "accounts") }
+		`, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@external", "@requires"])
+			type User @key(fields: "id") { id: ID! name: String @external greeting: String @requires(fields: "name") }
+		`)
+		require.NoError(t, err)
+		require.Len(t, planningSDLs, 3)
+		assert.Equal(t, unsafeprinter.Prettify(`
+			type Query { me: User }
+			type User @key(fields: "id") { id: ID! }
+		`), unsafeprinter.Prettify(planningSDLs[0]))
+		assert.Equal(t, unsafeprinter.Prettify(`
+			type User @key(fields: "id") { id: ID! name: String @override(from: "accounts") }
+		`), unsafeprinter.Prettify(planningSDLs[1]))
+		assert.Equal(t, unsafeprinter.Prettify(`
+			type User @key(fields: "id") { id: ID! name: String @external greeting: String @requires(fields: "name") }
+		`), unsafeprinter.Prettify(planningSDLs[2]))
+	})
+
+	t.Run("renamed federation directives are resolved", func(t *testing.T) {
+		planningSDLs, err := PlanningSDLs(`
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: [{ name: "@key", as: "@primaryKey" }])
+			type Query { me: User }
+			type User @primaryKey(fields: "id") { id: ID! name: String @federation__external }
+		`)
+		require.NoError(t, err)
+		assert.Equal(t, unsafeprinter.Prettify(`
+			type Query { me: User }
+			type User @key(fields: "id") { id: ID! name: String @external }
+		`), unsafeprinter.Prettify(planningSDLs[0]))
+	})
+}
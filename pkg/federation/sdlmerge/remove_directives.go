@@ -0,0 +1,73 @@
+package sdlmerge
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+)
+
+// removeDirectivesVisitor removes the given directives from all type system definitions.
+type removeDirectivesVisitor struct {
+	document   *ast.Document
+	directives map[string]struct{}
+}
+
+func newRemoveDirectivesVisitor(directives ...string) *removeDirectivesVisitor {
+	directivesSet := make(map[string]struct{}, len(directives))
+	for _, directive := range directives {
+		directivesSet[directive] = struct{}{}
+	}
+
+	return &removeDirectivesVisitor{
+		directives: directivesSet,
+	}
+}
+
+func (r *removeDirectivesVisitor) Register(walker *astvisitor.Walker) {
+	walker.RegisterEnterDocumentVisitor(r)
+	walker.RegisterLeaveDocumentVisitor(r)
+}
+
+func (r *removeDirectivesVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.document = operation
+}
+
+func (r *removeDirectivesVisitor) LeaveDocument(_, _ *ast.Document) {
+	for i := range r.document.ObjectTypeDefinitions {
+		r.removeDirectives(&r.document.ObjectTypeDefinitions[i].Directives, &r.document.ObjectTypeDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.InterfaceTypeDefinitions {
+		r.removeDirectives(&r.document.InterfaceTypeDefinitions[i].Directives, &r.document.InterfaceTypeDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.UnionTypeDefinitions {
+		r.removeDirectives(&r.document.UnionTypeDefinitions[i].Directives, &r.document.UnionTypeDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.EnumTypeDefinitions {
+		r.removeDirectives(&r.document.EnumTypeDefinitions[i].Directives, &r.document.EnumTypeDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.EnumValueDefinitions {
+		r.removeDirectives(&r.document.EnumValueDefinitions[i].Directives, &r.document.EnumValueDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.InputObjectTypeDefinitions {
+		r.removeDirectives(&r.document.InputObjectTypeDefinitions[i].Directives, &r.document.InputObjectTypeDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.ScalarTypeDefinitions {
+		r.removeDirectives(&r.document.ScalarTypeDefinitions[i].Directives, &r.document.ScalarTypeDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.FieldDefinitions {
+		r.removeDirectives(&r.document.FieldDefinitions[i].Directives, &r.document.FieldDefinitions[i].HasDirectives)
+	}
+	for i := range r.document.InputValueDefinitions {
+		r.removeDirectives(&r.document.InputValueDefinitions[i].Directives, &r.document.InputValueDefinitions[i].HasDirectives)
+	}
+}
+
+func (r *removeDirectivesVisitor) removeDirectives(directives *ast.DirectiveList, hasDirectives *bool) {
+	refs := make([]int, 0, len(directives.Refs))
+	for _, ref := range directives.Refs {
+		if _, ok := r.directives[r.document.DirectiveNameString(ref)]; !ok {
+			refs = append(refs, ref)
+		}
+	}
+	directives.Refs = refs
+	*hasDirectives = len(refs) > 0
+}
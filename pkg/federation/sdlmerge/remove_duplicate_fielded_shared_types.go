@@ -50,7 +50,8 @@ func (r *removeDuplicateFieldedSharedTypesVisitor) EnterInputObjectTypeDefinitio
 	input, exists := r.sharedTypeSet[name]
 	if exists {
 		if !input.areFieldsIdentical(refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			reportCompositionError(r.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name), ErrCodeSharedTypeMismatch),
+				r.document.NodePosition(ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref})))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref})
@@ -70,7 +71,8 @@ func (r *removeDuplicateFieldedSharedTypesVisitor) EnterInterfaceTypeDefinition(
 	iFace, exists := r.sharedTypeSet[name]
 	if exists {
 		if !iFace.areFieldsIdentical(refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			reportCompositionError(r.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name), ErrCodeSharedTypeMismatch),
+				r.document.NodePosition(ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref})))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref})
@@ -90,7 +92,8 @@ func (r *removeDuplicateFieldedSharedTypesVisitor) EnterObjectTypeDefinition(ref
 	object, exists := r.sharedTypeSet[name]
 	if exists {
 		if !object.areFieldsIdentical(refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			reportCompositionError(r.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name), ErrCodeSharedTypeMismatch),
+				r.document.NodePosition(ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref})))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref})
@@ -49,7 +49,8 @@ func (r *removeDuplicateFieldlessSharedTypesVisitor) EnterEnumTypeDefinition(ref
 	enum, exists := r.sharedTypeSet[name]
 	if exists {
 		if !enum.areValuesIdentical(r.document.EnumTypeDefinitions[ref].EnumValuesDefinition.Refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			reportCompositionError(r.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name), ErrCodeSharedTypeMismatch),
+				r.document.NodePosition(ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref})))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref})
@@ -81,7 +82,8 @@ func (r *removeDuplicateFieldlessSharedTypesVisitor) EnterUnionTypeDefinition(re
 	union, exists := r.sharedTypeSet[name]
 	if exists {
 		if !union.areValuesIdentical(r.document.UnionTypeDefinitions[ref].UnionMemberTypes.Refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			reportCompositionError(r.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name), ErrCodeSharedTypeMismatch),
+				r.document.NodePosition(ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref})))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref})
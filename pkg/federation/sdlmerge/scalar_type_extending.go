@@ -37,13 +37,15 @@ func (e *extendScalarTypeDefinitionVisitor) EnterScalarTypeExtension(ref int) {
 			continue
 		}
 		if hasExtended {
-			e.StopWithExternalErr(operationreport.ErrSharedTypesMustNotBeExtended(e.document.ScalarTypeExtensionNameString(ref)))
+			reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrSharedTypesMustNotBeExtended(e.document.ScalarTypeExtensionNameString(ref)), ErrCodeSharedTypeExtended),
+				e.document.NodePosition(ast.Node{Kind: ast.NodeKindScalarTypeExtension, Ref: ref})))
 			return
 		}
 		e.document.ExtendScalarTypeDefinitionByScalarTypeExtension(nodes[i].Ref, ref)
 		hasExtended = true
 	}
 	if !hasExtended {
-		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.ScalarTypeExtensionNameBytes(ref)))
+		reportCompositionError(e.Walker, withLocations(withCode(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.ScalarTypeExtensionNameBytes(ref)), ErrCodeExtensionOrphan),
+			e.document.NodePosition(ast.Node{Kind: ast.NodeKindScalarTypeExtension, Ref: ref})))
 	}
 }
@@ -1,6 +1,16 @@
 package sdlmerge
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
 
 func TestExtendScalarType(t *testing.T) {
 	t.Run("Scalar types can be extended", func(t *testing.T) {
@@ -26,4 +36,14 @@ func TestExtendScalarType(t *testing.T) {
 			extend scalar Badges @onScalar
 		`, unresolvedExtensionOrphansErrorMessage("Badges"))
 	})
+	t.Run("Unresolved scalar extension orphan error is located at the extension", func(t *testing.T) {
+		document := unsafeparser.ParseGraphqlDocumentString("scalar Attack\nextend scalar Badges @onScalar")
+		report := operationreport.Report{}
+		walker := astvisitor.NewWalker(48)
+		newExtendScalarTypeDefinition().Register(&walker)
+		walker.Walk(&document, nil, &report)
+
+		require.Len(t, report.ExternalErrors, 1)
+		assert.Equal(t, []graphqlerrors.Location{{Line: 2, Column: 15}}, report.ExternalErrors[0].Locations)
+	})
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -37,12 +38,23 @@ func MergeAST(ast *ast.Document) error {
 }
 
 func MergeSDLs(SDLs ...string) (string, error) {
+	return mergeSDLs(SDLs, false)
+}
+
+// mergeSDLs composes the subgraphs. If keepInaccessible is true, the elements marked @inaccessible
+// are kept in the composed schema together with the directive, e.g. to annotate a supergraph.
+func mergeSDLs(SDLs []string, keepInaccessible bool) (string, error) {
 	rawDocs := make([]string, 0, len(SDLs)+1)
 	rawDocs = append(rawDocs, rootOperationTypeDefinitions)
 	rawDocs = append(rawDocs, SDLs...)
+	// the subgraphs are validated before they are prepared, so that the errors refer to the original SDLs
 	if validationError := validateSubgraphs(rawDocs[1:]); validationError != nil {
 		return "", validationError
 	}
+	isFederationV2, err := prepareFederationV2Subgraphs(rawDocs[1:])
+	if err != nil {
+		return "", err
+	}
 	if normalizationError := normalizeSubgraphs(rawDocs[1:]); normalizationError != nil {
 		return "", normalizationError
 	}
@@ -57,7 +69,9 @@ func MergeSDLs(SDLs ...string) (string, error) {
 		return "", fmt.Errorf("merge ast: %w", report)
 	}
 
-	if err := MergeAST(&doc); err != nil {
+	normalizer := normalizer{federationV2: isFederationV2, keepInaccessible: keepInaccessible}
+	normalizer.setupWalkers()
+	if err := normalizer.normalize(&doc); err != nil {
 		return "", fmt.Errorf("merge ast: %w", err)
 	}
 
@@ -71,34 +85,47 @@ func MergeSDLs(SDLs ...string) (string, error) {
 
 func validateSubgraphs(subgraphs []string) error {
 	validator := astvalidation.NewDefinitionValidator(
-		astvalidation.PopulatedTypeBodies(), astvalidation.KnownTypeNames(),
+		astvalidation.PopulatedTypeBodies(),
+		astvalidation.KnownTypeNames(),
+		astvalidation.UniqueDirectiveNames(),
+		astvalidation.DirectivesAreInValidLocations(),
+		astvalidation.UniqueArgumentDefinitionNames(),
+		astvalidation.NoInputObjectCircularReferences(),
+		astvalidation.ValidTypeReferences(),
+		astvalidation.NamesAreNotReserved(),
 	)
-	for _, subgraph := range subgraphs {
+	var errs subgraphErrors
+	for i, subgraph := range subgraphs {
 		doc, report := astparser.ParseGraphqlDocumentString(subgraph)
 		if err := asttransform.MergeDefinitionWithBaseSchema(&doc); err != nil {
-			return err
+			errs.add(i, subgraph, err)
+			continue
 		}
 		if report.HasErrors() {
-			return fmt.Errorf(parseDocumentError, report)
+			errs.add(i, subgraph, fmt.Errorf(parseDocumentError, report))
+			continue
 		}
 		validator.Validate(&doc, &report)
 		if report.HasErrors() {
-			return fmt.Errorf("validate schema: %w", report)
+			errs.add(i, subgraph, fmt.Errorf("validate schema: %w", report))
 		}
 	}
-	return nil
+	return errs.errorOrNil()
 }
 
 func normalizeSubgraphs(subgraphs []string) error {
 	subgraphNormalizer := astnormalization.NewSubgraphDefinitionNormalizer()
+	var errs subgraphErrors
 	for i, subgraph := range subgraphs {
 		doc, report := astparser.ParseGraphqlDocumentString(subgraph)
 		if report.HasErrors() {
-			return fmt.Errorf(parseDocumentError, report)
+			errs.add(i, subgraph, fmt.Errorf(parseDocumentError, report))
+			continue
 		}
 		subgraphNormalizer.NormalizeDefinition(&doc, &report)
 		if report.HasErrors() {
-			return fmt.Errorf("normalize schema: %w", report)
+			errs.add(i, subgraph, fmt.Errorf("normalize schema: %w", report))
+			continue
 		}
 		out, err := astprinter.PrintString(&doc, nil)
 		if err != nil {
@@ -106,11 +133,15 @@ func normalizeSubgraphs(subgraphs []string) error {
 		}
 		subgraphs[i] = out
 	}
-	return nil
+	return errs.errorOrNil()
 }
 
 type normalizer struct {
 	walkers []*astvisitor.Walker
+	// federationV2 enables the composition rules of federation v2 like @shareable and @override
+	federationV2 bool
+	// keepInaccessible keeps the elements marked @inaccessible instead of removing them
+	keepInaccessible bool
 }
 
 type entitySet map[string]struct{}
@@ -131,16 +162,16 @@ func (m *normalizer) setupWalkers() {
 			newRemoveEmptyObjectTypeDefinition(),
 			newRemoveMergedTypeExtensions(),
 		},
-		// visitors for cleaning up federated duplicated fields and directives
-		{
-			newRemoveFieldDefinitions("external"),
-			newRemoveDuplicateFieldedSharedTypesVisitor(),
-			newRemoveDuplicateFieldlessSharedTypesVisitor(),
-			newMergeDuplicatedFieldsVisitor(),
-			newRemoveInterfaceDefinitionDirective("key"),
-			newRemoveObjectTypeDefinitionDirective("key"),
-			newRemoveFieldDefinitionDirective("provides", "requires"),
-		},
+	}
+	if m.federationV2 {
+		visitorGroups = append(visitorGroups, []Visitor{
+			newInterfaceObjectVisitor(),
+		})
+	}
+	// visitors for cleaning up federated duplicated fields and directives
+	visitorGroups = append(visitorGroups, m.cleanupVisitors())
+	if m.federationV2 {
+		visitorGroups = append(visitorGroups, m.compositionDirectivesVisitors())
 	}
 
 	for _, visitorGroup := range visitorGroups {
@@ -152,6 +183,43 @@ func (m *normalizer) setupWalkers() {
 	}
 }
 
+func (m *normalizer) cleanupVisitors() []Visitor {
+	visitors := []Visitor{
+		newRemoveFieldDefinitions("external"),
+	}
+	if m.federationV2 {
+		visitors = append(visitors, newShareableFieldsVisitor())
+	}
+	return append(visitors,
+		newRemoveDuplicateFieldedSharedTypesVisitor(),
+		newRemoveDuplicateFieldlessSharedTypesVisitor(),
+		newMergeDuplicatedFieldsVisitor(),
+		newRemoveInterfaceDefinitionDirective("key"),
+		newRemoveObjectTypeDefinitionDirective("key"),
+		newRemoveFieldDefinitionDirective("provides", "requires"),
+	)
+}
+
+// compositionDirectivesVisitors remove the elements marked @inaccessible and the directives which are only used for composition
+func (m *normalizer) compositionDirectivesVisitors() []Visitor {
+	if !m.keepInaccessible {
+		return []Visitor{
+			newRemoveInaccessibleVisitor(),
+			newRemoveDirectivesVisitor(federationV2CompositionDirectiveNames...),
+		}
+	}
+
+	directiveNames := make([]string, 0, len(federationV2CompositionDirectiveNames))
+	for _, name := range federationV2CompositionDirectiveNames {
+		if name != inaccessibleDirectiveName {
+			directiveNames = append(directiveNames, name)
+		}
+	}
+	return []Visitor{
+		newRemoveDirectivesVisitor(directiveNames...),
+	}
+}
+
 func (m *normalizer) normalize(operation *ast.Document) error {
 	report := operationreport.Report{}
 
@@ -172,17 +240,17 @@ func (e entitySet) isExtensionForEntity(nameBytes []byte, directiveRefs []int, d
 		if !hasDirectives || !isEntityExtension(directiveRefs, document) {
 			return false, nil
 		}
-		err := operationreport.ErrExtensionWithKeyDirectiveMustExtendEntity(name)
+		err := withCode(operationreport.ErrExtensionWithKeyDirectiveMustExtendEntity(name), ErrCodeExtensionWithKeyNotEntity)
 		return false, &err
 	}
 	if !hasDirectives {
-		err := operationreport.ErrEntityExtensionMustHaveKeyDirective(name)
+		err := withCode(operationreport.ErrEntityExtensionMustHaveKeyDirective(name), ErrCodeExtensionMissingKey)
 		return false, &err
 	}
 	if isEntityExtension(directiveRefs, document) {
 		return true, nil
 	}
-	err := operationreport.ErrEntityExtensionMustHaveKeyDirective(name)
+	err := withCode(operationreport.ErrEntityExtensionMustHaveKeyDirective(name), ErrCodeExtensionMissingKey)
 	return false, &err
 }
 
@@ -195,11 +263,11 @@ func isEntityExtension(directiveRefs []int, document *ast.Document) bool {
 	return false
 }
 
-func multipleExtensionError(isEntity bool, nameBytes []byte) *operationreport.ExternalError {
+func multipleExtensionError(isEntity bool, nameBytes []byte, pos position.Position) *operationreport.ExternalError {
 	if isEntity {
-		err := operationreport.ErrEntitiesMustNotBeDuplicated(string(nameBytes))
+		err := withLocations(withCode(operationreport.ErrEntitiesMustNotBeDuplicated(string(nameBytes)), ErrCodeEntityDuplicated), pos)
 		return &err
 	}
-	err := operationreport.ErrSharedTypesMustNotBeExtended(string(nameBytes))
+	err := withLocations(withCode(operationreport.ErrSharedTypesMustNotBeExtended(string(nameBytes)), ErrCodeSharedTypeExtended), pos)
 	return &err
 }
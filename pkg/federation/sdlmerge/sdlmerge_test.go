@@ -139,6 +139,21 @@ func TestMergeSDLs(t *testing.T) {
 		accountSchema, negativeTestingAccountSchema,
 	))
 
+	t.Run("Duplicate argument names should return an error", runMergeTestAndExpectError(
+		"argument 'Query.a(x:)' can only be defined once",
+		accountSchema, `type Query { a(x: Int, x: Int): String }`,
+	))
+
+	t.Run("Reserved field names should return an error", runMergeTestAndExpectError(
+		"name '__b' must not begin with '__', which is reserved by GraphQL introspection",
+		accountSchema, `type Query { a: String __b: String }`,
+	))
+
+	t.Run("Input object circular references should return an error", runMergeTestAndExpectError(
+		"cannot reference input object 'I' within itself through a series of non-null fields: 'i'",
+		accountSchema, `type Query { a(i: I): String } input I { i: I! }`,
+	))
+
 	t.Run("The first type encountered without a body should return an error", runMergeTestAndExpectError(
 		emptyTypeBodyErrorMessage("object", "Message"),
 		accountSchema, negativeTestingProductSchema,
@@ -245,7 +260,19 @@ const (
 			me: User
 		}
 
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 
 		scalar DateTime
 
@@ -270,7 +297,19 @@ const (
 			me: User
 		}
 
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 
 		scalar DateTime
 
@@ -326,7 +365,19 @@ const (
 			averageSatisfaction: Satisfaction!
 		}
 
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 	`
 
 	negativeTestingProductSchema = `
@@ -372,18 +423,41 @@ const (
 			content: String!
 		}
 
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 	`
 	reviewSchema = `
 		scalar DateTime
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 
 		input ReviewInput {
 			body: String!
-			author: User! @provides(fields: "username")
-			product: Product!
+			authorID: ID!
+			productUpc: String!
 			updated: DateTime!
-			inputType: AlphaNumeric!
 		}
 
 		type Review {
@@ -446,10 +520,9 @@ const (
 
 		input ReviewInput {
 			body: String!
-			author: User! @provides(fields: "username")
-			product: Product!
+			authorID: ID!
+			productUpc: String!
 			updated: DateTime!
-			inputType: AlphaNumeric!
 		}
 
 		type Review {
@@ -496,7 +569,19 @@ const (
 
 		scalar BigInt
 
-		union AlphaNumeric = BigInt | String
+		union AlphaNumeric = Numeric | Alpha
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 		
 		enum Satisfaction {
 			HAPPY,
@@ -584,7 +669,19 @@ const (
 
 		scalar DateTime
 
-		union AlphaNumeric = Int | String
+		union AlphaNumeric = Numeric | Alpha
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 
 		scalar BigInt
 
@@ -594,7 +691,7 @@ const (
 			amount: BigInt!
 		}
 		
-		extend union AlphaNumeric = Float
+		extend union AlphaNumeric = Decimal
 
 		enum Satisfaction {
 			HAPPY
@@ -602,7 +699,19 @@ const (
 		}
 	`
 	classicPaymentSchema = `
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 
 		scalar CustomScalar
 
@@ -625,7 +734,19 @@ const (
 			comments: [Comment]
 		}
 	
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 	
 		interface PaymentType @extends {
 			name: String!
@@ -649,7 +770,19 @@ const (
 			review: Review!
 		}
 
-		union AlphaNumeric = Int | String | Float
+		union AlphaNumeric = Numeric | Alpha | Decimal
+
+		type Alpha {
+			value: String!
+		}
+
+		type Numeric {
+			value: Int!
+		}
+
+		type Decimal {
+			value: Float!
+		}
 
 		scalar DateTime
 
@@ -696,10 +829,9 @@ const (
 
 		input ReviewInput {
 			body: String!
-			author: User! @provides(fields: "username")
-			product: Product!
+			authorID: ID!
+			productUpc: String!
 			updated: DateTime!
-			inputType: AlphaNumeric!
 		}
 		
 		type Review {
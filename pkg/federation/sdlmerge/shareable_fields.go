@@ -0,0 +1,110 @@
+package sdlmerge
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// shareableFieldsVisitor validates fields which are resolved by more than one subgraph.
+// Such fields must be @shareable in every subgraph unless one subgraph takes the field over with @override.
+// It must run after the extensions were merged and the @external fields were removed.
+type shareableFieldsVisitor struct {
+	*astvisitor.Walker
+	document *ast.Document
+}
+
+func newShareableFieldsVisitor() *shareableFieldsVisitor {
+	return &shareableFieldsVisitor{}
+}
+
+func (s *shareableFieldsVisitor) Register(walker *astvisitor.Walker) {
+	s.Walker = walker
+	walker.RegisterEnterDocumentVisitor(s)
+	walker.RegisterLeaveObjectTypeDefinitionVisitor(s)
+}
+
+func (s *shareableFieldsVisitor) EnterDocument(operation, _ *ast.Document) {
+	s.document = operation
+}
+
+func (s *shareableFieldsVisitor) LeaveObjectTypeDefinition(ref int) {
+	fieldRefsByName := make(map[string][]int)
+	var fieldNames []string
+	for _, fieldRef := range s.document.ObjectTypeDefinitions[ref].FieldsDefinition.Refs {
+		fieldName := s.document.FieldDefinitionNameString(fieldRef)
+		if _, exists := fieldRefsByName[fieldName]; !exists {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		fieldRefsByName[fieldName] = append(fieldRefsByName[fieldName], fieldRef)
+	}
+
+	var refsForDeletion []int
+	for _, fieldName := range fieldNames {
+		fieldRefs := fieldRefsByName[fieldName]
+		if len(fieldRefs) < 2 {
+			continue
+		}
+
+		var overridingRefs, inaccessibleRefs []int
+		allShareable := true
+		for _, fieldRef := range fieldRefs {
+			if s.document.FieldDefinitionHasNamedDirective(fieldRef, overrideDirectiveName) {
+				overridingRefs = append(overridingRefs, fieldRef)
+			}
+			if s.document.FieldDefinitionHasNamedDirective(fieldRef, inaccessibleDirectiveName) {
+				inaccessibleRefs = append(inaccessibleRefs, fieldRef)
+			}
+			if !s.document.FieldDefinitionHasNamedDirective(fieldRef, shareableDirectiveName) {
+				allShareable = false
+			}
+		}
+
+		typeName := s.document.ObjectTypeDefinitionNameString(ref)
+		switch {
+		case len(overridingRefs) > 1:
+			reportCompositionError(s.Walker, withLocations(withCode(operationreport.ErrFieldMustNotBeOverriddenMultipleTimes(fieldName, typeName), ErrCodeOverrideCollision), s.fieldPositions(overridingRefs)...))
+			continue
+		case len(overridingRefs) == 1:
+			// the overridden fields are no longer resolved by their subgraphs
+			refsForDeletion = append(refsForDeletion, without(fieldRefs, overridingRefs[0])...)
+			continue
+		case !allShareable:
+			reportCompositionError(s.Walker, withLocations(withCode(operationreport.ErrFieldMustBeShareable(fieldName, typeName), ErrCodeInvalidFieldSharing), s.fieldPositions(fieldRefs)...))
+			continue
+		}
+
+		// a field which is @inaccessible in one subgraph is @inaccessible in the supergraph,
+		// so the first remaining field, which is kept when merging the duplicates, has to carry the directive
+		if len(inaccessibleRefs) > 0 {
+			refsForDeletion = append(refsForDeletion, without(fieldRefs, inaccessibleRefs...)...)
+		}
+	}
+
+	s.document.RemoveFieldDefinitionsFromObjectTypeDefinition(refsForDeletion, ref)
+}
+
+func (s *shareableFieldsVisitor) fieldPositions(fieldRefs []int) []position.Position {
+	positions := make([]position.Position, 0, len(fieldRefs))
+	for _, fieldRef := range fieldRefs {
+		positions = append(positions, s.document.NodePosition(ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: fieldRef}))
+	}
+	return positions
+}
+
+func without(refs []int, excluded ...int) (out []int) {
+	for _, ref := range refs {
+		isExcluded := false
+		for _, excludedRef := range excluded {
+			if ref == excludedRef {
+				isExcluded = true
+				break
+			}
+		}
+		if !isExcluded {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
@@ -0,0 +1,537 @@
+package sdlmerge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+)
+
+const (
+	linkSpecURL         = "https://specs.apollo.dev/link/v1.0"
+	joinSpecURL         = "https://specs.apollo.dev/join/v0.3"
+	inaccessibleSpecURL = "https://specs.apollo.dev/inaccessible/v0.2"
+
+	joinGraphEnumName            = "join__Graph"
+	joinGraphDirectiveName       = "join__graph"
+	joinTypeDirectiveName        = "join__type"
+	joinFieldDirectiveName       = "join__field"
+	joinImplementsDirectiveName  = "join__implements"
+	joinUnionMemberDirectiveName = "join__unionMember"
+	joinEnumValueDirectiveName   = "join__enumValue"
+
+	joinPrefix = "join__"
+	linkPrefix = "link__"
+
+	supergraphDefinitions = `
+		directive @link(url: String, as: String, for: link__Purpose, import: [link__Import]) repeatable on SCHEMA
+		directive @join__graph(name: String!, url: String!) on ENUM_VALUE
+		directive @join__type(graph: join__Graph!, key: join__FieldSet, extension: Boolean! = false, resolvable: Boolean! = true, isInterfaceObject: Boolean! = false) repeatable on OBJECT | INTERFACE | UNION | ENUM | INPUT_OBJECT | SCALAR
+		directive @join__field(graph: join__Graph, requires: join__FieldSet, provides: join__FieldSet, type: String, external: Boolean, override: String, usedOverridden: Boolean) repeatable on FIELD_DEFINITION | INPUT_FIELD_DEFINITION
+		directive @join__implements(graph: join__Graph!, interface: String!) repeatable on OBJECT | INTERFACE
+		directive @join__unionMember(graph: join__Graph!, member: String!) repeatable on UNION
+		directive @join__enumValue(graph: join__Graph!) repeatable on ENUM_VALUE
+		scalar join__FieldSet
+		scalar link__Import
+		enum link__Purpose {
+			SECURITY
+			EXECUTION
+		}
+	`
+
+	// inaccessibleDirectiveDefinition is part of a supergraph with elements marked @inaccessible
+	inaccessibleDirectiveDefinition = `
+		directive @inaccessible on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION
+	`
+)
+
+// supergraphDirectiveNames are the directives of the link, join and inaccessible specs which are part of a supergraph.
+var supergraphDirectiveNames = []string{
+	linkDirectiveName,
+	inaccessibleDirectiveName,
+	joinGraphDirectiveName,
+	joinTypeDirectiveName,
+	joinFieldDirectiveName,
+	joinImplementsDirectiveName,
+	joinUnionMemberDirectiveName,
+	joinEnumValueDirectiveName,
+}
+
+// Subgraph is a named federation subgraph.
+type Subgraph struct {
+	// Name identifies the subgraph, e.g. in the join__Graph enum of a supergraph.
+	Name string
+	// URL is the routing URL of the subgraph.
+	URL string
+	// SDL is the federation SDL of the subgraph.
+	SDL string
+}
+
+// MergeSupergraph composes the subgraphs like MergeSDLs does and annotates the composed schema
+// with the directives of the Apollo join spec. The result is a supergraph SDL which records
+// which subgraph resolves which types and fields, so it can be shipped to a gateway instead
+// of the individual subgraph SDLs. Composition failures are reported as CompositionErrors.
+// Elements marked @inaccessible are kept in the supergraph with the directive, ParseSupergraph removes them from the API schema.
+func MergeSupergraph(subgraphs ...Subgraph) (string, error) {
+	graphEnumValues, err := joinGraphEnumValues(subgraphs)
+	if err != nil {
+		return "", err
+	}
+
+	SDLs := make([]string, len(subgraphs))
+	for i := range subgraphs {
+		SDLs[i] = subgraphs[i].SDL
+	}
+
+	// the supergraph keeps the @inaccessible elements, so that the subgraphs can still resolve them, e.g. for @requires.
+	// Removing them from the composed schema validates the API schema, the errors are reported like MergeSubgraphs does.
+	composed, err := mergeSDLs(SDLs, true)
+	if err != nil {
+		return "", newCompositionErrors(subgraphs, err)
+	}
+	if _, err := supergraphAPISchema(composed); err != nil {
+		return "", newCompositionErrors(subgraphs, err)
+	}
+
+	// the federation directives of v2 subgraphs can be renamed by @link,
+	// so the subgraphs are prepared to look up the directives by their canonical names
+	preparedSDLs := make([]string, len(SDLs))
+	copy(preparedSDLs, SDLs)
+	if _, err := prepareFederationV2Subgraphs(preparedSDLs); err != nil {
+		return "", err
+	}
+
+	graphs := make([]*joinGraph, len(subgraphs))
+	for i := range subgraphs {
+		graphs[i], err = newJoinGraph(graphEnumValues[i], subgraphs[i].Name, SDLs[i], preparedSDLs[i])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	doc, report := astparser.ParseGraphqlDocumentString(composed)
+	if report.HasErrors() {
+		return "", fmt.Errorf(parseDocumentError, report)
+	}
+
+	annotator := joinAnnotator{document: &doc, graphs: graphs}
+	annotator.annotate()
+
+	annotated, err := astprinter.PrintString(&doc, nil)
+	if err != nil {
+		return "", fmt.Errorf("stringify schema: %w", err)
+	}
+
+	hasInaccessible := hasInaccessibleDirective(&doc)
+	definitions := supergraphDefinitions
+	if hasInaccessible {
+		definitions += inaccessibleDirectiveDefinition
+	}
+
+	supergraph := strings.Join([]string{
+		supergraphSchemaDefinition(&doc, hasInaccessible),
+		definitions,
+		joinGraphEnumDefinition(graphs, subgraphs),
+		annotated,
+	}, "\n")
+
+	supergraphDoc, report := astparser.ParseGraphqlDocumentString(supergraph)
+	if report.HasErrors() {
+		return "", fmt.Errorf(parseDocumentError, report)
+	}
+
+	out, err := astprinter.PrintStringIndent(&supergraphDoc, nil, "  ")
+	if err != nil {
+		return "", fmt.Errorf("stringify schema: %w", err)
+	}
+
+	return out, nil
+}
+
+// joinGraphEnumValues derives the values of the join__Graph enum from the subgraph names.
+// Characters which are not allowed in enum values are replaced with an underscore.
+func joinGraphEnumValues(subgraphs []Subgraph) ([]string, error) {
+	names := make(map[string]struct{}, len(subgraphs))
+	enumValues := make(map[string]struct{}, len(subgraphs))
+	values := make([]string, len(subgraphs))
+
+	for i, subgraph := range subgraphs {
+		if subgraph.Name == "" {
+			return nil, fmt.Errorf("subgraph at index %d has no name", i)
+		}
+		if _, exists := names[subgraph.Name]; exists {
+			return nil, fmt.Errorf("subgraph name %q is not unique", subgraph.Name)
+		}
+		names[subgraph.Name] = struct{}{}
+
+		value := []byte(strings.ToUpper(subgraph.Name))
+		for j, char := range value {
+			if (char < 'A' || char > 'Z') && (char < '0' || char > '9') {
+				value[j] = '_'
+			}
+		}
+		if value[0] >= '0' && value[0] <= '9' {
+			value = append([]byte("_"), value...)
+		}
+
+		enumValue := string(value)
+		for suffix := 1; ; suffix++ {
+			if _, exists := enumValues[enumValue]; !exists {
+				break
+			}
+			enumValue = fmt.Sprintf("%s_%d", value, suffix)
+		}
+		enumValues[enumValue] = struct{}{}
+		values[i] = enumValue
+	}
+
+	return values, nil
+}
+
+func supergraphSchemaDefinition(document *ast.Document, hasInaccessible bool) string {
+	var rootOperationTypes strings.Builder
+	for _, operation := range []struct{ operation, typeName string }{
+		{"query", "Query"},
+		{"mutation", "Mutation"},
+		{"subscription", "Subscription"},
+	} {
+		if _, exists := document.Index.FirstNodeByNameStr(operation.typeName); exists {
+			rootOperationTypes.WriteString(fmt.Sprintf("%s: %s\n", operation.operation, operation.typeName))
+		}
+	}
+
+	links := fmt.Sprintf("@link(url: %q) @link(url: %q, for: EXECUTION)", linkSpecURL, joinSpecURL)
+	if hasInaccessible {
+		links += fmt.Sprintf(" @link(url: %q, for: SECURITY)", inaccessibleSpecURL)
+	}
+	return fmt.Sprintf("schema %s {\n%s}", links, rootOperationTypes.String())
+}
+
+// hasInaccessibleDirective returns true if any element of the composed schema is marked @inaccessible
+func hasInaccessibleDirective(document *ast.Document) bool {
+	for i := range document.Directives {
+		if document.DirectiveNameString(i) == inaccessibleDirectiveName {
+			return true
+		}
+	}
+	return false
+}
+
+func joinGraphEnumDefinition(graphs []*joinGraph, subgraphs []Subgraph) string {
+	values := make([]string, len(graphs))
+	for i := range graphs {
+		values[i] = fmt.Sprintf("%s @%s(name: %q, url: %q)", graphs[i].enumValue, joinGraphDirectiveName, subgraphs[i].Name, subgraphs[i].URL)
+	}
+	return fmt.Sprintf("enum %s {\n%s\n}", joinGraphEnumName, strings.Join(values, "\n"))
+}
+
+// joinGraph holds what a single subgraph contributes to the composed schema.
+type joinGraph struct {
+	enumValue string
+	name      string
+	types     map[string]*joinGraphType
+}
+
+type joinGraphType struct {
+	keys            []string
+	extension       bool
+	interfaceObject bool
+	fields          map[string]joinGraphField
+	interfaces      []string
+	unionMembers    []string
+}
+
+type joinGraphField struct {
+	external bool
+	requires string
+	provides string
+	override string
+}
+
+func (f joinGraphField) hasDirectives() bool {
+	return f.external || f.requires != "" || f.provides != "" || f.override != ""
+}
+
+// newJoinGraph collects the types and fields of a subgraph. Whether a type is an extension is
+// looked up in the original SDL, all federation directives are looked up in the prepared SDL.
+func newJoinGraph(enumValue, name, SDL, preparedSDL string) (*joinGraph, error) {
+	extensions := make(map[string]struct{})
+	original, report := astparser.ParseGraphqlDocumentString(SDL)
+	if report.HasErrors() {
+		return nil, fmt.Errorf(parseDocumentError, report)
+	}
+	for _, node := range original.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindObjectTypeExtension, ast.NodeKindInterfaceTypeExtension:
+			extensions[original.NodeNameString(node)] = struct{}{}
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition:
+			if hasDirective(&original, original.NodeDirectives(node), extendsDirectiveName) {
+				extensions[original.NodeNameString(node)] = struct{}{}
+			}
+		}
+	}
+
+	doc, report := astparser.ParseGraphqlDocumentString(preparedSDL)
+	if report.HasErrors() {
+		return nil, fmt.Errorf(parseDocumentError, report)
+	}
+
+	graph := &joinGraph{
+		enumValue: enumValue,
+		name:      name,
+		types:     make(map[string]*joinGraphType),
+	}
+	for _, node := range doc.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindObjectTypeExtension,
+			ast.NodeKindInterfaceTypeDefinition, ast.NodeKindInterfaceTypeExtension,
+			ast.NodeKindUnionTypeDefinition, ast.NodeKindUnionTypeExtension,
+			ast.NodeKindEnumTypeDefinition, ast.NodeKindEnumTypeExtension,
+			ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension,
+			ast.NodeKindScalarTypeDefinition, ast.NodeKindScalarTypeExtension:
+		default:
+			continue
+		}
+
+		typeName := doc.NodeNameString(node)
+		graphType, exists := graph.types[typeName]
+		if !exists {
+			graphType = &joinGraphType{fields: make(map[string]joinGraphField)}
+			graph.types[typeName] = graphType
+		}
+		if _, isExtension := extensions[typeName]; isExtension {
+			graphType.extension = true
+		}
+
+		directiveRefs := doc.NodeDirectives(node)
+		for _, directiveRef := range directiveRefs {
+			if doc.DirectiveNameString(directiveRef) != plan.FederationKeyDirectiveName {
+				continue
+			}
+			if fields, ok := directiveArgumentString(&doc, directiveRef, "fields"); ok {
+				graphType.keys = append(graphType.keys, fields)
+			}
+		}
+		if hasDirective(&doc, directiveRefs, interfaceObjectDirectiveName) {
+			graphType.interfaceObject = true
+		}
+		for _, typeRef := range doc.NodeInterfaceRefs(node) {
+			graphType.interfaces = append(graphType.interfaces, doc.ResolveTypeNameString(typeRef))
+		}
+		if node.Kind == ast.NodeKindInterfaceTypeDefinition {
+			for _, typeRef := range doc.InterfaceTypeDefinitions[node.Ref].ImplementsInterfaces.Refs {
+				graphType.interfaces = append(graphType.interfaces, doc.ResolveTypeNameString(typeRef))
+			}
+		}
+		for _, typeRef := range doc.NodeUnionMemberRefs(node) {
+			graphType.unionMembers = append(graphType.unionMembers, doc.ResolveTypeNameString(typeRef))
+		}
+
+		for _, fieldRef := range doc.NodeFieldDefinitions(node) {
+			var field joinGraphField
+			for _, directiveRef := range doc.FieldDefinitions[fieldRef].Directives.Refs {
+				switch doc.DirectiveNameString(directiveRef) {
+				case externalDirectiveName:
+					field.external = true
+				case requiresDirectiveName:
+					field.requires, _ = directiveArgumentString(&doc, directiveRef, "fields")
+				case providesDirectiveName:
+					field.provides, _ = directiveArgumentString(&doc, directiveRef, "fields")
+				case overrideDirectiveName:
+					field.override, _ = directiveArgumentString(&doc, directiveRef, "from")
+				}
+			}
+			graphType.fields[doc.FieldDefinitionNameString(fieldRef)] = field
+		}
+	}
+
+	return graph, nil
+}
+
+// joinAnnotator adds the join__ directives to a composed schema.
+type joinAnnotator struct {
+	document *ast.Document
+	graphs   []*joinGraph
+}
+
+func (j *joinAnnotator) annotate() {
+	for _, node := range j.document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition, ast.NodeKindUnionTypeDefinition,
+			ast.NodeKindEnumTypeDefinition, ast.NodeKindInputObjectTypeDefinition, ast.NodeKindScalarTypeDefinition:
+		default:
+			continue
+		}
+
+		typeName := j.document.NodeNameString(node)
+		var graphs []*joinGraph
+		for _, graph := range j.graphs {
+			if _, exists := graph.types[typeName]; exists {
+				graphs = append(graphs, graph)
+			}
+		}
+
+		for _, graph := range graphs {
+			j.addJoinTypeDirectives(node, graph, graph.types[typeName])
+		}
+
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition:
+			j.addJoinImplementsDirectives(node, typeName, graphs)
+			j.addJoinFieldDirectives(node, typeName, graphs)
+		case ast.NodeKindUnionTypeDefinition:
+			for _, graph := range graphs {
+				for _, member := range graph.types[typeName].unionMembers {
+					j.addDirective(node, j.joinDirective(joinUnionMemberDirectiveName, graph,
+						j.stringArgument("member", member),
+					))
+				}
+			}
+		}
+	}
+}
+
+func (j *joinAnnotator) addJoinTypeDirectives(node ast.Node, graph *joinGraph, graphType *joinGraphType) {
+	var arguments []int
+	if graphType.extension {
+		arguments = append(arguments, j.booleanArgument("extension", true))
+	}
+	if graphType.interfaceObject {
+		arguments = append(arguments, j.booleanArgument("isInterfaceObject", true))
+	}
+
+	if len(graphType.keys) == 0 {
+		j.addDirective(node, j.joinDirective(joinTypeDirectiveName, graph, arguments...))
+		return
+	}
+
+	for _, key := range graphType.keys {
+		keyArguments := append([]int{j.stringArgument("key", key)}, arguments...)
+		j.addDirective(node, j.joinDirective(joinTypeDirectiveName, graph, keyArguments...))
+	}
+}
+
+func (j *joinAnnotator) addJoinImplementsDirectives(node ast.Node, typeName string, graphs []*joinGraph) {
+	for _, graph := range graphs {
+		for _, interfaceName := range graph.types[typeName].interfaces {
+			j.addDirective(node, j.joinDirective(joinImplementsDirectiveName, graph,
+				j.stringArgument("interface", interfaceName),
+			))
+		}
+	}
+}
+
+// addJoinFieldDirectives adds a join__field directive per subgraph resolving the field.
+// Fields of types which are resolved by a single subgraph only get the directive if they
+// carry federation directives in the subgraph.
+func (j *joinAnnotator) addJoinFieldDirectives(node ast.Node, typeName string, graphs []*joinGraph) {
+	overridden := make(map[string]string)
+	for _, graph := range graphs {
+		for fieldName, field := range graph.types[typeName].fields {
+			if field.override != "" {
+				overridden[fieldName] = field.override
+			}
+		}
+	}
+
+	for _, fieldRef := range j.document.NodeFieldDefinitions(node) {
+		fieldName := j.document.FieldDefinitionNameString(fieldRef)
+		fieldNode := ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: fieldRef}
+
+		for _, graph := range graphs {
+			field, exists := graph.types[typeName].fields[fieldName]
+			if !exists || overridden[fieldName] == graph.name {
+				continue
+			}
+			if len(graphs) == 1 && !field.hasDirectives() {
+				continue
+			}
+
+			var arguments []int
+			if field.requires != "" {
+				arguments = append(arguments, j.stringArgument("requires", field.requires))
+			}
+			if field.provides != "" {
+				arguments = append(arguments, j.stringArgument("provides", field.provides))
+			}
+			if field.external {
+				arguments = append(arguments, j.booleanArgument("external", true))
+			}
+			if field.override != "" {
+				arguments = append(arguments, j.stringArgument("override", field.override))
+			}
+			j.addDirective(fieldNode, j.joinDirective(joinFieldDirectiveName, graph, arguments...))
+		}
+	}
+}
+
+func (j *joinAnnotator) joinDirective(name string, graph *joinGraph, arguments ...int) int {
+	graphArgument := j.document.ImportArgument("graph", ast.Value{
+		Kind: ast.ValueKindEnum,
+		Ref:  j.document.ImportEnumValue([]byte(graph.enumValue)),
+	})
+	return j.document.ImportDirective(name, append([]int{graphArgument}, arguments...))
+}
+
+func (j *joinAnnotator) stringArgument(name, value string) int {
+	return j.document.ImportArgument(name, ast.Value{
+		Kind: ast.ValueKindString,
+		Ref:  j.document.ImportStringValue([]byte(value), false),
+	})
+}
+
+func (j *joinAnnotator) booleanArgument(name string, value bool) int {
+	ref := 0
+	if value {
+		ref = 1
+	}
+	return j.document.ImportArgument(name, ast.Value{Kind: ast.ValueKindBoolean, Ref: ref})
+}
+
+func (j *joinAnnotator) addDirective(node ast.Node, directiveRef int) {
+	directives, hasDirectives := typeSystemNodeDirectives(j.document, node)
+	if directives == nil {
+		return
+	}
+	directives.Refs = append(directives.Refs, directiveRef)
+	*hasDirectives = true
+}
+
+// typeSystemNodeDirectives returns the directive list of a type system definition to modify it in place.
+func typeSystemNodeDirectives(document *ast.Document, node ast.Node) (*ast.DirectiveList, *bool) {
+	switch node.Kind {
+	case ast.NodeKindSchemaDefinition:
+		return &document.SchemaDefinitions[node.Ref].Directives, &document.SchemaDefinitions[node.Ref].HasDirectives
+	case ast.NodeKindObjectTypeDefinition:
+		return &document.ObjectTypeDefinitions[node.Ref].Directives, &document.ObjectTypeDefinitions[node.Ref].HasDirectives
+	case ast.NodeKindObjectTypeExtension:
+		return &document.ObjectTypeExtensions[node.Ref].Directives, &document.ObjectTypeExtensions[node.Ref].HasDirectives
+	case ast.NodeKindInterfaceTypeDefinition:
+		return &document.InterfaceTypeDefinitions[node.Ref].Directives, &document.InterfaceTypeDefinitions[node.Ref].HasDirectives
+	case ast.NodeKindInterfaceTypeExtension:
+		return &document.InterfaceTypeExtensions[node.Ref].Directives, &document.InterfaceTypeExtensions[node.Ref].HasDirectives
+	case ast.NodeKindUnionTypeDefinition:
+		return &document.UnionTypeDefinitions[node.Ref].Directives, &document.UnionTypeDefinitions[node.Ref].HasDirectives
+	case ast.NodeKindEnumTypeDefinition:
+		return &document.EnumTypeDefinitions[node.Ref].Directives, &document.EnumTypeDefinitions[node.Ref].HasDirectives
+	case ast.NodeKindInputObjectTypeDefinition:
+		return &document.InputObjectTypeDefinitions[node.Ref].Directives, &document.InputObjectTypeDefinitions[node.Ref].HasDirectives
+	case ast.NodeKindScalarTypeDefinition:
+		return &document.ScalarTypeDefinitions[node.Ref].Directives, &document.ScalarTypeDefinitions[node.Ref].HasDirectives
+	case ast.NodeKindFieldDefinition:
+		return &document.FieldDefinitions[node.Ref].Directives, &document.FieldDefinitions[node.Ref].HasDirectives
+	default:
+		return nil, nil
+	}
+}
+
+func directiveArgumentString(document *ast.Document, directiveRef int, argumentName string) (string, bool) {
+	value, ok := document.DirectiveArgumentValueByName(directiveRef, []byte(argumentName))
+	if !ok || value.Kind != ast.ValueKindString {
+		return "", false
+	}
+	return document.StringValueContentString(value.Ref), true
+}
@@ -0,0 +1,380 @@
+package sdlmerge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+var ErrMissingJoinGraphEnum = errors.New("supergraph has no join__Graph enum")
+
+// Supergraph is a parsed supergraph SDL.
+type Supergraph struct {
+	// APISchema is the composed schema without the definitions and directives of the link and join specs
+	// and without the elements marked @inaccessible.
+	APISchema string
+	// Subgraphs are the subgraphs of the join__Graph enum. The SDL of each subgraph is rebuilt from
+	// the join__ directives as a federation v1 SDL containing the types and fields the subgraph resolves.
+	Subgraphs []Subgraph
+}
+
+// ParseSupergraph parses a supergraph SDL annotated with the directives of the Apollo join spec,
+// like the ones created by MergeSupergraph.
+func ParseSupergraph(supergraphSDL string) (*Supergraph, error) {
+	doc, report := astparser.ParseGraphqlDocumentString(supergraphSDL)
+	if report.HasErrors() {
+		return nil, fmt.Errorf(parseDocumentError, report)
+	}
+
+	subgraphs, graphNames, err := supergraphSubgraphs(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	apiSchema, err := supergraphAPISchema(supergraphSDL)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subgraphs {
+		if subgraphs[i].SDL, err = supergraphSubgraphSDL(supergraphSDL, graphNames[i]); err != nil {
+			return nil, fmt.Errorf("extract subgraph %q: %w", subgraphs[i].Name, err)
+		}
+	}
+
+	return &Supergraph{
+		APISchema: apiSchema,
+		Subgraphs: subgraphs,
+	}, nil
+}
+
+// supergraphSubgraphs returns the subgraphs and the graph enum values of the join__Graph enum.
+func supergraphSubgraphs(document *ast.Document) (subgraphs []Subgraph, graphNames []string, err error) {
+	node, exists := document.Index.FirstNodeByNameStr(joinGraphEnumName)
+	if !exists || node.Kind != ast.NodeKindEnumTypeDefinition {
+		return nil, nil, ErrMissingJoinGraphEnum
+	}
+
+	for _, valueRef := range document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs {
+		graphName := document.EnumValueDefinitionNameString(valueRef)
+		subgraph := Subgraph{Name: graphName}
+		for _, directiveRef := range document.EnumValueDefinitions[valueRef].Directives.Refs {
+			if document.DirectiveNameString(directiveRef) != joinGraphDirectiveName {
+				continue
+			}
+			if name, ok := directiveArgumentString(document, directiveRef, "name"); ok {
+				subgraph.Name = name
+			}
+			subgraph.URL, _ = directiveArgumentString(document, directiveRef, "url")
+		}
+		subgraphs = append(subgraphs, subgraph)
+		graphNames = append(graphNames, graphName)
+	}
+
+	return subgraphs, graphNames, nil
+}
+
+// supergraphAPISchema removes everything belonging to the link and join specs
+// as well as the elements marked @inaccessible from the supergraph.
+func supergraphAPISchema(supergraphSDL string) (string, error) {
+	doc, report := astparser.ParseGraphqlDocumentString(supergraphSDL)
+	if report.HasErrors() {
+		return "", fmt.Errorf(parseDocumentError, report)
+	}
+
+	removeSupergraphDefinitions(&doc)
+
+	walker := astvisitor.NewWalker(48)
+	newRemoveInaccessibleVisitor().Register(&walker)
+	walker.Walk(&doc, nil, &report)
+	if report.HasErrors() {
+		return "", fmt.Errorf("walk: %w", report)
+	}
+
+	return printWithoutSupergraphDirectives(&doc)
+}
+
+// supergraphSubgraphSDL rebuilds the SDL of a single subgraph:
+//
+//   - types without a join__type for the graph are removed, types without any join__type belong to all graphs
+//   - the keys of join__type become @key directives, join__type(extension: true) turns the type into an extension
+//   - fields with join__field directives are only kept for the graphs of these directives
+//     and get @external, @requires and @provides from the join__field arguments
+//   - interfaces and union members are filtered by join__implements and join__unionMember
+//   - elements marked @inaccessible are kept without the directive, because the subgraph still resolves them
+func supergraphSubgraphSDL(supergraphSDL, graphName string) (string, error) {
+	doc, report := astparser.ParseGraphqlDocumentString(supergraphSDL)
+	if report.HasErrors() {
+		return "", fmt.Errorf(parseDocumentError, report)
+	}
+
+	removeSupergraphDefinitions(&doc)
+
+	extractor := subgraphExtractor{document: &doc, graphName: graphName}
+	extractor.extract()
+
+	return printWithoutSupergraphDirectives(&doc)
+}
+
+type subgraphExtractor struct {
+	document  *ast.Document
+	graphName string
+}
+
+func (s *subgraphExtractor) extract() {
+	rootNodes := make([]ast.Node, 0, len(s.document.RootNodes))
+	for _, node := range s.document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition, ast.NodeKindUnionTypeDefinition,
+			ast.NodeKindEnumTypeDefinition, ast.NodeKindInputObjectTypeDefinition, ast.NodeKindScalarTypeDefinition:
+		default:
+			rootNodes = append(rootNodes, node)
+			continue
+		}
+
+		directives, _ := typeSystemNodeDirectives(s.document, node)
+		directiveRefs := directives.Refs
+		joinTypeRefs := s.directivesByName(directiveRefs, joinTypeDirectiveName)
+		graphJoinTypeRefs := s.directivesOfGraph(joinTypeRefs)
+		if len(joinTypeRefs) > 0 && len(graphJoinTypeRefs) == 0 {
+			continue
+		}
+
+		isExtension := false
+		for _, directiveRef := range graphJoinTypeRefs {
+			if s.directiveArgumentBool(directiveRef, "extension") {
+				isExtension = true
+			}
+			if key, ok := directiveArgumentString(s.document, directiveRef, "key"); ok {
+				s.addDirective(node, plan.FederationKeyDirectiveName, "fields", key)
+			}
+		}
+
+		// the directives have to be removed before the type is copied into an extension
+		s.removeSupergraphDirectives(directives, node)
+
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition:
+			objectType := &s.document.ObjectTypeDefinitions[node.Ref]
+			objectType.ImplementsInterfaces.Refs = s.filterTypeRefs(objectType.ImplementsInterfaces.Refs, directiveRefs, joinImplementsDirectiveName, "interface")
+			objectType.FieldsDefinition.Refs = s.filterFields(objectType.FieldsDefinition.Refs)
+			objectType.HasFieldDefinitions = len(objectType.FieldsDefinition.Refs) > 0
+			if isExtension {
+				ref := s.document.AddObjectTypeDefinitionExtension(ast.ObjectTypeExtension{ObjectTypeDefinition: *objectType})
+				node = ast.Node{Kind: ast.NodeKindObjectTypeExtension, Ref: ref}
+			}
+		case ast.NodeKindInterfaceTypeDefinition:
+			interfaceType := &s.document.InterfaceTypeDefinitions[node.Ref]
+			interfaceType.ImplementsInterfaces.Refs = s.filterTypeRefs(interfaceType.ImplementsInterfaces.Refs, directiveRefs, joinImplementsDirectiveName, "interface")
+			interfaceType.FieldsDefinition.Refs = s.filterFields(interfaceType.FieldsDefinition.Refs)
+			interfaceType.HasFieldDefinitions = len(interfaceType.FieldsDefinition.Refs) > 0
+			if isExtension {
+				ref := s.document.AddInterfaceTypeExtension(ast.InterfaceTypeExtension{InterfaceTypeDefinition: *interfaceType})
+				node = ast.Node{Kind: ast.NodeKindInterfaceTypeExtension, Ref: ref}
+			}
+		case ast.NodeKindUnionTypeDefinition:
+			unionType := &s.document.UnionTypeDefinitions[node.Ref]
+			unionType.UnionMemberTypes.Refs = s.filterTypeRefs(unionType.UnionMemberTypes.Refs, directiveRefs, joinUnionMemberDirectiveName, "member")
+			unionType.HasUnionMemberTypes = len(unionType.UnionMemberTypes.Refs) > 0
+		}
+
+		rootNodes = append(rootNodes, node)
+	}
+	s.document.RootNodes = rootNodes
+	s.filterSchemaDefinition()
+}
+
+// filterSchemaDefinition removes the root operation types the graph doesn't define from the schema definition.
+// A schema definition with the default root operation type names only is removed entirely.
+func (s *subgraphExtractor) filterSchemaDefinition() {
+	typeNames := make(map[string]struct{}, len(s.document.RootNodes))
+	for _, node := range s.document.RootNodes {
+		typeNames[s.document.NodeNameString(node)] = struct{}{}
+	}
+
+	for i, node := range s.document.RootNodes {
+		if node.Kind != ast.NodeKindSchemaDefinition {
+			continue
+		}
+
+		schema := &s.document.SchemaDefinitions[node.Ref]
+		refs := make([]int, 0, len(schema.RootOperationTypeDefinitions.Refs))
+		hasCustomTypeNames := schema.HasDirectives
+		for _, ref := range schema.RootOperationTypeDefinitions.Refs {
+			typeName := s.document.Input.ByteSliceString(s.document.RootOperationTypeDefinitions[ref].NamedType.Name)
+			if _, exists := typeNames[typeName]; !exists {
+				continue
+			}
+			if !ast.IsRootType([]byte(typeName)) {
+				hasCustomTypeNames = true
+			}
+			refs = append(refs, ref)
+		}
+		schema.RootOperationTypeDefinitions.Refs = refs
+
+		if !hasCustomTypeNames {
+			s.document.RootNodes = append(s.document.RootNodes[:i], s.document.RootNodes[i+1:]...)
+		}
+		return
+	}
+}
+
+// filterFields removes the fields which are not resolved by the graph and
+// turns the arguments of join__field into federation directives.
+func (s *subgraphExtractor) filterFields(fieldRefs []int) []int {
+	filtered := make([]int, 0, len(fieldRefs))
+	for _, fieldRef := range fieldRefs {
+		fieldNode := ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: fieldRef}
+		joinFieldRefs := s.directivesByName(s.document.FieldDefinitions[fieldRef].Directives.Refs, joinFieldDirectiveName)
+		graphJoinFieldRefs := s.directivesOfGraph(joinFieldRefs)
+		if len(joinFieldRefs) > 0 && len(graphJoinFieldRefs) == 0 {
+			continue
+		}
+
+		for _, directiveRef := range graphJoinFieldRefs {
+			if s.directiveArgumentBool(directiveRef, "external") {
+				s.addDirective(fieldNode, externalDirectiveName, "", "")
+			}
+			if requires, ok := directiveArgumentString(s.document, directiveRef, "requires"); ok {
+				s.addDirective(fieldNode, requiresDirectiveName, "fields", requires)
+			}
+			if provides, ok := directiveArgumentString(s.document, directiveRef, "provides"); ok {
+				s.addDirective(fieldNode, providesDirectiveName, "fields", provides)
+			}
+		}
+		filtered = append(filtered, fieldRef)
+	}
+	return filtered
+}
+
+// filterTypeRefs keeps the interfaces or union members listed by the join directives of the graph.
+// Without any join directive all type refs are kept.
+func (s *subgraphExtractor) filterTypeRefs(typeRefs, directiveRefs []int, directiveName, argumentName string) []int {
+	joinRefs := s.directivesByName(directiveRefs, directiveName)
+	if len(joinRefs) == 0 {
+		return typeRefs
+	}
+
+	names := make(map[string]struct{})
+	for _, directiveRef := range s.directivesOfGraph(joinRefs) {
+		if name, ok := directiveArgumentString(s.document, directiveRef, argumentName); ok {
+			names[name] = struct{}{}
+		}
+	}
+
+	filtered := make([]int, 0, len(typeRefs))
+	for _, typeRef := range typeRefs {
+		if _, ok := names[s.document.ResolveTypeNameString(typeRef)]; ok {
+			filtered = append(filtered, typeRef)
+		}
+	}
+	return filtered
+}
+
+func (s *subgraphExtractor) removeSupergraphDirectives(directives *ast.DirectiveList, node ast.Node) {
+	directiveRefs := make([]int, 0, len(directives.Refs))
+	for _, directiveRef := range directives.Refs {
+		name := s.document.DirectiveNameString(directiveRef)
+		if name != linkDirectiveName && !strings.HasPrefix(name, joinPrefix) {
+			directiveRefs = append(directiveRefs, directiveRef)
+		}
+	}
+	_, hasDirectives := typeSystemNodeDirectives(s.document, node)
+	directives.Refs = directiveRefs
+	*hasDirectives = len(directiveRefs) > 0
+}
+
+func (s *subgraphExtractor) directivesByName(directiveRefs []int, name string) (refs []int) {
+	for _, directiveRef := range directiveRefs {
+		if s.document.DirectiveNameString(directiveRef) == name {
+			refs = append(refs, directiveRef)
+		}
+	}
+	return refs
+}
+
+// directivesOfGraph returns the join directives with a graph argument matching the graph.
+// Directives without a graph argument, like a bare @join__field, apply to all graphs.
+func (s *subgraphExtractor) directivesOfGraph(directiveRefs []int) (refs []int) {
+	for _, directiveRef := range directiveRefs {
+		value, ok := s.document.DirectiveArgumentValueByName(directiveRef, []byte("graph"))
+		if ok && (value.Kind != ast.ValueKindEnum || s.document.EnumValueNameString(value.Ref) != s.graphName) {
+			continue
+		}
+		refs = append(refs, directiveRef)
+	}
+	return refs
+}
+
+func (s *subgraphExtractor) directiveArgumentBool(directiveRef int, argumentName string) bool {
+	value, ok := s.document.DirectiveArgumentValueByName(directiveRef, []byte(argumentName))
+	return ok && value.Kind == ast.ValueKindBoolean && bool(s.document.BooleanValue(value.Ref))
+}
+
+func (s *subgraphExtractor) addDirective(node ast.Node, name, argumentName, argumentValue string) {
+	var argumentRefs []int
+	if argumentName != "" {
+		valueRef := s.document.ImportStringValue([]byte(argumentValue), false)
+		argumentRefs = append(argumentRefs, s.document.ImportArgument(argumentName, ast.Value{Kind: ast.ValueKindString, Ref: valueRef}))
+	}
+
+	directives, hasDirectives := typeSystemNodeDirectives(s.document, node)
+	directives.Refs = append(directives.Refs, s.document.ImportDirective(name, argumentRefs))
+	*hasDirectives = true
+}
+
+// removeSupergraphDefinitions removes the types and directive definitions of the link, join and inaccessible specs
+// as well as the @link directives of the schema definition.
+func removeSupergraphDefinitions(document *ast.Document) {
+	rootNodes := make([]ast.Node, 0, len(document.RootNodes))
+	for _, node := range document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindSchemaDefinition:
+			schema := &document.SchemaDefinitions[node.Ref]
+			directiveRefs := schema.Directives.Refs[:0]
+			for _, directiveRef := range schema.Directives.Refs {
+				if document.DirectiveNameString(directiveRef) != linkDirectiveName {
+					directiveRefs = append(directiveRefs, directiveRef)
+				}
+			}
+			schema.Directives.Refs = directiveRefs
+			schema.HasDirectives = len(directiveRefs) > 0
+		case ast.NodeKindDirectiveDefinition:
+			name := document.DirectiveDefinitionNameString(node.Ref)
+			if name == linkDirectiveName || name == inaccessibleDirectiveName || strings.HasPrefix(name, joinPrefix) {
+				continue
+			}
+		default:
+			name := document.NodeNameString(node)
+			if strings.HasPrefix(name, joinPrefix) || strings.HasPrefix(name, linkPrefix) {
+				continue
+			}
+		}
+		rootNodes = append(rootNodes, node)
+	}
+	document.RootNodes = rootNodes
+}
+
+func printWithoutSupergraphDirectives(document *ast.Document) (string, error) {
+	walker := astvisitor.NewWalker(48)
+	newRemoveDirectivesVisitor(supergraphDirectiveNames...).Register(&walker)
+
+	report := operationreport.Report{}
+	walker.Walk(document, nil, &report)
+	if report.HasErrors() {
+		return "", fmt.Errorf("walk: %w", report)
+	}
+
+	out, err := astprinter.PrintString(document, nil)
+	if err != nil {
+		return "", fmt.Errorf("stringify schema: %w", err)
+	}
+	return out, nil
+}
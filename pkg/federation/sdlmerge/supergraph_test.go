@@ -0,0 +1,392 @@
+package sdlmerge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+)
+
+const testSupergraphSchemaDefinition = `
+	schema @link(url: "https://specs.apollo.dev/link/v1.0") @link(url: "https://specs.apollo.dev/join/v0.3", for: EXECUTION) {
+		query: Query
+	}
+`
+
+func TestMergeSupergraph(t *testing.T) {
+	runMergeSupergraphTest := func(expectedSupergraph string, subgraphs ...Subgraph) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := MergeSupergraph(subgraphs...)
+			require.NoError(t, err)
+
+			expectedDocument := unsafeparser.ParseGraphqlDocumentString(testSupergraphSchemaDefinition + supergraphDefinitions + expectedSupergraph)
+			want := mustString(astprinter.PrintStringIndent(&expectedDocument, nil, "  "))
+			assert.Equal(t, want, got)
+		}
+	}
+
+	t.Run("annotates entities, extensions and federation directives with join directives", runMergeSupergraphTest(`
+		enum join__Graph {
+			ACCOUNTS @join__graph(name: "accounts", url: "http://accounts.service")
+			REVIEWS @join__graph(name: "reviews", url: "http://reviews.service")
+		}
+		type Query @join__type(graph: ACCOUNTS, extension: true) @join__type(graph: REVIEWS, extension: true) {
+			me: User @join__field(graph: ACCOUNTS)
+			topReviews: [Review] @join__field(graph: REVIEWS)
+		}
+		type User @join__type(graph: ACCOUNTS, key: "id") @join__type(graph: REVIEWS, key: "id", extension: true) {
+			id: ID! @join__field(graph: ACCOUNTS) @join__field(graph: REVIEWS, external: true)
+			username: String! @join__field(graph: ACCOUNTS) @join__field(graph: REVIEWS, external: true)
+			reviews: [Review] @join__field(graph: REVIEWS)
+		}
+		interface Node @join__type(graph: REVIEWS) {
+			id: ID!
+		}
+		type Review implements Node @join__type(graph: REVIEWS) @join__implements(graph: REVIEWS, interface: "Node") {
+			id: ID!
+			body: String!
+			author: User! @join__field(graph: REVIEWS, provides: "username")
+		}
+		union SearchResult @join__type(graph: REVIEWS) @join__unionMember(graph: REVIEWS, member: "User") @join__unionMember(graph: REVIEWS, member: "Review") = User | Review
+	`,
+		Subgraph{Name: "accounts", URL: "http://accounts.service", SDL: `
+			extend type Query {
+				me: User
+			}
+			type User @key(fields: "id") {
+				id: ID!
+				username: String!
+			}
+		`},
+		Subgraph{Name: "reviews", URL: "http://reviews.service", SDL: `
+			extend type Query {
+				topReviews: [Review]
+			}
+			extend type User @key(fields: "id") {
+				id: ID! @external
+				username: String! @external
+				reviews: [Review]
+			}
+			interface Node {
+				id: ID!
+			}
+			type Review implements Node {
+				id: ID!
+				body: String!
+				author: User! @provides(fields: "username")
+			}
+			union SearchResult = User | Review
+		`},
+	))
+
+	t.Run("annotates multiple keys and requires", runMergeSupergraphTest(`
+		enum join__Graph {
+			PRODUCT_CATALOG @join__graph(name: "product-catalog", url: "http://products.service")
+			_2ND_INVENTORY @join__graph(name: "2nd inventory", url: "http://inventory.service")
+		}
+		type Query @join__type(graph: PRODUCT_CATALOG, extension: true) {
+			topProducts: [Product]
+		}
+		type Product @join__type(graph: PRODUCT_CATALOG, key: "upc") @join__type(graph: PRODUCT_CATALOG, key: "sku") @join__type(graph: _2ND_INVENTORY, key: "upc", extension: true) {
+			upc: String! @join__field(graph: PRODUCT_CATALOG) @join__field(graph: _2ND_INVENTORY, external: true)
+			sku: String! @join__field(graph: PRODUCT_CATALOG)
+			weight: Int! @join__field(graph: PRODUCT_CATALOG) @join__field(graph: _2ND_INVENTORY, external: true)
+			shippingEstimate: Int @join__field(graph: _2ND_INVENTORY, requires: "weight")
+		}
+	`,
+		Subgraph{Name: "product-catalog", URL: "http://products.service", SDL: `
+			extend type Query {
+				topProducts: [Product]
+			}
+			type Product @key(fields: "upc") @key(fields: "sku") {
+				upc: String!
+				sku: String!
+				weight: Int!
+			}
+		`},
+		Subgraph{Name: "2nd inventory", URL: "http://inventory.service", SDL: `
+			extend type Product @key(fields: "upc") {
+				upc: String! @external
+				weight: Int! @external
+				shippingEstimate: Int @requires(fields: "weight")
+			}
+		`},
+	))
+
+	t.Run("annotates overridden fields of federation v2 subgraphs", runMergeSupergraphTest(`
+		enum join__Graph {
+			ACCOUNTS @join__graph(name: "accounts", url: "http://accounts.service")
+			PROFILES @join__graph(name: "profiles", url: "http://profiles.service")
+		}
+		type Query @join__type(graph: ACCOUNTS) {
+			me: User
+		}
+		type User @join__type(graph: ACCOUNTS, key: "id") @join__type(graph: PROFILES, key: "id") {
+			id: ID! @join__field(graph: ACCOUNTS) @join__field(graph: PROFILES)
+			name: String! @join__field(graph: PROFILES, override: "accounts")
+		}
+	`,
+		Subgraph{Name: "accounts", URL: "http://accounts.service", SDL: `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+			type Query {
+				me: User
+			}
+			type User @key(fields: "id") {
+				id: ID!
+				name: String!
+			}
+		`},
+		Subgraph{Name: "profiles", URL: "http://profiles.service", SDL: `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@override"])
+			type User @key(fields: "id") {
+				id: ID!
+				name: String! @override(from: "accounts")
+			}
+		`},
+	))
+
+	t.Run("subgraphs must have a name", func(t *testing.T) {
+		_, err := MergeSupergraph(Subgraph{URL: "http://accounts.service", SDL: accountSchema})
+		assert.EqualError(t, err, "subgraph at index 0 has no name")
+	})
+
+	t.Run("subgraph names must be unique", func(t *testing.T) {
+		_, err := MergeSupergraph(
+			Subgraph{Name: "accounts", SDL: accountSchema},
+			Subgraph{Name: "accounts", SDL: productSchema},
+		)
+		assert.EqualError(t, err, `subgraph name "accounts" is not unique`)
+	})
+
+	t.Run("reports accessible fields referencing @inaccessible types as composition errors", func(t *testing.T) {
+		_, err := MergeSupergraph(
+			Subgraph{Name: "accounts", SDL: v2AccountsSchema},
+			Subgraph{Name: "internal", SDL: `
+				extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@inaccessible"])
+
+				type User @key(fields: "id") {
+					id: ID!
+					internal: Internal
+				}
+
+				type Internal @inaccessible {
+					debug: Boolean
+				}
+			`},
+		)
+		var compositionErrors CompositionErrors
+		require.ErrorAs(t, err, &compositionErrors)
+		require.Len(t, compositionErrors, 1)
+		assert.Equal(t, ErrCodeReferencedInaccessible, compositionErrors[0].Code)
+		assert.Equal(t, []string{"internal"}, compositionErrors[0].Subgraphs)
+	})
+
+	t.Run("graph enum values are unique", func(t *testing.T) {
+		values, err := joinGraphEnumValues([]Subgraph{{Name: "a-b"}, {Name: "a_b"}, {Name: "A.B"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A_B", "A_B_1", "A_B_2"}, values)
+	})
+}
+
+func TestParseSupergraph(t *testing.T) {
+	normalize := func(t *testing.T, sdl string) string {
+		doc := unsafeparser.ParseGraphqlDocumentString(sdl)
+		return mustString(astprinter.PrintString(&doc, nil))
+	}
+
+	t.Run("extracts the api schema and the subgraphs", func(t *testing.T) {
+		supergraph, err := ParseSupergraph(supergraphDefinitions + `
+			schema @link(url: "https://specs.apollo.dev/link/v1.0") @link(url: "https://specs.apollo.dev/join/v0.3", for: EXECUTION) {
+				query: Query
+				mutation: Mutation
+			}
+			enum join__Graph {
+				ACCOUNTS @join__graph(name: "accounts", url: "http://accounts.service")
+				REVIEWS @join__graph(name: "reviews", url: "http://reviews.service")
+			}
+			scalar DateTime
+			type Query @join__type(graph: ACCOUNTS) @join__type(graph: REVIEWS) {
+				me: User @join__field(graph: ACCOUNTS)
+				review(id: ID!): Review @join__field(graph: REVIEWS)
+			}
+			type Mutation @join__type(graph: REVIEWS) {
+				addReview(body: String!): Review
+			}
+			interface Node @join__type(graph: ACCOUNTS) @join__type(graph: REVIEWS) {
+				id: ID!
+			}
+			type User implements Node @join__type(graph: ACCOUNTS, key: "id") @join__type(graph: REVIEWS, key: "id", extension: true) @join__implements(graph: ACCOUNTS, interface: "Node") {
+				id: ID! @join__field(graph: ACCOUNTS) @join__field(graph: REVIEWS, external: true)
+				username: String! @join__field(graph: ACCOUNTS)
+				reviews: [Review] @join__field(graph: REVIEWS)
+			}
+			type Review implements Node @join__type(graph: REVIEWS, key: "id") @join__implements(graph: REVIEWS, interface: "Node") {
+				id: ID!
+				created: DateTime!
+				author: User! @join__field(graph: REVIEWS, provides: "username")
+			}
+			union Entity @join__type(graph: ACCOUNTS) @join__type(graph: REVIEWS) @join__unionMember(graph: ACCOUNTS, member: "User") @join__unionMember(graph: REVIEWS, member: "Review") = User | Review
+		`)
+		require.NoError(t, err)
+
+		assert.Equal(t, normalize(t, `
+			schema {
+				query: Query
+				mutation: Mutation
+			}
+			scalar DateTime
+			type Query {
+				me: User
+				review(id: ID!): Review
+			}
+			type Mutation {
+				addReview(body: String!): Review
+			}
+			interface Node {
+				id: ID!
+			}
+			type User implements Node {
+				id: ID!
+				username: String!
+				reviews: [Review]
+			}
+			type Review implements Node {
+				id: ID!
+				created: DateTime!
+				author: User!
+			}
+			union Entity = User | Review
+		`), supergraph.APISchema)
+
+		require.Len(t, supergraph.Subgraphs, 2)
+		assert.Equal(t, "accounts", supergraph.Subgraphs[0].Name)
+		assert.Equal(t, "http://accounts.service", supergraph.Subgraphs[0].URL)
+		assert.Equal(t, normalize(t, `
+			scalar DateTime
+			type Query {
+				me: User
+			}
+			interface Node {
+				id: ID!
+			}
+			type User implements Node @key(fields: "id") {
+				id: ID!
+				username: String!
+			}
+			union Entity = User
+		`), supergraph.Subgraphs[0].SDL)
+
+		assert.Equal(t, "reviews", supergraph.Subgraphs[1].Name)
+		assert.Equal(t, "http://reviews.service", supergraph.Subgraphs[1].URL)
+		assert.Equal(t, normalize(t, `
+			scalar DateTime
+			type Query {
+				review(id: ID!): Review
+			}
+			type Mutation {
+				addReview(body: String!): Review
+			}
+			interface Node {
+				id: ID!
+			}
+			extend type User @key(fields: "id") {
+				id: ID! @external
+				reviews: [Review]
+			}
+			type Review implements Node @key(fields: "id") {
+				id: ID!
+				created: DateTime!
+				author: User! @provides(fields: "username")
+			}
+			union Entity = Review
+		`), supergraph.Subgraphs[1].SDL)
+	})
+
+	t.Run("keeps schema definitions with custom root operation type names", func(t *testing.T) {
+		supergraph, err := ParseSupergraph(supergraphDefinitions + `
+			schema @link(url: "https://specs.apollo.dev/link/v1.0") @link(url: "https://specs.apollo.dev/join/v0.3", for: EXECUTION) {
+				query: RootQuery
+			}
+			enum join__Graph {
+				ACCOUNTS @join__graph(name: "accounts", url: "http://accounts.service")
+			}
+			type RootQuery @join__type(graph: ACCOUNTS) {
+				me: String
+			}
+		`)
+		require.NoError(t, err)
+		require.Len(t, supergraph.Subgraphs, 1)
+		assert.Equal(t, normalize(t, `
+			schema {
+				query: RootQuery
+			}
+			type RootQuery {
+				me: String
+			}
+		`), supergraph.Subgraphs[0].SDL)
+	})
+
+	t.Run("round trips a composed supergraph", func(t *testing.T) {
+		supergraphSDL, err := MergeSupergraph(
+			Subgraph{Name: "accounts", URL: "http://accounts.service", SDL: accountSchema},
+			Subgraph{Name: "products", URL: "http://products.service", SDL: productSchema},
+			Subgraph{Name: "reviews", URL: "http://reviews.service", SDL: reviewSchema},
+		)
+		require.NoError(t, err)
+
+		supergraph, err := ParseSupergraph(supergraphSDL)
+		require.NoError(t, err)
+
+		composed, err := MergeSDLs(accountSchema, productSchema, reviewSchema)
+		require.NoError(t, err)
+		assert.Equal(t, normalize(t, "schema {query: Query mutation: Mutation subscription: Subscription} "+composed), supergraph.APISchema)
+
+		recomposed, err := MergeSDLs(supergraph.Subgraphs[0].SDL, supergraph.Subgraphs[1].SDL, supergraph.Subgraphs[2].SDL)
+		require.NoError(t, err)
+		assert.Equal(t, composed, recomposed)
+	})
+
+	t.Run("round trips requires of an inaccessible field", func(t *testing.T) {
+		accounts := `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@inaccessible"])
+			type Query { me: User }
+			type User @key(fields: "id") { id: ID! name: String secret: String @inaccessible }
+		`
+		greetings := `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@external", "@requires"])
+			type User @key(fields: "id") { id: ID! secret: String @external greeting: String @requires(fields: "secret") }
+		`
+		supergraphSDL, err := MergeSupergraph(
+			Subgraph{Name: "accounts", URL: "http://accounts.service", SDL: accounts},
+			Subgraph{Name: "greetings", URL: "http://greetings.service", SDL: greetings},
+		)
+		require.NoError(t, err)
+		assert.Contains(t, supergraphSDL, `@link(url: "https://specs.apollo.dev/inaccessible/v0.2", for: SECURITY)`)
+		assert.Contains(t, supergraphSDL, `secret: String @inaccessible @join__field(graph: ACCOUNTS) @join__field(graph: GREETINGS, external: true)`)
+		assert.Contains(t, supergraphSDL, `greeting: String @join__field(graph: GREETINGS, requires: "secret")`)
+
+		supergraph, err := ParseSupergraph(supergraphSDL)
+		require.NoError(t, err)
+
+		composed, err := MergeSDLs(accounts, greetings)
+		require.NoError(t, err)
+		assert.Equal(t, normalize(t, "schema {query: Query} "+composed), supergraph.APISchema)
+
+		require.Len(t, supergraph.Subgraphs, 2)
+		assert.Equal(t, normalize(t, `
+			type Query { me: User }
+			type User @key(fields: "id") { id: ID! name: String secret: String }
+		`), supergraph.Subgraphs[0].SDL)
+		assert.Equal(t, normalize(t, `
+			type User @key(fields: "id") { id: ID! secret: String @external greeting: String @requires(fields: "secret") }
+		`), supergraph.Subgraphs[1].SDL)
+	})
+
+	t.Run("fails without join__Graph enum", func(t *testing.T) {
+		_, err := ParseSupergraph(accountSchema)
+		assert.ErrorIs(t, err, ErrMissingJoinGraphEnum)
+	})
+}
@@ -0,0 +1,103 @@
+package sdlmerge
+
+import (
+	"fmt"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+)
+
+// SubgraphTags returns the names of the @tag directives of the subgraphs by schema coordinate,
+// e.g. "Type", "Type.field", "Type.field.argument" or "Enum.VALUE".
+// The composed schema doesn't contain @tag, so the tags are collected from the subgraphs to derive contracts.
+// Directives of federation v2 subgraphs which are renamed by @link are resolved to @tag.
+func SubgraphTags(SDLs ...string) (map[string][]string, error) {
+	preparedSDLs := make([]string, len(SDLs))
+	copy(preparedSDLs, SDLs)
+	if _, err := prepareFederationV2Subgraphs(preparedSDLs); err != nil {
+		return nil, err
+	}
+
+	collector := tagCollector{
+		tags: make(map[string][]string),
+		seen: make(map[string]map[string]struct{}),
+	}
+	for _, SDL := range preparedSDLs {
+		doc, report := astparser.ParseGraphqlDocumentString(SDL)
+		if report.HasErrors() {
+			return nil, fmt.Errorf(parseDocumentError, report)
+		}
+		collector.collect(&doc)
+	}
+
+	return collector.tags, nil
+}
+
+type tagCollector struct {
+	tags map[string][]string
+	seen map[string]map[string]struct{}
+}
+
+func (t *tagCollector) collect(document *ast.Document) {
+	for _, node := range document.RootNodes {
+		typeName := document.NodeNameString(node)
+		if typeName == "" {
+			continue
+		}
+		directives, _ := typeSystemNodeDirectives(document, node)
+		if directives != nil {
+			t.collectDirectives(document, typeName, directives.Refs)
+		}
+
+		for _, fieldRef := range document.NodeFieldDefinitions(node) {
+			fieldCoordinate := typeName + "." + document.FieldDefinitionNameString(fieldRef)
+			t.collectDirectives(document, fieldCoordinate, document.FieldDefinitions[fieldRef].Directives.Refs)
+			for _, argumentRef := range document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs {
+				t.collectDirectives(document, fieldCoordinate+"."+document.InputValueDefinitionNameString(argumentRef), document.InputValueDefinitions[argumentRef].Directives.Refs)
+			}
+		}
+
+		switch node.Kind {
+		case ast.NodeKindInputObjectTypeDefinition:
+			t.collectInputValues(document, typeName, document.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs)
+		case ast.NodeKindInputObjectTypeExtension:
+			t.collectInputValues(document, typeName, document.InputObjectTypeExtensions[node.Ref].InputFieldsDefinition.Refs)
+		case ast.NodeKindEnumTypeDefinition:
+			t.collectEnumValues(document, typeName, document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs)
+		case ast.NodeKindEnumTypeExtension:
+			t.collectEnumValues(document, typeName, document.EnumTypeExtensions[node.Ref].EnumValuesDefinition.Refs)
+		}
+	}
+}
+
+func (t *tagCollector) collectInputValues(document *ast.Document, typeName string, inputValueRefs []int) {
+	for _, inputValueRef := range inputValueRefs {
+		t.collectDirectives(document, typeName+"."+document.InputValueDefinitionNameString(inputValueRef), document.InputValueDefinitions[inputValueRef].Directives.Refs)
+	}
+}
+
+func (t *tagCollector) collectEnumValues(document *ast.Document, typeName string, valueRefs []int) {
+	for _, valueRef := range valueRefs {
+		t.collectDirectives(document, typeName+"."+document.EnumValueDefinitionNameString(valueRef), document.EnumValueDefinitions[valueRef].Directives.Refs)
+	}
+}
+
+func (t *tagCollector) collectDirectives(document *ast.Document, coordinate string, directiveRefs []int) {
+	for _, directiveRef := range directiveRefs {
+		if document.DirectiveNameString(directiveRef) != tagDirectiveName {
+			continue
+		}
+		name, ok := directiveArgumentString(document, directiveRef, "name")
+		if !ok {
+			continue
+		}
+		if _, ok := t.seen[coordinate]; !ok {
+			t.seen[coordinate] = make(map[string]struct{})
+		}
+		if _, ok := t.seen[coordinate][name]; ok {
+			continue
+		}
+		t.seen[coordinate][name] = struct{}{}
+		t.tags[coordinate] = append(t.tags[coordinate], name)
+	}
+}
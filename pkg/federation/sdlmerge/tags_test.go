@@ -0,0 +1,66 @@
+package sdlmerge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubgraphTags(t *testing.T) {
+	t.Run("collects tags of federation v1 subgraphs", func(t *testing.T) {
+		tags, err := SubgraphTags(`
+			type Query {
+				products(filter: ProductFilter @tag(name: "public")): [Product] @tag(name: "public") @tag(name: "partner")
+			}
+			type Product @key(fields: "upc") @tag(name: "public") {
+				upc: String!
+				status: ProductStatus
+			}
+			enum ProductStatus {
+				AVAILABLE
+				DISCONTINUED @tag(name: "internal")
+			}
+			input ProductFilter {
+				upc: String @tag(name: "internal")
+			}
+		`, `
+			extend type Product @key(fields: "upc") @tag(name: "public") {
+				upc: String! @external
+				cost: Int @tag(name: "internal")
+			}
+		`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{
+			"Query.products":             {"public", "partner"},
+			"Query.products.filter":      {"public"},
+			"Product":                    {"public"},
+			"Product.cost":               {"internal"},
+			"ProductStatus.DISCONTINUED": {"internal"},
+			"ProductFilter.upc":          {"internal"},
+		}, tags)
+	})
+
+	t.Run("resolves renamed tag directives of federation v2 subgraphs", func(t *testing.T) {
+		tags, err := SubgraphTags(`
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", {name: "@tag", as: "@label"}])
+			type Query {
+				products: [Product] @label(name: "public")
+			}
+			type Product @key(fields: "upc") {
+				upc: String!
+			}
+		`, `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+			type Product @key(fields: "upc") {
+				upc: String!
+				cost: Int @federation__tag(name: "internal")
+			}
+		`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{
+			"Query.products": {"public"},
+			"Product.cost":   {"internal"},
+		}, tags)
+	})
+}
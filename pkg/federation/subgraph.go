@@ -0,0 +1,103 @@
+package federation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+)
+
+// BuildSubgraphSchema turns the SDL of a subgraph into the schema the subgraph is executed with.
+// Type extensions are merged into their definitions or become definitions if the subgraph doesn't define the type.
+// The Query type is extended with _service and, if the subgraph defines entities, with _entities.
+// Federation directives and types which are not defined by the SDL are added.
+func BuildSubgraphSchema(serviceSDL string) (string, error) {
+	builder := schemaBuilder{}
+	return builder.buildSubgraphSchema(serviceSDL)
+}
+
+// EntityTypes returns the names of the object types of the SDL which are entities, i.e. which have a @key directive
+func EntityTypes(serviceSDL string) []string {
+	builder := schemaBuilder{}
+	return builder.entityUnionTypes(serviceSDL)
+}
+
+func (s *schemaBuilder) buildSubgraphSchema(serviceSDL string) (string, error) {
+	doc, report := astparser.ParseGraphqlDocumentString(serviceSDL)
+	if report.HasErrors() {
+		return "", report
+	}
+
+	var sb strings.Builder
+	sb.WriteString(serviceSDL)
+	sb.WriteString("\n")
+
+	for _, definition := range subgraphDefinitions {
+		if _, exists := doc.Index.FirstNodeByNameStr(definition.name); exists {
+			continue
+		}
+		sb.WriteString(definition.sdl)
+		sb.WriteString("\n")
+	}
+	for _, directive := range subgraphDirectiveDefinitions {
+		if s.hasDirectiveDefinition(&doc, directive.name) {
+			continue
+		}
+		sb.WriteString(directive.sdl)
+		sb.WriteString("\n")
+	}
+
+	queryTypeName := doc.Index.QueryTypeName.String()
+	if queryTypeName == "" {
+		queryTypeName = "Query"
+	}
+	entityTypes := s.entityUnionTypes(serviceSDL)
+	if len(entityTypes) == 0 {
+		sb.WriteString(fmt.Sprintf("extend type %s { _service: _Service! }\n", queryTypeName))
+	} else {
+		sb.WriteString(fmt.Sprintf("union _Entity = %s\n", strings.Join(entityTypes, " | ")))
+		sb.WriteString(fmt.Sprintf("extend type %s { _service: _Service! _entities(representations: [_Any!]!): [_Entity]! }\n", queryTypeName))
+	}
+
+	subgraphDoc, report := astparser.ParseGraphqlDocumentString(sb.String())
+	if report.HasErrors() {
+		return "", report
+	}
+	astnormalization.NormalizeDefinition(&subgraphDoc, &report)
+	if report.HasErrors() {
+		return "", report
+	}
+
+	return astprinter.PrintStringIndent(&subgraphDoc, nil, "  ")
+}
+
+func (s *schemaBuilder) hasDirectiveDefinition(doc *ast.Document, name string) bool {
+	for i := range doc.DirectiveDefinitions {
+		if doc.DirectiveDefinitionNameString(i) == name {
+			return true
+		}
+	}
+	return false
+}
+
+type subgraphDefinition struct {
+	name string
+	sdl  string
+}
+
+var subgraphDefinitions = []subgraphDefinition{
+	{name: "_Any", sdl: "scalar _Any"},
+	{name: "_FieldSet", sdl: "scalar _FieldSet"},
+	{name: "_Service", sdl: "type _Service { sdl: String }"},
+}
+
+var subgraphDirectiveDefinitions = []subgraphDefinition{
+	{name: "external", sdl: "directive @external on FIELD_DEFINITION"},
+	{name: "requires", sdl: "directive @requires(fields: _FieldSet!) on FIELD_DEFINITION"},
+	{name: "provides", sdl: "directive @provides(fields: _FieldSet!) on FIELD_DEFINITION"},
+	{name: "key", sdl: "directive @key(fields: _FieldSet!) on OBJECT | INTERFACE"},
+	{name: "extends", sdl: "directive @extends on OBJECT | INTERFACE"},
+}
@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+)
+
+// engineHandle counts the requests and connections using an engine,
+// so that a retired engine can be shut down once it is no longer in use.
+type engineHandle struct {
+	schema  *graphql.Schema
+	engine  *graphql.ExecutionEngineV2
+	handler http.Handler
+	cancel  context.CancelFunc
+
+	mu      sync.Mutex
+	active  int
+	retired bool
+}
+
+func (e *engineHandle) acquire() {
+	e.mu.Lock()
+	e.active++
+	e.mu.Unlock()
+}
+
+func (e *engineHandle) release() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.active--
+	if e.retired && e.active == 0 {
+		e.cancel()
+	}
+}
+
+func (e *engineHandle) retire() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.retired = true
+	if e.active == 0 {
+		e.cancel()
+	}
+}
+
+// trackingResponseWriter hands over the ownership of the engine handle
+// to the connection when the handler hijacks it, e.g. for a websocket upgrade.
+type trackingResponseWriter struct {
+	http.ResponseWriter
+	handle   *engineHandle
+	hijacked bool
+}
+
+func (t *trackingResponseWriter) Flush() {
+	if flusher, ok := t.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (t *trackingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := t.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not implement http.Hijacker")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t.hijacked = true
+	return &trackingConn{Conn: conn, handle: t.handle}, rw, nil
+}
+
+type trackingConn struct {
+	net.Conn
+	handle    *engineHandle
+	closeOnce sync.Once
+}
+
+func (t *trackingConn) Close() error {
+	err := t.Conn.Close()
+	t.closeOnce.Do(t.handle.release)
+	return err
+}
@@ -0,0 +1,272 @@
+// Package gateway provides a federation gateway which composes the SDLs of subgraphs
+// into a supergraph and hot reloads the execution engine whenever a subgraph changes.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/jensneuse/abstractlogger"
+
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+	graphqlhttp "github.com/wundergraph/graphql-go-tools/pkg/http"
+)
+
+var ErrGatewayNotReady = errors.New("gateway is not ready")
+
+type HandlerFactory interface {
+	Make(schema *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler
+}
+
+type HandlerFactoryFn func(schema *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler
+
+func (h HandlerFactoryFn) Make(schema *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler {
+	return h(schema, engine)
+}
+
+// NewGraphQLHTTPHandlerFactory creates a HandlerFactory serving every engine with a
+// GraphQL-over-HTTP compliant handler, including subscriptions over websockets.
+func NewGraphQLHTTPHandlerFactory(logger log.Logger, opts ...graphqlhttp.HandlerV2Option) HandlerFactory {
+	return HandlerFactoryFn(func(_ *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler {
+		return graphqlhttp.NewGraphqlHTTPHandlerV2(engine, logger, opts...)
+	})
+}
+
+type options struct {
+	httpClient              *http.Client
+	logger                  log.Logger
+	engineConfigFactoryOpts []graphql.FederationEngineConfigFactoryOption
+}
+
+type Option func(options *options)
+
+func WithHttpClient(client *http.Client) Option {
+	return func(options *options) {
+		options.httpClient = client
+	}
+}
+
+func WithLogger(logger log.Logger) Option {
+	return func(options *options) {
+		options.logger = logger
+	}
+}
+
+// WithFederationEngineConfigFactoryOptions passes additional options to the
+// graphql.FederationEngineConfigFactory used to compose the supergraph.
+func WithFederationEngineConfigFactoryOptions(opts ...graphql.FederationEngineConfigFactoryOption) Option {
+	return func(options *options) {
+		options.engineConfigFactoryOpts = append(options.engineConfigFactoryOpts, opts...)
+	}
+}
+
+// NewGateway creates a Gateway. The engines created by the gateway live until ctx is done.
+func NewGateway(ctx context.Context, handlerFactory HandlerFactory, opts ...Option) *Gateway {
+	options := options{
+		httpClient: http.DefaultClient,
+		logger:     log.NoopLogger,
+	}
+
+	for _, optFunc := range opts {
+		optFunc(&options)
+	}
+
+	return &Gateway{
+		ctx:                     ctx,
+		handlerFactory:          handlerFactory,
+		httpClient:              options.httpClient,
+		logger:                  options.logger,
+		engineConfigFactoryOpts: options.engineConfigFactoryOpts,
+		readyCh:                 make(chan struct{}),
+	}
+}
+
+// Gateway serves a federated graph. Every update of the subgraph data sources
+// composes a new supergraph and creates a new engine which gets swapped in atomically.
+// Requests and websocket connections which are in flight during a swap keep using
+// the engine they were started with; a retired engine is shut down after all of them finished.
+// If the composition fails, the previous engine keeps serving.
+type Gateway struct {
+	ctx                     context.Context
+	handlerFactory          HandlerFactory
+	httpClient              *http.Client
+	logger                  log.Logger
+	engineConfigFactoryOpts []graphql.FederationEngineConfigFactoryOption
+
+	updateMu sync.Mutex
+
+	mu         sync.RWMutex
+	current    *engineHandle
+	lastUpdate time.Time
+	lastError  error
+	generation int
+
+	readyCh   chan struct{}
+	readyOnce sync.Once
+}
+
+// Status describes the current state of a Gateway.
+type Status struct {
+	// Ready is true as soon as an engine is serving requests.
+	Ready bool `json:"ready"`
+	// Healthy is false if the last composition failed and the gateway serves a stale engine.
+	Healthy bool `json:"healthy"`
+	// Generation is incremented on every successful engine swap.
+	Generation int       `json:"generation"`
+	LastUpdate time.Time `json:"lastUpdate"`
+	LastError  string    `json:"lastError,omitempty"`
+}
+
+func (g *Gateway) Status() Status {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	status := Status{
+		Ready:      g.current != nil,
+		Healthy:    g.lastError == nil,
+		Generation: g.generation,
+		LastUpdate: g.lastUpdate,
+	}
+	if g.lastError != nil {
+		status.LastError = g.lastError.Error()
+	}
+
+	return status
+}
+
+// Ready blocks until the first engine is available.
+func (g *Gateway) Ready() {
+	<-g.readyCh
+}
+
+// IsReady reports whether the gateway has an engine to serve requests.
+func (g *Gateway) IsReady() bool {
+	return g.Status().Ready
+}
+
+// HealthHandler responds with the Status of the gateway and 503 if the last composition failed.
+func (g *Gateway) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := g.Status()
+		g.writeStatus(w, status, status.Healthy)
+	})
+}
+
+// ReadinessHandler responds with the Status of the gateway and 503 until an engine is available.
+func (g *Gateway) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := g.Status()
+		g.writeStatus(w, status, status.Ready)
+	})
+}
+
+func (g *Gateway) writeStatus(w http.ResponseWriter, status Status, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		g.logger.Error("gateway.Gateway.writeStatus", log.Error(err))
+	}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.RLock()
+	handle := g.current
+	if handle != nil {
+		handle.acquire()
+	}
+	g.mu.RUnlock()
+
+	if handle == nil {
+		http.Error(w, ErrGatewayNotReady.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	trackingWriter := &trackingResponseWriter{ResponseWriter: w, handle: handle}
+	defer func() {
+		// a hijacked connection releases the engine when it gets closed
+		if !trackingWriter.hijacked {
+			handle.release()
+		}
+	}()
+
+	handle.handler.ServeHTTP(trackingWriter, r)
+}
+
+// UpdateDataSources composes the supergraph out of the given subgraph data sources
+// and swaps in a new engine. On failure the current engine is kept.
+func (g *Gateway) UpdateDataSources(newDataSourcesConfig []graphqlDataSource.Configuration) {
+	g.updateMu.Lock()
+	defer g.updateMu.Unlock()
+
+	handle, err := g.buildEngine(newDataSourcesConfig)
+	if err != nil {
+		g.logger.Error("gateway.Gateway.UpdateDataSources", log.Error(err))
+
+		g.mu.Lock()
+		g.lastError = err
+		g.mu.Unlock()
+		return
+	}
+
+	g.mu.Lock()
+	previous := g.current
+	g.current = handle
+	g.lastError = nil
+	g.lastUpdate = time.Now()
+	g.generation++
+	g.mu.Unlock()
+
+	if previous != nil {
+		previous.retire()
+	}
+
+	g.readyOnce.Do(func() { close(g.readyCh) })
+}
+
+func (g *Gateway) buildEngine(dataSourcesConfig []graphqlDataSource.Configuration) (*engineHandle, error) {
+	if len(dataSourcesConfig) == 0 {
+		return nil, errors.New("no data sources to compose")
+	}
+
+	factoryOpts := append([]graphql.FederationEngineConfigFactoryOption{graphql.WithFederationHttpClient(g.httpClient)}, g.engineConfigFactoryOpts...)
+	engineConfigFactory := graphql.NewFederationEngineConfigFactory(
+		dataSourcesConfig,
+		graphqlDataSource.NewBatchFactory(),
+		factoryOpts...,
+	)
+
+	schema, err := engineConfigFactory.MergedSchema()
+	if err != nil {
+		return nil, fmt.Errorf("get schema: %w", err)
+	}
+
+	engineConfig, err := engineConfigFactory.EngineV2Configuration()
+	if err != nil {
+		return nil, fmt.Errorf("get engine config: %w", err)
+	}
+
+	engineCtx, cancel := context.WithCancel(g.ctx)
+	engine, err := graphql.NewExecutionEngineV2(engineCtx, g.logger, engineConfig)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("create engine: %w", err)
+	}
+
+	return &engineHandle{
+		schema:  schema,
+		engine:  engine,
+		handler: g.handlerFactory.Make(schema, engine),
+		cancel:  cancel,
+	}, nil
+}
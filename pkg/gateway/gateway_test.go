@@ -0,0 +1,269 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/atomic"
+
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+	accounts "github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/accounts/graph"
+	products "github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/products/graph"
+	reviews "github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/reviews/graph"
+)
+
+const (
+	accountsSDL = `
+		extend type Query {
+			me: User
+		}
+
+		type User @key(fields: "id") {
+			id: ID!
+			username: String!
+		}`
+
+	accountsSDLWithEmail = `
+		extend type Query {
+			me: User
+		}
+
+		type User @key(fields: "id") {
+			id: ID!
+			username: String!
+			email: String!
+		}`
+
+	productsSDL = `
+		extend type Query {
+			topProducts: [Product]
+		}
+
+		type Product @key(fields: "upc") {
+			upc: String!
+			name: String!
+		}`
+
+	invalidSDL = `
+		extend type Query {
+			me: User`
+)
+
+func dataSourceConfigs(sdl string) []graphqlDataSource.Configuration {
+	return []graphqlDataSource.Configuration{
+		{
+			Fetch: graphqlDataSource.FetchConfiguration{
+				URL:    "http://accounts.service",
+				Method: http.MethodPost,
+			},
+			Federation: graphqlDataSource.FederationConfiguration{
+				Enabled:    true,
+				ServiceSDL: sdl,
+			},
+		},
+	}
+}
+
+type recordingHandlerFactory struct {
+	engines []*graphql.ExecutionEngineV2
+}
+
+func (r *recordingHandlerFactory) Make(schema *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler {
+	r.engines = append(r.engines, engine)
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(schema.Document())
+	})
+}
+
+func TestGateway(t *testing.T) {
+	t.Run("should respond with 503 until the first engine is available", func(t *testing.T) {
+		gtw := NewGateway(context.Background(), &recordingHandlerFactory{})
+
+		rec := httptest.NewRecorder()
+		gtw.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+		rec = httptest.NewRecorder()
+		gtw.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.False(t, gtw.IsReady())
+	})
+
+	t.Run("should swap in a new engine on update", func(t *testing.T) {
+		factory := &recordingHandlerFactory{}
+		gtw := NewGateway(context.Background(), factory)
+
+		gtw.UpdateDataSources(dataSourceConfigs(accountsSDL))
+		gtw.Ready()
+
+		rec := httptest.NewRecorder()
+		gtw.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "email")
+
+		gtw.UpdateDataSources(dataSourceConfigs(accountsSDLWithEmail))
+
+		rec = httptest.NewRecorder()
+		gtw.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "email")
+
+		assert.Len(t, factory.engines, 2)
+		status := gtw.Status()
+		assert.True(t, status.Ready)
+		assert.True(t, status.Healthy)
+		assert.Equal(t, 2, status.Generation)
+	})
+
+	t.Run("should keep the previous engine if the composition fails", func(t *testing.T) {
+		factory := &recordingHandlerFactory{}
+		gtw := NewGateway(context.Background(), factory)
+
+		gtw.UpdateDataSources(dataSourceConfigs(accountsSDL))
+		gtw.UpdateDataSources(dataSourceConfigs(invalidSDL))
+
+		rec := httptest.NewRecorder()
+		gtw.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "username")
+		assert.Len(t, factory.engines, 1)
+
+		rec = httptest.NewRecorder()
+		gtw.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+		var status Status
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+		assert.True(t, status.Ready)
+		assert.False(t, status.Healthy)
+		assert.NotEmpty(t, status.LastError)
+		assert.Equal(t, 1, status.Generation)
+
+		rec = httptest.NewRecorder()
+		gtw.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should shut down a retired engine after in-flight requests finished", func(t *testing.T) {
+		started := make(chan struct{})
+		finish := make(chan struct{})
+
+		var factory HandlerFactoryFn = func(schema *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				close(started)
+				<-finish
+				w.WriteHeader(http.StatusOK)
+			})
+		}
+
+		gtw := NewGateway(context.Background(), factory)
+		gtw.UpdateDataSources(dataSourceConfigs(accountsSDL))
+
+		gtw.mu.RLock()
+		first := gtw.current
+		gtw.mu.RUnlock()
+
+		engineCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		first.cancel = cancel
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			gtw.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+		}()
+		<-started
+
+		gtw.UpdateDataSources(dataSourceConfigs(accountsSDLWithEmail))
+		assert.NoError(t, engineCtx.Err(), "engine must not be shut down while a request is in flight")
+
+		close(finish)
+		<-done
+		assert.Error(t, engineCtx.Err())
+	})
+}
+
+func TestNewGraphQLHTTPHandlerFactory(t *testing.T) {
+	gtw := NewGateway(context.Background(), NewGraphQLHTTPHandlerFactory(abstractlogger.NoopLogger))
+	gtw.UpdateDataSources(dataSourceConfigs(accountsSDL))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"query":"{ unknown }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	gtw.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"errors":[{"message":"field: unknown not defined on type: Query","path":["query","unknown"]}]}`, rec.Body.String())
+}
+
+func TestGateway_UnhealthySubgraph(t *testing.T) {
+	accountsServer := httptest.NewServer(accounts.GraphQLEndpointHandler(accounts.TestOptions))
+	defer accountsServer.Close()
+	productsServer := httptest.NewServer(products.GraphQLEndpointHandler(products.TestOptions))
+	defer productsServer.Close()
+	reviewsServer := httptest.NewServer(reviews.GraphQLEndpointHandler(reviews.TestOptions))
+	defer reviewsServer.Close()
+
+	var productsHealthy atomic.Bool
+	productsHealthy.Store(true)
+	productsHealthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !productsHealthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer productsHealthServer.Close()
+
+	poller := NewPoller(http.DefaultClient, abstractlogger.NoopLogger, PollerConfig{
+		Services: []ServiceConfig{
+			{Name: "accounts", URL: accountsServer.URL},
+			{Name: "products", URL: productsServer.URL, HealthCheck: HealthCheckConfig{URL: productsHealthServer.URL}},
+			{Name: "reviews", URL: reviewsServer.URL},
+		},
+	})
+
+	gtw := NewGateway(context.Background(), NewGraphQLHTTPHandlerFactory(abstractlogger.NoopLogger),
+		WithHttpClient(&http.Client{Transport: poller.Transport(http.DefaultTransport)}),
+	)
+	poller.Register(gtw)
+
+	poller.checkHealth(context.Background())
+	poller.updateSDLs(context.Background())
+	gtw.Ready()
+
+	query := func(t *testing.T) string {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"query":"{ me { username reviews { body product { upc name } } } }"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		gtw.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	t.Run("should resolve all subgraphs while they are healthy", func(t *testing.T) {
+		assert.Equal(t, `{"data":{"me":{"username":"Me","reviews":[{"body":"A highly effective form of birth control.","product":{"upc":"top-1","name":"Trilby"}},{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits.","product":{"upc":"top-2","name":"Fedora"}}]}}}`, query(t))
+	})
+
+	t.Run("should fail the fields of an unhealthy subgraph and resolve the rest", func(t *testing.T) {
+		productsHealthy.Store(false)
+		poller.checkHealth(context.Background())
+
+		// product is non-nullable, so the null of the unavailable name bubbles up to the nullable review
+		assert.Equal(t, `{"errors":[{"message":"subgraph products is unavailable","extensions":{"code":"SUBGRAPH_UNAVAILABLE","serviceName":"products"}},{"message":"unable to resolve","locations":[{"line":1,"column":46}],"path":["me","reviews","0","product"]},{"message":"subgraph products is unavailable","extensions":{"code":"SUBGRAPH_UNAVAILABLE","serviceName":"products"}},{"message":"unable to resolve","locations":[{"line":1,"column":46}],"path":["me","reviews","1","product"]}],"data":{"me":{"username":"Me","reviews":[null,null]}}}`, query(t))
+	})
+
+	t.Run("should resolve the subgraph again as soon as it is healthy", func(t *testing.T) {
+		productsHealthy.Store(true)
+		poller.checkHealth(context.Background())
+
+		assert.Contains(t, query(t), `"product":{"upc":"top-1","name":"Trilby"}`)
+	})
+}
@@ -0,0 +1,261 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/jensneuse/abstractlogger"
+)
+
+// ErrCodeSubgraphUnavailable is the extensions code of the errors of fields owned by an unhealthy subgraph.
+const ErrCodeSubgraphUnavailable = "SUBGRAPH_UNAVAILABLE"
+
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// HealthCheckConfig configures how the health of a subgraph is checked.
+type HealthCheckConfig struct {
+	// URL is an endpoint which responds with a 2xx status code while the subgraph is healthy.
+	// Without a URL the subgraph is healthy as long as its SDL can be fetched.
+	URL string
+	// Timeout of a single health check, defaults to 5 seconds.
+	Timeout time.Duration
+	// UnhealthyThreshold is the number of consecutive failed checks after which the subgraph is unhealthy, defaults to 1.
+	UnhealthyThreshold int
+}
+
+// ServiceHealth describes the health of a single subgraph.
+type ServiceHealth struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// StaleSDL is true if the last SDL fetch failed and the last known good SDL of the subgraph is used.
+	StaleSDL bool `json:"staleSDL"`
+	// Composed is true if an SDL of the subgraph is known and part of the composition.
+	Composed            bool      `json:"composed"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastCheck           time.Time `json:"lastCheck"`
+	LastError           string    `json:"lastError,omitempty"`
+}
+
+// SubgraphUnavailableError is the error of all fields owned by a subgraph while it is unhealthy.
+type SubgraphUnavailableError struct {
+	ServiceName string
+}
+
+func (e *SubgraphUnavailableError) Error() string {
+	return fmt.Sprintf("subgraph %s is unavailable", e.ServiceName)
+}
+
+// graphqlResponse renders the error as the GraphQL response of the subgraph,
+// so that the fields of other subgraphs are resolved as usual.
+func (e *SubgraphUnavailableError) graphqlResponse() []byte {
+	response, _ := json.Marshal(map[string]interface{}{
+		"errors": []interface{}{
+			map[string]interface{}{
+				"message": e.Error(),
+				"extensions": map[string]string{
+					"code":        ErrCodeSubgraphUnavailable,
+					"serviceName": e.ServiceName,
+				},
+			},
+		},
+	})
+	return response
+}
+
+// serviceHealth is the mutable health state of a subgraph, guarded by the mutex of the Poller
+type serviceHealth struct {
+	healthy             bool
+	staleSDL            bool
+	consecutiveFailures int
+	lastCheck           time.Time
+	lastError           error
+}
+
+// recordCheck updates the health with the result of a check and reports whether the health changed
+func (s *serviceHealth) recordCheck(err error, threshold int, now time.Time) (changed bool) {
+	s.lastCheck = now
+	s.lastError = err
+	if err == nil {
+		s.consecutiveFailures = 0
+		changed = !s.healthy
+		s.healthy = true
+		return changed
+	}
+
+	s.consecutiveFailures++
+	if threshold < 1 {
+		threshold = 1
+	}
+	if s.healthy && s.consecutiveFailures >= threshold {
+		s.healthy = false
+		return true
+	}
+	return false
+}
+
+// ServiceHealth returns the health of all configured subgraphs.
+func (p *Poller) ServiceHealth() []ServiceHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	services := make([]ServiceHealth, 0, len(p.config.Services))
+	for _, serviceConfig := range p.config.Services {
+		health := p.health[serviceConfig.Name]
+		_, composed := p.sdlMap[serviceConfig.Name]
+		serviceHealth := ServiceHealth{
+			Name:                serviceConfig.Name,
+			Healthy:             health.healthy,
+			StaleSDL:            health.staleSDL,
+			Composed:            composed,
+			ConsecutiveFailures: health.consecutiveFailures,
+			LastCheck:           health.lastCheck,
+		}
+		if health.lastError != nil {
+			serviceHealth.LastError = health.lastError.Error()
+		}
+		services = append(services, serviceHealth)
+	}
+	return services
+}
+
+// IsHealthy reports whether the named subgraph is healthy.
+func (p *Poller) IsHealthy(serviceName string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	health, ok := p.health[serviceName]
+	return ok && health.healthy
+}
+
+// HealthHandler responds with the ServiceHealth of all subgraphs and 503 if at least one of them is unhealthy.
+func (p *Poller) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		services := p.ServiceHealth()
+
+		w.Header().Set("Content-Type", "application/json")
+		status := http.StatusOK
+		for i := range services {
+			if !services[i].Healthy {
+				status = http.StatusServiceUnavailable
+				break
+			}
+		}
+		w.WriteHeader(status)
+
+		if err := json.NewEncoder(w).Encode(services); err != nil {
+			p.logger.Error("gateway.Poller.HealthHandler", log.Error(err))
+		}
+	})
+}
+
+// Transport wraps next so that requests to an unhealthy subgraph fail fast.
+// Instead of waiting for the subgraph, such a request is answered with a GraphQL error
+// with the code ErrCodeSubgraphUnavailable, so that the fields of all other subgraphs still resolve.
+// The returned transport is meant for the http client of the Gateway, not for the Poller itself.
+func (p *Poller) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &healthAwareTransport{poller: p, next: next}
+}
+
+type healthAwareTransport struct {
+	poller *Poller
+	next   http.RoundTripper
+}
+
+func (t *healthAwareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	serviceName, ok := t.poller.serviceNameByURL(req.URL)
+	if !ok || t.poller.IsHealthy(serviceName) {
+		return t.next.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	body := (&SubgraphUnavailableError{ServiceName: serviceName}).graphqlResponse()
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (p *Poller) serviceNameByURL(requestURL *url.URL) (string, bool) {
+	for _, serviceConfig := range p.config.Services {
+		serviceURL, err := url.Parse(serviceConfig.URL)
+		if err != nil {
+			continue
+		}
+		if serviceURL.Host == requestURL.Host && strings.TrimSuffix(serviceURL.Path, "/") == strings.TrimSuffix(requestURL.Path, "/") {
+			return serviceConfig.Name, true
+		}
+	}
+	return "", false
+}
+
+// checkHealth runs the health checks of all subgraphs with a health check URL
+// and reports whether at least one of them recovered
+func (p *Poller) checkHealth(ctx context.Context) (recovered bool) {
+	for _, serviceConfig := range p.config.Services {
+		if serviceConfig.HealthCheck.URL == "" {
+			continue
+		}
+		err := p.runHealthCheck(ctx, serviceConfig.HealthCheck)
+		if ctx.Err() != nil {
+			return false
+		}
+		if err != nil {
+			p.logger.Error("gateway.Poller.checkHealth",
+				log.String("service", serviceConfig.Name),
+				log.Error(err),
+			)
+		}
+
+		p.mu.Lock()
+		health := p.health[serviceConfig.Name]
+		if health.recordCheck(err, serviceConfig.HealthCheck.UnhealthyThreshold, time.Now()) && health.healthy {
+			recovered = true
+		}
+		p.mu.Unlock()
+	}
+	return recovered
+}
+
+func (p *Poller) runHealthCheck(ctx context.Context, config HealthCheckConfig) error {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.URL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %v", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unhealthy status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/jensneuse/abstractlogger"
+
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+)
+
+// ServiceDefinitionQuery is the request body used to fetch the SDL of a federated subgraph.
+const ServiceDefinitionQuery = `
+	{
+		"query": "query __ApolloGetServiceDefinition__ { _service { sdl } }",
+		"operationName": "__ApolloGetServiceDefinition__",
+		"variables": {}
+	}`
+
+// ServiceConfig describes a single subgraph of the federated graph.
+type ServiceConfig struct {
+	// Name uniquely identifies the subgraph.
+	Name string
+	// URL is the http endpoint used for queries, mutations and for fetching the SDL.
+	URL string
+	// WS is the optional websocket endpoint used for subscriptions.
+	WS string
+	// HealthCheck optionally configures a dedicated health check of the subgraph.
+	HealthCheck HealthCheckConfig
+}
+
+// DataSourceObserver gets notified whenever the set of subgraph data sources changes.
+type DataSourceObserver interface {
+	UpdateDataSources(dataSourceConfig []graphqlDataSource.Configuration)
+}
+
+// DataSourceSubject is implemented by everything that can notify a DataSourceObserver.
+type DataSourceSubject interface {
+	Register(observer DataSourceObserver)
+}
+
+type GQLErr []struct {
+	Message string `json:"message"`
+}
+
+func (g GQLErr) Error() string {
+	var builder strings.Builder
+	for _, m := range g {
+		_ = builder.WriteByte('\t')
+		_, _ = builder.WriteString(m.Message)
+	}
+
+	return builder.String()
+}
+
+type PollerConfig struct {
+	Services []ServiceConfig
+	// PollingInterval defines how often the SDLs get fetched from the subgraphs.
+	// A zero value fetches the SDLs only once, further updates must be pushed via UpdateServiceSDL.
+	PollingInterval time.Duration
+	// HealthCheckInterval defines how often the subgraphs with a HealthCheck URL are checked.
+	// A zero value checks them whenever the SDLs get fetched.
+	HealthCheckInterval time.Duration
+}
+
+// NewPoller creates a Poller which fetches the SDLs of all configured subgraphs.
+func NewPoller(httpClient *http.Client, logger log.Logger, config PollerConfig) *Poller {
+	health := make(map[string]*serviceHealth, len(config.Services))
+	for _, serviceConfig := range config.Services {
+		health[serviceConfig.Name] = &serviceHealth{}
+	}
+
+	return &Poller{
+		httpClient: httpClient,
+		logger:     logger,
+		config:     config,
+		sdlMap:     make(map[string]string),
+		health:     health,
+	}
+}
+
+// Poller fetches the SDLs of the configured subgraphs and notifies
+// all registered observers whenever at least one SDL has changed.
+// SDLs can also be pushed into the Poller with UpdateServiceSDL.
+// If the SDL of a subgraph can't be fetched, the last known good SDL is composed,
+// a subgraph which has never been reachable is left out of the composition until it becomes reachable.
+type Poller struct {
+	httpClient *http.Client
+	logger     log.Logger
+
+	config PollerConfig
+
+	mu        sync.Mutex
+	sdlMap    map[string]string
+	health    map[string]*serviceHealth
+	observers []DataSourceObserver
+}
+
+func (p *Poller) Register(observer DataSourceObserver) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observers = append(p.observers, observer)
+}
+
+// Run fetches the SDLs immediately and afterwards in the configured interval until ctx is done.
+// The health checks run in their own interval, a recovered subgraph triggers fetching the SDLs.
+func (p *Poller) Run(ctx context.Context) {
+	p.checkHealth(ctx)
+	p.updateSDLs(ctx)
+
+	var pollingCh, healthCheckCh <-chan time.Time
+	if p.config.PollingInterval != 0 {
+		ticker := time.NewTicker(p.config.PollingInterval)
+		defer ticker.Stop()
+		pollingCh = ticker.C
+	}
+	if p.config.HealthCheckInterval != 0 {
+		ticker := time.NewTicker(p.config.HealthCheckInterval)
+		defer ticker.Stop()
+		healthCheckCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pollingCh:
+			if p.config.HealthCheckInterval == 0 {
+				p.checkHealth(ctx)
+			}
+			p.updateSDLs(ctx)
+		case <-healthCheckCh:
+			if p.checkHealth(ctx) {
+				p.updateSDLs(ctx)
+			}
+		}
+	}
+}
+
+// UpdateServiceSDL pushes a new SDL for the named subgraph.
+// A subgraph without a dedicated health check is healthy as soon as its SDL has been pushed.
+// Observers are only notified if the SDL differs from the last known one.
+func (p *Poller) UpdateServiceSDL(serviceName, sdl string) error {
+	serviceConfig, ok := p.serviceConfig(serviceName)
+	if !ok {
+		return fmt.Errorf("unknown service: %s", serviceName)
+	}
+
+	p.mu.Lock()
+	health := p.health[serviceName]
+	if serviceConfig.HealthCheck.URL == "" {
+		health.recordCheck(nil, serviceConfig.HealthCheck.UnhealthyThreshold, time.Now())
+	}
+	health.staleSDL = false
+	if current, exists := p.sdlMap[serviceName]; exists && current == sdl {
+		p.mu.Unlock()
+		return nil
+	}
+	p.sdlMap[serviceName] = sdl
+	p.mu.Unlock()
+
+	p.updateObservers()
+	return nil
+}
+
+func (p *Poller) serviceConfig(serviceName string) (ServiceConfig, bool) {
+	for i := range p.config.Services {
+		if p.config.Services[i].Name == serviceName {
+			return p.config.Services[i], true
+		}
+	}
+	return ServiceConfig{}, false
+}
+
+type fetchResult struct {
+	name string
+	sdl  string
+	err  error
+}
+
+func (p *Poller) updateSDLs(ctx context.Context) {
+	var wg sync.WaitGroup
+	resultCh := make(chan fetchResult)
+
+	for _, serviceConf := range p.config.Services {
+		serviceConf := serviceConf // Create new instance of serviceConf for the goroutine.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sdl, err := p.fetchServiceSDL(ctx, serviceConf.URL)
+
+			select {
+			case <-ctx.Done():
+			case resultCh <- fetchResult{name: serviceConf.Name, sdl: sdl, err: err}:
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	results := make([]fetchResult, 0, len(p.config.Services))
+	for result := range resultCh {
+		if result.err != nil {
+			p.logger.Error("gateway.Poller.updateSDLs",
+				log.String("service", result.name),
+				log.Error(result.err),
+			)
+		}
+		results = append(results, result)
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	now := time.Now()
+	changed := false
+
+	p.mu.Lock()
+	for _, result := range results {
+		serviceConfig, _ := p.serviceConfig(result.name)
+		health := p.health[result.name]
+		// without a dedicated health check the subgraph is healthy as long as its SDL can be fetched
+		if serviceConfig.HealthCheck.URL == "" {
+			health.recordCheck(result.err, serviceConfig.HealthCheck.UnhealthyThreshold, now)
+		}
+
+		if result.err != nil {
+			// the last known good SDL of an unavailable subgraph stays part of the composition
+			_, health.staleSDL = p.sdlMap[result.name]
+			continue
+		}
+		health.staleSDL = false
+		if sdl, exists := p.sdlMap[result.name]; !exists || sdl != result.sdl {
+			p.sdlMap[result.name] = result.sdl
+			changed = true
+		}
+	}
+	p.mu.Unlock()
+
+	if changed {
+		p.updateObservers()
+	}
+}
+
+func (p *Poller) updateObservers() {
+	p.mu.Lock()
+	dataSourceConfigs := p.createDatasourceConfig()
+	observers := make([]DataSourceObserver, len(p.observers))
+	copy(observers, p.observers)
+	p.mu.Unlock()
+
+	for i := range observers {
+		observers[i].UpdateDataSources(dataSourceConfigs)
+	}
+}
+
+func (p *Poller) createDatasourceConfig() []graphqlDataSource.Configuration {
+	dataSourceConfigs := make([]graphqlDataSource.Configuration, 0, len(p.config.Services))
+
+	for _, serviceConfig := range p.config.Services {
+		sdl, exists := p.sdlMap[serviceConfig.Name]
+		if !exists {
+			continue
+		}
+
+		dataSourceConfig := graphqlDataSource.Configuration{
+			Fetch: graphqlDataSource.FetchConfiguration{
+				URL:    serviceConfig.URL,
+				Method: http.MethodPost,
+			},
+			Subscription: graphqlDataSource.SubscriptionConfiguration{
+				URL: serviceConfig.WS,
+			},
+			Federation: graphqlDataSource.FederationConfiguration{
+				Enabled:    true,
+				ServiceSDL: sdl,
+			},
+		}
+
+		dataSourceConfigs = append(dataSourceConfigs, dataSourceConfig)
+	}
+
+	return dataSourceConfigs
+}
+
+func (p *Poller) fetchServiceSDL(ctx context.Context, serviceURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, serviceURL, bytes.NewReader([]byte(ServiceDefinitionQuery)))
+	if err != nil {
+		return "", fmt.Errorf("create request: %v", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("do request: %v", err)
+	}
+
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			Service struct {
+				SDL string `json:"sdl"`
+			} `json:"_service"`
+		} `json:"data"`
+		Errors GQLErr `json:"errors,omitempty"`
+	}
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read bytes: %v", err)
+	}
+
+	if err = json.NewDecoder(bytes.NewReader(bs)).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode response: %v", err)
+	}
+
+	if result.Errors != nil {
+		return "", fmt.Errorf("response error:%v", result.Errors)
+	}
+
+	return result.Data.Service.SDL, nil
+}
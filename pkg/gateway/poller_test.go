@@ -0,0 +1,301 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	log "github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+)
+
+type observerMock struct {
+	mu      sync.Mutex
+	updates [][]graphqlDataSource.Configuration
+}
+
+func (o *observerMock) UpdateDataSources(dataSourceConfig []graphqlDataSource.Configuration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.updates = append(o.updates, dataSourceConfig)
+}
+
+func (o *observerMock) Updates() [][]graphqlDataSource.Configuration {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.updates
+}
+
+func sdlServer(sdl *string, mu *sync.Mutex) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if *sdl == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"_service": map[string]string{"sdl": *sdl},
+			},
+		})
+	}))
+}
+
+func TestPoller(t *testing.T) {
+	mu := &sync.Mutex{}
+	sdl := accountsSDL
+	server := sdlServer(&sdl, mu)
+	defer server.Close()
+
+	poller := NewPoller(http.DefaultClient, log.NoopLogger, PollerConfig{
+		Services: []ServiceConfig{{Name: "accounts", URL: server.URL, WS: "ws://accounts.service"}},
+	})
+
+	observer := &observerMock{}
+	poller.Register(observer)
+
+	t.Run("should notify observers with fetched sdls", func(t *testing.T) {
+		poller.updateSDLs(context.Background())
+
+		updates := observer.Updates()
+		require.Len(t, updates, 1)
+		require.Len(t, updates[0], 1)
+		assert.Equal(t, server.URL, updates[0][0].Fetch.URL)
+		assert.Equal(t, "ws://accounts.service", updates[0][0].Subscription.URL)
+		assert.Equal(t, accountsSDL, updates[0][0].Federation.ServiceSDL)
+	})
+
+	t.Run("should not notify observers if nothing changed", func(t *testing.T) {
+		poller.updateSDLs(context.Background())
+		assert.Len(t, observer.Updates(), 1)
+	})
+
+	t.Run("should keep the current state if a service is unavailable", func(t *testing.T) {
+		mu.Lock()
+		sdl = ""
+		mu.Unlock()
+
+		poller.updateSDLs(context.Background())
+		assert.Len(t, observer.Updates(), 1)
+	})
+
+	t.Run("should notify observers about changed sdls", func(t *testing.T) {
+		mu.Lock()
+		sdl = accountsSDLWithEmail
+		mu.Unlock()
+
+		poller.updateSDLs(context.Background())
+		updates := observer.Updates()
+		require.Len(t, updates, 2)
+		assert.Equal(t, accountsSDLWithEmail, updates[1][0].Federation.ServiceSDL)
+	})
+
+	t.Run("should notify observers about pushed sdls", func(t *testing.T) {
+		require.NoError(t, poller.UpdateServiceSDL("accounts", accountsSDL))
+		updates := observer.Updates()
+		require.Len(t, updates, 3)
+		assert.Equal(t, accountsSDL, updates[2][0].Federation.ServiceSDL)
+
+		require.NoError(t, poller.UpdateServiceSDL("accounts", accountsSDL))
+		assert.Len(t, observer.Updates(), 3)
+	})
+
+	t.Run("should reject pushed sdls of unknown services", func(t *testing.T) {
+		assert.Error(t, poller.UpdateServiceSDL("products", accountsSDL))
+	})
+}
+
+func TestPoller_Degradation(t *testing.T) {
+	mu := &sync.Mutex{}
+	accounts := accountsSDL
+	accountsServer := sdlServer(&accounts, mu)
+	defer accountsServer.Close()
+	products := ""
+	productsServer := sdlServer(&products, mu)
+	defer productsServer.Close()
+
+	poller := NewPoller(http.DefaultClient, log.NoopLogger, PollerConfig{
+		Services: []ServiceConfig{
+			{Name: "accounts", URL: accountsServer.URL},
+			{Name: "products", URL: productsServer.URL},
+		},
+	})
+
+	observer := &observerMock{}
+	poller.Register(observer)
+
+	t.Run("should compose the available services if a service is unavailable at startup", func(t *testing.T) {
+		poller.updateSDLs(context.Background())
+
+		updates := observer.Updates()
+		require.Len(t, updates, 1)
+		require.Len(t, updates[0], 1)
+		assert.Equal(t, accountsServer.URL, updates[0][0].Fetch.URL)
+
+		assert.True(t, poller.IsHealthy("accounts"))
+		assert.False(t, poller.IsHealthy("products"))
+	})
+
+	t.Run("should add a service as soon as it is available", func(t *testing.T) {
+		mu.Lock()
+		products = productsSDL
+		mu.Unlock()
+
+		poller.updateSDLs(context.Background())
+
+		updates := observer.Updates()
+		require.Len(t, updates, 2)
+		require.Len(t, updates[1], 2)
+		assert.Equal(t, productsSDL, updates[1][1].Federation.ServiceSDL)
+		assert.True(t, poller.IsHealthy("products"))
+	})
+
+	t.Run("should compose the last known good sdl of an unavailable service", func(t *testing.T) {
+		mu.Lock()
+		products = ""
+		mu.Unlock()
+
+		poller.updateSDLs(context.Background())
+		assert.Len(t, observer.Updates(), 2)
+
+		health := poller.ServiceHealth()
+		require.Len(t, health, 2)
+		assert.Equal(t, ServiceHealth{Name: "accounts", Healthy: true, Composed: true, LastCheck: health[0].LastCheck}, health[0])
+		assert.Equal(t, "products", health[1].Name)
+		assert.False(t, health[1].Healthy)
+		assert.True(t, health[1].StaleSDL)
+		assert.True(t, health[1].Composed)
+		assert.Equal(t, 1, health[1].ConsecutiveFailures)
+		assert.NotEmpty(t, health[1].LastError)
+
+		rec := httptest.NewRecorder()
+		poller.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("should be healthy after an sdl has been pushed for an unavailable service", func(t *testing.T) {
+		require.NoError(t, poller.UpdateServiceSDL("products", productsSDL))
+
+		assert.True(t, poller.IsHealthy("products"))
+		health := poller.ServiceHealth()
+		require.Len(t, health, 2)
+		assert.False(t, health[1].StaleSDL)
+		assert.Equal(t, 0, health[1].ConsecutiveFailures)
+		assert.Empty(t, health[1].LastError)
+
+		rec := httptest.NewRecorder()
+		poller.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestPoller_HealthCheck(t *testing.T) {
+	mu := &sync.Mutex{}
+	sdl := accountsSDL
+	server := sdlServer(&sdl, mu)
+	defer server.Close()
+
+	var healthy atomic.Bool
+	healthy.Store(true)
+	healthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer healthServer.Close()
+
+	poller := NewPoller(http.DefaultClient, log.NoopLogger, PollerConfig{
+		Services: []ServiceConfig{
+			{
+				Name: "accounts",
+				URL:  server.URL,
+				HealthCheck: HealthCheckConfig{
+					URL:                healthServer.URL,
+					UnhealthyThreshold: 2,
+				},
+			},
+		},
+	})
+
+	t.Run("should be healthy after a successful check", func(t *testing.T) {
+		assert.False(t, poller.IsHealthy("accounts"))
+		assert.True(t, poller.checkHealth(context.Background()))
+		assert.True(t, poller.IsHealthy("accounts"))
+	})
+
+	t.Run("should be unhealthy after reaching the threshold of consecutive failures", func(t *testing.T) {
+		healthy.Store(false)
+
+		assert.False(t, poller.checkHealth(context.Background()))
+		assert.True(t, poller.IsHealthy("accounts"))
+
+		assert.False(t, poller.checkHealth(context.Background()))
+		assert.False(t, poller.IsHealthy("accounts"))
+	})
+
+	t.Run("should not take the sdl fetch into account", func(t *testing.T) {
+		poller.updateSDLs(context.Background())
+		assert.False(t, poller.IsHealthy("accounts"))
+	})
+
+	t.Run("should not take pushed sdls into account", func(t *testing.T) {
+		require.NoError(t, poller.UpdateServiceSDL("accounts", accountsSDLWithEmail))
+		assert.False(t, poller.IsHealthy("accounts"))
+	})
+
+	t.Run("should report a recovered service", func(t *testing.T) {
+		healthy.Store(true)
+
+		assert.True(t, poller.checkHealth(context.Background()))
+		assert.True(t, poller.IsHealthy("accounts"))
+	})
+}
+
+func TestPoller_Transport(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Inc()
+		_, _ = w.Write([]byte(`{"data":{"me":{"id":"1"}}}`))
+	}))
+	defer server.Close()
+
+	poller := NewPoller(http.DefaultClient, log.NoopLogger, PollerConfig{
+		Services: []ServiceConfig{{Name: "accounts", URL: server.URL}},
+	})
+	client := &http.Client{Transport: poller.Transport(nil)}
+
+	post := func(t *testing.T) string {
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"{me {id}}"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	t.Run("should fail fast while the service is unhealthy", func(t *testing.T) {
+		assert.Equal(t, `{"errors":[{"extensions":{"code":"SUBGRAPH_UNAVAILABLE","serviceName":"accounts"},"message":"subgraph accounts is unavailable"}]}`, post(t))
+		assert.Equal(t, int32(0), requests.Load())
+	})
+
+	t.Run("should forward requests while the service is healthy", func(t *testing.T) {
+		poller.mu.Lock()
+		poller.health["accounts"].healthy = true
+		poller.mu.Unlock()
+
+		assert.Equal(t, `{"data":{"me":{"id":"1"}}}`, post(t))
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
 	"github.com/wundergraph/graphql-go-tools/pkg/federation"
+	"github.com/wundergraph/graphql-go-tools/pkg/federation/sdlmerge"
 )
 
 type federationEngineConfigFactoryOptions struct {
@@ -83,6 +84,48 @@ func NewFederationEngineConfigFactory(dataSourceConfigs []graphqlDataSource.Conf
 	}
 }
 
+// NewFederationEngineConfigFactoryFromSupergraph creates a FederationEngineConfigFactory from a supergraph SDL
+// annotated with the directives of the Apollo join spec instead of the individual subgraph SDLs,
+// e.g. a supergraph composed with sdlmerge.MergeSupergraph.
+// The data source configurations are looked up by subgraph name. The fetch URL defaults to the URL
+// of the subgraph in the supergraph, the federation SDL of every subgraph is taken from the supergraph.
+func NewFederationEngineConfigFactoryFromSupergraph(supergraphSDL string, dataSourceConfigs map[string]graphqlDataSource.Configuration, batchFactory resolve.DataSourceBatchFactory, opts ...FederationEngineConfigFactoryOption) (*FederationEngineConfigFactory, error) {
+	supergraph, err := sdlmerge.ParseSupergraph(supergraphSDL)
+	if err != nil {
+		return nil, fmt.Errorf("parse supergraph: %w", err)
+	}
+
+	subgraphNames := make(map[string]struct{}, len(supergraph.Subgraphs))
+	configs := make([]graphqlDataSource.Configuration, 0, len(supergraph.Subgraphs))
+	for _, subgraph := range supergraph.Subgraphs {
+		subgraphNames[subgraph.Name] = struct{}{}
+
+		config := dataSourceConfigs[subgraph.Name]
+		if config.Fetch.URL == "" {
+			config.Fetch.URL = subgraph.URL
+		}
+		if config.Fetch.URL == "" {
+			return nil, fmt.Errorf("subgraph %q has no url", subgraph.Name)
+		}
+		config.Federation.Enabled = true
+		config.Federation.ServiceSDL = subgraph.SDL
+		configs = append(configs, config)
+	}
+
+	for name := range dataSourceConfigs {
+		if _, exists := subgraphNames[name]; !exists {
+			return nil, fmt.Errorf("subgraph %q is not part of the supergraph", name)
+		}
+	}
+
+	factory := NewFederationEngineConfigFactory(configs, batchFactory, opts...)
+	if err := factory.SetMergedSchemaFromString(supergraph.APISchema); err != nil {
+		return nil, err
+	}
+
+	return factory, nil
+}
+
 // FederationEngineConfigFactory is used to create a v2 engine config for a supergraph with multiple data sources for subgraphs.
 type FederationEngineConfigFactory struct {
 	httpClient                *http.Client
@@ -133,12 +176,17 @@ func (f *FederationEngineConfigFactory) EngineV2Configuration() (conf EngineV2Co
 
 	conf = NewEngineV2Configuration(schema)
 
-	fieldConfigs, err := f.engineConfigFieldConfigs(schema)
+	dataSourceConfigs, err := f.planningDataSourceConfigs()
+	if err != nil {
+		return conf, fmt.Errorf("prepare subgraphs for planning: %v", err)
+	}
+
+	fieldConfigs, err := f.engineConfigFieldConfigs(schema, dataSourceConfigs)
 	if err != nil {
 		return conf, fmt.Errorf("create field configs: %v", err)
 	}
 
-	dataSources, err := f.engineConfigDataSources()
+	dataSources, err := f.engineConfigDataSources(dataSourceConfigs)
 	if err != nil {
 		return conf, fmt.Errorf("create datasource config: %v", err)
 	}
@@ -153,10 +201,57 @@ func (f *FederationEngineConfigFactory) EngineV2Configuration() (conf EngineV2Co
 	return conf, nil
 }
 
-func (f *FederationEngineConfigFactory) engineConfigFieldConfigs(schema *Schema) (plan.FieldConfigurations, error) {
+// EngineV2ContractConfiguration derives a contract from the engine configuration of the supergraph, see NewContract.
+// The tags are taken from the @tag directives of the subgraphs because the composed schema doesn't contain them.
+func (f *FederationEngineConfigFactory) EngineV2ContractConfiguration(contractConfig ContractConfiguration) (*Schema, EngineV2Configuration, error) {
+	conf, err := f.EngineV2Configuration()
+	if err != nil {
+		return nil, conf, err
+	}
+
+	SDLs := make([]string, len(f.dataSourceConfigs))
+	for i := range f.dataSourceConfigs {
+		SDLs[i] = f.dataSourceConfigs[i].Federation.ServiceSDL
+	}
+	subgraphTags, err := sdlmerge.SubgraphTags(SDLs...)
+	if err != nil {
+		return nil, conf, fmt.Errorf("collect subgraph tags: %w", err)
+	}
+
+	tags := make(schemaTags, len(subgraphTags))
+	for coordinate, names := range subgraphTags {
+		for _, name := range names {
+			tags.add(coordinate, name)
+		}
+	}
+
+	return newContract(conf, tags, contractConfig)
+}
+
+// planningDataSourceConfigs returns the data source configurations with the SDLs of the subgraphs as they are used for planning,
+// so that fields taken over by @override are only resolved by the overriding subgraph, see sdlmerge.PlanningSDLs
+func (f *FederationEngineConfigFactory) planningDataSourceConfigs() ([]graphqlDataSource.Configuration, error) {
+	SDLs := make([]string, len(f.dataSourceConfigs))
+	for i := range f.dataSourceConfigs {
+		SDLs[i] = f.dataSourceConfigs[i].Federation.ServiceSDL
+	}
+	planningSDLs, err := sdlmerge.PlanningSDLs(SDLs...)
+	if err != nil {
+		return nil, err
+	}
+
+	dataSourceConfigs := make([]graphqlDataSource.Configuration, len(f.dataSourceConfigs))
+	for i := range f.dataSourceConfigs {
+		dataSourceConfigs[i] = f.dataSourceConfigs[i]
+		dataSourceConfigs[i].Federation.ServiceSDL = planningSDLs[i]
+	}
+	return dataSourceConfigs, nil
+}
+
+func (f *FederationEngineConfigFactory) engineConfigFieldConfigs(schema *Schema, dataSourceConfigs []graphqlDataSource.Configuration) (plan.FieldConfigurations, error) {
 	var planFieldConfigs plan.FieldConfigurations
 
-	for _, dataSourceConfig := range f.dataSourceConfigs {
+	for _, dataSourceConfig := range dataSourceConfigs {
 		doc, report := astparser.ParseGraphqlDocumentString(dataSourceConfig.Federation.ServiceSDL)
 		if report.HasErrors() {
 			return nil, fmt.Errorf("parse graphql document string: %s", report.Error())
@@ -169,8 +264,8 @@ func (f *FederationEngineConfigFactory) engineConfigFieldConfigs(schema *Schema)
 	return planFieldConfigs, nil
 }
 
-func (f *FederationEngineConfigFactory) engineConfigDataSources() (planDataSources []plan.DataSourceConfiguration, err error) {
-	for _, dataSourceConfig := range f.dataSourceConfigs {
+func (f *FederationEngineConfigFactory) engineConfigDataSources(dataSourceConfigs []graphqlDataSource.Configuration) (planDataSources []plan.DataSourceConfiguration, err error) {
+	for _, dataSourceConfig := range dataSourceConfigs {
 		doc, report := astparser.ParseGraphqlDocumentString(dataSourceConfig.Federation.ServiceSDL)
 		if report.HasErrors() {
 			return nil, fmt.Errorf("parse graphql document string: %s", report.Error())
@@ -1,9 +1,14 @@
 package graphql
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/jensneuse/abstractlogger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -12,6 +17,7 @@ import (
 	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/federation/sdlmerge"
 )
 
 func TestEngineConfigV2Factory_EngineV2Configuration(t *testing.T) {
@@ -214,6 +220,13 @@ func TestEngineConfigV2Factory_EngineV2Configuration(t *testing.T) {
 							FieldNames: []string{"reviews", "id", "username"},
 						},
 					},
+					Provides: []plan.ProvidesConfiguration{
+						{
+							TypeName:   "Review",
+							FieldName:  "author",
+							FieldPaths: []string{"username"},
+						},
+					},
 					Factory: &graphqlDataSource.Factory{
 						HTTPClient:         httpClient,
 						StreamingClient:    streamingClient,
@@ -296,3 +309,214 @@ const (
 	}
 `
 )
+
+func TestNewFederationEngineConfigFactoryFromSupergraph(t *testing.T) {
+	supergraphSDL, err := sdlmerge.MergeSupergraph(
+		sdlmerge.Subgraph{Name: "accounts", URL: "http://user.service", SDL: accountSchema},
+		sdlmerge.Subgraph{Name: "products", URL: "http://product.service", SDL: productSchema},
+		sdlmerge.Subgraph{Name: "reviews", URL: "http://review.service", SDL: reviewSchema},
+	)
+	require.NoError(t, err)
+
+	batchFactory := graphqlDataSource.NewBatchFactory()
+
+	t.Run("should create the same engine V2 configuration as from the subgraph SDLs", func(t *testing.T) {
+		subgraphFactory := NewFederationEngineConfigFactory([]graphqlDataSource.Configuration{
+			{
+				Fetch:      graphqlDataSource.FetchConfiguration{URL: "http://user.service"},
+				Federation: graphqlDataSource.FederationConfiguration{Enabled: true, ServiceSDL: accountSchema},
+			},
+			{
+				Fetch:      graphqlDataSource.FetchConfiguration{URL: "http://product.service"},
+				Federation: graphqlDataSource.FederationConfiguration{Enabled: true, ServiceSDL: productSchema},
+			},
+			{
+				Fetch:      graphqlDataSource.FetchConfiguration{URL: "http://review.service"},
+				Federation: graphqlDataSource.FederationConfiguration{Enabled: true, ServiceSDL: reviewSchema},
+			},
+		}, batchFactory)
+		expected, err := subgraphFactory.EngineV2Configuration()
+		require.NoError(t, err)
+
+		supergraphFactory, err := NewFederationEngineConfigFactoryFromSupergraph(supergraphSDL, nil, batchFactory)
+		require.NoError(t, err)
+		actual, err := supergraphFactory.EngineV2Configuration()
+		require.NoError(t, err)
+
+		assert.Equal(t, expected.schema.Document(), actual.schema.Document())
+		assert.ElementsMatch(t, expected.FieldConfigurations(), actual.FieldConfigurations())
+		require.Len(t, actual.DataSources(), 3)
+		for i, dataSource := range actual.DataSources() {
+			assert.ElementsMatch(t, expected.DataSources()[i].RootNodes, dataSource.RootNodes)
+			assert.ElementsMatch(t, expected.DataSources()[i].ChildNodes, dataSource.ChildNodes)
+			assert.Equal(t, expected.DataSources()[i].Provides, dataSource.Provides)
+		}
+	})
+
+	t.Run("should apply the data source configurations by subgraph name", func(t *testing.T) {
+		factory, err := NewFederationEngineConfigFactoryFromSupergraph(supergraphSDL, map[string]graphqlDataSource.Configuration{
+			"reviews": {
+				Fetch: graphqlDataSource.FetchConfiguration{
+					URL:    "http://reviews.internal",
+					Header: http.Header{"X-Subgraph": []string{"reviews"}},
+				},
+				Subscription: graphqlDataSource.SubscriptionConfiguration{UseSSE: true},
+			},
+		}, batchFactory)
+		require.NoError(t, err)
+
+		conf, err := factory.EngineV2Configuration()
+		require.NoError(t, err)
+		require.Len(t, conf.DataSources(), 3)
+
+		var products, reviews graphqlDataSource.Configuration
+		require.NoError(t, json.Unmarshal(conf.DataSources()[1].Custom, &products))
+		require.NoError(t, json.Unmarshal(conf.DataSources()[2].Custom, &reviews))
+
+		assert.Equal(t, "http://product.service", products.Fetch.URL)
+		assert.Equal(t, "http://reviews.internal", reviews.Fetch.URL)
+		assert.Equal(t, http.Header{"X-Subgraph": []string{"reviews"}}, reviews.Fetch.Header)
+		assert.True(t, reviews.Subscription.UseSSE)
+		assert.True(t, reviews.Federation.Enabled)
+		assert.Contains(t, reviews.Federation.ServiceSDL, `extend type User @key(fields: "id")`)
+	})
+
+	t.Run("should fail for data source configurations of unknown subgraphs", func(t *testing.T) {
+		_, err := NewFederationEngineConfigFactoryFromSupergraph(supergraphSDL, map[string]graphqlDataSource.Configuration{
+			"inventory": {},
+		}, batchFactory)
+		assert.EqualError(t, err, `subgraph "inventory" is not part of the supergraph`)
+	})
+
+	t.Run("should fail without join__Graph enum", func(t *testing.T) {
+		_, err := NewFederationEngineConfigFactoryFromSupergraph(baseFederationSchema, nil, batchFactory)
+		assert.ErrorIs(t, err, sdlmerge.ErrMissingJoinGraphEnum)
+	})
+}
+
+func TestFederationEngineConfigFactory_Override(t *testing.T) {
+	accountsSDL := `
+		extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+		type Query {
+			me: User
+		}
+		type User @key(fields: "id") {
+			id: ID!
+			name: String
+		}
+	`
+	profilesSDL := `
+		extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: [{ name: "@key", as: "@primaryKey" }])
+		type User @primaryKey(fields: "id") {
+			id: ID!
+			name: String @federation__override(from: "accounts")
+		}
+	`
+
+	var accountsRequests, profilesRequests []string
+	accounts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		accountsRequests = append(accountsRequests, string(body))
+		_, _ = w.Write([]byte(`{"data":{"me":{"__typename":"User","id":"1","name":"old"}}}`))
+	}))
+	defer accounts.Close()
+	profiles := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		profilesRequests = append(profilesRequests, string(body))
+		_, _ = w.Write([]byte(`{"data":{"_entities":[{"__typename":"User","name":"new"}]}}`))
+	}))
+	defer profiles.Close()
+
+	factory := NewFederationEngineConfigFactory([]graphqlDataSource.Configuration{
+		{
+			Fetch:      graphqlDataSource.FetchConfiguration{URL: accounts.URL, Method: http.MethodPost},
+			Federation: graphqlDataSource.FederationConfiguration{Enabled: true, ServiceSDL: accountsSDL},
+		},
+		{
+			Fetch:      graphqlDataSource.FetchConfiguration{URL: profiles.URL, Method: http.MethodPost},
+			Federation: graphqlDataSource.FederationConfiguration{Enabled: true, ServiceSDL: profilesSDL},
+		},
+	}, graphqlDataSource.NewBatchFactory())
+	engineConfig, err := factory.EngineV2Configuration()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine, err := NewExecutionEngineV2(ctx, abstractlogger.Noop{}, engineConfig)
+	require.NoError(t, err)
+
+	resultWriter := NewEngineResultWriter()
+	err = engine.Execute(ctx, &Request{Query: `{ me { name } }`}, &resultWriter)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"data":{"me":{"name":"new"}}}`, resultWriter.String())
+	require.Len(t, accountsRequests, 1)
+	assert.NotContains(t, accountsRequests[0], "name")
+	require.Len(t, profilesRequests, 1)
+	assert.Contains(t, profilesRequests[0], "_entities")
+	assert.Contains(t, profilesRequests[0], "name")
+}
+
+func TestFederationEngineConfigFactory_Requires(t *testing.T) {
+	accountsSDL := `
+		extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])
+		type Query {
+			me: User
+		}
+		type User @key(fields: "id") {
+			id: ID!
+			secret: String!
+		}
+	`
+	greetingsSDL := `
+		extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@external"])
+		type User @key(fields: "id") {
+			id: ID!
+			secret: String! @external
+			greeting: String! @federation__requires(fields: "secret")
+		}
+	`
+
+	var accountsRequests, greetingsRequests []string
+	accounts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		accountsRequests = append(accountsRequests, string(body))
+		_, _ = w.Write([]byte(`{"data":{"me":{"__typename":"User","id":"1","secret":"s3cret"}}}`))
+	}))
+	defer accounts.Close()
+	greetings := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		greetingsRequests = append(greetingsRequests, string(body))
+		_, _ = w.Write([]byte(`{"data":{"_entities":[{"__typename":"User","greeting":"hello"}]}}`))
+	}))
+	defer greetings.Close()
+
+	factory := NewFederationEngineConfigFactory([]graphqlDataSource.Configuration{
+		{
+			Fetch:      graphqlDataSource.FetchConfiguration{URL: accounts.URL, Method: http.MethodPost},
+			Federation: graphqlDataSource.FederationConfiguration{Enabled: true, ServiceSDL: accountsSDL},
+		},
+		{
+			Fetch:      graphqlDataSource.FetchConfiguration{URL: greetings.URL, Method: http.MethodPost},
+			Federation: graphqlDataSource.FederationConfiguration{Enabled: true, ServiceSDL: greetingsSDL},
+		},
+	}, graphqlDataSource.NewBatchFactory())
+	engineConfig, err := factory.EngineV2Configuration()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine, err := NewExecutionEngineV2(ctx, abstractlogger.Noop{}, engineConfig)
+	require.NoError(t, err)
+
+	resultWriter := NewEngineResultWriter()
+	err = engine.Execute(ctx, &Request{Query: `{ me { greeting } }`}, &resultWriter)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"data":{"me":{"greeting":"hello"}}}`, resultWriter.String())
+	require.Len(t, accountsRequests, 1)
+	assert.Contains(t, accountsRequests[0], "secret")
+	require.Len(t, greetingsRequests, 1)
+	assert.Contains(t, greetingsRequests[0], "_entities")
+	assert.Contains(t, greetingsRequests[0], `"representations":[{"secret":"s3cret","id":"1","__typename":"User"}]`)
+}
@@ -0,0 +1,534 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+)
+
+const tagDirectiveName = "tag"
+
+var ErrContractWithoutQueryFields = errors.New("contract does not contain any query field")
+
+// ContractConfiguration selects the types and fields of a contract by their @tag directives.
+type ContractConfiguration struct {
+	// IncludeTags keeps only the object and interface types and fields which are tagged with one of the tags.
+	// All fields of an included type are kept. Nothing is filtered by inclusion if IncludeTags is empty.
+	IncludeTags []string
+	// ExcludeTags removes the types, fields, arguments, input fields and enum values which are tagged with one of the tags.
+	// Exclusion takes precedence over inclusion.
+	ExcludeTags []string
+}
+
+// schemaTags maps schema coordinates like "Type", "Type.field" or "Type.field.argument" to their tags.
+type schemaTags map[string]map[string]struct{}
+
+func (s schemaTags) add(coordinate, tag string) {
+	if _, ok := s[coordinate]; !ok {
+		s[coordinate] = make(map[string]struct{})
+	}
+	s[coordinate][tag] = struct{}{}
+}
+
+func (s schemaTags) hasAny(coordinate string, tags map[string]struct{}) bool {
+	for tag := range s[coordinate] {
+		if _, ok := tags[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// NewContract derives a contract from the engine configuration by filtering the schema by the @tag directives
+// of its types and fields. Types which are no longer reachable from the root operation types are removed.
+// Fields and arguments removed from an implementation of an interface are removed from the interface as well.
+// The contract schema is validated, an invalid contract is returned as SchemaValidationErrors.
+// The returned schema is meant for validation and introspection, the returned engine configuration uses it
+// for the same purposes but still plans operations against the full schema, so the data sources stay intact.
+func NewContract(engineConfig EngineV2Configuration, contractConfig ContractConfiguration) (*Schema, EngineV2Configuration, error) {
+	return newContract(engineConfig, make(schemaTags), contractConfig)
+}
+
+func newContract(engineConfig EngineV2Configuration, tags schemaTags, contractConfig ContractConfiguration) (*Schema, EngineV2Configuration, error) {
+	if engineConfig.schema == nil {
+		return nil, engineConfig, errors.New("engine configuration has no schema")
+	}
+
+	document, report := astparser.ParseGraphqlDocumentBytes(engineConfig.schema.rawSchema)
+	if report.HasErrors() {
+		return nil, engineConfig, fmt.Errorf("parse schema: %w", report)
+	}
+
+	filter := contractFilter{
+		document: &document,
+		tags:     tags,
+		include:  stringSet(contractConfig.IncludeTags),
+		exclude:  stringSet(contractConfig.ExcludeTags),
+		removed:  make(map[string]struct{}),
+	}
+	filter.collectTags()
+	if err := filter.apply(); err != nil {
+		return nil, engineConfig, err
+	}
+
+	contractSchemaContent, err := astprinter.PrintStringIndent(&document, nil, "  ")
+	if err != nil {
+		return nil, engineConfig, fmt.Errorf("stringify contract schema: %w", err)
+	}
+	contractSchema, err := createSchema([]byte(contractSchemaContent), false)
+	if err != nil {
+		return nil, engineConfig, fmt.Errorf("create contract schema: %w", err)
+	}
+	validationResult, err := contractSchema.Validate()
+	if err != nil {
+		return nil, engineConfig, fmt.Errorf("validate contract schema: %w", err)
+	}
+	if !validationResult.Valid {
+		return nil, engineConfig, fmt.Errorf("validate contract schema: %w", validationResult.Errors)
+	}
+
+	contractEngineConfig := engineConfig
+	contractEngineConfig.schema = contractSchema
+	contractEngineConfig.executionSchema = engineConfig.executionSchemaOrSchema()
+	// the data sources and field configurations are copied, so adding to one configuration doesn't affect the other
+	contractEngineConfig.plannerConfig.DataSources = append([]plan.DataSourceConfiguration(nil), engineConfig.plannerConfig.DataSources...)
+	contractEngineConfig.plannerConfig.Fields = append(plan.FieldConfigurations(nil), engineConfig.plannerConfig.Fields...)
+
+	return contractSchema, contractEngineConfig, nil
+}
+
+func stringSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
+
+// contractFilter removes the types and fields from a schema document which are not part of a contract.
+type contractFilter struct {
+	document *ast.Document
+	tags     schemaTags
+	include  map[string]struct{}
+	exclude  map[string]struct{}
+	// removed contains the names of the removed types
+	removed map[string]struct{}
+}
+
+func (c *contractFilter) collectTags() {
+	for _, node := range c.document.RootNodes {
+		typeName := c.document.NodeNameString(node)
+		c.collectDirectiveTags(typeName, c.document.NodeDirectives(node))
+
+		for _, fieldRef := range c.document.NodeFieldDefinitions(node) {
+			fieldCoordinate := typeName + "." + c.document.FieldDefinitionNameString(fieldRef)
+			c.collectDirectiveTags(fieldCoordinate, c.document.FieldDefinitions[fieldRef].Directives.Refs)
+			for _, argumentRef := range c.document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs {
+				c.collectDirectiveTags(fieldCoordinate+"."+c.document.InputValueDefinitionNameString(argumentRef), c.document.InputValueDefinitions[argumentRef].Directives.Refs)
+			}
+		}
+		for _, inputValueRef := range c.document.NodeInputFieldDefinitions(node) {
+			c.collectDirectiveTags(typeName+"."+c.document.InputValueDefinitionNameString(inputValueRef), c.document.InputValueDefinitions[inputValueRef].Directives.Refs)
+		}
+		if node.Kind == ast.NodeKindEnumTypeDefinition {
+			for _, valueRef := range c.document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs {
+				c.collectDirectiveTags(typeName+"."+c.document.EnumValueDefinitionNameString(valueRef), c.document.EnumValueDefinitions[valueRef].Directives.Refs)
+			}
+		}
+	}
+}
+
+func (c *contractFilter) collectDirectiveTags(coordinate string, directiveRefs []int) {
+	for _, directiveRef := range directiveRefs {
+		if c.document.DirectiveNameString(directiveRef) != tagDirectiveName {
+			continue
+		}
+		value, ok := c.document.DirectiveArgumentValueByName(directiveRef, []byte("name"))
+		if !ok || value.Kind != ast.ValueKindString {
+			continue
+		}
+		c.tags.add(coordinate, c.document.StringValueContentString(value.Ref))
+	}
+}
+
+func (c *contractFilter) apply() error {
+	c.filterByTags()
+
+	// removing a type can make other types empty or unreachable, so the document is cleaned up until nothing changes
+	for {
+		removedTypes := len(c.removed)
+		c.removeInterfaceFieldsMissingInImplementations()
+		c.removeReferencesToRemovedTypes()
+		c.removeUnreachableTypes()
+		if len(c.removed) == removedTypes {
+			break
+		}
+	}
+
+	var rootNodesToRemove []ast.Node
+	for _, node := range c.document.RootNodes {
+		if _, ok := c.removed[c.document.NodeNameString(node)]; ok && node.Kind != ast.NodeKindSchemaDefinition {
+			rootNodesToRemove = append(rootNodesToRemove, node)
+		}
+	}
+	c.document.DeleteRootNodes(rootNodesToRemove)
+	c.updateBodyFlags()
+
+	return c.filterRootOperationTypes()
+}
+
+// removeInterfaceFieldsMissingInImplementations removes the fields and arguments of an interface which have been
+// removed from one of its implementations, so that the implementations still satisfy the interface.
+func (c *contractFilter) removeInterfaceFieldsMissingInImplementations() {
+	for _, node := range c.document.RootNodes {
+		if node.Kind != ast.NodeKindObjectTypeDefinition && node.Kind != ast.NodeKindInterfaceTypeDefinition {
+			continue
+		}
+		if _, ok := c.removed[c.document.NodeNameString(node)]; ok {
+			continue
+		}
+		for _, interfaceRef := range *c.implementedInterfaces(node) {
+			interfaceNode, ok := c.interfaceNode(c.document.TypeNameString(interfaceRef))
+			if !ok {
+				continue
+			}
+			c.filterFields(interfaceNode, func(ref int) bool {
+				fieldName := c.document.FieldDefinitionNameBytes(ref)
+				if _, ok := c.document.NodeFieldDefinitionByName(node, fieldName); !ok {
+					return isReservedName(string(fieldName))
+				}
+				c.filterInputValues(&c.document.FieldDefinitions[ref].ArgumentsDefinition.Refs, func(argumentRef int) bool {
+					argumentName := c.document.InputValueDefinitionNameBytes(argumentRef)
+					return c.document.NodeFieldDefinitionArgumentDefinitionByName(node, fieldName, argumentName) != -1
+				})
+				return true
+			})
+		}
+	}
+}
+
+func (c *contractFilter) interfaceNode(typeName string) (ast.Node, bool) {
+	nodes, _ := c.document.Index.NodesByNameStr(typeName)
+	for _, node := range nodes {
+		if node.Kind == ast.NodeKindInterfaceTypeDefinition {
+			return node, true
+		}
+	}
+	return ast.Node{}, false
+}
+
+// updateBodyFlags keeps the flags of the filtered definitions in sync with their remaining refs
+func (c *contractFilter) updateBodyFlags() {
+	for _, node := range c.document.RootNodes {
+		for _, fieldRef := range c.document.NodeFieldDefinitions(node) {
+			c.document.FieldDefinitions[fieldRef].HasArgumentsDefinitions = len(c.document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs) > 0
+		}
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition:
+			c.document.ObjectTypeDefinitions[node.Ref].HasFieldDefinitions = len(c.document.ObjectTypeDefinitions[node.Ref].FieldsDefinition.Refs) > 0
+		case ast.NodeKindInterfaceTypeDefinition:
+			c.document.InterfaceTypeDefinitions[node.Ref].HasFieldDefinitions = len(c.document.InterfaceTypeDefinitions[node.Ref].FieldsDefinition.Refs) > 0
+		case ast.NodeKindInputObjectTypeDefinition:
+			c.document.InputObjectTypeDefinitions[node.Ref].HasInputFieldsDefinition = len(c.document.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs) > 0
+		case ast.NodeKindEnumTypeDefinition:
+			c.document.EnumTypeDefinitions[node.Ref].HasEnumValuesDefinition = len(c.document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs) > 0
+		case ast.NodeKindUnionTypeDefinition:
+			c.document.UnionTypeDefinitions[node.Ref].HasUnionMemberTypes = len(c.document.UnionTypeDefinitions[node.Ref].UnionMemberTypes.Refs) > 0
+		}
+	}
+}
+
+func (c *contractFilter) isExcluded(coordinate string) bool {
+	return c.tags.hasAny(coordinate, c.exclude)
+}
+
+func (c *contractFilter) isIncluded(coordinate string) bool {
+	return len(c.include) == 0 || c.tags.hasAny(coordinate, c.include)
+}
+
+func (c *contractFilter) filterByTags() {
+	for _, node := range c.document.RootNodes {
+		typeName := c.document.NodeNameString(node)
+		if isReservedName(typeName) {
+			continue
+		}
+		if c.isExcluded(typeName) {
+			c.removed[typeName] = struct{}{}
+			continue
+		}
+
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition:
+			typeIsIncluded := c.isIncluded(typeName)
+			c.filterFields(node, func(ref int) bool {
+				fieldName := c.document.FieldDefinitionNameString(ref)
+				if isReservedName(fieldName) {
+					return true
+				}
+				coordinate := typeName + "." + fieldName
+				if c.isExcluded(coordinate) {
+					return false
+				}
+				return typeIsIncluded || c.isIncluded(coordinate)
+			})
+			for _, fieldRef := range c.document.NodeFieldDefinitions(node) {
+				coordinate := typeName + "." + c.document.FieldDefinitionNameString(fieldRef)
+				c.filterInputValues(&c.document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs, func(ref int) bool {
+					return !c.isExcluded(coordinate + "." + c.document.InputValueDefinitionNameString(ref))
+				})
+			}
+		case ast.NodeKindInputObjectTypeDefinition:
+			c.filterInputValues(&c.document.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs, func(ref int) bool {
+				return !c.isExcluded(typeName + "." + c.document.InputValueDefinitionNameString(ref))
+			})
+		case ast.NodeKindEnumTypeDefinition:
+			values := &c.document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs
+			*values = filterRefs(*values, func(ref int) bool {
+				return !c.isExcluded(typeName + "." + c.document.EnumValueDefinitionNameString(ref))
+			})
+		}
+	}
+}
+
+func (c *contractFilter) isRemoved(typeRef int) bool {
+	_, removed := c.removed[c.document.ResolveTypeNameString(typeRef)]
+	return removed
+}
+
+// removeReferencesToRemovedTypes removes fields, arguments, input fields, union members and interfaces
+// referencing removed types. Types which become empty are removed as well.
+func (c *contractFilter) removeReferencesToRemovedTypes() {
+	for _, node := range c.document.RootNodes {
+		typeName := c.document.NodeNameString(node)
+		if _, ok := c.removed[typeName]; ok {
+			continue
+		}
+
+		isEmpty := false
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition:
+			c.filterFields(node, func(ref int) bool {
+				if c.isRemoved(c.document.FieldDefinitions[ref].Type) {
+					return false
+				}
+				// a field can't be kept if one of its required arguments has been removed
+				for _, argumentRef := range c.document.FieldDefinitions[ref].ArgumentsDefinition.Refs {
+					if c.isRemoved(c.document.InputValueDefinitions[argumentRef].Type) && c.isRequired(argumentRef) {
+						return false
+					}
+				}
+				c.filterInputValues(&c.document.FieldDefinitions[ref].ArgumentsDefinition.Refs, func(argumentRef int) bool {
+					return !c.isRemoved(c.document.InputValueDefinitions[argumentRef].Type)
+				})
+				return true
+			})
+			interfaces := c.implementedInterfaces(node)
+			*interfaces = filterRefs(*interfaces, func(ref int) bool {
+				return !c.isRemoved(ref)
+			})
+			isEmpty = !c.hasNonReservedFields(node)
+		case ast.NodeKindInputObjectTypeDefinition:
+			inputFields := &c.document.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs
+			for _, ref := range *inputFields {
+				if c.isRemoved(c.document.InputValueDefinitions[ref].Type) && c.isRequired(ref) {
+					isEmpty = true
+				}
+			}
+			c.filterInputValues(inputFields, func(ref int) bool {
+				return !c.isRemoved(c.document.InputValueDefinitions[ref].Type)
+			})
+			isEmpty = isEmpty || len(*inputFields) == 0
+		case ast.NodeKindUnionTypeDefinition:
+			members := &c.document.UnionTypeDefinitions[node.Ref].UnionMemberTypes.Refs
+			*members = filterRefs(*members, func(ref int) bool {
+				return !c.isRemoved(ref)
+			})
+			isEmpty = len(*members) == 0
+		case ast.NodeKindEnumTypeDefinition:
+			isEmpty = len(c.document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs) == 0
+		}
+
+		if isEmpty {
+			c.removed[typeName] = struct{}{}
+		}
+	}
+}
+
+// removeUnreachableTypes removes the types which can't be reached from the root operation types,
+// the introspection types or the directive definitions.
+func (c *contractFilter) removeUnreachableTypes() {
+	reachable := make(map[string]struct{})
+	var queue []string
+	visit := func(typeName string) {
+		if _, ok := c.removed[typeName]; ok {
+			return
+		}
+		if _, ok := reachable[typeName]; ok {
+			return
+		}
+		reachable[typeName] = struct{}{}
+		queue = append(queue, typeName)
+	}
+
+	for _, typeName := range []string{
+		string(c.document.Index.QueryTypeName),
+		string(c.document.Index.MutationTypeName),
+		string(c.document.Index.SubscriptionTypeName),
+	} {
+		if typeName != "" {
+			visit(typeName)
+		}
+	}
+	for _, node := range c.document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindDirectiveDefinition:
+			for _, argumentRef := range c.document.DirectiveDefinitions[node.Ref].ArgumentsDefinition.Refs {
+				visit(c.document.ResolveTypeNameString(c.document.InputValueDefinitions[argumentRef].Type))
+			}
+		case ast.NodeKindSchemaDefinition:
+			for _, rootOperationTypeRef := range c.document.SchemaDefinitions[node.Ref].RootOperationTypeDefinitions.Refs {
+				visit(c.document.Input.ByteSliceString(c.document.RootOperationTypeDefinitions[rootOperationTypeRef].NamedType.Name))
+			}
+		default:
+			if typeName := c.document.NodeNameString(node); isReservedName(typeName) || isBuiltInScalar(typeName) {
+				visit(typeName)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		typeName := queue[0]
+		queue = queue[1:]
+
+		nodes, _ := c.document.Index.NodesByNameStr(typeName)
+		for _, node := range nodes {
+			for _, fieldRef := range c.document.NodeFieldDefinitions(node) {
+				visit(c.document.ResolveTypeNameString(c.document.FieldDefinitions[fieldRef].Type))
+				for _, argumentRef := range c.document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs {
+					visit(c.document.ResolveTypeNameString(c.document.InputValueDefinitions[argumentRef].Type))
+				}
+			}
+			for _, inputValueRef := range c.document.NodeInputFieldDefinitions(node) {
+				visit(c.document.ResolveTypeNameString(c.document.InputValueDefinitions[inputValueRef].Type))
+			}
+			if node.Kind == ast.NodeKindUnionTypeDefinition {
+				for _, memberRef := range c.document.UnionTypeDefinitions[node.Ref].UnionMemberTypes.Refs {
+					visit(c.document.TypeNameString(memberRef))
+				}
+			}
+			if node.Kind == ast.NodeKindInterfaceTypeDefinition {
+				// the implementations of a reachable interface can be returned by its fields
+				for _, implementation := range c.document.RootNodes {
+					if implementation.Kind != ast.NodeKindObjectTypeDefinition {
+						continue
+					}
+					for _, interfaceRef := range c.document.ObjectTypeDefinitions[implementation.Ref].ImplementsInterfaces.Refs {
+						if c.document.TypeNameString(interfaceRef) == typeName {
+							visit(c.document.ObjectTypeDefinitionNameString(implementation.Ref))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, node := range c.document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindSchemaDefinition, ast.NodeKindDirectiveDefinition:
+			continue
+		}
+		typeName := c.document.NodeNameString(node)
+		if _, ok := reachable[typeName]; !ok {
+			c.removed[typeName] = struct{}{}
+		}
+	}
+}
+
+// filterRootOperationTypes removes the root operation types which have been removed from the schema definition
+func (c *contractFilter) filterRootOperationTypes() error {
+	for _, node := range c.document.RootNodes {
+		if node.Kind != ast.NodeKindSchemaDefinition {
+			continue
+		}
+		rootOperationTypes := &c.document.SchemaDefinitions[node.Ref].RootOperationTypeDefinitions.Refs
+		*rootOperationTypes = filterRefs(*rootOperationTypes, func(ref int) bool {
+			rootOperationType := c.document.RootOperationTypeDefinitions[ref]
+			_, removed := c.removed[c.document.Input.ByteSliceString(rootOperationType.NamedType.Name)]
+			return !removed || rootOperationType.OperationType == ast.OperationTypeQuery
+		})
+	}
+
+	if _, ok := c.removed[string(c.document.Index.QueryTypeName)]; ok {
+		return ErrContractWithoutQueryFields
+	}
+	queryNode, ok := c.document.Index.FirstNodeByNameBytes(c.document.Index.QueryTypeName)
+	if !ok || !c.hasNonReservedFields(queryNode) {
+		return ErrContractWithoutQueryFields
+	}
+	return nil
+}
+
+// hasNonReservedFields reports whether the type has other fields than the ones added for introspection like __typename
+func (c *contractFilter) hasNonReservedFields(node ast.Node) bool {
+	for _, fieldRef := range c.document.NodeFieldDefinitions(node) {
+		if !isReservedName(c.document.FieldDefinitionNameString(fieldRef)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *contractFilter) isRequired(inputValueRef int) bool {
+	return c.document.TypeIsNonNull(c.document.InputValueDefinitions[inputValueRef].Type) &&
+		!c.document.InputValueDefinitions[inputValueRef].DefaultValue.IsDefined
+}
+
+func (c *contractFilter) filterFields(node ast.Node, keep func(ref int) bool) {
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		fields := &c.document.ObjectTypeDefinitions[node.Ref].FieldsDefinition.Refs
+		*fields = filterRefs(*fields, keep)
+	case ast.NodeKindInterfaceTypeDefinition:
+		fields := &c.document.InterfaceTypeDefinitions[node.Ref].FieldsDefinition.Refs
+		*fields = filterRefs(*fields, keep)
+	}
+}
+
+func (c *contractFilter) filterInputValues(refs *[]int, keep func(ref int) bool) {
+	*refs = filterRefs(*refs, keep)
+}
+
+func (c *contractFilter) implementedInterfaces(node ast.Node) *[]int {
+	if node.Kind == ast.NodeKindInterfaceTypeDefinition {
+		return &c.document.InterfaceTypeDefinitions[node.Ref].ImplementsInterfaces.Refs
+	}
+	return &c.document.ObjectTypeDefinitions[node.Ref].ImplementsInterfaces.Refs
+}
+
+func filterRefs(refs []int, keep func(ref int) bool) []int {
+	out := refs[:0]
+	for _, ref := range refs {
+		if keep(ref) {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
+
+func isReservedName(name string) bool {
+	return strings.HasPrefix(name, "__")
+}
+
+func isBuiltInScalar(typeName string) bool {
+	switch typeName {
+	case "Int", "Float", "String", "Boolean", "ID":
+		return true
+	default:
+		return false
+	}
+}
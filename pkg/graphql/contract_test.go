@@ -0,0 +1,366 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+)
+
+const contractTestSchema = `
+	directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION
+
+	type Query {
+		products(filter: ProductFilter): [Product] @tag(name: "public")
+		product(upc: String!): Product @tag(name: "public")
+		users: [User] @tag(name: "internal")
+		search(term: String!, scope: SearchScope @tag(name: "internal")): [SearchResult] @tag(name: "public")
+	}
+
+	type Mutation {
+		deleteUser(id: ID!): User @tag(name: "internal")
+	}
+
+	interface Node {
+		id: ID!
+	}
+
+	type Product implements Node @tag(name: "public") {
+		id: ID!
+		upc: String!
+		price: Int
+		cost: Int @tag(name: "internal")
+		status: ProductStatus
+	}
+
+	enum ProductStatus {
+		AVAILABLE
+		DISCONTINUED @tag(name: "internal")
+	}
+
+	input ProductFilter {
+		upc: String
+		minCost: Int @tag(name: "internal")
+	}
+
+	type User implements Node {
+		id: ID!
+		name: String
+	}
+
+	enum SearchScope {
+		PRODUCTS
+		USERS
+	}
+
+	union SearchResult = Product | User
+`
+
+// contractTypeFields returns the fields, input fields, enum values and union members of the non-reserved types of the schema
+func contractTypeFields(schema *Schema) map[string][]string {
+	document := &schema.document
+	types := make(map[string][]string)
+	for _, node := range document.RootNodes {
+		typeName := document.NodeNameString(node)
+		if isReservedName(typeName) || isBuiltInScalar(typeName) || node.Kind == ast.NodeKindDirectiveDefinition || node.Kind == ast.NodeKindSchemaDefinition {
+			continue
+		}
+		fields := []string{}
+		for _, fieldRef := range document.NodeFieldDefinitions(node) {
+			fieldName := document.FieldDefinitionNameString(fieldRef)
+			if isReservedName(fieldName) {
+				continue
+			}
+			for _, argumentRef := range document.FieldDefinitions[fieldRef].ArgumentsDefinition.Refs {
+				fieldName += " " + document.InputValueDefinitionNameString(argumentRef)
+			}
+			fields = append(fields, fieldName)
+		}
+		for _, inputValueRef := range document.NodeInputFieldDefinitions(node) {
+			fields = append(fields, document.InputValueDefinitionNameString(inputValueRef))
+		}
+		switch node.Kind {
+		case ast.NodeKindEnumTypeDefinition:
+			for _, valueRef := range document.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs {
+				fields = append(fields, document.EnumValueDefinitionNameString(valueRef))
+			}
+		case ast.NodeKindUnionTypeDefinition:
+			for _, memberRef := range document.UnionTypeDefinitions[node.Ref].UnionMemberTypes.Refs {
+				fields = append(fields, document.TypeNameString(memberRef))
+			}
+		}
+		types[typeName] = fields
+	}
+	return types
+}
+
+func TestNewContract(t *testing.T) {
+	newEngineConfig := func(t *testing.T) EngineV2Configuration {
+		schema, err := NewSchemaFromString(contractTestSchema)
+		require.NoError(t, err)
+		engineConfig := NewEngineV2Configuration(schema)
+		engineConfig.AddDataSource(plan.DataSourceConfiguration{
+			RootNodes: []plan.TypeField{
+				{TypeName: "Query", FieldNames: []string{"products", "product", "users", "search"}},
+				{TypeName: "Mutation", FieldNames: []string{"deleteUser"}},
+			},
+		})
+		return engineConfig
+	}
+	newEngineConfigFromSchema := func(t *testing.T, schemaContent string) EngineV2Configuration {
+		schema, err := NewSchemaFromString(schemaContent)
+		require.NoError(t, err)
+		return NewEngineV2Configuration(schema)
+	}
+
+	t.Run("exclude tags", func(t *testing.T) {
+		engineConfig := newEngineConfig(t)
+		contractSchema, contractEngineConfig, err := NewContract(engineConfig, ContractConfiguration{
+			ExcludeTags: []string{"internal"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			"Query":         {"products filter", "product upc", "search term"},
+			"Product":       {"id", "upc", "price", "status"},
+			"ProductStatus": {"AVAILABLE"},
+			"ProductFilter": {"upc"},
+			"User":          {"id", "name"},
+			"SearchResult":  {"Product", "User"},
+		}, contractTypeFields(contractSchema))
+		assert.False(t, contractSchema.HasMutationType())
+
+		assert.Equal(t, contractSchema, contractEngineConfig.schema)
+		assert.Equal(t, engineConfig.schema, contractEngineConfig.executionSchemaOrSchema())
+		assert.Equal(t, engineConfig.DataSources(), contractEngineConfig.DataSources())
+	})
+
+	t.Run("include tags", func(t *testing.T) {
+		contractSchema, _, err := NewContract(newEngineConfig(t), ContractConfiguration{
+			IncludeTags: []string{"public"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			"Query":         {"products filter", "product upc", "search term scope"},
+			"Product":       {"id", "upc", "price", "cost", "status"},
+			"ProductStatus": {"AVAILABLE", "DISCONTINUED"},
+			"ProductFilter": {"upc", "minCost"},
+			"SearchScope":   {"PRODUCTS", "USERS"},
+			"SearchResult":  {"Product"},
+		}, contractTypeFields(contractSchema))
+	})
+
+	t.Run("include and exclude tags", func(t *testing.T) {
+		contractSchema, _, err := NewContract(newEngineConfig(t), ContractConfiguration{
+			IncludeTags: []string{"public"},
+			ExcludeTags: []string{"internal"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			"Query":         {"products filter", "product upc", "search term"},
+			"Product":       {"id", "upc", "price", "status"},
+			"ProductStatus": {"AVAILABLE"},
+			"ProductFilter": {"upc"},
+			"SearchResult":  {"Product"},
+		}, contractTypeFields(contractSchema))
+	})
+
+	t.Run("removing a type removes the fields returning it", func(t *testing.T) {
+		contractSchema, _, err := NewContract(newEngineConfig(t), ContractConfiguration{
+			ExcludeTags: []string{"public"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			"Query":    {"users"},
+			"Mutation": {"deleteUser id"},
+			"User":     {"id", "name"},
+		}, contractTypeFields(contractSchema))
+		assert.True(t, contractSchema.HasMutationType())
+	})
+
+	t.Run("contract without query fields", func(t *testing.T) {
+		_, _, err := NewContract(newEngineConfig(t), ContractConfiguration{
+			IncludeTags: []string{"unknown"},
+		})
+		assert.Equal(t, ErrContractWithoutQueryFields, err)
+	})
+
+	t.Run("keeps interfaces and their implementations consistent", func(t *testing.T) {
+		t.Run("exclude tags remove the interface fields and arguments excluded from an implementation", func(t *testing.T) {
+			contractSchema, _, err := NewContract(newEngineConfigFromSchema(t, `
+				directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT | INTERFACE | ARGUMENT_DEFINITION
+				type Query { animals: [Animal] }
+				interface Animal {
+					name(short: Boolean): String
+					secret: String
+				}
+				type Dog implements Animal {
+					name(short: Boolean @tag(name: "internal")): String
+					secret: String @tag(name: "internal")
+				}
+				type Cat implements Animal {
+					name(short: Boolean): String
+					secret: String
+				}
+			`), ContractConfiguration{
+				ExcludeTags: []string{"internal"},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string][]string{
+				"Query":  {"animals"},
+				"Animal": {"name"},
+				"Dog":    {"name"},
+				"Cat":    {"name short", "secret"},
+			}, contractTypeFields(contractSchema))
+		})
+
+		t.Run("include tags remove the interface fields which are not included in an implementation", func(t *testing.T) {
+			contractSchema, _, err := NewContract(newEngineConfigFromSchema(t, `
+				directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT | INTERFACE | ARGUMENT_DEFINITION
+				type Query { animals: [Animal] @tag(name: "public") }
+				interface Animal {
+					name: String @tag(name: "public")
+					secret: String @tag(name: "public")
+				}
+				type Dog implements Animal {
+					name: String @tag(name: "public")
+					secret: String
+				}
+			`), ContractConfiguration{
+				IncludeTags: []string{"public"},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string][]string{
+				"Query":  {"animals"},
+				"Animal": {"name"},
+				"Dog":    {"name"},
+			}, contractTypeFields(contractSchema))
+		})
+	})
+
+	t.Run("invalid contract schemas are rejected", func(t *testing.T) {
+		_, _, err := NewContract(newEngineConfigFromSchema(t, `
+			directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT | INTERFACE | ARGUMENT_DEFINITION | ENUM
+			directive @auth(role: Role) on FIELD_DEFINITION
+			type Query { animals: [Animal] }
+			interface Animal {
+				name: String
+			}
+			enum Role @tag(name: "internal") {
+				ADMIN
+			}
+		`), ContractConfiguration{
+			ExcludeTags: []string{"internal"},
+		})
+		require.Error(t, err)
+
+		var validationErrors SchemaValidationErrors
+		require.ErrorAs(t, err, &validationErrors)
+		assert.NotEmpty(t, validationErrors)
+	})
+
+	t.Run("operations are validated against the contract", func(t *testing.T) {
+		contractSchema, _, err := NewContract(newEngineConfig(t), ContractConfiguration{
+			ExcludeTags: []string{"internal"},
+		})
+		require.NoError(t, err)
+
+		request := Request{Query: `{ products { upc price } }`}
+		result, err := request.ValidateForSchema(contractSchema)
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+
+		request = Request{Query: `{ products { upc cost } }`}
+		result, err = request.ValidateForSchema(contractSchema)
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+	})
+}
+
+func TestFederationEngineConfigFactory_EngineV2ContractConfiguration(t *testing.T) {
+	factory := NewFederationEngineConfigFactory([]graphqlDataSource.Configuration{
+		{
+			Fetch: graphqlDataSource.FetchConfiguration{URL: "http://products.service"},
+			Federation: graphqlDataSource.FederationConfiguration{
+				Enabled: true,
+				ServiceSDL: `
+					extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", {name: "@tag", as: "@label"}])
+					type Query {
+						products: [Product]
+					}
+					type Product @key(fields: "upc") {
+						upc: String!
+						cost: Int @label(name: "internal")
+					}
+				`,
+			},
+		},
+		{
+			Fetch: graphqlDataSource.FetchConfiguration{URL: "http://accounts.service"},
+			Federation: graphqlDataSource.FederationConfiguration{
+				Enabled: true,
+				ServiceSDL: `
+					extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@tag"])
+					type Query {
+						users: [User] @tag(name: "internal")
+					}
+					type User @key(fields: "id") {
+						id: ID!
+					}
+				`,
+			},
+		},
+	}, graphqlDataSource.NewBatchFactory(), WithFederationHttpClient(&http.Client{
+		Transport: createTestRoundTripper(t, roundTripperTestCase{
+			expectedHost:     "products.service",
+			expectedPath:     "",
+			expectedBody:     `{"query":"{products {upc}}"}`,
+			sendStatusCode:   200,
+			sendResponseBody: `{"data":{"products":[{"upc":"top-1"}]}}`,
+		}),
+	}))
+
+	engineConfig, err := factory.EngineV2Configuration()
+	require.NoError(t, err)
+
+	contractSchema, contractEngineConfig, err := factory.EngineV2ContractConfiguration(ContractConfiguration{
+		ExcludeTags: []string{"internal"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"Query":   {"products"},
+		"Product": {"upc"},
+	}, contractTypeFields(contractSchema))
+	assert.Equal(t, len(engineConfig.DataSources()), len(contractEngineConfig.DataSources()))
+	assert.Equal(t, engineConfig.FieldConfigurations(), contractEngineConfig.FieldConfigurations())
+	assert.Equal(t, contractTypeFields(engineConfig.schema), contractTypeFields(contractEngineConfig.executionSchemaOrSchema()))
+
+	engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.NoopLogger, contractEngineConfig)
+	require.NoError(t, err)
+
+	t.Run("execute operation of the contract", func(t *testing.T) {
+		resultWriter := NewEngineResultWriter()
+		err := engine.Execute(context.Background(), &Request{Query: `{ products { upc } }`}, &resultWriter)
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":{"products":[{"upc":"top-1"}]}}`, resultWriter.String())
+	})
+
+	t.Run("reject fields excluded from the contract", func(t *testing.T) {
+		resultWriter := NewEngineResultWriter()
+		err := engine.Execute(context.Background(), &Request{Query: `{ products { cost } }`}, &resultWriter)
+		assert.Error(t, err)
+	})
+}
@@ -15,7 +15,9 @@ const (
 )
 
 type EngineV2Configuration struct {
-	schema                   *Schema
+	schema *Schema
+	// executionSchema is the schema operations are planned against if it differs from schema, e.g. for contracts
+	executionSchema          *Schema
 	plannerConfig            plan.Configuration
 	websocketBeforeStartHook WebsocketBeforeStartHook
 	dataLoaderConfig         dataLoaderConfig
@@ -41,6 +43,13 @@ type dataLoaderConfig struct {
 	EnableDataLoader         bool
 }
 
+func (e *EngineV2Configuration) executionSchemaOrSchema() *Schema {
+	if e.executionSchema != nil {
+		return e.executionSchema
+	}
+	return e.schema
+}
+
 func (e *EngineV2Configuration) SetCustomResolveMap(customResolveMap map[string]resolve.CustomResolve) {
 	e.plannerConfig.CustomResolveMap = customResolveMap
 }
@@ -117,6 +126,7 @@ func (d *graphqlDataSourceV2Generator) Generate(config graphqlDataSource.Configu
 	var planDataSource plan.DataSourceConfiguration
 	extractor := plan.NewLocalTypeFieldExtractor(d.document)
 	planDataSource.RootNodes, planDataSource.ChildNodes = extractor.GetAllNodes()
+	planDataSource.Provides = plan.NewProvidedFieldExtractor(d.document).GetAllProvidedFields()
 
 	definedOptions := &dataSourceV2GeneratorOptions{
 		streamingClient:           &http.Client{Timeout: 0},
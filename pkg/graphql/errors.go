@@ -39,6 +39,7 @@ func RequestErrorsFromError(err error) RequestErrors {
 				Path: ErrorPath{
 					astPath: externalError.Path,
 				},
+				Code: externalError.Code,
 			})
 		}
 		return errors
@@ -70,6 +71,7 @@ func RequestErrorsFromOperationReport(report operationreport.Report) (errors Req
 			Message:   externalError.Message,
 			Path:      ErrorPath{astPath: externalError.Path},
 			Locations: locations,
+			Code:      externalError.Code,
 		}
 
 		errors = append(errors, validationError)
@@ -114,16 +116,28 @@ type RequestError struct {
 	Message   string                   `json:"message"`
 	Locations []graphqlerrors.Location `json:"locations,omitempty"`
 	Path      ErrorPath                `json:"path"`
+	// Code optionally classifies the error, it is rendered as the code of the extensions, e.g. BAD_USER_INPUT
+	Code string `json:"-"`
+}
+
+type requestErrorExtensions struct {
+	Code string `json:"code"`
 }
 
 func (o RequestError) MarshalJSON() ([]byte, error) {
+	var extensions *requestErrorExtensions
+	if o.Code != "" {
+		extensions = &requestErrorExtensions{Code: o.Code}
+	}
 	if o.Path.Len() == 0 {
 		return json.Marshal(struct {
-			Message   string                   `json:"message"`
-			Locations []graphqlerrors.Location `json:"locations,omitempty"`
+			Message    string                   `json:"message"`
+			Locations  []graphqlerrors.Location `json:"locations,omitempty"`
+			Extensions *requestErrorExtensions  `json:"extensions,omitempty"`
 		}{
-			Message:   o.Message,
-			Locations: o.Locations,
+			Message:    o.Message,
+			Locations:  o.Locations,
+			Extensions: extensions,
 		})
 	}
 	path, err := o.Path.MarshalJSON()
@@ -131,13 +145,15 @@ func (o RequestError) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 	return json.Marshal(struct {
-		Message   string                   `json:"message"`
-		Locations []graphqlerrors.Location `json:"locations,omitempty"`
-		Path      json.RawMessage          `json:"path"`
+		Message    string                   `json:"message"`
+		Locations  []graphqlerrors.Location `json:"locations,omitempty"`
+		Path       json.RawMessage          `json:"path"`
+		Extensions *requestErrorExtensions  `json:"extensions,omitempty"`
 	}{
-		Message:   o.Message,
-		Locations: o.Locations,
-		Path:      path,
+		Message:    o.Message,
+		Locations:  o.Locations,
+		Path:       path,
+		Extensions: extensions,
 	})
 }
 
@@ -244,6 +244,14 @@ func (e *ExecutionEngineV2) Execute(ctx context.Context, operation *Request, wri
 		return result.Errors
 	}
 
+	result, err = operation.CoerceVariableValues(e.config.schema)
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return result.Errors
+	}
+
 	execContext := e.getExecutionCtx()
 	defer e.putExecutionCtx(execContext)
 
@@ -254,7 +262,7 @@ func (e *ExecutionEngineV2) Execute(ctx context.Context, operation *Request, wri
 	}
 
 	var report operationreport.Report
-	cachedPlan := e.getCachedPlan(execContext, &operation.document, &e.config.schema.document, operation.OperationName, &report)
+	cachedPlan := e.getCachedPlan(execContext, &operation.document, &e.config.executionSchemaOrSchema().document, operation.OperationName, &report)
 	if report.HasErrors() {
 		return report
 	}
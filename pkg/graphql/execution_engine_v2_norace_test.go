@@ -5,11 +5,19 @@ package graphql
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/jensneuse/abstractlogger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/postprocess"
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting"
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/flags"
 )
@@ -60,6 +68,51 @@ func TestExecutionEngineV2_FederationAndSubscription_IntegrationTest(t *testing.
 			}
 		})
 
+		t.Run("should successfully execute a federation subscription with entity fields of another subgraph", func(t *testing.T) {
+			query := `
+subscription ReviewAdded {
+  reviewAdded {
+    body
+    product {
+      upc
+      name
+    }
+  }
+}`
+
+			gqlRequest := &Request{
+				OperationName: "",
+				Variables:     nil,
+				Query:         query,
+			}
+
+			validationResult, err := gqlRequest.ValidateForSchema(schema)
+			require.NoError(t, err)
+			require.True(t, validationResult.Valid)
+
+			execCtx, execCtxCancelFn := context.WithCancel(context.Background())
+			defer execCtxCancelFn()
+
+			// the subscription must not block on the channel once the test has stopped reading from it
+			message := make(chan string, 2)
+			resultWriter := NewEngineResultWriter()
+			resultWriter.SetFlushCallback(func(data []byte) {
+				select {
+				case message <- string(data):
+				case <-execCtx.Done():
+				}
+			})
+
+			go func() {
+				err := engine.Execute(execCtx, gqlRequest, &resultWriter)
+				assert.NoError(t, err)
+			}()
+
+			// each event runs the entity fetch of the product to the products subgraph
+			assert.Equal(t, `{"data":{"reviewAdded":{"body":"A highly effective form of birth control.","product":{"upc":"top-1","name":"Trilby"}}}}`, <-message)
+			assert.Equal(t, `{"data":{"reviewAdded":{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits.","product":{"upc":"top-2","name":"Fedora"}}}}`, <-message)
+		})
+
 		t.Run("should successfully execute a federation subscription", func(t *testing.T) {
 			query := `
 subscription UpdatedPrice {
@@ -168,3 +221,97 @@ subscription UpdatedPrice {
 		runIntegration(t, true, true)
 	})
 }
+
+func TestExecutionEngineV2_ParallelEntityFetches(t *testing.T) {
+	setup := newFederationSetup()
+	t.Cleanup(func() {
+		setup.accountsUpstreamServer.Close()
+		setup.productsUpstreamServer.Close()
+		setup.reviewsUpstreamServer.Close()
+		setup.pollingUpstreamServer.Close()
+	})
+
+	upstreams := []struct {
+		upstream federationtesting.Upstream
+		url      string
+	}{
+		{upstream: federationtesting.UpstreamAccounts, url: setup.accountsUpstreamServer.URL},
+		{upstream: federationtesting.UpstreamProducts, url: setup.productsUpstreamServer.URL},
+		{upstream: federationtesting.UpstreamReviews, url: setup.reviewsUpstreamServer.URL},
+	}
+	dataSourceConfigs := make([]graphqlDataSource.Configuration, 0, len(upstreams))
+	for _, upstream := range upstreams {
+		sdl, err := federationtesting.LoadSDLFromExamplesDirectoryWithinPkg(upstream.upstream)
+		require.NoError(t, err)
+		dataSourceConfigs = append(dataSourceConfigs, graphqlDataSource.Configuration{
+			Fetch: graphqlDataSource.FetchConfiguration{
+				URL:    upstream.url,
+				Method: http.MethodPost,
+			},
+			Federation: graphqlDataSource.FederationConfiguration{
+				Enabled:    true,
+				ServiceSDL: string(sdl),
+			},
+		})
+	}
+
+	engineConfig, err := NewFederationEngineConfigFactory(dataSourceConfigs, graphqlDataSource.NewBatchFactory()).EngineV2Configuration()
+	require.NoError(t, err)
+
+	// the product and the author of a review are resolved by different subgraphs,
+	// both entity fetches only depend on the review and are resolved in parallel
+	query := `{ me { reviews { body product { name } author { history { ... on Sale { rating } } } } } }`
+
+	// sequentialFetches counts the fetches which are resolved one after another,
+	// the fetches of array items are batched by the data loader
+	var sequentialFetches func(node resolve.Node) int
+	sequentialFetches = func(node resolve.Node) (count int) {
+		switch n := node.(type) {
+		case *resolve.Object:
+			if n.Fetch != nil {
+				count++
+			}
+			for _, field := range n.Fields {
+				count += sequentialFetches(field.Value)
+			}
+		case *resolve.Array:
+			count += sequentialFetches(n.Item)
+		}
+		return count
+	}
+
+	planQuery := func(t *testing.T, postProcessors ...postprocess.PostProcessor) *plan.SynchronousResponsePlan {
+		request := Request{Query: query}
+		_, err := request.Normalize(engineConfig.schema)
+		require.NoError(t, err)
+
+		report := operationreport.Report{}
+		planResult := plan.NewPlanner(context.Background(), engineConfig.plannerConfig).Plan(&request.document, &engineConfig.schema.document, "", &report)
+		require.False(t, report.HasErrors())
+		for _, postProcessor := range postProcessors {
+			planResult = postProcessor.Process(planResult)
+		}
+		return planResult.(*plan.SynchronousResponsePlan)
+	}
+
+	t.Run("plan", func(t *testing.T) {
+		sequential := planQuery(t, &postprocess.ProcessDataSource{})
+		parallel := planQuery(t, &postprocess.ProcessDataSource{}, &postprocess.ProcessParallelFetch{})
+
+		assert.Equal(t, 4, sequentialFetches(sequential.Response.Data))
+		assert.Equal(t, 3, sequentialFetches(parallel.Response.Data))
+	})
+
+	for _, enableDataLoader := range []bool{false, true} {
+		t.Run(fmt.Sprintf("execute with data loader enabled: %t", enableDataLoader), func(t *testing.T) {
+			engineConfig.EnableDataLoader(enableDataLoader)
+			engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.Noop{}, engineConfig)
+			require.NoError(t, err)
+
+			resultWriter := NewEngineResultWriter()
+			err = engine.Execute(context.Background(), &Request{Query: query}, &resultWriter)
+			require.NoError(t, err)
+			assert.Equal(t, `{"data":{"me":{"reviews":[{"body":"A highly effective form of birth control.","product":{"name":"Trilby"},"author":{"history":[{"rating":5}]}},{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits.","product":{"name":"Fedora"},"author":{"history":[{"rating":5}]}}]}}}`, resultWriter.String())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
@@ -29,8 +30,9 @@ const (
 )
 
 var (
-	ErrEmptyRequest = errors.New("the provided request is empty")
-	ErrNilSchema    = errors.New("the provided schema is nil")
+	ErrEmptyRequest      = errors.New("the provided request is empty")
+	ErrEmptyBatchRequest = errors.New("the provided batch request is empty")
+	ErrNilSchema         = errors.New("the provided schema is nil")
 )
 
 type Request struct {
@@ -64,6 +66,35 @@ func UnmarshalHttpRequest(r *http.Request, request *Request) error {
 	return UnmarshalRequest(r.Body, request)
 }
 
+// UnmarshalBatchRequest reads either a single request object or an array of request objects.
+// isBatch reports whether the payload was an array.
+func UnmarshalBatchRequest(reader io.Reader) (requests []Request, isBatch bool, err error) {
+	requestBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, false, err
+	}
+
+	requestBytes = bytes.TrimSpace(requestBytes)
+	if len(requestBytes) == 0 {
+		return nil, false, ErrEmptyRequest
+	}
+
+	if requestBytes[0] != '[' {
+		requests = make([]Request, 1)
+		return requests, false, json.Unmarshal(requestBytes, &requests[0])
+	}
+
+	if err = json.Unmarshal(requestBytes, &requests); err != nil {
+		return nil, true, err
+	}
+
+	if len(requests) == 0 {
+		return nil, true, ErrEmptyBatchRequest
+	}
+
+	return requests, true, nil
+}
+
 func (r *Request) SetHeader(header http.Header) {
 	r.request.Header = header
 }
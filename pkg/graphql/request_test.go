@@ -35,6 +35,46 @@ func TestUnmarshalRequest(t *testing.T) {
 	})
 }
 
+func TestUnmarshalBatchRequest(t *testing.T) {
+	t.Run("should return error when request is empty", func(t *testing.T) {
+		_, _, err := UnmarshalBatchRequest(bytes.NewBufferString("  "))
+		assert.Equal(t, ErrEmptyRequest, err)
+	})
+
+	t.Run("should return error when batch is empty", func(t *testing.T) {
+		_, isBatch, err := UnmarshalBatchRequest(bytes.NewBufferString("[]"))
+		assert.True(t, isBatch)
+		assert.Equal(t, ErrEmptyBatchRequest, err)
+	})
+
+	t.Run("should unmarshal single request", func(t *testing.T) {
+		requests, isBatch, err := UnmarshalBatchRequest(bytes.NewBufferString(`{"operationName": "Hello", "query": "query Hello { hello }"}`))
+		assert.NoError(t, err)
+		assert.False(t, isBatch)
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "Hello", requests[0].OperationName)
+	})
+
+	t.Run("should unmarshal batch request", func(t *testing.T) {
+		requests, isBatch, err := UnmarshalBatchRequest(bytes.NewBufferString(`
+			[
+				{"operationName": "Hello", "query": "query Hello { hello }"},
+				{"query": "{ world }", "variables": {"a": 1}}
+			]`))
+		assert.NoError(t, err)
+		assert.True(t, isBatch)
+		assert.Len(t, requests, 2)
+		assert.Equal(t, "query Hello { hello }", requests[0].Query)
+		assert.Equal(t, "{ world }", requests[1].Query)
+		assert.Equal(t, `{"a": 1}`, string(requests[1].Variables))
+	})
+
+	t.Run("should return error for invalid json", func(t *testing.T) {
+		_, _, err := UnmarshalBatchRequest(bytes.NewBufferString(`[{"query": }]`))
+		assert.Error(t, err)
+	})
+}
+
 func TestRequest_Print(t *testing.T) {
 	query := "query Hello { hello }"
 	request := Request{
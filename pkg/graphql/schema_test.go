@@ -457,6 +457,16 @@ func TestSchema_GetAllFieldArguments(t *testing.T) {
 				FieldName:     "multiArgLevel2",
 				ArgumentNames: []string{"lvl", "number"},
 			},
+			{
+				TypeName:      "__Directive",
+				FieldName:     "args",
+				ArgumentNames: []string{"includeDeprecated"},
+			},
+			{
+				TypeName:      "__Field",
+				FieldName:     "args",
+				ArgumentNames: []string{"includeDeprecated"},
+			},
 			{
 				TypeName:      "__Type",
 				FieldName:     "fields",
@@ -467,6 +477,11 @@ func TestSchema_GetAllFieldArguments(t *testing.T) {
 				FieldName:     "enumValues",
 				ArgumentNames: []string{"includeDeprecated"},
 			},
+			{
+				TypeName:      "__Type",
+				FieldName:     "inputFields",
+				ArgumentNames: []string{"includeDeprecated"},
+			},
 		}
 		assert.Equal(t, expectedFieldArguments, fieldArguments)
 	})
@@ -0,0 +1,285 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/staticdatasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/federation"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+)
+
+// EntityResolverFunc resolves an entity of a subgraph by its representation,
+// which is a JSON object containing the __typename and the key fields of the entity.
+// The returned JSON object contains the fields of the entity the subgraph resolves itself.
+type EntityResolverFunc func(ctx context.Context, representation []byte) (entity []byte, err error)
+
+// EntityResolvers are the reference resolvers of a subgraph by entity type name.
+// Entities without a reference resolver are resolved to their representation.
+type EntityResolvers map[string]EntityResolverFunc
+
+// NewSubgraphEngineConfiguration turns the configuration of an engine into the configuration of a federation subgraph.
+// The schema of the engine must have been created from the SDL of the subgraph including the federation directives.
+// The subgraph answers _service { sdl } with that SDL and resolves _entities(representations:) with the entity resolvers,
+// fields of the entities which are root nodes of the data sources of the engine are resolved by those data sources.
+func NewSubgraphEngineConfiguration(engineConfig EngineV2Configuration, entityResolvers EntityResolvers) (EngineV2Configuration, error) {
+	serviceSDL := string(engineConfig.schema.rawInput)
+
+	entityTypes := federation.EntityTypes(serviceSDL)
+	for typeName := range entityResolvers {
+		if !containsString(entityTypes, typeName) {
+			return EngineV2Configuration{}, fmt.Errorf("entity resolver registered for type %s which is not an entity", typeName)
+		}
+	}
+
+	subgraphSDL, err := federation.BuildSubgraphSchema(serviceSDL)
+	if err != nil {
+		return EngineV2Configuration{}, err
+	}
+	subgraphSchema, err := NewSchemaFromString(subgraphSDL)
+	if err != nil {
+		return EngineV2Configuration{}, err
+	}
+	queryTypeName := subgraphSchema.QueryTypeName()
+
+	serviceData, err := json.Marshal(map[string]string{"sdl": serviceSDL})
+	if err != nil {
+		return EngineV2Configuration{}, err
+	}
+
+	subgraphEngineConfig := engineConfig
+	subgraphEngineConfig.schema = subgraphSchema
+	subgraphEngineConfig.plannerConfig.DataSources = append([]plan.DataSourceConfiguration(nil), engineConfig.plannerConfig.DataSources...)
+	subgraphEngineConfig.plannerConfig.Fields = append(plan.FieldConfigurations(nil), engineConfig.plannerConfig.Fields...)
+
+	subgraphEngineConfig.AddDataSource(plan.DataSourceConfiguration{
+		RootNodes: []plan.TypeField{
+			{TypeName: queryTypeName, FieldNames: []string{"_service"}},
+		},
+		ChildNodes: []plan.TypeField{
+			{TypeName: "_Service", FieldNames: []string{"sdl"}},
+		},
+		Factory: &staticdatasource.Factory{},
+		Custom: staticdatasource.ConfigJSON(staticdatasource.Configuration{
+			Data: string(serviceData),
+		}),
+	})
+	subgraphEngineConfig.AddFieldConfiguration(plan.FieldConfiguration{
+		TypeName:              queryTypeName,
+		FieldName:             "_service",
+		DisableDefaultMapping: true,
+	})
+
+	if len(entityTypes) == 0 {
+		return subgraphEngineConfig, nil
+	}
+
+	subgraphEngineConfig.AddDataSource(plan.DataSourceConfiguration{
+		RootNodes: []plan.TypeField{
+			{TypeName: queryTypeName, FieldNames: []string{"_entities"}},
+		},
+		ChildNodes: entityChildNodes(&subgraphSchema.document, engineConfig.plannerConfig.DataSources),
+		Factory: &entitiesDataSourceFactory{
+			resolvers: entityResolvers,
+		},
+	})
+	subgraphEngineConfig.AddFieldConfiguration(plan.FieldConfiguration{
+		TypeName:  queryTypeName,
+		FieldName: "_entities",
+		Arguments: []plan.ArgumentConfiguration{
+			{
+				Name:         "representations",
+				SourceType:   plan.FieldArgumentSource,
+				RenderConfig: plan.RenderArgumentAsJSONValue,
+			},
+		},
+	})
+
+	return subgraphEngineConfig, nil
+}
+
+// entityChildNodes returns the fields of the object and interface types which are resolved from the entities,
+// i.e. all fields except those of the root operation types and those which are root nodes of the data sources of the engine
+func entityChildNodes(definition *ast.Document, dataSources []plan.DataSourceConfiguration) []plan.TypeField {
+	var childNodes []plan.TypeField
+	for _, node := range definition.RootNodes {
+		if node.Kind != ast.NodeKindObjectTypeDefinition && node.Kind != ast.NodeKindInterfaceTypeDefinition {
+			continue
+		}
+		typeName := definition.NodeNameString(node)
+		if strings.HasPrefix(typeName, "_") || definition.Index.IsRootOperationTypeNameString(typeName) {
+			continue
+		}
+		var fieldNames []string
+		for _, fieldRef := range definition.NodeFieldDefinitions(node) {
+			fieldName := definition.FieldDefinitionNameString(fieldRef)
+			if strings.HasPrefix(fieldName, "__") || isDataSourceRootNode(dataSources, typeName, fieldName) {
+				continue
+			}
+			fieldNames = append(fieldNames, fieldName)
+		}
+		if len(fieldNames) != 0 {
+			childNodes = append(childNodes, plan.TypeField{TypeName: typeName, FieldNames: fieldNames})
+		}
+	}
+	return childNodes
+}
+
+func isDataSourceRootNode(dataSources []plan.DataSourceConfiguration, typeName, fieldName string) bool {
+	for i := range dataSources {
+		for _, rootNode := range dataSources[i].RootNodes {
+			if rootNode.TypeName == typeName && containsString(rootNode.FieldNames, fieldName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+	return false
+}
+
+type entitiesDataSourceFactory struct {
+	resolvers EntityResolvers
+}
+
+func (f *entitiesDataSourceFactory) Planner(_ context.Context) plan.DataSourcePlanner {
+	return &entitiesDataSourcePlanner{
+		resolvers: f.resolvers,
+	}
+}
+
+type entitiesDataSourcePlanner struct {
+	resolvers EntityResolvers
+}
+
+func (p *entitiesDataSourcePlanner) DownstreamResponseFieldAlias(_ int) (alias string, exists bool) {
+	// skip, not required
+	return
+}
+
+func (p *entitiesDataSourcePlanner) DataSourcePlanningBehavior() plan.DataSourcePlanningBehavior {
+	return plan.DataSourcePlanningBehavior{
+		MergeAliasedRootNodes:      false,
+		OverrideFieldPathFromAlias: false,
+	}
+}
+
+func (p *entitiesDataSourcePlanner) Register(_ *plan.Visitor, _ plan.DataSourceConfiguration, _ bool) error {
+	return nil
+}
+
+func (p *entitiesDataSourcePlanner) ConfigureFetch() plan.FetchConfiguration {
+	return plan.FetchConfiguration{
+		Input: `{"representations":{{ .arguments.representations }}}`,
+		DataSource: &entitiesDataSource{
+			resolvers: p.resolvers,
+		},
+		DisableDataLoader: true,
+		ProcessResponseConfig: resolve.ProcessResponseConfig{
+			ExtractGraphqlResponse: true,
+		},
+	}
+}
+
+func (p *entitiesDataSourcePlanner) ConfigureSubscription() plan.SubscriptionConfiguration {
+	return plan.SubscriptionConfiguration{}
+}
+
+// entitiesDataSource resolves the representations of the input by their __typename with the entity resolvers
+type entitiesDataSource struct {
+	resolvers EntityResolvers
+}
+
+// Load responds with a GraphQL response, an entity which can't be resolved is null and causes an error with the path of the entity
+func (e *entitiesDataSource) Load(ctx context.Context, input []byte, w io.Writer) (err error) {
+	representations, _, _, err := jsonparser.Get(input, "representations")
+	if err != nil {
+		return fmt.Errorf("invalid representations: %w", err)
+	}
+
+	var entityErrors []entityError
+	out := &bytes.Buffer{}
+	out.WriteString(`{"data":{"_entities":[`)
+	i := 0
+	_, err = jsonparser.ArrayEach(representations, func(representation []byte, dataType jsonparser.ValueType, _ int, _ error) {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		entity, resolveErr := e.resolveEntity(ctx, representation, dataType)
+		if resolveErr != nil {
+			entityErrors = append(entityErrors, entityError{
+				Message: resolveErr.Error(),
+				Path:    []interface{}{"_entities", i},
+			})
+			entity = literal.NULL
+		}
+		out.Write(entity)
+		i++
+	})
+	if err != nil {
+		return fmt.Errorf("invalid representations: %w", err)
+	}
+	out.WriteString(`]}`)
+
+	if len(entityErrors) != 0 {
+		errorsJSON, err := json.Marshal(entityErrors)
+		if err != nil {
+			return err
+		}
+		out.WriteString(`,"errors":`)
+		out.Write(errorsJSON)
+	}
+	out.WriteByte('}')
+
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+type entityError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+func (e *entitiesDataSource) resolveEntity(ctx context.Context, representation []byte, dataType jsonparser.ValueType) ([]byte, error) {
+	if dataType != jsonparser.Object {
+		return nil, fmt.Errorf("representation must be an object: %s", representation)
+	}
+	typeName, err := jsonparser.GetString(representation, "__typename")
+	if err != nil {
+		return nil, fmt.Errorf("representation without __typename: %s", representation)
+	}
+
+	resolver, ok := e.resolvers[typeName]
+	if !ok {
+		// an entity without reference resolver only consists of its representation
+		return representation, nil
+	}
+
+	entity, err := resolver(ctx, representation)
+	if err != nil {
+		return nil, err
+	}
+	if len(entity) == 0 || bytes.Equal(entity, literal.NULL) {
+		return literal.NULL, nil
+	}
+	if _, _, _, err := jsonparser.Get(entity, "__typename"); err == jsonparser.KeyPathNotFoundError {
+		return jsonparser.Set(entity, []byte(`"`+typeName+`"`), "__typename")
+	}
+	return entity, nil
+}
@@ -0,0 +1,141 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/staticdatasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+)
+
+const subgraphTestSDL = `type Query {
+	topProducts: [Product]
+}
+
+type Product @key(fields: "upc") {
+	upc: String!
+	name: String!
+	warehouse: String!
+}
+
+extend type User @key(fields: "id") {
+	id: ID! @external
+}`
+
+func TestNewSubgraphEngineConfiguration(t *testing.T) {
+	newEngineConfig := func(t *testing.T) EngineV2Configuration {
+		schema, err := NewSchemaFromString(subgraphTestSDL)
+		require.NoError(t, err)
+
+		engineConfig := NewEngineV2Configuration(schema)
+		engineConfig.AddDataSource(plan.DataSourceConfiguration{
+			RootNodes: []plan.TypeField{
+				{TypeName: "Query", FieldNames: []string{"topProducts"}},
+			},
+			ChildNodes: []plan.TypeField{
+				{TypeName: "Product", FieldNames: []string{"upc", "name"}},
+			},
+			Factory: &staticdatasource.Factory{},
+			Custom: staticdatasource.ConfigJSON(staticdatasource.Configuration{
+				Data: `[{"upc":"top-1","name":"Trilby"}]`,
+			}),
+		})
+		engineConfig.AddDataSource(plan.DataSourceConfiguration{
+			RootNodes: []plan.TypeField{
+				{TypeName: "Product", FieldNames: []string{"warehouse"}},
+			},
+			Factory: &staticdatasource.Factory{},
+			Custom: staticdatasource.ConfigJSON(staticdatasource.Configuration{
+				Data: `"warehouse-{{ .object.upc }}"`,
+			}),
+		})
+		engineConfig.SetFieldConfigurations(plan.FieldConfigurations{
+			{TypeName: "Query", FieldName: "topProducts", DisableDefaultMapping: true},
+			{TypeName: "Product", FieldName: "warehouse", DisableDefaultMapping: true},
+		})
+		return engineConfig
+	}
+
+	entityResolvers := EntityResolvers{
+		"Product": func(ctx context.Context, representation []byte) ([]byte, error) {
+			var product struct {
+				Upc string `json:"upc"`
+			}
+			if err := json.Unmarshal(representation, &product); err != nil {
+				return nil, err
+			}
+			if product.Upc == "unknown" {
+				return nil, errors.New("unknown product")
+			}
+			return []byte(`{"upc":"` + product.Upc + `","name":"Product ` + product.Upc + `"}`), nil
+		},
+	}
+
+	subgraphEngineConfig, err := NewSubgraphEngineConfiguration(newEngineConfig(t), entityResolvers)
+	require.NoError(t, err)
+
+	engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.NoopLogger, subgraphEngineConfig)
+	require.NoError(t, err)
+
+	execute := func(t *testing.T, request *Request) (string, error) {
+		resultWriter := NewEngineResultWriter()
+		err := engine.Execute(context.Background(), request, &resultWriter)
+		return resultWriter.String(), err
+	}
+
+	t.Run("_service returns the original SDL", func(t *testing.T) {
+		result, err := execute(t, &Request{Query: `{ _service { sdl } }`})
+		require.NoError(t, err)
+
+		expected, err := json.Marshal(map[string]interface{}{
+			"data": map[string]interface{}{
+				"_service": map[string]string{"sdl": subgraphTestSDL},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), result)
+	})
+
+	t.Run("_entities dispatches the representations to the entity resolvers", func(t *testing.T) {
+		result, err := execute(t, &Request{
+			Query: `query($representations: [_Any!]!) {
+				_entities(representations: $representations) {
+					__typename
+					... on Product { upc name warehouse }
+					... on User { id }
+				}
+			}`,
+			Variables: []byte(`{"representations":[{"__typename":"Product","upc":"top-1"},{"__typename":"User","id":"1234"},{"__typename":"Product","upc":"top-2"}]}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":{"_entities":[{"__typename":"Product","upc":"top-1","name":"Product top-1","warehouse":"warehouse-top-1"},{"__typename":"User","id":"1234"},{"__typename":"Product","upc":"top-2","name":"Product top-2","warehouse":"warehouse-top-2"}]}}`, result)
+	})
+
+	t.Run("errors of entity resolvers are returned with the path of the entity", func(t *testing.T) {
+		result, err := execute(t, &Request{
+			Query:     `query($representations: [_Any!]!) { _entities(representations: $representations) { ... on Product { name } } }`,
+			Variables: []byte(`{"representations":[{"__typename":"Product","upc":"unknown"}]}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"errors":[{"message":"unknown product","path":["_entities",0]}],"data":{"_entities":[null]}}`, result)
+	})
+
+	t.Run("operations of the service are executed", func(t *testing.T) {
+		result, err := execute(t, &Request{Query: `{ topProducts { upc name } }`})
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":{"topProducts":[{"upc":"top-1","name":"Trilby"}]}}`, result)
+	})
+
+	t.Run("entity resolvers must resolve entities", func(t *testing.T) {
+		_, err := NewSubgraphEngineConfiguration(newEngineConfig(t), EntityResolvers{
+			"Query": entityResolvers["Product"],
+		})
+		assert.EqualError(t, err, "entity resolver registered for type Query which is not an entity")
+	})
+}
@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvalidation"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
@@ -40,6 +41,28 @@ func (r *Request) ValidateForSchema(schema *Schema) (result ValidationResult, er
 	return result, err
 }
 
+// CoerceVariableValues validates the variables of the request against the variable definitions of the operation
+// and replaces them with their coerced values, e.g. a single value of a list type becomes a list.
+// Invalid variables result in errors with the code BAD_USER_INPUT.
+// The request must be normalized, so that the default values of the variables have been applied.
+func (r *Request) CoerceVariableValues(schema *Schema) (result ValidationResult, err error) {
+	if schema == nil {
+		return ValidationResult{Valid: false, Errors: nil}, ErrNilSchema
+	}
+
+	report := r.parseQueryOnce()
+	if report.HasErrors() {
+		return operationValidationResultFromReport(report)
+	}
+
+	r.document.Input.Variables = r.Variables
+	astnormalization.CoerceVariableValues(&r.document, &schema.document, []byte(r.OperationName), &report)
+	if !report.HasErrors() {
+		r.Variables = r.document.Input.Variables
+	}
+	return operationValidationResultFromReport(report)
+}
+
 // ValidateRestrictedFields validates a request by checking if `restrictedFields` contains blocked fields.
 //
 // Deprecated: This function can only handle blocked fields. Use `ValidateFieldRestrictions` if you
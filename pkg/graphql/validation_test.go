@@ -96,6 +96,54 @@ func TestRequest_ValidateForSchema(t *testing.T) {
 	})
 }
 
+func TestRequest_CoerceVariableValues(t *testing.T) {
+	schema, err := NewSchemaFromString("schema { query: Query } type Query { hello(names: [String!]!, times: Int): String }")
+	require.NoError(t, err)
+
+	t.Run("should return error when schema is nil", func(t *testing.T) {
+		request := Request{
+			Query: `query Hello($names: [String!]!) { hello(names: $names) }`,
+		}
+
+		result, err := request.CoerceVariableValues(nil)
+		assert.Error(t, err)
+		assert.Equal(t, ErrNilSchema, err)
+		assert.Equal(t, ValidationResult{Valid: false, Errors: nil}, result)
+	})
+
+	t.Run("should replace variables with coerced values", func(t *testing.T) {
+		request := Request{
+			Variables: []byte(`{"names":"Luke","times":2.0}`),
+			Query:     `query Hello($names: [String!]!, $times: Int) { hello(names: $names, times: $times) }`,
+		}
+
+		result, err := request.CoerceVariableValues(schema)
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.JSONEq(t, `{"names":["Luke"],"times":2}`, string(request.Variables))
+	})
+
+	t.Run("should return bad user input errors for invalid variables", func(t *testing.T) {
+		request := Request{
+			Variables: []byte(`{"names":["Luke",null],"times":"twice"}`),
+			Query:     `query Hello($names: [String!]!, $times: Int) { hello(names: $names, times: $times) }`,
+		}
+
+		result, err := request.CoerceVariableValues(schema)
+		assert.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.JSONEq(t, `{"names":["Luke",null],"times":"twice"}`, string(request.Variables))
+
+		buf := &bytes.Buffer{}
+		_, err = result.Errors.WriteResponse(buf)
+		require.NoError(t, err)
+		assert.Equal(t, `{"errors":[`+
+			`{"message":"Variable \"$names\" got invalid value null at \"names[1]\"; Expected non-nullable type \"String!\" not to be null.","locations":[{"line":1,"column":13}],"extensions":{"code":"BAD_USER_INPUT"}},`+
+			`{"message":"Variable \"$times\" got invalid value \"twice\"; Int cannot represent non-integer value: \"twice\"","locations":[{"line":1,"column":33}],"extensions":{"code":"BAD_USER_INPUT"}}`+
+			`]}`, buf.String())
+	})
+}
+
 func TestRequest_ValidateRestrictedFields(t *testing.T) {
 	t.Run("should return error when schema is nil", func(t *testing.T) {
 		request := Request{}
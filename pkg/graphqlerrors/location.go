@@ -1,6 +1,17 @@
 package graphqlerrors
 
+import "fmt"
+
 type Location struct {
 	Line   uint32 `json:"line"`
 	Column uint32 `json:"column"`
+	// Source optionally names the input the location refers to, e.g. a .graphql file of a document built from multiple files
+	Source string `json:"source,omitempty"`
+}
+
+func (l Location) String() string {
+	if l.Source == "" {
+		return fmt.Sprintf("{Line:%d Column:%d}", l.Line, l.Column)
+	}
+	return fmt.Sprintf("{Source:%s Line:%d Column:%d}", l.Source, l.Line, l.Column)
 }
@@ -144,6 +144,7 @@ func (r *fromTypeRefResolver) fromTypeRef(operation, definition *ast.Document, t
 		if node, ok := definition.Index.FirstNodeByNameStr(name); ok {
 			switch node.Kind {
 			case ast.NodeKindInputObjectTypeDefinition:
+				isOneOf := definition.InputObjectTypeDefinitionIsOneOf(node.Ref)
 				for _, ref := range definition.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs {
 					fieldName := definition.Input.ByteSliceString(definition.InputValueDefinitions[ref].Name)
 					fieldType := definition.InputValueDefinitions[ref].Type
@@ -152,6 +153,17 @@ func (r *fromTypeRefResolver) fromTypeRef(operation, definition *ast.Document, t
 					if definition.TypeIsNonNull(fieldType) {
 						object.Required = append(object.Required, fieldName)
 					}
+					if isOneOf {
+						object.OneOf = append(object.OneOf, NewOneOfFieldBranch(fieldName))
+					}
+				}
+				if isOneOf {
+					// exactly one field must be provided with a non-null value
+					maxProperties := 1
+					object.MaxProperties = &maxProperties
+					if !nonNull {
+						object.OneOf = append(object.OneOf, NewOneOfNullBranch())
+					}
 				}
 			case ast.NodeKindObjectTypeDefinition:
 				for _, ref := range definition.ObjectTypeDefinitions[node.Ref].FieldsDefinition.Refs {
@@ -372,6 +384,8 @@ type Object struct {
 	Properties           map[string]JsonSchema `json:"properties,omitempty"`
 	Required             []string              `json:"required,omitempty"`
 	AdditionalProperties bool                  `json:"additionalProperties"`
+	MaxProperties        *int                  `json:"maxProperties,omitempty"`
+	OneOf                []OneOfBranch         `json:"oneOf,omitempty"`
 	Defs                 map[string]JsonSchema `json:"$defs,omitempty"`
 }
 
@@ -395,6 +409,42 @@ func NewObjectAny(nonNull bool) Object {
 	}
 }
 
+// OneOfBranch is one alternative of a OneOf Input Object,
+// it either matches objects containing a single field with a non-null value or null
+type OneOfBranch struct {
+	Type       []string           `json:"type"`
+	Required   []string           `json:"required,omitempty"`
+	Properties map[string]NotNull `json:"properties,omitempty"`
+}
+
+func NewOneOfFieldBranch(fieldName string) OneOfBranch {
+	return OneOfBranch{
+		Type:     []string{"object"},
+		Required: []string{fieldName},
+		Properties: map[string]NotNull{
+			fieldName: NewNotNull(),
+		},
+	}
+}
+
+func NewOneOfNullBranch() OneOfBranch {
+	return OneOfBranch{
+		Type: []string{"null"},
+	}
+}
+
+type NotNull struct {
+	Not struct {
+		Type []string `json:"type"`
+	} `json:"not"`
+}
+
+func NewNotNull() NotNull {
+	notNull := NotNull{}
+	notNull.Not.Type = []string{"null"}
+	return notNull
+}
+
 type Array struct {
 	Type     []string              `json:"type"`
 	Items    JsonSchema            `json:"items"`
@@ -187,6 +187,50 @@ func TestJsonSchema(t *testing.T) {
 			`{"str":"validString","nested":{"boo":123}}`,
 		},
 	))
+	t.Run("oneOf input object", runTest(
+		`scalar String scalar Int directive @oneOf on INPUT_OBJECT input Test @oneOf { str: String int: Int }`,
+		`query ($input: Test!){}`,
+		`{"type":["object"],"properties":{"int":{"type":["integer","null"]},"str":{"type":["string","null"]}},"additionalProperties":false,"maxProperties":1,"oneOf":[{"type":["object"],"required":["str"],"properties":{"str":{"not":{"type":["null"]}}}},{"type":["object"],"required":["int"],"properties":{"int":{"not":{"type":["null"]}}}}]}`,
+		[]string{
+			`{"str":"validString"}`,
+			`{"int":1}`,
+		},
+		[]string{
+			`null`,
+			`{}`,
+			`{"str":null}`,
+			`{"str":"validString","int":1}`,
+			`{"str":"validString","int":null}`,
+			`{"int":"invalid"}`,
+		},
+	))
+	t.Run("nullable oneOf input object", runTest(
+		`scalar String scalar Int directive @oneOf on INPUT_OBJECT input Test @oneOf { str: String int: Int }`,
+		`query ($input: Test){}`,
+		`{"type":["object","null"],"properties":{"int":{"type":["integer","null"]},"str":{"type":["string","null"]}},"additionalProperties":false,"maxProperties":1,"oneOf":[{"type":["object"],"required":["str"],"properties":{"str":{"not":{"type":["null"]}}}},{"type":["object"],"required":["int"],"properties":{"int":{"not":{"type":["null"]}}}},{"type":["null"]}]}`,
+		[]string{
+			`null`,
+			`{"str":"validString"}`,
+			`{"int":1}`,
+		},
+		[]string{
+			`{}`,
+			`{"int":null}`,
+			`{"str":"validString","int":1}`,
+		},
+	))
+	t.Run("nested oneOf input object", runTest(
+		`scalar String scalar Int directive @oneOf on INPUT_OBJECT input Test { by: By! } input By @oneOf { str: String int: Int }`,
+		`query ($input: Test!){}`,
+		`{"type":["object"],"properties":{"by":{"$ref":"#/$defs/By"}},"required":["by"],"additionalProperties":false,"$defs":{"By":{"type":["object"],"properties":{"int":{"type":["integer","null"]},"str":{"type":["string","null"]}},"additionalProperties":false,"maxProperties":1,"oneOf":[{"type":["object"],"required":["str"],"properties":{"str":{"not":{"type":["null"]}}}},{"type":["object"],"required":["int"],"properties":{"int":{"not":{"type":["null"]}}}}]}}}`,
+		[]string{
+			`{"by":{"str":"validString"}}`,
+		},
+		[]string{
+			`{"by":{}}`,
+			`{"by":{"str":"validString","int":1}}`,
+		},
+	))
 	t.Run("nested object with override", runTest(
 		`scalar String scalar Boolean input Test { str: String! override: Override } input Override { boo: Boolean }`,
 		`query ($input: Test){}`,
@@ -54,6 +54,10 @@ func (g *GraphQLHTTPRequestHandler) upgradeWithNewGoroutine(w http.ResponseWrite
 }
 
 func (g *GraphQLHTTPRequestHandler) isWebsocketUpgrade(r *http.Request) bool {
+	return isWebsocketUpgrade(r)
+}
+
+func isWebsocketUpgrade(r *http.Request) bool {
 	for _, header := range r.Header[httpHeaderUpgrade] {
 		if header == "websocket" {
 			return true
@@ -0,0 +1,402 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gobwas/ws"
+	log "github.com/jensneuse/abstractlogger"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/httpclient"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/subscription"
+)
+
+const (
+	httpHeaderAccept         string = "Accept"
+	httpHeaderAcceptEncoding string = "Accept-Encoding"
+	httpHeaderAllow          string = "Allow"
+
+	httpContentTypeApplicationGraphQLResponseJson string = "application/graphql-response+json"
+
+	defaultMaxBatchSize = 32
+)
+
+var (
+	errMutationOverGet   = errors.New("mutations can only be executed via POST")
+	errMissingQuery      = errors.New("missing query parameter")
+	errInvalidVariables  = errors.New("variables must be a JSON object")
+	errBatchingDisabled  = errors.New("batching is disabled")
+	errUnsupportedMethod = errors.New("only GET and POST requests are supported")
+)
+
+type handlerV2Options struct {
+	maxBatchSize        int
+	wsUpgrader          *ws.HTTPUpgrader
+	subscriptionOptions []subscription.HandlerOption
+}
+
+type HandlerV2Option func(options *handlerV2Options)
+
+// WithMaxBatchSize limits the number of operations accepted in a single batch request.
+// A value <= 0 disables batching, so that only single operations are accepted.
+func WithMaxBatchSize(maxBatchSize int) HandlerV2Option {
+	return func(options *handlerV2Options) {
+		options.maxBatchSize = maxBatchSize
+	}
+}
+
+// WithWebsocketUpgrader sets the upgrader used for subscriptions over websockets.
+func WithWebsocketUpgrader(upgrader *ws.HTTPUpgrader) HandlerV2Option {
+	return func(options *handlerV2Options) {
+		options.wsUpgrader = upgrader
+	}
+}
+
+// WithSubscriptionHandlerOptions configures the subscription.Handler of websocket connections,
+// e.g. to limit the resources a single client can hold.
+func WithSubscriptionHandlerOptions(subscriptionOptions ...subscription.HandlerOption) HandlerV2Option {
+	return func(options *handlerV2Options) {
+		options.subscriptionOptions = append(options.subscriptionOptions, subscriptionOptions...)
+	}
+}
+
+func NewGraphqlHTTPHandlerV2(engine *graphql.ExecutionEngineV2, logger log.Logger, opts ...HandlerV2Option) http.Handler {
+	options := handlerV2Options{
+		maxBatchSize: defaultMaxBatchSize,
+		wsUpgrader:   &ws.DefaultHTTPUpgrader,
+	}
+
+	for _, optFunc := range opts {
+		optFunc(&options)
+	}
+
+	return &GraphQLHTTPRequestHandlerV2{
+		log:                 logger,
+		engine:              engine,
+		maxBatchSize:        options.maxBatchSize,
+		wsUpgrader:          options.wsUpgrader,
+		subscriptionOptions: options.subscriptionOptions,
+	}
+}
+
+// GraphQLHTTPRequestHandlerV2 serves GraphQL requests with an ExecutionEngineV2
+// following the GraphQL-over-HTTP specification:
+//
+//   - GET requests carry the operation in the query parameters and can't execute mutations.
+//   - POST requests carry the operation as application/json body.
+//   - The response media type is negotiated via the Accept header; application/graphql-response+json
+//     answers requests which fail to parse or validate with 400, application/json with 200.
+//   - The response is compressed according to the Accept-Encoding header.
+//   - Websocket upgrades are handed over to subscription.Handler.
+//
+// Besides single operations it accepts a JSON array of operations (batching),
+// which get executed concurrently and answered with an array of results in the same order.
+type GraphQLHTTPRequestHandlerV2 struct {
+	log                 log.Logger
+	engine              *graphql.ExecutionEngineV2
+	maxBatchSize        int
+	wsUpgrader          *ws.HTTPUpgrader
+	subscriptionOptions []subscription.HandlerOption
+}
+
+func (g *GraphQLHTTPRequestHandlerV2) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if isWebsocketUpgrade(r) {
+		g.upgradeWebsocket(w, r)
+		return
+	}
+
+	responseContentType, ok := negotiateResponseContentType(r.Header.Get(httpHeaderAccept))
+	if !ok {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	var (
+		requests []graphql.Request
+		isBatch  bool
+		err      error
+	)
+
+	switch r.Method {
+	case http.MethodGet:
+		requests = make([]graphql.Request, 1)
+		err = unmarshalQueryParams(r.URL.Query(), &requests[0])
+	case http.MethodPost:
+		if !isJsonContentType(r.Header.Get(httpHeaderContentType)) {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		requests, isBatch, err = graphql.UnmarshalBatchRequest(r.Body)
+	default:
+		w.Header().Set(httpHeaderAllow, strings.Join([]string{http.MethodGet, http.MethodPost}, ", "))
+		g.writeErrors(w, r, http.StatusMethodNotAllowed, responseContentType, errUnsupportedMethod)
+		return
+	}
+
+	if err != nil {
+		g.log.Error("GraphQLHTTPRequestHandlerV2.ServeHTTP", log.Error(err))
+		g.writeErrors(w, r, http.StatusBadRequest, responseContentType, err)
+		return
+	}
+
+	if !isBatch {
+		g.handleSingle(w, r, responseContentType, &requests[0])
+		return
+	}
+
+	if g.maxBatchSize <= 0 {
+		g.writeErrors(w, r, http.StatusBadRequest, responseContentType, errBatchingDisabled)
+		return
+	}
+
+	if len(requests) > g.maxBatchSize {
+		g.writeErrors(w, r, http.StatusBadRequest, responseContentType, fmt.Errorf("batch contains %d operations, the maximum allowed is %d", len(requests), g.maxBatchSize))
+		return
+	}
+
+	g.handleBatch(w, r, responseContentType, requests)
+}
+
+func (g *GraphQLHTTPRequestHandlerV2) handleSingle(w http.ResponseWriter, r *http.Request, responseContentType string, gqlRequest *graphql.Request) {
+	if r.Method == http.MethodGet {
+		operationType, err := gqlRequest.OperationType()
+		if err != nil {
+			g.writeErrors(w, r, requestErrorStatus(responseContentType), responseContentType, err)
+			return
+		}
+
+		if operationType == graphql.OperationTypeMutation {
+			w.Header().Set(httpHeaderAllow, http.MethodPost)
+			g.writeErrors(w, r, http.StatusMethodNotAllowed, responseContentType, errMutationOverGet)
+			return
+		}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 4096))
+	status := g.execute(r.Context(), r.Header, responseContentType, gqlRequest, buf)
+	g.writeResponse(w, r, status, responseContentType, buf)
+}
+
+func (g *GraphQLHTTPRequestHandlerV2) handleBatch(w http.ResponseWriter, r *http.Request, responseContentType string, gqlRequests []graphql.Request) {
+	results := make([]*bytes.Buffer, len(gqlRequests))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(gqlRequests))
+	for i := range gqlRequests {
+		results[i] = bytes.NewBuffer(make([]byte, 0, 1024))
+		go func(i int) {
+			defer wg.Done()
+			// every operation has its own result, so errors stay isolated within the batch
+			_ = g.execute(r.Context(), r.Header, responseContentType, &gqlRequests[i], results[i])
+		}(i)
+	}
+	wg.Wait()
+
+	response := bytes.NewBuffer(make([]byte, 0, 4096))
+	response.WriteByte('[')
+	for i := range results {
+		if i != 0 {
+			response.WriteByte(',')
+		}
+		_, _ = results[i].WriteTo(response)
+	}
+	response.WriteByte(']')
+
+	g.writeResponse(w, r, http.StatusOK, responseContentType, response)
+}
+
+// execute runs a single operation and writes either the result or the errors into buf.
+func (g *GraphQLHTTPRequestHandlerV2) execute(ctx context.Context, header http.Header, responseContentType string, gqlRequest *graphql.Request, buf *bytes.Buffer) (status int) {
+	gqlRequest.SetHeader(header)
+
+	resultWriter := graphql.NewEngineResultWriterFromBuffer(buf)
+	err := g.engine.Execute(ctx, gqlRequest, &resultWriter)
+	if err == nil {
+		return http.StatusOK
+	}
+
+	g.log.Error("GraphQLHTTPRequestHandlerV2.execute", log.Error(err))
+
+	status = http.StatusInternalServerError
+	if isRequestError(err) {
+		status = requestErrorStatus(responseContentType)
+	}
+
+	buf.Reset()
+	if _, err = graphql.RequestErrorsFromError(err).WriteResponse(buf); err != nil {
+		g.log.Error("GraphQLHTTPRequestHandlerV2.execute", log.Error(err))
+	}
+
+	return status
+}
+
+func (g *GraphQLHTTPRequestHandlerV2) writeErrors(w http.ResponseWriter, r *http.Request, status int, responseContentType string, err error) {
+	buf := &bytes.Buffer{}
+	if _, err = graphql.RequestErrorsFromError(err).WriteResponse(buf); err != nil {
+		g.log.Error("GraphQLHTTPRequestHandlerV2.writeErrors", log.Error(err))
+	}
+
+	g.writeResponse(w, r, status, responseContentType, buf)
+}
+
+// writeResponse writes buf compressed according to the Accept-Encoding header of the request.
+func (g *GraphQLHTTPRequestHandlerV2) writeResponse(w http.ResponseWriter, r *http.Request, status int, responseContentType string, buf *bytes.Buffer) {
+	headers := http.Header{}
+	headers.Set(httpHeaderContentType, responseContentType)
+	if encoding := negotiateContentEncoding(r.Header.Get(httpHeaderAcceptEncoding)); encoding != "" {
+		headers.Set(httpclient.ContentEncodingHeader, encoding)
+	}
+
+	resultWriter := graphql.NewEngineResultWriterFromBuffer(buf)
+	response := resultWriter.AsHTTPResponse(status, headers)
+
+	for key, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(response.StatusCode)
+
+	if _, err := io.Copy(w, response.Body); err != nil {
+		g.log.Error("GraphQLHTTPRequestHandlerV2.writeResponse", log.Error(err))
+	}
+}
+
+func (g *GraphQLHTTPRequestHandlerV2) upgradeWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, _, _, err := g.wsUpgrader.Upgrade(r, w)
+	if err != nil {
+		g.log.Error("GraphQLHTTPRequestHandlerV2.upgradeWebsocket", log.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	g.handleWebsocket(r.Context(), conn)
+}
+
+// handleWebsocket will handle the websocket connection.
+func (g *GraphQLHTTPRequestHandlerV2) handleWebsocket(connInitReqCtx context.Context, conn net.Conn) {
+	done := make(chan bool)
+	errChan := make(chan error)
+
+	executorPool := subscription.NewExecutorV2Pool(g.engine, connInitReqCtx)
+	go HandleWebsocket(done, errChan, conn, executorPool, g.log, g.subscriptionOptions...)
+	select {
+	case err := <-errChan:
+		g.log.Error("http.GraphQLHTTPRequestHandlerV2.handleWebsocket()",
+			log.Error(err),
+		)
+	case <-done:
+	}
+}
+
+func unmarshalQueryParams(params url.Values, request *graphql.Request) error {
+	request.Query = params.Get("query")
+	if request.Query == "" {
+		return errMissingQuery
+	}
+
+	request.OperationName = params.Get("operationName")
+
+	if variables := params.Get("variables"); variables != "" {
+		if !json.Valid([]byte(variables)) || strings.TrimSpace(variables)[0] != '{' {
+			return errInvalidVariables
+		}
+		request.Variables = json.RawMessage(variables)
+	}
+
+	return nil
+}
+
+func isJsonContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == httpContentTypeApplicationJson
+}
+
+// negotiateResponseContentType picks the response media type from the Accept header.
+// A missing Accept header falls back to application/json for legacy clients.
+func negotiateResponseContentType(accept string) (contentType string, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		return httpContentTypeApplicationJson, true
+	}
+
+	acceptsJson := false
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+
+		switch mediaType {
+		case httpContentTypeApplicationGraphQLResponseJson:
+			return httpContentTypeApplicationGraphQLResponseJson, true
+		case httpContentTypeApplicationJson, "application/*", "*/*":
+			acceptsJson = true
+		}
+	}
+
+	if acceptsJson {
+		return httpContentTypeApplicationJson, true
+	}
+
+	return "", false
+}
+
+// negotiateContentEncoding picks a content encoding supported by EngineResultWriter.AsHTTPResponse.
+func negotiateContentEncoding(acceptEncoding string) string {
+	acceptsDeflate := false
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, err := mime.ParseMediaType(strings.TrimSpace(coding))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+
+		switch name {
+		case "gzip":
+			return "gzip"
+		case "deflate":
+			acceptsDeflate = true
+		}
+	}
+
+	if acceptsDeflate {
+		return "deflate"
+	}
+
+	return ""
+}
+
+// isRequestError reports whether the operation failed to parse, normalize or validate.
+func isRequestError(err error) bool {
+	switch e := err.(type) {
+	case operationreport.Report:
+		return len(e.ExternalErrors) > 0
+	case graphql.Errors:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestErrorStatus is the status code for requests which fail to parse or validate.
+// Legacy application/json responses always use 200.
+func requestErrorStatus(responseContentType string) int {
+	if responseContentType == httpContentTypeApplicationGraphQLResponseJson {
+		return http.StatusBadRequest
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gobwas/ws"
+	"github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/httpclient"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/staticdatasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+	"github.com/wundergraph/graphql-go-tools/pkg/subscription"
+)
+
+func newHelloWorldEngine(t *testing.T, ctx context.Context) *graphql.ExecutionEngineV2 {
+	schema, err := graphql.NewSchemaFromString(`type Query { hello: String } type Mutation { setHello(value: String!): String }`)
+	require.NoError(t, err)
+
+	engineConf := graphql.NewEngineV2Configuration(schema)
+	engineConf.SetDataSources([]plan.DataSourceConfiguration{
+		{
+			RootNodes: []plan.TypeField{
+				{TypeName: "Query", FieldNames: []string{"hello"}},
+				{TypeName: "Mutation", FieldNames: []string{"setHello"}},
+			},
+			Factory: &staticdatasource.Factory{},
+			Custom: staticdatasource.ConfigJSON(staticdatasource.Configuration{
+				Data: `"world"`,
+			}),
+		},
+	})
+	engineConf.SetFieldConfigurations([]plan.FieldConfiguration{
+		{
+			TypeName:              "Query",
+			FieldName:             "hello",
+			DisableDefaultMapping: true,
+		},
+		{
+			TypeName:              "Mutation",
+			FieldName:             "setHello",
+			DisableDefaultMapping: true,
+		},
+	})
+
+	engine, err := graphql.NewExecutionEngineV2(ctx, abstractlogger.NoopLogger, engineConf)
+	require.NoError(t, err)
+	return engine
+}
+
+func TestGraphQLHTTPRequestHandlerV2_ServeHTTP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	engine := newHelloWorldEngine(t, ctx)
+
+	do := func(t *testing.T, handler http.Handler, req *http.Request) (*http.Response, string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		resp := rec.Result()
+		defer resp.Body.Close()
+
+		responseBody, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(responseBody)
+	}
+
+	post := func(t *testing.T, handler http.Handler, body string) (int, string) {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(body))
+		req.Header.Set(httpHeaderContentType, httpContentTypeApplicationJson)
+		resp, responseBody := do(t, handler, req)
+		return resp.StatusCode, responseBody
+	}
+
+	handler := NewGraphqlHTTPHandlerV2(engine, abstractlogger.NoopLogger, WithMaxBatchSize(2))
+
+	t.Run("should handle single operation", func(t *testing.T) {
+		status, body := post(t, handler, `{"query":"{ hello }"}`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"data":{"hello":"world"}}`, body)
+	})
+
+	t.Run("should return 200 for invalid single operation with application/json", func(t *testing.T) {
+		status, body := post(t, handler, `{"query":"{ goodbye }"}`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, `"errors"`)
+	})
+
+	t.Run("should return 400 for malformed body", func(t *testing.T) {
+		status, _ := post(t, handler, `{"query":`)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("should handle batch in order", func(t *testing.T) {
+		status, body := post(t, handler, `[{"query":"{ hello }"},{"query":"mutation { setHello(value: \"a\") }"}]`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `[{"data":{"hello":"world"}},{"data":{"setHello":"world"}}]`, body)
+	})
+
+	t.Run("should isolate errors of single operations in a batch", func(t *testing.T) {
+		status, body := post(t, handler, `[{"query":"{ goodbye }"},{"query":"{ hello }"}]`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `[{"errors":[{"message":"field: goodbye not defined on type: Query","path":["query","goodbye"]}]},{"data":{"hello":"world"}}]`, body)
+	})
+
+	t.Run("should reject batch exceeding max batch size", func(t *testing.T) {
+		status, body := post(t, handler, `[{"query":"{ hello }"},{"query":"{ hello }"},{"query":"{ hello }"}]`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, `{"errors":[{"message":"batch contains 3 operations, the maximum allowed is 2"}]}`, body)
+	})
+
+	t.Run("should reject batch when batching is disabled", func(t *testing.T) {
+		status, body := post(t, NewGraphqlHTTPHandlerV2(engine, abstractlogger.NoopLogger, WithMaxBatchSize(0)), `[{"query":"{ hello }"}]`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, `{"errors":[{"message":"batching is disabled"}]}`, body)
+	})
+
+	t.Run("GET", func(t *testing.T) {
+		t.Run("should handle query from query params", func(t *testing.T) {
+			params := url.Values{}
+			params.Set("query", "query Hello($a: String) { hello }")
+			params.Set("operationName", "Hello")
+			params.Set("variables", `{"a":"b"}`)
+
+			resp, body := do(t, handler, httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil))
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, httpContentTypeApplicationJson, resp.Header.Get(httpHeaderContentType))
+			assert.Equal(t, `{"data":{"hello":"world"}}`, body)
+		})
+
+		t.Run("should reject mutations", func(t *testing.T) {
+			params := url.Values{}
+			params.Set("query", `mutation { setHello(value: "a") }`)
+
+			resp, body := do(t, handler, httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil))
+			assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+			assert.Equal(t, http.MethodPost, resp.Header.Get(httpHeaderAllow))
+			assert.Equal(t, `{"errors":[{"message":"mutations can only be executed via POST"}]}`, body)
+		})
+
+		t.Run("should return 400 for missing query", func(t *testing.T) {
+			resp, _ := do(t, handler, httptest.NewRequest(http.MethodGet, "/graphql", nil))
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+
+		t.Run("should return 400 for invalid variables", func(t *testing.T) {
+			resp, _ := do(t, handler, httptest.NewRequest(http.MethodGet, "/graphql?query=%7Bhello%7D&variables=%5B%5D", nil))
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+
+	t.Run("should return 405 for unsupported methods", func(t *testing.T) {
+		resp, _ := do(t, handler, httptest.NewRequest(http.MethodPut, "/graphql", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		assert.Equal(t, "GET, POST", resp.Header.Get(httpHeaderAllow))
+	})
+
+	t.Run("should return 415 for unsupported content type", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{ hello }`))
+		req.Header.Set(httpHeaderContentType, "application/graphql")
+		resp, _ := do(t, handler, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	t.Run("should accept content type with charset", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query":"{ hello }"}`))
+		req.Header.Set(httpHeaderContentType, "application/json; charset=utf-8")
+		resp, body := do(t, handler, req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"data":{"hello":"world"}}`, body)
+	})
+
+	t.Run("application/graphql-response+json", func(t *testing.T) {
+		newRequest := func(body string) *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(body))
+			req.Header.Set(httpHeaderContentType, httpContentTypeApplicationJson)
+			req.Header.Set(httpHeaderAccept, "application/graphql-response+json, application/json;q=0.9")
+			return req
+		}
+
+		t.Run("should respond with negotiated content type", func(t *testing.T) {
+			resp, body := do(t, handler, newRequest(`{"query":"{ hello }"}`))
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, httpContentTypeApplicationGraphQLResponseJson, resp.Header.Get(httpHeaderContentType))
+			assert.Equal(t, `{"data":{"hello":"world"}}`, body)
+		})
+
+		t.Run("should return 400 for validation errors", func(t *testing.T) {
+			resp, body := do(t, handler, newRequest(`{"query":"{ goodbye }"}`))
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, `{"errors":[{"message":"field: goodbye not defined on type: Query","path":["query","goodbye"]}]}`, body)
+		})
+
+		t.Run("should return 400 for parse errors", func(t *testing.T) {
+			resp, _ := do(t, handler, newRequest(`{"query":"{ hello "}`))
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+
+	t.Run("should return 406 for unsupported accept header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query":"{ hello }"}`))
+		req.Header.Set(httpHeaderContentType, httpContentTypeApplicationJson)
+		req.Header.Set(httpHeaderAccept, "text/html")
+		resp, _ := do(t, handler, req)
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+	})
+
+	t.Run("should compress response according to accept encoding", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query":"{ hello }"}`))
+		req.Header.Set(httpHeaderContentType, httpContentTypeApplicationJson)
+		req.Header.Set(httpHeaderAcceptEncoding, "br;q=1.0, gzip;q=0.8")
+		resp, body := do(t, handler, req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "gzip", resp.Header.Get(httpclient.ContentEncodingHeader))
+
+		reader, err := gzip.NewReader(bytes.NewBufferString(body))
+		require.NoError(t, err)
+		decompressed, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":{"hello":"world"}}`, string(decompressed))
+	})
+
+	t.Run("should upgrade to websocket", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		clientConn, _, _, err := ws.Dial(ctx, strings.Replace(server.URL, "http", "ws", 1))
+		require.NoError(t, err)
+		defer clientConn.Close()
+
+		sendMessageToServer(t, clientConn, subscription.Message{
+			Type: subscription.MessageTypeConnectionInit,
+		})
+
+		serverMessage := readMessageFromServer(t, clientConn)
+		assert.Equal(t, `{"id":"","type":"connection_ack","payload":null}`, string(serverMessage))
+	})
+}
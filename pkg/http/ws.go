@@ -124,6 +124,7 @@ func HandleWebsocketWithInitFunc(
 	executorPool subscription.ExecutorPool,
 	logger abstractlogger.Logger,
 	initFunc subscription.WebsocketInitFunc,
+	opts ...subscription.HandlerOption,
 ) {
 	defer func() {
 		if err := conn.Close(); err != nil {
@@ -135,7 +136,7 @@ func HandleWebsocketWithInitFunc(
 	}()
 
 	websocketClient := NewWebsocketSubscriptionClient(logger, conn)
-	subscriptionHandler, err := subscription.NewHandlerWithInitFunc(logger, websocketClient, executorPool, initFunc)
+	subscriptionHandler, err := subscription.NewHandlerWithInitFunc(logger, websocketClient, executorPool, initFunc, opts...)
 	if err != nil {
 		logger.Error("http.HandleWebsocket()",
 			abstractlogger.String("message", "could not create subscriptionHandler"),
@@ -150,8 +151,8 @@ func HandleWebsocketWithInitFunc(
 	subscriptionHandler.Handle(context.Background()) // Blocking
 }
 
-func HandleWebsocket(done chan bool, errChan chan error, conn net.Conn, executorPool subscription.ExecutorPool, logger abstractlogger.Logger) {
-	HandleWebsocketWithInitFunc(done, errChan, conn, executorPool, logger, nil)
+func HandleWebsocket(done chan bool, errChan chan error, conn net.Conn, executorPool subscription.ExecutorPool, logger abstractlogger.Logger, opts ...subscription.HandlerOption) {
+	HandleWebsocketWithInitFunc(done, errChan, conn, executorPool, logger, nil, opts...)
 }
 
 // handleWebsocket will handle the websocket connection.
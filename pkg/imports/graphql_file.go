@@ -2,9 +2,12 @@ package imports
 
 import (
 	"bufio"
+	"bytes"
 	"io"
+	"io/ioutil"
 	"os"
 
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
 )
 
@@ -17,6 +20,39 @@ func (g GraphQLFile) Render(printFilePath bool, out io.Writer) error {
 	return g.render(printFilePath, out)
 }
 
+// AppendSources appends the file and all of its imports to the Input of the document in the same order as Render.
+// Each file is recorded in the SourceMap of the document, so that errors can be resolved to the file and line they
+// originate from using operationreport.Report.ResolveSources. Import statements are replaced with empty lines
+// to keep the line numbers of the files.
+func (g GraphQLFile) AppendSources(document *ast.Document) error {
+	if g.RelativePath != "" {
+		content, err := ioutil.ReadFile(g.RelativePath)
+		if err != nil {
+			return err
+		}
+		document.AppendSource(g.RelativePath, g.removeImportStatements(content))
+	}
+
+	for _, importFile := range g.Imports {
+		err := importFile.AppendSources(document)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g GraphQLFile) removeImportStatements(content []byte) []byte {
+	lines := bytes.Split(content, literal.LINETERMINATOR)
+	for i := range lines {
+		if importStatementRegex.Match(lines[i]) {
+			lines[i] = nil
+		}
+	}
+	return bytes.Join(lines, literal.LINETERMINATOR)
+}
+
 func (g GraphQLFile) render(printFilePath bool, out io.Writer) error {
 
 	var err error
@@ -3,10 +3,18 @@ package imports
 import (
 	"bytes"
 	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/jensneuse/diffview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvalidation"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/goldie"
 )
 
@@ -35,3 +43,44 @@ func TestGraphQLFile_Render(t *testing.T) {
 		diffview.NewGoland().DiffViewBytes("render_result", fixture, dump)
 	}
 }
+
+func TestGraphQLFile_AppendSources(t *testing.T) {
+	scanner := Scanner{}
+	file, err := scanner.ScanFile("testdata/schema.graphql")
+	require.NoError(t, err)
+
+	document := ast.NewDocument()
+	require.NoError(t, file.AppendSources(document))
+
+	sourceNames := make([]string, 0, len(document.SourceMap.Sources))
+	for _, source := range document.SourceMap.Sources {
+		sourceNames = append(sourceNames, filepath.ToSlash(source.Name))
+	}
+	assert.Equal(t, []string{
+		"testdata/schema.graphql",
+		"testdata/scalars/json.graphql",
+		"testdata/types/query.graphql",
+		"testdata/nested/nested.graphql",
+		"testdata/nested2/nested2.graphql",
+		"testdata/deep/deeper/custom_types.graphql",
+	}, sourceNames)
+
+	t.Run("errors are resolved to the files", func(t *testing.T) {
+		report := operationreport.Report{}
+		astparser.NewParser().Parse(document, &report)
+		require.False(t, report.HasErrors(), report.Error())
+
+		astvalidation.DefaultDefinitionValidator().Validate(document, &report)
+		require.True(t, report.HasErrors())
+		report.ResolveSources(&document.SourceMap)
+
+		var locations []graphqlerrors.Location
+		for _, externalError := range report.ExternalErrors {
+			for _, location := range externalError.Locations {
+				location.Source = filepath.ToSlash(location.Source)
+				locations = append(locations, location)
+			}
+		}
+		assert.Contains(t, locations, graphqlerrors.Location{Source: "testdata/schema.graphql", Line: 8, Column: 15})
+	})
+}
@@ -56,7 +56,7 @@ func (j *JsonConverter) importSchema() error {
 func (j *JsonConverter) importFullType(fullType FullType) (err error) {
 	switch fullType.Kind {
 	case SCALAR:
-		j.doc.ImportScalarTypeDefinition(fullType.Name, fullType.Description)
+		j.importScalar(fullType)
 	case OBJECT:
 		err = j.importObject(fullType)
 	case ENUM:
@@ -71,6 +71,18 @@ func (j *JsonConverter) importFullType(fullType FullType) (err error) {
 	return
 }
 
+func (j *JsonConverter) importScalar(fullType FullType) {
+	var directiveRefs []int
+	if fullType.SpecifiedByURL != nil {
+		directiveRefs = append(directiveRefs, j.importSpecifiedByDirective(*fullType.SpecifiedByURL))
+	}
+
+	j.doc.ImportScalarTypeDefinitionWithDirectives(
+		fullType.Name,
+		fullType.Description,
+		directiveRefs)
+}
+
 func (j *JsonConverter) importObject(fullType FullType) error {
 	fieldRefs, err := j.importFields(fullType.Fields)
 	if err != nil {
@@ -126,10 +138,16 @@ func (j *JsonConverter) importInputObject(fullType FullType) error {
 		return err
 	}
 
-	j.doc.ImportInputObjectTypeDefinition(
+	var directiveRefs []int
+	if fullType.IsOneOf != nil && *fullType.IsOneOf {
+		directiveRefs = append(directiveRefs, j.doc.ImportDirective(ast.OneOfDirectiveName, nil))
+	}
+
+	j.doc.ImportInputObjectTypeDefinitionWithDirectives(
 		fullType.Name,
 		fullType.Description,
-		argRefs)
+		argRefs,
+		directiveRefs)
 
 	return nil
 }
@@ -219,8 +237,13 @@ func (j *JsonConverter) importInputField(field InputValue) (ref int, err error)
 		return -1, err
 	}
 
-	return j.doc.ImportInputValueDefinition(
-		field.Name, field.Description, typeRef, defaultValue), nil
+	var directiveRefs []int
+	if field.IsDeprecated {
+		directiveRefs = append(directiveRefs, j.importDeprecatedDirective(field.DeprecationReason))
+	}
+
+	return j.doc.ImportInputValueDefinitionWithDirectives(
+		field.Name, field.Description, typeRef, defaultValue, directiveRefs), nil
 }
 
 func (j *JsonConverter) importType(typeRef TypeRef) (ref int) {
@@ -273,3 +296,13 @@ func (j *JsonConverter) importDeprecatedDirective(reason *string) (ref int) {
 
 	return j.doc.ImportDirective(DeprecatedDirectiveName, args)
 }
+
+func (j *JsonConverter) importSpecifiedByDirective(url string) (ref int) {
+	value := ast.Value{
+		Kind: ast.ValueKindString,
+		Ref:  j.doc.ImportStringValue([]byte(url), false),
+	}
+	j.doc.AddValue(value)
+
+	return j.doc.ImportDirective(SpecifiedByDirectiveName, []int{j.doc.ImportArgument(SpecifiedByURLArgName, value)})
+}
@@ -63,6 +63,43 @@ func TestJSONConverter_GraphQLDocument(t *testing.T) {
 	}
 }
 
+func TestJSONConverter_GraphQLDocument_OneOf(t *testing.T) {
+	introspectionJSON := `{"__schema":{"queryType":{"name":"Query"},"types":[
+		{"kind":"OBJECT","name":"Query","fields":[{"name":"pet","args":[{"name":"by","type":{"kind":"NON_NULL","ofType":{"kind":"INPUT_OBJECT","name":"PetBy"}}}],"type":{"kind":"SCALAR","name":"String"}}]},
+		{"kind":"INPUT_OBJECT","name":"PetBy","inputFields":[{"name":"id","type":{"kind":"SCALAR","name":"ID"}}],"isOneOf":true},
+		{"kind":"INPUT_OBJECT","name":"PetFilter","inputFields":[{"name":"name","type":{"kind":"SCALAR","name":"String"}}],"isOneOf":false}
+	],"directives":[]}}`
+
+	converter := JsonConverter{}
+	doc, err := converter.GraphQLDocument(bytes.NewBufferString(introspectionJSON))
+	require.NoError(t, err)
+
+	printed, err := astprinter.PrintString(doc, nil)
+	require.NoError(t, err)
+	assert.Contains(t, printed, "input PetBy @oneOf {id: ID}")
+	assert.Contains(t, printed, "input PetFilter {name: String}")
+}
+
+func TestJSONConverter_GraphQLDocument_SpecifiedByAndDeprecatedInputValues(t *testing.T) {
+	introspectionJSON := `{"__schema":{"queryType":{"name":"Query"},"types":[
+		{"kind":"OBJECT","name":"Query","fields":[{"name":"user","args":[{"name":"id","type":{"kind":"SCALAR","name":"UUID"}},{"name":"legacyId","type":{"kind":"SCALAR","name":"Int"},"isDeprecated":true,"deprecationReason":"use id"}],"type":{"kind":"SCALAR","name":"String"}}]},
+		{"kind":"SCALAR","name":"UUID","specifiedByURL":"https://tools.ietf.org/html/rfc4122"},
+		{"kind":"SCALAR","name":"Date"},
+		{"kind":"INPUT_OBJECT","name":"Filter","inputFields":[{"name":"name","type":{"kind":"SCALAR","name":"String"}},{"name":"oldName","type":{"kind":"SCALAR","name":"String"},"isDeprecated":true,"deprecationReason":null}]}
+	],"directives":[]}}`
+
+	converter := JsonConverter{}
+	doc, err := converter.GraphQLDocument(bytes.NewBufferString(introspectionJSON))
+	require.NoError(t, err)
+
+	printed, err := astprinter.PrintString(doc, nil)
+	require.NoError(t, err)
+	assert.Contains(t, printed, `scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")`)
+	assert.Contains(t, printed, "scalar Date ")
+	assert.Contains(t, printed, `user(id: UUID, legacyId: Int @deprecated(reason: "use id")): String`)
+	assert.Contains(t, printed, "input Filter {name: String oldName: String @deprecated}")
+}
+
 func BenchmarkJsonConverter_GraphQLDocument(b *testing.B) {
 	introspectedBytes, err := ioutil.ReadFile("./testdata/swapi_introspection_response.json")
 	require.NoError(b, err)
@@ -12,6 +12,8 @@ import (
 const (
 	DeprecatedDirectiveName  = "deprecated"
 	DeprecationReasonArgName = "reason"
+	SpecifiedByDirectiveName = "specifiedBy"
+	SpecifiedByURLArgName    = "url"
 )
 
 type Generator struct {
@@ -131,6 +133,14 @@ func (i *introspectionVisitor) EnterInputValueDefinition(ref int) {
 		DefaultValue: defaultValue,
 	}
 
+	if i.definition.InputValueDefinitions[ref].HasDirectives {
+		directiveRef, exists := i.definition.InputValueDefinitionDirectiveByName(ref, []byte(DeprecatedDirectiveName))
+		if exists {
+			inputValue.IsDeprecated = true
+			inputValue.DeprecationReason = i.deprecationReason(directiveRef)
+		}
+	}
+
 	switch i.Ancestors[len(i.Ancestors)-1].Kind {
 	case ast.NodeKindInputObjectTypeDefinition:
 		i.currentType.InputFields = append(i.currentType.InputFields, inputValue)
@@ -192,6 +202,7 @@ func (i *introspectionVisitor) EnterScalarTypeDefinition(ref int) {
 	typeDefinition.Kind = SCALAR
 	typeDefinition.Name = i.definition.ScalarTypeDefinitionNameString(ref)
 	typeDefinition.Description = i.definition.ScalarTypeDefinitionDescriptionString(ref)
+	typeDefinition.SpecifiedByURL = i.specifiedByURL(ref)
 	i.data.Schema.Types = append(i.data.Schema.Types, typeDefinition)
 }
 
@@ -253,6 +264,8 @@ func (i *introspectionVisitor) EnterInputObjectTypeDefinition(ref int) {
 	i.currentType.Kind = INPUTOBJECT
 	i.currentType.Name = i.definition.InputObjectTypeDefinitionNameString(ref)
 	i.currentType.Description = i.definition.InputObjectTypeDefinitionDescriptionString(ref)
+	isOneOf := i.definition.InputObjectTypeDefinitionIsOneOf(ref)
+	i.currentType.IsOneOf = &isOneOf
 }
 
 func (i *introspectionVisitor) LeaveInputObjectTypeDefinition(ref int) {
@@ -350,3 +363,22 @@ func (i *introspectionVisitor) deprecationReason(directiveRef int) (reason *stri
 
 	return
 }
+
+func (i *introspectionVisitor) specifiedByURL(scalarRef int) *string {
+	if !i.definition.ScalarTypeDefinitionHasDirectives(scalarRef) {
+		return nil
+	}
+
+	directiveRef, exists := i.definition.ScalarTypeDefinitionDirectiveByName(scalarRef, []byte(SpecifiedByDirectiveName))
+	if !exists {
+		return nil
+	}
+
+	argValue, exists := i.definition.DirectiveArgumentValueByName(directiveRef, []byte(SpecifiedByURLArgName))
+	if !exists {
+		return nil
+	}
+
+	url := i.definition.ValueContentString(argValue)
+	return &url
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/jensneuse/diffview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/goldie"
@@ -78,3 +80,68 @@ func TestGenerator_Generate_Interfaces_Implementing_Interfaces(t *testing.T) {
 		diffview.NewGoland().DiffViewBytes("interfaces_implements_interfaces", fixture, outputPretty)
 	}
 }
+
+func TestGenerator_Generate_OneOf(t *testing.T) {
+	definition, report := astparser.ParseGraphqlDocumentString(`
+		directive @oneOf on INPUT_OBJECT
+		input PetBy @oneOf { id: ID name: String }
+		input PetFilter { name: String }
+		type Query { pet(by: PetBy!): String }
+	`)
+	require.False(t, report.HasErrors(), report.Error())
+
+	var data Data
+	NewGenerator().Generate(&definition, &report, &data)
+	require.False(t, report.HasErrors(), report.Error())
+
+	isOneOf := map[string]*bool{}
+	for _, fullType := range data.Schema.Types {
+		isOneOf[fullType.Name] = fullType.IsOneOf
+	}
+
+	require.NotNil(t, isOneOf["PetBy"])
+	assert.True(t, *isOneOf["PetBy"])
+	require.NotNil(t, isOneOf["PetFilter"])
+	assert.False(t, *isOneOf["PetFilter"])
+	assert.Nil(t, isOneOf["Query"])
+}
+
+func TestGenerator_Generate_SpecifiedByAndDeprecatedInputValues(t *testing.T) {
+	definition, report := astparser.ParseGraphqlDocumentString(`
+		directive @deprecated(reason: String = "No longer supported") on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
+		directive @specifiedBy(url: String!) on SCALAR
+		scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+		scalar Date
+		input Filter { name: String oldName: String @deprecated(reason: "use name") }
+		type Query { user(id: UUID, legacyId: Int @deprecated): String }
+	`)
+	require.False(t, report.HasErrors(), report.Error())
+
+	var data Data
+	NewGenerator().Generate(&definition, &report, &data)
+	require.False(t, report.HasErrors(), report.Error())
+
+	types := map[string]FullType{}
+	for _, fullType := range data.Schema.Types {
+		types[fullType.Name] = fullType
+	}
+
+	require.NotNil(t, types["UUID"].SpecifiedByURL)
+	assert.Equal(t, "https://tools.ietf.org/html/rfc4122", *types["UUID"].SpecifiedByURL)
+	assert.Nil(t, types["Date"].SpecifiedByURL)
+
+	inputFields := types["Filter"].InputFields
+	require.Len(t, inputFields, 2)
+	assert.False(t, inputFields[0].IsDeprecated)
+	assert.Nil(t, inputFields[0].DeprecationReason)
+	assert.True(t, inputFields[1].IsDeprecated)
+	require.NotNil(t, inputFields[1].DeprecationReason)
+	assert.Equal(t, "use name", *inputFields[1].DeprecationReason)
+
+	args := types["Query"].Fields[0].Args
+	require.Len(t, args, 2)
+	assert.False(t, args[0].IsDeprecated)
+	assert.True(t, args[1].IsDeprecated)
+	require.NotNil(t, args[1].DeprecationReason)
+	assert.Equal(t, "No longer supported", *args[1].DeprecationReason)
+}
@@ -57,6 +57,10 @@ type FullType struct {
 	EnumValues []EnumValue `json:"enumValues"`
 	// not empty for __TypeKind INTERFACE and UNION only
 	PossibleTypes []TypeRef `json:"possibleTypes"`
+	// not nil for __TypeKind INPUT_OBJECT only
+	IsOneOf *bool `json:"isOneOf,omitempty"`
+	// may be set for __TypeKind SCALAR only
+	SpecifiedByURL *string `json:"specifiedByURL,omitempty"`
 }
 
 func NewFullType() FullType {
@@ -133,10 +137,12 @@ type EnumValue struct {
 }
 
 type InputValue struct {
-	Name         string  `json:"name"`
-	Description  string  `json:"description"`
-	Type         TypeRef `json:"type"`
-	DefaultValue *string `json:"defaultValue"`
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	Type              TypeRef `json:"type"`
+	DefaultValue      *string `json:"defaultValue"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
 }
 
 type Directive struct {
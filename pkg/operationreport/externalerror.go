@@ -27,12 +27,22 @@ const (
 	UnknownFieldOfInputObjectErrMsg         = `Field "%s" is not defined by type "%s".`
 	DuplicatedFieldInputObjectErrMsg        = `There can be only one input field named "%s".`
 	ValueIsNotAnInputObjectTypeErrMsg       = `Expected value of type "%s", found %s.`
+	OneOfInputObjectKeyCountErrMsg          = `OneOf Input Object "%s" must specify exactly one key.`
+	OneOfInputObjectNullFieldErrMsg         = `Field "%s.%s" must be non-null.`
+	OneOfInputObjectNullableVariableErrMsg  = `Variable "$%s" must be non-nullable to be used for OneOf Input Object "%s".`
 )
 
+// ErrCodeBadUserInput is the code of errors caused by invalid variable values of a request
+const ErrCodeBadUserInput = "BAD_USER_INPUT"
+
 type ExternalError struct {
 	Message   string                   `json:"message"`
 	Path      ast.Path                 `json:"path"`
 	Locations []graphqlerrors.Location `json:"locations"`
+	// Code optionally classifies the error, e.g. the federation composition errors are classified by their code
+	Code string `json:"code,omitempty"`
+	// Coordinate is the schema coordinate, e.g. "Type.field", of the definition an error of the type system refers to
+	Coordinate string `json:"coordinate,omitempty"`
 }
 
 func LocationsFromPosition(position ...position.Position) []graphqlerrors.Location {
@@ -49,6 +59,18 @@ func ErrDocumentDoesntContainExecutableOperation() (err ExternalError) {
 	return
 }
 
+func ErrDefinitionIsNotExecutable(definitionName ast.ByteSlice, pos position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf(`The "%s" definition is not executable.`, definitionName)
+	err.Locations = LocationsFromPosition(pos)
+	return err
+}
+
+func ErrSchemaDefinitionIsNotExecutable(pos position.Position) (err ExternalError) {
+	err.Message = "The schema definition is not executable."
+	err.Locations = LocationsFromPosition(pos)
+	return err
+}
+
 func ErrFieldUndefinedOnType(fieldName, typeName ast.ByteSlice) (err ExternalError) {
 	err.Message = fmt.Sprintf("field: %s not defined on type: %s", fieldName, typeName)
 	return err
@@ -252,6 +274,27 @@ func ErrValueIsNotAnInputObjectType(value, inputType ast.ByteSlice, position pos
 	return err
 }
 
+func ErrOneOfInputObjectKeyCount(inputType ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf(OneOfInputObjectKeyCountErrMsg, inputType)
+	err.Locations = LocationsFromPosition(position)
+
+	return err
+}
+
+func ErrOneOfInputObjectNullField(inputType, fieldName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf(OneOfInputObjectNullFieldErrMsg, inputType, fieldName)
+	err.Locations = LocationsFromPosition(position)
+
+	return err
+}
+
+func ErrOneOfInputObjectNullableVariable(variableName, inputType ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf(OneOfInputObjectNullableVariableErrMsg, variableName, inputType)
+	err.Locations = LocationsFromPosition(position)
+
+	return err
+}
+
 func ErrValueDoesntSatisfyString(value, inputType ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf(NotStringErrMsg, inputType, value)
 	err.Locations = LocationsFromPosition(position)
@@ -294,6 +337,35 @@ func ErrValueDoesntSatisfyID(value, inputType ast.ByteSlice, position position.P
 	return err
 }
 
+func ErrVariableOfRequiredTypeNotProvided(variableName, typeName string) (err ExternalError) {
+	err.Message = fmt.Sprintf(`Variable "$%s" of required type "%s" was not provided.`, variableName, typeName)
+	err.Code = ErrCodeBadUserInput
+	return err
+}
+
+func ErrVariableOfNonNullTypeMustNotBeNull(variableName, typeName string) (err ExternalError) {
+	err.Message = fmt.Sprintf(`Variable "$%s" of non-null type "%s" must not be null.`, variableName, typeName)
+	err.Code = ErrCodeBadUserInput
+	return err
+}
+
+// ErrValueInvalid reports an invalid literal value, the reason is the message of the error
+func ErrValueInvalid(reason string) (err ExternalError) {
+	err.Message = reason
+	return err
+}
+
+// ErrVariableValueInvalid reports an invalid value of a variable, path is the path of the value within the variable, e.g. input.items[0]
+func ErrVariableValueInvalid(variableName, path, value, reason string) (err ExternalError) {
+	if path != "" {
+		err.Message = fmt.Sprintf(`Variable "$%s" got invalid value %s at "%s"; %s`, variableName, value, path, reason)
+	} else {
+		err.Message = fmt.Sprintf(`Variable "$%s" got invalid value %s; %s`, variableName, value, reason)
+	}
+	err.Code = ErrCodeBadUserInput
+	return err
+}
+
 func ErrVariableTypeDoesntSatisfyInputValueDefinition(value, inputType, expectedType ast.ByteSlice, valuePos, variableDefinitionPos position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf(`Variable "%v" of type "%v" used in position expecting type "%v".`, value, inputType, expectedType)
 	err.Locations = LocationsFromPosition(variableDefinitionPos, valuePos)
@@ -431,6 +503,66 @@ func ErrUnionMembersMustBeUnique(unionName, memberName ast.ByteSlice) (err Exter
 	return err
 }
 
+func ErrDirectiveNameMustBeUnique(directiveName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("there can be only one directive named '@%s'", directiveName)
+	return err
+}
+
+func ErrArgumentDefinitionMustBeUnique(parentName, argName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("argument '%s(%s:)' can only be defined once", parentName, argName)
+	return err
+}
+
+func ErrInputObjectCircularReference(inputObjectName ast.ByteSlice, fieldPath string) (err ExternalError) {
+	err.Message = fmt.Sprintf("cannot reference input object '%s' within itself through a series of non-null fields: '%s'", inputObjectName, fieldPath)
+	return err
+}
+
+func ErrRootOperationTypeMustBeObjectType(operationType string, typeName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("%s root type must be an object type, it cannot be '%s'", operationType, typeName)
+	return err
+}
+
+func ErrUnionMemberMustBeObjectType(unionName, memberName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("union '%s' can only include object types, it cannot include '%s'", unionName, memberName)
+	return err
+}
+
+func ErrImplementedTypeMustBeInterface(typeName, implementedTypeName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("type '%s' can only implement interface types, it cannot implement '%s'", typeName, implementedTypeName)
+	return err
+}
+
+func ErrFieldTypeMustBeOutputType(fieldCoordinate string, typeName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("the type of '%s' must be an output type but got '%s'", fieldCoordinate, typeName)
+	return err
+}
+
+func ErrInputValueTypeMustBeInputType(inputValueCoordinate string, typeName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("the type of '%s' must be an input type but got '%s'", inputValueCoordinate, typeName)
+	return err
+}
+
+func ErrNameIsReserved(name ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("name '%s' must not begin with '__', which is reserved by GraphQL introspection", name)
+	return err
+}
+
+func ErrEnumValueNameIsReserved(enumName, enumValueName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("enum '%s' cannot include value '%s'", enumName, enumValueName)
+	return err
+}
+
+func ErrOneOfInputFieldMustBeNullable(inputObjectName, fieldName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("oneOf input field '%s.%s' must be nullable", inputObjectName, fieldName)
+	return err
+}
+
+func ErrOneOfInputFieldMustNotHaveDefaultValue(inputObjectName, fieldName ast.ByteSlice) (err ExternalError) {
+	err.Message = fmt.Sprintf("oneOf input field '%s.%s' cannot have a default value", inputObjectName, fieldName)
+	return err
+}
+
 func ErrTransitiveInterfaceNotImplemented(typeName, transitiveInterfaceName ast.ByteSlice) (err ExternalError) {
 	err.Message = fmt.Sprintf("type %s does not implement transitive interface %s", typeName, transitiveInterfaceName)
 	return err
@@ -453,21 +585,25 @@ func ErrImplementingTypeDoesNotHaveFields(typeName ast.ByteSlice) (err ExternalE
 
 func ErrSharedTypesMustBeIdenticalToFederate(typeName string) (err ExternalError) {
 	err.Message = fmt.Sprintf("the shared type named '%s' must be identical in any subgraphs to federate", typeName)
+	err.Coordinate = typeName
 	return err
 }
 
 func ErrEntitiesMustNotBeDuplicated(typeName string) (err ExternalError) {
 	err.Message = fmt.Sprintf("the entity named '%s' is defined in the subgraph(s) more than once", typeName)
+	err.Coordinate = typeName
 	return err
 }
 
 func ErrSharedTypesMustNotBeExtended(typeName string) (err ExternalError) {
 	err.Message = fmt.Sprintf("the type named '%s' cannot be extended because it is a shared type", typeName)
+	err.Coordinate = typeName
 	return err
 }
 
 func ErrExtensionOrphansMustResolveInSupergraph(extensionNameBytes []byte) (err ExternalError) {
 	err.Message = fmt.Sprintf("the extension orphan named '%s' was never resolved in the supergraph", extensionNameBytes)
+	err.Coordinate = string(extensionNameBytes)
 	return err
 }
 
@@ -478,11 +614,13 @@ func ErrTypeBodyMustNotBeEmpty(definitionType, typeName string) (err ExternalErr
 
 func ErrEntityExtensionMustHaveKeyDirective(typeName string) (err ExternalError) {
 	err.Message = fmt.Sprintf("an extension of the entity named '%s' does not have a key directive", typeName)
+	err.Coordinate = typeName
 	return err
 }
 
 func ErrExtensionWithKeyDirectiveMustExtendEntity(typeName string) (err ExternalError) {
 	err.Message = fmt.Sprintf("the extension named '%s' has a key directive but there is no entity of the same name", typeName)
+	err.Coordinate = typeName
 	return err
 }
 
@@ -490,5 +628,36 @@ func ErrDuplicateFieldsMustBeIdentical(fieldName, parentName, typeOne, typeTwo s
 	err.Message = fmt.Sprintf("field '%s' on type '%s' is defined in multiple subgraphs "+
 		"but the fields cannot be merged because the types of the fields are non-identical:\n"+
 		"first subgraph: type '%s'\n second subgraph: type '%s'", fieldName, parentName, typeOne, typeTwo)
+	err.Coordinate = parentName + "." + fieldName
+	return err
+}
+
+func ErrFieldMustBeShareable(fieldName, parentName string) (err ExternalError) {
+	err.Message = fmt.Sprintf("field '%s' on type '%s' is resolved by multiple subgraphs "+
+		"but is not marked @shareable in all of them", fieldName, parentName)
+	err.Coordinate = parentName + "." + fieldName
+	return err
+}
+
+func ErrFieldMustNotBeOverriddenMultipleTimes(fieldName, parentName string) (err ExternalError) {
+	err.Message = fmt.Sprintf("field '%s' on type '%s' is marked @override in more than one subgraph", fieldName, parentName)
+	err.Coordinate = parentName + "." + fieldName
+	return err
+}
+
+func ErrInterfaceObjectMustHaveInterface(typeName string) (err ExternalError) {
+	err.Message = fmt.Sprintf("the type named '%s' is marked @interfaceObject but there is no interface of the same name", typeName)
+	err.Coordinate = typeName
+	return err
+}
+
+func ErrInaccessibleTypeMustNotBeReferenced(typeName, coordinate string) (err ExternalError) {
+	err.Message = fmt.Sprintf("the type named '%s' is @inaccessible but it is referenced by '%s' which is not @inaccessible", typeName, coordinate)
+	err.Coordinate = coordinate
+	return err
+}
+
+func ErrUnknownFederationDirectiveImport(directiveName string) (err ExternalError) {
+	err.Message = fmt.Sprintf("the directive '%s' imported by @link is not a federation directive", directiveName)
 	return err
 }
@@ -4,6 +4,8 @@ package operationreport
 import (
 	"errors"
 	"fmt"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 )
 
 type Report struct {
@@ -48,6 +50,25 @@ func (r *Report) AddExternalError(gqlError ExternalError) {
 	r.ExternalErrors = append(r.ExternalErrors, gqlError)
 }
 
+// ResolveSources maps the locations of all external errors to the sources of a document built from multiple inputs,
+// e.g. the .graphql files added with ast.Document.AppendSource. Locations outside of any source are left untouched.
+func (r *Report) ResolveSources(sourceMap *ast.SourceMap) {
+	for i := range r.ExternalErrors {
+		for j := range r.ExternalErrors[i].Locations {
+			location := &r.ExternalErrors[i].Locations[j]
+			if location.Line == 0 || location.Source != "" {
+				continue
+			}
+			source, line, ok := sourceMap.Resolve(location.Line)
+			if !ok {
+				continue
+			}
+			location.Source = source.Name
+			location.Line = line
+		}
+	}
+}
+
 type FormatExternalErrorMessage func(report *Report) string
 
 func ExternalErrorMessage(err error, formatFunction FormatExternalErrorMessage) (message string, ok bool) {
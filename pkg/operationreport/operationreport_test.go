@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
 )
 
 func TestExternalErrorMessage(t *testing.T) {
@@ -30,6 +33,40 @@ func TestUnwrappedErrorMessage(t *testing.T) {
 	assert.Equal(t, testErrorString, actual)
 }
 
+func TestReport_ResolveSources(t *testing.T) {
+	var document ast.Document
+	document.AppendSource("schema.graphql", []byte("schema {\n\tquery: Query\n}"))
+	document.AppendSource("types/query.graphql", []byte("type Query {\n\thello: String\n}\n"))
+
+	report := Report{
+		ExternalErrors: []ExternalError{
+			{
+				Message:   "in schema",
+				Locations: []graphqlerrors.Location{{Line: 2, Column: 9}},
+			},
+			{
+				Message:   "in query",
+				Locations: []graphqlerrors.Location{{Line: 5, Column: 2}},
+			},
+			{
+				Message:   "already resolved",
+				Locations: []graphqlerrors.Location{{Source: "other.graphql", Line: 5, Column: 2}},
+			},
+			{
+				Message: "without location",
+			},
+		},
+	}
+
+	report.ResolveSources(&document.SourceMap)
+
+	assert.Equal(t, []graphqlerrors.Location{{Source: "schema.graphql", Line: 2, Column: 9}}, report.ExternalErrors[0].Locations)
+	assert.Equal(t, []graphqlerrors.Location{{Source: "types/query.graphql", Line: 2, Column: 2}}, report.ExternalErrors[1].Locations)
+	assert.Equal(t, []graphqlerrors.Location{{Source: "other.graphql", Line: 5, Column: 2}}, report.ExternalErrors[2].Locations)
+	assert.Nil(t, report.ExternalErrors[3].Locations)
+	assert.Equal(t, "external: in query, locations: [{Source:types/query.graphql Line:2 Column:2}], path: []", Report{ExternalErrors: report.ExternalErrors[1:2]}.Error())
+}
+
 const (
 	externalErrorString = "example external error 1"
 	testErrorString     = "test error string"
@@ -0,0 +1,232 @@
+package postprocess
+
+import (
+	"reflect"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+)
+
+// ProcessParallelFetch hoists the fetches of nested objects into the fetch of an ancestor object
+// if their input is already available to the ancestor, so that they are resolved in parallel instead of one after another.
+// The input of a nested fetch, including the fields required via @requires, is available to the ancestor
+// if the nested object is reached through fields which are not resolved by the fetch of the ancestor.
+// Fetches to the same subgraph are only merged into a single fetch if they are identical apart from their buffer.
+// Fetches to the same subgraph with different inputs, e.g. for different representations or selections,
+// are not combined into one request but are executed side by side within the same parallel fetch.
+// ProcessParallelFetch must run after ProcessDataSource as it rewrites the input templates of the fetches.
+type ProcessParallelFetch struct{}
+
+// hoistedFetch is the fetch of a nested object and the path from the ancestor to the nested object
+type hoistedFetch struct {
+	object *resolve.Object
+	path   []string
+}
+
+func (p *ProcessParallelFetch) Process(pre plan.Plan) plan.Plan {
+	switch t := pre.(type) {
+	case *plan.SynchronousResponsePlan:
+		p.traverseNode(t.Response.Data)
+	case *plan.SubscriptionResponsePlan:
+		p.traverseNode(t.Response.Response.Data)
+	}
+	return pre
+}
+
+func (p *ProcessParallelFetch) traverseNode(node resolve.Node) {
+	switch n := node.(type) {
+	case *resolve.Object:
+		p.hoistFetches(n)
+		for i := range n.Fields {
+			p.traverseNode(n.Fields[i].Value)
+		}
+	case *resolve.Array:
+		p.traverseNode(n.Item)
+	}
+}
+
+func (p *ProcessParallelFetch) hoistFetches(object *resolve.Object) {
+	hoisted := p.collectHoistableFetches(object, nil)
+	// hoisting a single fetch into an object without a fetch doesn't save a round trip
+	if len(hoisted) == 0 || object.Fetch == nil && len(hoisted) < 2 {
+		return
+	}
+
+	fetches := p.flattenFetch(object.Fetch, nil)
+	for i := range hoisted {
+		for _, fetch := range p.flattenFetch(hoisted[i].object.Fetch, nil) {
+			p.prefixObjectVariables(fetch, hoisted[i].path)
+			fetches = append(fetches, fetch)
+		}
+		hoisted[i].object.Fetch = nil
+		hoisted[i].object.HoistedFetch = true
+	}
+
+	fetches, mergedBufferIDs := p.mergeIdenticalFetches(fetches)
+	if len(mergedBufferIDs) != 0 {
+		p.replaceBufferIDs(object, mergedBufferIDs)
+	}
+
+	object.Fetch = &resolve.ParallelFetch{
+		Fetches: fetches,
+	}
+}
+
+// collectHoistableFetches returns the nested objects with a fetch which only depends on the data of the object
+func (p *ProcessParallelFetch) collectHoistableFetches(object *resolve.Object, path []string) (hoisted []hoistedFetch) {
+	for _, field := range object.Fields {
+		child, ok := p.hoistableObject(field)
+		if !ok {
+			continue
+		}
+		childPath := make([]string, 0, len(path)+len(child.Path))
+		childPath = append(childPath, path...)
+		childPath = append(childPath, child.Path...)
+		if child.Fetch != nil {
+			if !p.isHoistableFetch(child.Fetch) {
+				continue
+			}
+			hoisted = append(hoisted, hoistedFetch{object: child, path: childPath})
+		}
+		hoisted = append(hoisted, p.collectHoistableFetches(child, childPath)...)
+	}
+	return hoisted
+}
+
+// hoistableObject returns the object of the field if it is always resolved from the data of its parent.
+// Fields resolved by a fetch, conditional fields and nullable or deferred objects are not hoisted
+// as the fetch of the object might not be executed at all or depends on the result of another fetch.
+func (p *ProcessParallelFetch) hoistableObject(field *resolve.Field) (*resolve.Object, bool) {
+	if field.HasBuffer || field.Defer != nil || field.Stream != nil || field.OnTypeNames != nil ||
+		field.SkipDirectiveDefined || field.IncludeDirectiveDefined {
+		return nil, false
+	}
+	object, ok := field.Value.(*resolve.Object)
+	if !ok || object.Nullable || object.UnescapeResponseJson {
+		return nil, false
+	}
+	return object, true
+}
+
+func (p *ProcessParallelFetch) isHoistableFetch(fetch resolve.Fetch) bool {
+	switch f := fetch.(type) {
+	case *resolve.SingleFetch:
+		// fetches which are not processed by ProcessDataSource keep their variables in the input
+		return f.Input == "" && len(f.Variables) == 0
+	case *resolve.BatchFetch:
+		return p.isHoistableFetch(f.Fetch)
+	case *resolve.ParallelFetch:
+		for i := range f.Fetches {
+			if !p.isHoistableFetch(f.Fetches[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *ProcessParallelFetch) flattenFetch(fetch resolve.Fetch, fetches []resolve.Fetch) []resolve.Fetch {
+	switch f := fetch.(type) {
+	case *resolve.SingleFetch, *resolve.BatchFetch:
+		fetches = append(fetches, f)
+	case *resolve.ParallelFetch:
+		for i := range f.Fetches {
+			fetches = p.flattenFetch(f.Fetches[i], fetches)
+		}
+	}
+	return fetches
+}
+
+// prefixObjectVariables makes the object variables of the fetch relative to the ancestor it has been hoisted into
+func (p *ProcessParallelFetch) prefixObjectVariables(fetch resolve.Fetch, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	var single *resolve.SingleFetch
+	switch f := fetch.(type) {
+	case *resolve.SingleFetch:
+		single = f
+	case *resolve.BatchFetch:
+		single = f.Fetch
+	default:
+		return
+	}
+	for i := range single.InputTemplate.Segments {
+		segment := &single.InputTemplate.Segments[i]
+		if segment.SegmentType != resolve.VariableSegmentType || segment.VariableKind != resolve.ObjectVariableKind {
+			continue
+		}
+		sourcePath := make([]string, 0, len(path)+len(segment.VariableSourcePath))
+		sourcePath = append(sourcePath, path...)
+		segment.VariableSourcePath = append(sourcePath, segment.VariableSourcePath...)
+	}
+}
+
+// mergeIdenticalFetches removes fetches which are identical to a previous fetch except for their buffer
+// and returns the buffer ids of the removed fetches mapped to the buffer ids of the remaining fetches
+func (p *ProcessParallelFetch) mergeIdenticalFetches(fetches []resolve.Fetch) ([]resolve.Fetch, map[int]int) {
+	var mergedBufferIDs map[int]int
+	merged := make([]resolve.Fetch, 0, len(fetches))
+WithNextFetch:
+	for _, fetch := range fetches {
+		for _, existing := range merged {
+			if p.isIdenticalFetch(existing, fetch) {
+				if mergedBufferIDs == nil {
+					mergedBufferIDs = make(map[int]int)
+				}
+				mergedBufferIDs[p.bufferID(fetch)] = p.bufferID(existing)
+				continue WithNextFetch
+			}
+		}
+		merged = append(merged, fetch)
+	}
+	return merged, mergedBufferIDs
+}
+
+func (p *ProcessParallelFetch) isIdenticalFetch(a, b resolve.Fetch) bool {
+	switch fa := a.(type) {
+	case *resolve.SingleFetch:
+		fb, ok := b.(*resolve.SingleFetch)
+		if !ok {
+			return false
+		}
+		withoutBuffer := *fa
+		withoutBuffer.BufferId = fb.BufferId
+		return reflect.DeepEqual(&withoutBuffer, fb)
+	case *resolve.BatchFetch:
+		fb, ok := b.(*resolve.BatchFetch)
+		if !ok {
+			return false
+		}
+		return reflect.DeepEqual(fa.BatchFactory, fb.BatchFactory) && p.isIdenticalFetch(fa.Fetch, fb.Fetch)
+	default:
+		return false
+	}
+}
+
+func (p *ProcessParallelFetch) bufferID(fetch resolve.Fetch) int {
+	switch f := fetch.(type) {
+	case *resolve.SingleFetch:
+		return f.BufferId
+	case *resolve.BatchFetch:
+		return f.Fetch.BufferId
+	default:
+		return -1
+	}
+}
+
+func (p *ProcessParallelFetch) replaceBufferIDs(node resolve.Node, bufferIDs map[int]int) {
+	switch n := node.(type) {
+	case *resolve.Object:
+		for _, field := range n.Fields {
+			if bufferID, ok := bufferIDs[field.BufferID]; ok && field.HasBuffer {
+				field.BufferID = bufferID
+			}
+			p.replaceBufferIDs(field.Value, bufferIDs)
+		}
+	case *resolve.Array:
+		p.replaceBufferIDs(n.Item, bufferIDs)
+	}
+}
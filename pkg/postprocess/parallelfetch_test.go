@@ -0,0 +1,328 @@
+package postprocess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+)
+
+func TestProcessParallelFetch_Process(t *testing.T) {
+	accountsService := &fakeService{}
+	productsService := &fakeService{}
+	reviewsService := &fakeService{}
+
+	entityFetch := func(bufferID int, dataSource resolve.DataSource, path ...string) *resolve.SingleFetch {
+		return &resolve.SingleFetch{
+			BufferId:   bufferID,
+			DataSource: dataSource,
+			InputTemplate: resolve.InputTemplate{
+				Segments: []resolve.TemplateSegment{
+					{
+						SegmentType: resolve.StaticSegmentType,
+						Data:        []byte(`{"representations":[`),
+					},
+					{
+						SegmentType:        resolve.VariableSegmentType,
+						VariableKind:       resolve.ObjectVariableKind,
+						VariableSourcePath: path,
+					},
+					{
+						SegmentType: resolve.StaticSegmentType,
+						Data:        []byte(`]}`),
+					},
+				},
+			},
+		}
+	}
+
+	bufferedField := func(name string, bufferID int) *resolve.Field {
+		return &resolve.Field{
+			Name:      []byte(name),
+			HasBuffer: true,
+			BufferID:  bufferID,
+			Value: &resolve.String{
+				Path: []string{name},
+			},
+		}
+	}
+
+	run := func(pre, expected plan.Plan) func(t *testing.T) {
+		return func(t *testing.T) {
+			processor := &ProcessParallelFetch{}
+			actual := processor.Process(pre)
+			assert.Equal(t, expected, actual)
+		}
+	}
+
+	t.Run("hoist independent entity fetches into the fetch of the parent", run(
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Path:  []string{"me"},
+					Fetch: entityFetch(0, reviewsService, "id"),
+					Fields: []*resolve.Field{
+						bufferedField("reviews", 0),
+						{
+							Name: []byte("account"),
+							Value: &resolve.Object{
+								Path: []string{"account"},
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("product"),
+										Value: &resolve.Object{
+											Path:   []string{"product"},
+											Fetch:  entityFetch(1, productsService, "upc"),
+											Fields: []*resolve.Field{bufferedField("name", 1)},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name: []byte("author"),
+							Value: &resolve.Object{
+								Path:   []string{"author"},
+								Fetch:  entityFetch(2, accountsService, "id"),
+								Fields: []*resolve.Field{bufferedField("username", 2)},
+							},
+						},
+					},
+				},
+			},
+		},
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Path: []string{"me"},
+					Fetch: &resolve.ParallelFetch{
+						Fetches: []resolve.Fetch{
+							entityFetch(0, reviewsService, "id"),
+							entityFetch(1, productsService, "account", "product", "upc"),
+							entityFetch(2, accountsService, "author", "id"),
+						},
+					},
+					Fields: []*resolve.Field{
+						bufferedField("reviews", 0),
+						{
+							Name: []byte("account"),
+							Value: &resolve.Object{
+								Path: []string{"account"},
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("product"),
+										Value: &resolve.Object{
+											Path:         []string{"product"},
+											HoistedFetch: true,
+											Fields:       []*resolve.Field{bufferedField("name", 1)},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name: []byte("author"),
+							Value: &resolve.Object{
+								Path:         []string{"author"},
+								HoistedFetch: true,
+								Fields:       []*resolve.Field{bufferedField("username", 2)},
+							},
+						},
+					},
+				},
+			},
+		},
+	))
+
+	t.Run("merge identical fetches", run(
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fields: []*resolve.Field{
+						{
+							Name: []byte("author"),
+							Value: &resolve.Object{
+								Fetch:  entityFetch(0, accountsService, "authorId"),
+								Fields: []*resolve.Field{bufferedField("username", 0)},
+							},
+						},
+						{
+							Name: []byte("writer"),
+							Value: &resolve.Object{
+								Fetch:  entityFetch(1, accountsService, "authorId"),
+								Fields: []*resolve.Field{bufferedField("username", 1)},
+							},
+						},
+					},
+				},
+			},
+		},
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.ParallelFetch{
+						Fetches: []resolve.Fetch{
+							entityFetch(0, accountsService, "authorId"),
+						},
+					},
+					Fields: []*resolve.Field{
+						{
+							Name: []byte("author"),
+							Value: &resolve.Object{
+								HoistedFetch: true,
+								Fields:       []*resolve.Field{bufferedField("username", 0)},
+							},
+						},
+						{
+							Name: []byte("writer"),
+							Value: &resolve.Object{
+								HoistedFetch: true,
+								Fields:       []*resolve.Field{bufferedField("username", 0)},
+							},
+						},
+					},
+				},
+			},
+		},
+	))
+
+	t.Run("keep different fetches to the same subgraph as separate fetches", run(
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fields: []*resolve.Field{
+						{
+							Name: []byte("author"),
+							Value: &resolve.Object{
+								Fetch:  entityFetch(0, accountsService, "authorId"),
+								Fields: []*resolve.Field{bufferedField("username", 0)},
+							},
+						},
+						{
+							Name: []byte("editor"),
+							Value: &resolve.Object{
+								Fetch:  entityFetch(1, accountsService, "editorId"),
+								Fields: []*resolve.Field{bufferedField("username", 1)},
+							},
+						},
+					},
+				},
+			},
+		},
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.ParallelFetch{
+						Fetches: []resolve.Fetch{
+							entityFetch(0, accountsService, "authorId"),
+							entityFetch(1, accountsService, "editorId"),
+						},
+					},
+					Fields: []*resolve.Field{
+						{
+							Name: []byte("author"),
+							Value: &resolve.Object{
+								HoistedFetch: true,
+								Fields:       []*resolve.Field{bufferedField("username", 0)},
+							},
+						},
+						{
+							Name: []byte("editor"),
+							Value: &resolve.Object{
+								HoistedFetch: true,
+								Fields:       []*resolve.Field{bufferedField("username", 1)},
+							},
+						},
+					},
+				},
+			},
+		},
+	))
+
+	t.Run("keep fetches which depend on the fetch of the parent or might not be executed", func(t *testing.T) {
+		newPlan := func() *plan.SynchronousResponsePlan {
+			return &plan.SynchronousResponsePlan{
+				Response: &resolve.GraphQLResponse{
+					Data: &resolve.Object{
+						Fetch: entityFetch(0, reviewsService, "id"),
+						Fields: []*resolve.Field{
+							{
+								Name:      []byte("buffered"),
+								HasBuffer: true,
+								BufferID:  0,
+								Value: &resolve.Object{
+									Fetch:  entityFetch(1, productsService, "upc"),
+									Fields: []*resolve.Field{bufferedField("name", 1)},
+								},
+							},
+							{
+								Name: []byte("nullable"),
+								Value: &resolve.Object{
+									Nullable: true,
+									Fetch:    entityFetch(2, productsService, "upc"),
+									Fields:   []*resolve.Field{bufferedField("name", 2)},
+								},
+							},
+							{
+								Name:        []byte("conditional"),
+								OnTypeNames: [][]byte{[]byte("Product")},
+								Value: &resolve.Object{
+									Fetch:  entityFetch(3, productsService, "upc"),
+									Fields: []*resolve.Field{bufferedField("name", 3)},
+								},
+							},
+							{
+								Name:  []byte("deferred"),
+								Defer: &resolve.DeferField{},
+								Value: &resolve.Object{
+									Fetch:  entityFetch(4, productsService, "upc"),
+									Fields: []*resolve.Field{bufferedField("name", 4)},
+								},
+							},
+							{
+								Name: []byte("list"),
+								Value: &resolve.Array{
+									Item: &resolve.Object{
+										Fetch:  entityFetch(5, productsService, "upc"),
+										Fields: []*resolve.Field{bufferedField("name", 5)},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		processor := &ProcessParallelFetch{}
+		actual := processor.Process(newPlan())
+		assert.Equal(t, newPlan(), actual)
+	})
+
+	t.Run("keep a single fetch in an object without a fetch", func(t *testing.T) {
+		newPlan := func() *plan.SynchronousResponsePlan {
+			return &plan.SynchronousResponsePlan{
+				Response: &resolve.GraphQLResponse{
+					Data: &resolve.Object{
+						Fields: []*resolve.Field{
+							{
+								Name: []byte("product"),
+								Value: &resolve.Object{
+									Path:   []string{"product"},
+									Fetch:  entityFetch(0, productsService, "upc"),
+									Fields: []*resolve.Field{bufferedField("name", 0)},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		processor := &ProcessParallelFetch{}
+		actual := processor.Process(newPlan())
+		assert.Equal(t, newPlan(), actual)
+	})
+}
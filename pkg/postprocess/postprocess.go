@@ -18,6 +18,7 @@ func DefaultProcessor() *Processor {
 			&ProcessDefer{},
 			&ProcessStream{},
 			&ProcessDataSource{},
+			&ProcessParallelFetch{},
 		},
 	}
 }
@@ -0,0 +1,474 @@
+package schemadiff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+// differ compares the old with the new schema and collects the changes
+type differ struct {
+	old     *ast.Document
+	new     *ast.Document
+	changes Changes
+}
+
+func (d *differ) report(changeType ChangeType, criticality Criticality, coordinate, usage, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Type:        changeType,
+		Criticality: criticality,
+		Coordinate:  coordinate,
+		Message:     fmt.Sprintf(format, args...),
+		usage:       usage,
+	})
+}
+
+func (d *differ) diff() {
+	d.diffRootOperationTypes()
+
+	for _, oldNode := range d.old.RootNodes {
+		if !isComparableNode(oldNode.Kind) {
+			continue
+		}
+		name := d.old.NodeNameString(oldNode)
+		newNode, exists := findNode(d.new, oldNode.Kind, name)
+		if !exists {
+			if oldNode.Kind == ast.NodeKindDirectiveDefinition {
+				d.report(DirectiveRemoved, CriticalityBreaking, "@"+name, "@"+name, "Directive '@%s' was removed", name)
+			} else {
+				d.report(TypeRemoved, CriticalityBreaking, name, name, "Type '%s' was removed", name)
+			}
+			continue
+		}
+		if newNode.Kind != oldNode.Kind {
+			d.report(TypeKindChanged, CriticalityBreaking, name, name, "Type '%s' changed from %s to %s", name, kindName(oldNode.Kind), kindName(newNode.Kind))
+			continue
+		}
+
+		switch oldNode.Kind {
+		case ast.NodeKindObjectTypeDefinition:
+			d.diffImplementedInterfaces(name, d.old.ObjectTypeDefinitions[oldNode.Ref].ImplementsInterfaces.Refs, d.new.ObjectTypeDefinitions[newNode.Ref].ImplementsInterfaces.Refs)
+			d.diffFields(name, d.old.ObjectTypeDefinitions[oldNode.Ref].FieldsDefinition.Refs, d.new.ObjectTypeDefinitions[newNode.Ref].FieldsDefinition.Refs)
+		case ast.NodeKindInterfaceTypeDefinition:
+			d.diffImplementedInterfaces(name, d.old.InterfaceTypeDefinitions[oldNode.Ref].ImplementsInterfaces.Refs, d.new.InterfaceTypeDefinitions[newNode.Ref].ImplementsInterfaces.Refs)
+			d.diffFields(name, d.old.InterfaceTypeDefinitions[oldNode.Ref].FieldsDefinition.Refs, d.new.InterfaceTypeDefinitions[newNode.Ref].FieldsDefinition.Refs)
+		case ast.NodeKindUnionTypeDefinition:
+			d.diffUnionMembers(name, d.old.UnionTypeDefinitions[oldNode.Ref].UnionMemberTypes.Refs, d.new.UnionTypeDefinitions[newNode.Ref].UnionMemberTypes.Refs)
+		case ast.NodeKindEnumTypeDefinition:
+			d.diffEnumValues(name, d.old.EnumTypeDefinitions[oldNode.Ref].EnumValuesDefinition.Refs, d.new.EnumTypeDefinitions[newNode.Ref].EnumValuesDefinition.Refs)
+		case ast.NodeKindInputObjectTypeDefinition:
+			d.diffInputValues(inputFieldChanges, name, d.old.InputObjectTypeDefinitions[oldNode.Ref].InputFieldsDefinition.Refs, d.new.InputObjectTypeDefinitions[newNode.Ref].InputFieldsDefinition.Refs)
+		case ast.NodeKindDirectiveDefinition:
+			d.diffDirectiveDefinitions(name, oldNode.Ref, newNode.Ref)
+		}
+	}
+
+	for _, newNode := range d.new.RootNodes {
+		if !isComparableNode(newNode.Kind) {
+			continue
+		}
+		name := d.new.NodeNameString(newNode)
+		if _, exists := findNode(d.old, newNode.Kind, name); exists {
+			continue
+		}
+		if newNode.Kind == ast.NodeKindDirectiveDefinition {
+			d.report(DirectiveAdded, CriticalitySafe, "@"+name, "", "Directive '@%s' was added", name)
+		} else {
+			d.report(TypeAdded, CriticalitySafe, name, "", "Type '%s' was added", name)
+		}
+	}
+}
+
+func (d *differ) diffRootOperationTypes() {
+	for _, operationType := range []ast.OperationType{ast.OperationTypeQuery, ast.OperationTypeMutation, ast.OperationTypeSubscription} {
+		oldName, newName := rootOperationTypeName(d.old, operationType), rootOperationTypeName(d.new, operationType)
+		if oldName == "" || oldName == newName {
+			continue
+		}
+		d.report(RootOperationTypeChanged, CriticalityBreaking, oldName, "", "Root %s type changed from '%s' to '%s'", operationTypeName(operationType), oldName, newName)
+	}
+}
+
+func (d *differ) diffImplementedInterfaces(typeName string, oldRefs, newRefs []int) {
+	for _, oldRef := range oldRefs {
+		name := d.old.TypeNameString(oldRef)
+		if !containsTypeName(d.new, newRefs, name) {
+			d.report(ImplementedInterfaceRemoved, CriticalityBreaking, typeName, typeName, "Type '%s' no longer implements interface '%s'", typeName, name)
+		}
+	}
+	for _, newRef := range newRefs {
+		name := d.new.TypeNameString(newRef)
+		if !containsTypeName(d.old, oldRefs, name) {
+			d.report(ImplementedInterfaceAdded, CriticalityDangerous, typeName, "", "Type '%s' implements new interface '%s'", typeName, name)
+		}
+	}
+}
+
+func (d *differ) diffUnionMembers(typeName string, oldRefs, newRefs []int) {
+	for _, oldRef := range oldRefs {
+		name := d.old.TypeNameString(oldRef)
+		if !containsTypeName(d.new, newRefs, name) {
+			d.report(UnionMemberRemoved, CriticalityBreaking, typeName, typeName, "Member '%s' was removed from union type '%s'", name, typeName)
+		}
+	}
+	for _, newRef := range newRefs {
+		name := d.new.TypeNameString(newRef)
+		if !containsTypeName(d.old, oldRefs, name) {
+			d.report(UnionMemberAdded, CriticalityDangerous, typeName, "", "Member '%s' was added to union type '%s'", name, typeName)
+		}
+	}
+}
+
+func (d *differ) diffEnumValues(typeName string, oldRefs, newRefs []int) {
+	for _, oldRef := range oldRefs {
+		name := d.old.EnumValueDefinitionNameString(oldRef)
+		if !containsEnumValue(d.new, newRefs, name) {
+			d.report(EnumValueRemoved, CriticalityBreaking, typeName+"."+name, typeName, "Enum value '%s' was removed from enum '%s'", name, typeName)
+		}
+	}
+	for _, newRef := range newRefs {
+		name := d.new.EnumValueDefinitionNameString(newRef)
+		if !containsEnumValue(d.old, oldRefs, name) {
+			d.report(EnumValueAdded, CriticalityDangerous, typeName+"."+name, "", "Enum value '%s' was added to enum '%s'", name, typeName)
+		}
+	}
+}
+
+func (d *differ) diffFields(typeName string, oldRefs, newRefs []int) {
+	for _, oldRef := range oldRefs {
+		name := d.old.FieldDefinitionNameString(oldRef)
+		coordinate := typeName + "." + name
+		newRef, exists := findFieldDefinition(d.new, newRefs, name)
+		if !exists {
+			d.report(FieldRemoved, CriticalityBreaking, coordinate, coordinate, "Field '%s' was removed", coordinate)
+			continue
+		}
+
+		oldType, newType := d.old.FieldDefinitionType(oldRef), d.new.FieldDefinitionType(newRef)
+		if !typesAreEqual(d.old, oldType, d.new, newType) {
+			criticality := CriticalityBreaking
+			if isSafeOutputTypeChange(d.old, oldType, d.new, newType) {
+				criticality = CriticalitySafe
+			}
+			d.report(FieldTypeChanged, criticality, coordinate, coordinate, "Field '%s' changed type from '%s' to '%s'", coordinate, typeString(d.old, oldType), typeString(d.new, newType))
+		}
+
+		d.diffInputValues(argumentChanges, coordinate, d.old.FieldDefinitions[oldRef].ArgumentsDefinition.Refs, d.new.FieldDefinitions[newRef].ArgumentsDefinition.Refs)
+	}
+	for _, newRef := range newRefs {
+		name := d.new.FieldDefinitionNameString(newRef)
+		if _, exists := findFieldDefinition(d.old, oldRefs, name); !exists {
+			coordinate := typeName + "." + name
+			d.report(FieldAdded, CriticalitySafe, coordinate, "", "Field '%s' was added", coordinate)
+		}
+	}
+}
+
+func (d *differ) diffDirectiveDefinitions(name string, oldRef, newRef int) {
+	coordinate := "@" + name
+	if d.old.DirectiveDefinitionIsRepeatable(oldRef) && !d.new.DirectiveDefinitionIsRepeatable(newRef) {
+		d.report(DirectiveRepeatableRemoved, CriticalityBreaking, coordinate, coordinate, "Directive '%s' is no longer repeatable", coordinate)
+	}
+
+	oldLocations, newLocations := d.old.DirectiveDefinitions[oldRef].DirectiveLocations, d.new.DirectiveDefinitions[newRef].DirectiveLocations
+	iter := oldLocations.Iterable()
+	for iter.Next() {
+		if !newLocations.Get(iter.Value()) {
+			d.report(DirectiveLocationRemoved, CriticalityBreaking, coordinate, coordinate, "Location '%s' was removed from directive '%s'", iter.Value().LiteralString(), coordinate)
+		}
+	}
+	iter = newLocations.Iterable()
+	for iter.Next() {
+		if !oldLocations.Get(iter.Value()) {
+			d.report(DirectiveLocationAdded, CriticalitySafe, coordinate, "", "Location '%s' was added to directive '%s'", iter.Value().LiteralString(), coordinate)
+		}
+	}
+
+	d.diffInputValues(directiveArgumentChanges, coordinate, d.old.DirectiveDefinitions[oldRef].ArgumentsDefinition.Refs, d.new.DirectiveDefinitions[newRef].ArgumentsDefinition.Refs)
+}
+
+// inputValueChanges configures how changes of arguments and input fields get reported
+type inputValueChanges struct {
+	added, removed, typeChanged, defaultValueChanged ChangeType
+	// description is used in messages, e.g. "Argument"
+	description string
+	// isArgument marks arguments which are referenced by a coordinate like "Type.field(arg:)"
+	isArgument bool
+}
+
+var (
+	argumentChanges = inputValueChanges{
+		added: ArgumentAdded, removed: ArgumentRemoved, typeChanged: ArgumentTypeChanged, defaultValueChanged: ArgumentDefaultValueChanged,
+		description: "Argument", isArgument: true,
+	}
+	directiveArgumentChanges = inputValueChanges{
+		added: DirectiveArgumentAdded, removed: DirectiveArgumentRemoved, typeChanged: DirectiveArgumentTypeChanged, defaultValueChanged: DirectiveArgumentDefaultValueChanged,
+		description: "Argument", isArgument: true,
+	}
+	inputFieldChanges = inputValueChanges{
+		added: InputFieldAdded, removed: InputFieldRemoved, typeChanged: InputFieldTypeChanged, defaultValueChanged: InputFieldDefaultValueChanged,
+		description: "Input field",
+	}
+)
+
+// diffInputValues compares arguments or input fields of the parent,
+// arguments only affect operations which use the parent field or directive, removed arguments only operations which use the argument itself.
+// Input fields affect all operations which use the input object type.
+func (d *differ) diffInputValues(changes inputValueChanges, parent string, oldRefs, newRefs []int) {
+	coordinate := func(name string) string {
+		if changes.isArgument {
+			return parent + "(" + name + ":)"
+		}
+		return parent + "." + name
+	}
+
+	for _, oldRef := range oldRefs {
+		name := d.old.InputValueDefinitionNameString(oldRef)
+		newRef, exists := findInputValueDefinition(d.new, newRefs, name)
+		if !exists {
+			usage := parent
+			if changes.isArgument {
+				usage = coordinate(name)
+			}
+			d.report(changes.removed, CriticalityBreaking, coordinate(name), usage, "%s '%s' was removed", changes.description, coordinate(name))
+			continue
+		}
+
+		oldType, newType := d.old.InputValueDefinitionType(oldRef), d.new.InputValueDefinitionType(newRef)
+		if !typesAreEqual(d.old, oldType, d.new, newType) {
+			criticality := CriticalityBreaking
+			if isSafeInputTypeChange(d.old, oldType, d.new, newType) {
+				criticality = CriticalitySafe
+			}
+			d.report(changes.typeChanged, criticality, coordinate(name), parent, "%s '%s' changed type from '%s' to '%s'", changes.description, coordinate(name), typeString(d.old, oldType), typeString(d.new, newType))
+		}
+
+		oldDefault, newDefault := defaultValueString(d.old, oldRef), defaultValueString(d.new, newRef)
+		if oldDefault != newDefault {
+			d.report(changes.defaultValueChanged, CriticalityDangerous, coordinate(name), "", "%s '%s' changed default value from %s to %s", changes.description, coordinate(name), quoteValue(oldDefault), quoteValue(newDefault))
+		}
+	}
+
+	for _, newRef := range newRefs {
+		name := d.new.InputValueDefinitionNameString(newRef)
+		if _, exists := findInputValueDefinition(d.old, oldRefs, name); exists {
+			continue
+		}
+		if isRequiredInputValue(d.new, newRef) {
+			d.report(changes.added, CriticalityBreaking, coordinate(name), parent, "Required %s '%s' was added", lowerFirst(changes.description), coordinate(name))
+		} else {
+			d.report(changes.added, CriticalitySafe, coordinate(name), "", "Optional %s '%s' was added", lowerFirst(changes.description), coordinate(name))
+		}
+	}
+}
+
+// isComparableNode returns true for the root nodes which are compared by name
+func isComparableNode(kind ast.NodeKind) bool {
+	switch kind {
+	case ast.NodeKindObjectTypeDefinition,
+		ast.NodeKindInterfaceTypeDefinition,
+		ast.NodeKindUnionTypeDefinition,
+		ast.NodeKindEnumTypeDefinition,
+		ast.NodeKindInputObjectTypeDefinition,
+		ast.NodeKindScalarTypeDefinition,
+		ast.NodeKindDirectiveDefinition:
+		return true
+	default:
+		return false
+	}
+}
+
+// findNode looks up a type definition or a directive definition by name,
+// types and directives don't share a namespace, so a directive only matches a directive and vice versa
+func findNode(document *ast.Document, kind ast.NodeKind, name string) (ast.Node, bool) {
+	nodes, _ := document.Index.NodesByNameStr(name)
+	for _, node := range nodes {
+		if !isComparableNode(node.Kind) {
+			continue
+		}
+		if (node.Kind == ast.NodeKindDirectiveDefinition) == (kind == ast.NodeKindDirectiveDefinition) {
+			return node, true
+		}
+	}
+	return ast.InvalidNode, false
+}
+
+func findFieldDefinition(document *ast.Document, refs []int, name string) (int, bool) {
+	for _, ref := range refs {
+		if document.FieldDefinitionNameString(ref) == name {
+			return ref, true
+		}
+	}
+	return ast.InvalidRef, false
+}
+
+func findInputValueDefinition(document *ast.Document, refs []int, name string) (int, bool) {
+	for _, ref := range refs {
+		if document.InputValueDefinitionNameString(ref) == name {
+			return ref, true
+		}
+	}
+	return ast.InvalidRef, false
+}
+
+func containsTypeName(document *ast.Document, typeRefs []int, name string) bool {
+	for _, ref := range typeRefs {
+		if document.TypeNameString(ref) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsEnumValue(document *ast.Document, refs []int, name string) bool {
+	for _, ref := range refs {
+		if document.EnumValueDefinitionNameString(ref) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func rootOperationTypeName(document *ast.Document, operationType ast.OperationType) string {
+	for i := range document.SchemaDefinitions {
+		for _, ref := range document.SchemaDefinitions[i].RootOperationTypeDefinitions.Refs {
+			if document.RootOperationTypeDefinitions[ref].OperationType == operationType {
+				return document.Input.ByteSliceString(document.RootOperationTypeDefinitions[ref].NamedType.Name)
+			}
+		}
+	}
+	if len(document.SchemaDefinitions) != 0 {
+		return ""
+	}
+
+	var defaultName string
+	switch operationType {
+	case ast.OperationTypeQuery:
+		defaultName = string(ast.DefaultQueryTypeName)
+	case ast.OperationTypeMutation:
+		defaultName = string(ast.DefaultMutationTypeName)
+	case ast.OperationTypeSubscription:
+		defaultName = string(ast.DefaultSubscriptionTypeName)
+	}
+	if _, exists := findNode(document, ast.NodeKindObjectTypeDefinition, defaultName); exists {
+		return defaultName
+	}
+	return ""
+}
+
+func operationTypeName(operationType ast.OperationType) string {
+	switch operationType {
+	case ast.OperationTypeMutation:
+		return "mutation"
+	case ast.OperationTypeSubscription:
+		return "subscription"
+	default:
+		return "query"
+	}
+}
+
+func kindName(kind ast.NodeKind) string {
+	switch kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return "object type"
+	case ast.NodeKindInterfaceTypeDefinition:
+		return "interface type"
+	case ast.NodeKindUnionTypeDefinition:
+		return "union type"
+	case ast.NodeKindEnumTypeDefinition:
+		return "enum type"
+	case ast.NodeKindInputObjectTypeDefinition:
+		return "input object type"
+	case ast.NodeKindScalarTypeDefinition:
+		return "scalar type"
+	default:
+		return kind.String()
+	}
+}
+
+func typeString(document *ast.Document, ref int) string {
+	out, _ := document.PrintTypeBytes(ref, nil)
+	return string(out)
+}
+
+func typesAreEqual(left *ast.Document, leftRef int, right *ast.Document, rightRef int) bool {
+	return typeString(left, leftRef) == typeString(right, rightRef)
+}
+
+// isSafeOutputTypeChange returns true if every value of the new type is also a valid value of the old type,
+// e.g. a nullable field becoming non-null
+func isSafeOutputTypeChange(oldDocument *ast.Document, oldRef int, newDocument *ast.Document, newRef int) bool {
+	oldType, newType := oldDocument.Types[oldRef], newDocument.Types[newRef]
+	switch oldType.TypeKind {
+	case ast.TypeKindNamed:
+		switch newType.TypeKind {
+		case ast.TypeKindNamed:
+			return bytes.Equal(oldDocument.TypeNameBytes(oldRef), newDocument.TypeNameBytes(newRef))
+		case ast.TypeKindNonNull:
+			return isSafeOutputTypeChange(oldDocument, oldRef, newDocument, newType.OfType)
+		}
+	case ast.TypeKindList:
+		switch newType.TypeKind {
+		case ast.TypeKindList:
+			return isSafeOutputTypeChange(oldDocument, oldType.OfType, newDocument, newType.OfType)
+		case ast.TypeKindNonNull:
+			return isSafeOutputTypeChange(oldDocument, oldRef, newDocument, newType.OfType)
+		}
+	case ast.TypeKindNonNull:
+		if newType.TypeKind == ast.TypeKindNonNull {
+			return isSafeOutputTypeChange(oldDocument, oldType.OfType, newDocument, newType.OfType)
+		}
+	}
+	return false
+}
+
+// isSafeInputTypeChange returns true if every value accepted by the old type is also accepted by the new type,
+// e.g. a non-null argument becoming nullable
+func isSafeInputTypeChange(oldDocument *ast.Document, oldRef int, newDocument *ast.Document, newRef int) bool {
+	oldType, newType := oldDocument.Types[oldRef], newDocument.Types[newRef]
+	switch oldType.TypeKind {
+	case ast.TypeKindNamed:
+		if newType.TypeKind == ast.TypeKindNamed {
+			return bytes.Equal(oldDocument.TypeNameBytes(oldRef), newDocument.TypeNameBytes(newRef))
+		}
+	case ast.TypeKindList:
+		if newType.TypeKind == ast.TypeKindList {
+			return isSafeInputTypeChange(oldDocument, oldType.OfType, newDocument, newType.OfType)
+		}
+	case ast.TypeKindNonNull:
+		if newType.TypeKind == ast.TypeKindNonNull {
+			return isSafeInputTypeChange(oldDocument, oldType.OfType, newDocument, newType.OfType)
+		}
+		return isSafeInputTypeChange(oldDocument, oldType.OfType, newDocument, newRef)
+	}
+	return false
+}
+
+func isRequiredInputValue(document *ast.Document, ref int) bool {
+	return document.TypeIsNonNull(document.InputValueDefinitionType(ref)) && !document.InputValueDefinitionHasDefaultValue(ref)
+}
+
+func defaultValueString(document *ast.Document, ref int) string {
+	if !document.InputValueDefinitionHasDefaultValue(ref) {
+		return ""
+	}
+	out, _ := document.PrintValueBytes(document.InputValueDefinitionDefaultValue(ref), nil)
+	return string(out)
+}
+
+// quoteValue quotes a printed value for messages, a missing value is described as none
+func quoteValue(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return "'" + value + "'"
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
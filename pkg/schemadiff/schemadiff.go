@@ -0,0 +1,162 @@
+// Package schemadiff compares two GraphQL schemas and reports the changes between them categorized by their impact on clients.
+package schemadiff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+// Criticality describes the impact of a change on existing clients
+type Criticality string
+
+const (
+	// CriticalityBreaking changes make existing operations invalid or change their results in an incompatible way
+	CriticalityBreaking Criticality = "BREAKING"
+	// CriticalityDangerous changes keep existing operations valid but might break clients at runtime,
+	// e.g. a client receives an enum value it doesn't know about
+	CriticalityDangerous Criticality = "DANGEROUS"
+	// CriticalitySafe changes don't affect existing clients
+	CriticalitySafe Criticality = "SAFE"
+)
+
+// ChangeType identifies the kind of change
+type ChangeType string
+
+const (
+	TypeAdded                            ChangeType = "TYPE_ADDED"
+	TypeRemoved                          ChangeType = "TYPE_REMOVED"
+	TypeKindChanged                      ChangeType = "TYPE_KIND_CHANGED"
+	RootOperationTypeChanged             ChangeType = "ROOT_OPERATION_TYPE_CHANGED"
+	FieldAdded                           ChangeType = "FIELD_ADDED"
+	FieldRemoved                         ChangeType = "FIELD_REMOVED"
+	FieldTypeChanged                     ChangeType = "FIELD_TYPE_CHANGED"
+	ArgumentAdded                        ChangeType = "ARGUMENT_ADDED"
+	ArgumentRemoved                      ChangeType = "ARGUMENT_REMOVED"
+	ArgumentTypeChanged                  ChangeType = "ARGUMENT_TYPE_CHANGED"
+	ArgumentDefaultValueChanged          ChangeType = "ARGUMENT_DEFAULT_VALUE_CHANGED"
+	InputFieldAdded                      ChangeType = "INPUT_FIELD_ADDED"
+	InputFieldRemoved                    ChangeType = "INPUT_FIELD_REMOVED"
+	InputFieldTypeChanged                ChangeType = "INPUT_FIELD_TYPE_CHANGED"
+	InputFieldDefaultValueChanged        ChangeType = "INPUT_FIELD_DEFAULT_VALUE_CHANGED"
+	EnumValueAdded                       ChangeType = "ENUM_VALUE_ADDED"
+	EnumValueRemoved                     ChangeType = "ENUM_VALUE_REMOVED"
+	UnionMemberAdded                     ChangeType = "UNION_MEMBER_ADDED"
+	UnionMemberRemoved                   ChangeType = "UNION_MEMBER_REMOVED"
+	ImplementedInterfaceAdded            ChangeType = "IMPLEMENTED_INTERFACE_ADDED"
+	ImplementedInterfaceRemoved          ChangeType = "IMPLEMENTED_INTERFACE_REMOVED"
+	DirectiveAdded                       ChangeType = "DIRECTIVE_ADDED"
+	DirectiveRemoved                     ChangeType = "DIRECTIVE_REMOVED"
+	DirectiveRepeatableRemoved           ChangeType = "DIRECTIVE_REPEATABLE_REMOVED"
+	DirectiveLocationAdded               ChangeType = "DIRECTIVE_LOCATION_ADDED"
+	DirectiveLocationRemoved             ChangeType = "DIRECTIVE_LOCATION_REMOVED"
+	DirectiveArgumentAdded               ChangeType = "DIRECTIVE_ARGUMENT_ADDED"
+	DirectiveArgumentRemoved             ChangeType = "DIRECTIVE_ARGUMENT_REMOVED"
+	DirectiveArgumentTypeChanged         ChangeType = "DIRECTIVE_ARGUMENT_TYPE_CHANGED"
+	DirectiveArgumentDefaultValueChanged ChangeType = "DIRECTIVE_ARGUMENT_DEFAULT_VALUE_CHANGED"
+)
+
+// Change is a single difference between the old and the new schema
+type Change struct {
+	Type        ChangeType  `json:"type"`
+	Criticality Criticality `json:"criticality"`
+	// Coordinate is the schema coordinate of the changed element,
+	// e.g. "Query", "Query.droid", "Query.droid(id:)", "Episode.NEWHOPE" or "@auth(role:)"
+	Coordinate string `json:"coordinate"`
+	Message    string `json:"message"`
+
+	// usage is the coordinate an operation has to use to be affected by the change,
+	// changes with an empty usage affect all operations
+	usage string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Criticality, c.Coordinate, c.Message)
+}
+
+// Changes is the list of changes returned by Diff
+type Changes []Change
+
+// HasBreakingChanges returns true if at least one change is breaking
+func (c Changes) HasBreakingChanges() bool {
+	for i := range c {
+		if c[i].Criticality == CriticalityBreaking {
+			return true
+		}
+	}
+	return false
+}
+
+// ByCriticality returns all changes of the given criticality
+func (c Changes) ByCriticality(criticality Criticality) Changes {
+	var out Changes
+	for i := range c {
+		if c[i].Criticality == criticality {
+			out = append(out, c[i])
+		}
+	}
+	return out
+}
+
+func (c Changes) String() string {
+	lines := make([]string, len(c))
+	for i := range c {
+		lines[i] = c[i].String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+type options struct {
+	operations []*ast.Document
+}
+
+type Option func(options *options)
+
+// WithOperations checks the breaking changes against a set of recorded client operations.
+// A breaking change which doesn't affect any of the operations is reported as dangerous instead,
+// as it might still affect clients which are not part of the recorded operations.
+// The operations must be valid against the old schema.
+func WithOperations(operations ...*ast.Document) Option {
+	return func(options *options) {
+		options.operations = append(options.operations, operations...)
+	}
+}
+
+// Diff compares the old with the new schema and returns all changes in the order of the old schema followed by additions.
+// Both schemas are compared as they are, type extensions should be merged into their types beforehand,
+// e.g. by normalizing the schemas with astnormalization.NormalizeDefinition.
+// An error is only returned if the operations passed by WithOperations can't be walked against the old schema.
+func Diff(oldSchema, newSchema *ast.Document, opts ...Option) (Changes, error) {
+	options := options{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	differ := differ{
+		old: oldSchema,
+		new: newSchema,
+	}
+	differ.diff()
+
+	if len(options.operations) == 0 {
+		return differ.changes, nil
+	}
+
+	usages, err := collectUsages(oldSchema, options.operations)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range differ.changes {
+		change := &differ.changes[i]
+		if change.Criticality != CriticalityBreaking || change.usage == "" {
+			continue
+		}
+		if _, used := usages[change.usage]; !used {
+			change.Criticality = CriticalityDangerous
+		}
+	}
+
+	return differ.changes, nil
+}
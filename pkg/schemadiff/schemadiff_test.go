@@ -0,0 +1,252 @@
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+func TestDiff(t *testing.T) {
+	type expectedChange struct {
+		Type        ChangeType
+		Criticality Criticality
+		Coordinate  string
+	}
+
+	run := func(t *testing.T, oldSchema, newSchema string, expected []expectedChange, opts ...Option) Changes {
+		t.Helper()
+
+		oldDocument := unsafeparser.ParseGraphqlDocumentString(oldSchema)
+		newDocument := unsafeparser.ParseGraphqlDocumentString(newSchema)
+
+		changes, err := Diff(&oldDocument, &newDocument, opts...)
+		require.NoError(t, err)
+
+		actual := make([]expectedChange, 0, len(changes))
+		for _, change := range changes {
+			actual = append(actual, expectedChange{Type: change.Type, Criticality: change.Criticality, Coordinate: change.Coordinate})
+		}
+		assert.Equal(t, expected, actual)
+		return changes
+	}
+
+	t.Run("identical schemas", func(t *testing.T) {
+		schema := `
+			type Query { droid(id: ID!): Droid }
+			type Droid { name: String }`
+		changes := run(t, schema, schema, []expectedChange{})
+		assert.False(t, changes.HasBreakingChanges())
+	})
+
+	t.Run("types", func(t *testing.T) {
+		changes := run(t, `
+			type Query { droid: Droid }
+			type Droid { name: String }
+			interface Node { id: ID! }
+			scalar Date`, `
+			type Query { droid: Droid }
+			type Droid { name: String }
+			type Node { id: ID! }
+			type Human { name: String }`,
+			[]expectedChange{
+				{Type: TypeKindChanged, Criticality: CriticalityBreaking, Coordinate: "Node"},
+				{Type: TypeRemoved, Criticality: CriticalityBreaking, Coordinate: "Date"},
+				{Type: TypeAdded, Criticality: CriticalitySafe, Coordinate: "Human"},
+			},
+		)
+		assert.True(t, changes.HasBreakingChanges())
+		assert.Equal(t, "Type 'Node' changed from interface type to object type", changes[0].Message)
+		assert.Len(t, changes.ByCriticality(CriticalityBreaking), 2)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		changes := run(t, `
+			type Query {
+				removed: String
+				nullable: String
+				nonNull: String!
+				list: [String]
+				other: String
+			}`, `
+			type Query {
+				nullable: String!
+				nonNull: String
+				list: [String!]!
+				other: Int
+				added: String
+			}`,
+			[]expectedChange{
+				{Type: FieldRemoved, Criticality: CriticalityBreaking, Coordinate: "Query.removed"},
+				{Type: FieldTypeChanged, Criticality: CriticalitySafe, Coordinate: "Query.nullable"},
+				{Type: FieldTypeChanged, Criticality: CriticalityBreaking, Coordinate: "Query.nonNull"},
+				{Type: FieldTypeChanged, Criticality: CriticalitySafe, Coordinate: "Query.list"},
+				{Type: FieldTypeChanged, Criticality: CriticalityBreaking, Coordinate: "Query.other"},
+				{Type: FieldAdded, Criticality: CriticalitySafe, Coordinate: "Query.added"},
+			},
+		)
+		assert.Equal(t, "Field 'Query.nonNull' changed type from 'String!' to 'String'", changes[2].Message)
+	})
+
+	t.Run("arguments", func(t *testing.T) {
+		changes := run(t, `
+			type Query {
+				droids(removed: Int, nullable: Int, nonNull: Int!, first: Int = 10): [String]
+			}`, `
+			type Query {
+				droids(nullable: Int!, nonNull: Int, first: Int = 20, required: ID!, optional: ID, defaulted: ID! = "1"): [String]
+			}`,
+			[]expectedChange{
+				{Type: ArgumentRemoved, Criticality: CriticalityBreaking, Coordinate: "Query.droids(removed:)"},
+				{Type: ArgumentTypeChanged, Criticality: CriticalityBreaking, Coordinate: "Query.droids(nullable:)"},
+				{Type: ArgumentTypeChanged, Criticality: CriticalitySafe, Coordinate: "Query.droids(nonNull:)"},
+				{Type: ArgumentDefaultValueChanged, Criticality: CriticalityDangerous, Coordinate: "Query.droids(first:)"},
+				{Type: ArgumentAdded, Criticality: CriticalityBreaking, Coordinate: "Query.droids(required:)"},
+				{Type: ArgumentAdded, Criticality: CriticalitySafe, Coordinate: "Query.droids(optional:)"},
+				{Type: ArgumentAdded, Criticality: CriticalitySafe, Coordinate: "Query.droids(defaulted:)"},
+			},
+		)
+		assert.Equal(t, "Argument 'Query.droids(first:)' changed default value from '10' to '20'", changes[3].Message)
+		assert.Equal(t, "Required argument 'Query.droids(required:)' was added", changes[4].Message)
+	})
+
+	t.Run("input objects", func(t *testing.T) {
+		run(t, `
+			input Filter { removed: String name: String limit: Int }`, `
+			input Filter { name: String! limit: Int = 5 required: Int! optional: Int }`,
+			[]expectedChange{
+				{Type: InputFieldRemoved, Criticality: CriticalityBreaking, Coordinate: "Filter.removed"},
+				{Type: InputFieldTypeChanged, Criticality: CriticalityBreaking, Coordinate: "Filter.name"},
+				{Type: InputFieldDefaultValueChanged, Criticality: CriticalityDangerous, Coordinate: "Filter.limit"},
+				{Type: InputFieldAdded, Criticality: CriticalityBreaking, Coordinate: "Filter.required"},
+				{Type: InputFieldAdded, Criticality: CriticalitySafe, Coordinate: "Filter.optional"},
+			},
+		)
+	})
+
+	t.Run("enums, unions and interfaces", func(t *testing.T) {
+		run(t, `
+			enum Episode { NEWHOPE EMPIRE }
+			union SearchResult = Droid | Human
+			interface Node { id: ID! }
+			type Droid implements Node { id: ID! }
+			type Human { id: ID! }`, `
+			enum Episode { NEWHOPE JEDI }
+			union SearchResult = Droid | Starship
+			interface Node { id: ID! }
+			type Droid { id: ID! }
+			type Human implements Node { id: ID! }
+			type Starship { id: ID! }`,
+			[]expectedChange{
+				{Type: EnumValueRemoved, Criticality: CriticalityBreaking, Coordinate: "Episode.EMPIRE"},
+				{Type: EnumValueAdded, Criticality: CriticalityDangerous, Coordinate: "Episode.JEDI"},
+				{Type: UnionMemberRemoved, Criticality: CriticalityBreaking, Coordinate: "SearchResult"},
+				{Type: UnionMemberAdded, Criticality: CriticalityDangerous, Coordinate: "SearchResult"},
+				{Type: ImplementedInterfaceRemoved, Criticality: CriticalityBreaking, Coordinate: "Droid"},
+				{Type: ImplementedInterfaceAdded, Criticality: CriticalityDangerous, Coordinate: "Human"},
+				{Type: TypeAdded, Criticality: CriticalitySafe, Coordinate: "Starship"},
+			},
+		)
+	})
+
+	t.Run("directives", func(t *testing.T) {
+		run(t, `
+			directive @removed on FIELD
+			directive @auth(role: String, level: Int) repeatable on FIELD_DEFINITION | OBJECT`, `
+			directive @auth(role: String!, scope: String!) on FIELD_DEFINITION | INTERFACE
+			directive @added on FIELD`,
+			[]expectedChange{
+				{Type: DirectiveRemoved, Criticality: CriticalityBreaking, Coordinate: "@removed"},
+				{Type: DirectiveRepeatableRemoved, Criticality: CriticalityBreaking, Coordinate: "@auth"},
+				{Type: DirectiveLocationRemoved, Criticality: CriticalityBreaking, Coordinate: "@auth"},
+				{Type: DirectiveLocationAdded, Criticality: CriticalitySafe, Coordinate: "@auth"},
+				{Type: DirectiveArgumentTypeChanged, Criticality: CriticalityBreaking, Coordinate: "@auth(role:)"},
+				{Type: DirectiveArgumentRemoved, Criticality: CriticalityBreaking, Coordinate: "@auth(level:)"},
+				{Type: DirectiveArgumentAdded, Criticality: CriticalityBreaking, Coordinate: "@auth(scope:)"},
+				{Type: DirectiveAdded, Criticality: CriticalitySafe, Coordinate: "@added"},
+			},
+		)
+	})
+
+	t.Run("root operation types", func(t *testing.T) {
+		run(t, `
+			schema { query: Query }
+			type Query { a: String }
+			type NewQuery { a: String }`, `
+			schema { query: NewQuery }
+			type Query { a: String }
+			type NewQuery { a: String }`,
+			[]expectedChange{
+				{Type: RootOperationTypeChanged, Criticality: CriticalityBreaking, Coordinate: "Query"},
+			},
+		)
+	})
+
+	t.Run("with operations", func(t *testing.T) {
+		oldSchema := `
+			type Query {
+				droid(id: ID!, filter: Filter): Droid
+				human(id: ID!): Human
+			}
+			type Droid { name: String primaryFunction: String }
+			type Human { name: String }
+			input Filter { name: String inner: InnerFilter }
+			input InnerFilter { value: String }
+			enum Episode { NEWHOPE EMPIRE }
+			directive @cached(ttl: Int) on FIELD`
+		newSchema := `
+			type Query {
+				droid(id: ID!, filter: Filter, required: Int!): Droid
+			}
+			type Droid { name: String }
+			input Filter { name: String inner: InnerFilter }
+			input InnerFilter { other: String }
+			enum Episode { NEWHOPE }
+			directive @cached on FIELD`
+
+		operation := unsafeparser.ParseGraphqlDocumentString(`
+			query Droid($filter: Filter) {
+				droid(id: 1, filter: $filter) @cached(ttl: 10) {
+					...DroidFields
+				}
+			}
+			fragment DroidFields on Droid { name __typename }`)
+
+		changes := run(t, oldSchema, newSchema,
+			[]expectedChange{
+				{Type: ArgumentAdded, Criticality: CriticalityBreaking, Coordinate: "Query.droid(required:)"},
+				{Type: FieldRemoved, Criticality: CriticalityDangerous, Coordinate: "Query.human"},
+				{Type: FieldRemoved, Criticality: CriticalityDangerous, Coordinate: "Droid.primaryFunction"},
+				{Type: TypeRemoved, Criticality: CriticalityDangerous, Coordinate: "Human"},
+				{Type: InputFieldRemoved, Criticality: CriticalityBreaking, Coordinate: "InnerFilter.value"},
+				{Type: InputFieldAdded, Criticality: CriticalitySafe, Coordinate: "InnerFilter.other"},
+				{Type: EnumValueRemoved, Criticality: CriticalityDangerous, Coordinate: "Episode.EMPIRE"},
+				{Type: DirectiveArgumentRemoved, Criticality: CriticalityBreaking, Coordinate: "@cached(ttl:)"},
+			},
+			WithOperations(&operation),
+		)
+		assert.True(t, changes.HasBreakingChanges())
+	})
+
+	t.Run("with invalid operations", func(t *testing.T) {
+		oldDocument := unsafeparser.ParseGraphqlDocumentString(`type Query { a: String }`)
+		newDocument := unsafeparser.ParseGraphqlDocumentString(`type Query { b: String }`)
+		operation := unsafeparser.ParseGraphqlDocumentString(`{ unknown }`)
+
+		_, err := Diff(&oldDocument, &newDocument, WithOperations(&operation))
+		assert.Error(t, err)
+	})
+
+	t.Run("does not modify the schemas", func(t *testing.T) {
+		oldDocument := unsafeparser.ParseGraphqlDocumentString(`type Query { a: String }`)
+		newDocument := unsafeparser.ParseGraphqlDocumentString(`type Query { a: String }`)
+		operation := unsafeparser.ParseGraphqlDocumentString(`{ a }`)
+
+		_, err := Diff(&oldDocument, &newDocument, WithOperations(&operation))
+		require.NoError(t, err)
+		assert.Equal(t, []ast.Node{{Kind: ast.NodeKindObjectTypeDefinition, Ref: 0}}, oldDocument.RootNodes)
+	})
+}
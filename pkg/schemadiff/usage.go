@@ -0,0 +1,143 @@
+package schemadiff
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// collectUsages walks all operations against the schema and returns the coordinates used by at least one of them:
+// types, fields, arguments, directives and directive arguments, as well as all input types reachable from variables and arguments
+func collectUsages(schema *ast.Document, operations []*ast.Document) (map[string]struct{}, error) {
+	definition, err := usageDefinition(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	walker := astvisitor.NewWalker(48)
+	visitor := &usageVisitor{
+		Walker:     &walker,
+		definition: definition,
+		usages:     map[string]struct{}{},
+	}
+	walker.RegisterEnterDocumentVisitor(visitor)
+	walker.RegisterEnterFieldVisitor(visitor)
+	walker.RegisterEnterDirectiveVisitor(visitor)
+	walker.RegisterEnterVariableDefinitionVisitor(visitor)
+	walker.RegisterEnterInlineFragmentVisitor(visitor)
+	walker.RegisterEnterFragmentDefinitionVisitor(visitor)
+
+	report := operationreport.Report{}
+	for _, operation := range operations {
+		walker.Walk(operation, definition, &report)
+		if report.HasErrors() {
+			return nil, report
+		}
+	}
+
+	return visitor.usages, nil
+}
+
+// usageDefinition returns a copy of the schema which includes the base schema,
+// so that the operations can be walked without modifying the schema passed to Diff
+func usageDefinition(schema *ast.Document) (*ast.Document, error) {
+	sdl, err := astprinter.PrintString(schema, nil)
+	if err != nil {
+		return nil, err
+	}
+	definition, report := astparser.ParseGraphqlDocumentString(sdl)
+	if report.HasErrors() {
+		return nil, report
+	}
+	if _, hasBaseSchema := definition.Index.FirstNodeByNameStr("__Schema"); !hasBaseSchema {
+		if err := asttransform.MergeDefinitionWithBaseSchema(&definition); err != nil {
+			return nil, err
+		}
+	}
+	return &definition, nil
+}
+
+type usageVisitor struct {
+	*astvisitor.Walker
+	operation, definition *ast.Document
+	usages                map[string]struct{}
+}
+
+func (u *usageVisitor) use(coordinate string) {
+	u.usages[coordinate] = struct{}{}
+}
+
+// useInputType marks an input type and all input types reachable through its fields as used
+func (u *usageVisitor) useInputType(typeName string) {
+	if _, used := u.usages[typeName]; used {
+		return
+	}
+	u.use(typeName)
+
+	node, exists := u.definition.Index.FirstNonExtensionNodeByNameBytes([]byte(typeName))
+	if !exists || node.Kind != ast.NodeKindInputObjectTypeDefinition {
+		return
+	}
+	for _, ref := range u.definition.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs {
+		u.useInputType(u.definition.ResolveTypeNameString(u.definition.InputValueDefinitionType(ref)))
+	}
+}
+
+// useArguments marks the arguments used by a field or directive as well as their input types
+func (u *usageVisitor) useArguments(parent string, arguments []int, argumentDefinitions []int) {
+	for _, argument := range arguments {
+		name := u.operation.ArgumentNameString(argument)
+		u.use(parent + "(" + name + ":)")
+		for _, argumentDefinition := range argumentDefinitions {
+			if u.definition.InputValueDefinitionNameString(argumentDefinition) == name {
+				u.useInputType(u.definition.ResolveTypeNameString(u.definition.InputValueDefinitionType(argumentDefinition)))
+			}
+		}
+	}
+}
+
+func (u *usageVisitor) EnterDocument(operation, definition *ast.Document) {
+	u.operation = operation
+}
+
+func (u *usageVisitor) EnterField(ref int) {
+	typeName := u.definition.NodeNameString(u.EnclosingTypeDefinition)
+	coordinate := typeName + "." + u.operation.FieldNameString(ref)
+	u.use(typeName)
+	u.use(coordinate)
+
+	fieldDefinition, exists := u.FieldDefinition(ref)
+	if !exists {
+		return
+	}
+	u.use(u.definition.ResolveTypeNameString(u.definition.FieldDefinitionType(fieldDefinition)))
+	u.useArguments(coordinate, u.operation.Fields[ref].Arguments.Refs, u.definition.FieldDefinitionArgumentsDefinitions(fieldDefinition))
+}
+
+func (u *usageVisitor) EnterDirective(ref int) {
+	coordinate := "@" + u.operation.DirectiveNameString(ref)
+	u.use(coordinate)
+
+	var argumentDefinitions []int
+	if directiveDefinition, exists := u.definition.DirectiveDefinitionByNameBytes(u.operation.DirectiveNameBytes(ref)); exists {
+		argumentDefinitions = u.definition.DirectiveDefinitions[directiveDefinition].ArgumentsDefinition.Refs
+	}
+	u.useArguments(coordinate, u.operation.Directives[ref].Arguments.Refs, argumentDefinitions)
+}
+
+func (u *usageVisitor) EnterVariableDefinition(ref int) {
+	u.useInputType(u.operation.ResolveTypeNameString(u.operation.VariableDefinitions[ref].Type))
+}
+
+func (u *usageVisitor) EnterInlineFragment(ref int) {
+	if u.operation.InlineFragmentHasTypeCondition(ref) {
+		u.use(u.operation.InlineFragmentTypeConditionNameString(ref))
+	}
+}
+
+func (u *usageVisitor) EnterFragmentDefinition(ref int) {
+	u.use(string(u.operation.FragmentDefinitionTypeName(ref)))
+}
@@ -28,6 +28,10 @@ const (
 
 	DefaultKeepAliveInterval          = "15s"
 	DefaultSubscriptionUpdateInterval = "1s"
+
+	// DefaultMaxQueuedMessagesPerSubscription is the size of the message queue of a subscription
+	// unless it is changed with WithMaxQueuedMessagesPerSubscription.
+	DefaultMaxQueuedMessagesPerSubscription = 32
 )
 
 // Message defines the actual subscription message wich will be passed from client to server and vice versa.
@@ -84,6 +88,66 @@ type Handler struct {
 	bufferPool *sync.Pool
 	// initFunc will check initial payload to see whether to accept the websocket connection.
 	initFunc WebsocketInitFunc
+	// maxSubscriptions is the maximum number of active subscriptions per connection.
+	maxSubscriptions int
+	// maxQueuedMessages is the maximum number of messages queued per subscription while the client is busy.
+	maxQueuedMessages int
+	// overflowPolicy decides what happens when the message queue of a subscription is full.
+	overflowPolicy OverflowPolicy
+	// connectionLimiter limits the number of connections per identity.
+	connectionLimiter *ConnectionLimiter
+	// metrics is notified about subscription lifecycle events.
+	metrics Metrics
+}
+
+type handlerOptions struct {
+	maxSubscriptions  int
+	maxQueuedMessages int
+	overflowPolicy    OverflowPolicy
+	connectionLimiter *ConnectionLimiter
+	metrics           Metrics
+}
+
+type HandlerOption func(options *handlerOptions)
+
+// WithMaxSubscriptionsPerConnection limits the number of active subscriptions of a single connection.
+// Further subscriptions are rejected with an error message. A value <= 0 disables the limit.
+func WithMaxSubscriptionsPerConnection(max int) HandlerOption {
+	return func(options *handlerOptions) {
+		options.maxSubscriptions = max
+	}
+}
+
+// WithMaxQueuedMessagesPerSubscription decouples the execution of a subscription from writing to the client
+// by queueing up to max messages. When the queue is full the OverflowPolicy is applied.
+// It defaults to DefaultMaxQueuedMessagesPerSubscription with OverflowPolicyBlock, so no message is lost.
+// A value <= 0 disables queueing, so a slow client blocks the subscription.
+func WithMaxQueuedMessagesPerSubscription(max int) HandlerOption {
+	return func(options *handlerOptions) {
+		options.maxQueuedMessages = max
+	}
+}
+
+// WithOverflowPolicy sets the policy which is applied when the message queue of a subscription is full.
+func WithOverflowPolicy(policy OverflowPolicy) HandlerOption {
+	return func(options *handlerOptions) {
+		options.overflowPolicy = policy
+	}
+}
+
+// WithConnectionLimiter limits the number of connections per identity.
+// The limiter has to be shared between the handlers of all connections.
+func WithConnectionLimiter(limiter *ConnectionLimiter) HandlerOption {
+	return func(options *handlerOptions) {
+		options.connectionLimiter = limiter
+	}
+}
+
+// WithMetrics sets the Metrics implementation which is notified about subscription lifecycle events.
+func WithMetrics(metrics Metrics) HandlerOption {
+	return func(options *handlerOptions) {
+		options.metrics = metrics
+	}
 }
 
 func NewHandlerWithInitFunc(
@@ -91,6 +155,7 @@ func NewHandlerWithInitFunc(
 	client Client,
 	executorPool ExecutorPool,
 	initFunc WebsocketInitFunc,
+	opts ...HandlerOption,
 ) (*Handler, error) {
 	keepAliveInterval, err := time.ParseDuration(DefaultKeepAliveInterval)
 	if err != nil {
@@ -102,6 +167,15 @@ func NewHandlerWithInitFunc(
 		return nil, err
 	}
 
+	options := handlerOptions{
+		maxQueuedMessages: DefaultMaxQueuedMessagesPerSubscription,
+		overflowPolicy:    OverflowPolicyBlock,
+		metrics:           noopMetrics{},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	return &Handler{
 		logger:                     logger,
 		client:                     client,
@@ -115,13 +189,18 @@ func NewHandlerWithInitFunc(
 				return &writer
 			},
 		},
-		initFunc: initFunc,
+		initFunc:          initFunc,
+		maxSubscriptions:  options.maxSubscriptions,
+		maxQueuedMessages: options.maxQueuedMessages,
+		overflowPolicy:    options.overflowPolicy,
+		connectionLimiter: options.connectionLimiter,
+		metrics:           options.metrics,
 	}, nil
 }
 
 // NewHandler creates a new subscription handler.
-func NewHandler(logger abstractlogger.Logger, client Client, executorPool ExecutorPool) (*Handler, error) {
-	return NewHandlerWithInitFunc(logger, client, executorPool, nil)
+func NewHandler(logger abstractlogger.Logger, client Client, executorPool ExecutorPool, opts ...HandlerOption) (*Handler, error) {
+	return NewHandlerWithInitFunc(logger, client, executorPool, nil, opts...)
 }
 
 // Handle will handle the subscription connection.
@@ -148,11 +227,19 @@ func (h *Handler) Handle(ctx context.Context) {
 		} else if message != nil {
 			switch message.Type {
 			case MessageTypeConnectionInit:
-				ctx, err = h.handleInit(ctx, message.Payload)
+				var identity string
+				ctx, identity, err = h.handleInit(ctx, message.Payload)
+				if err == ErrMaxConnectionsPerIdentityReached {
+					h.terminateConnection(err.Error())
+					return
+				}
 				if err != nil {
 					h.terminateConnection("failed to accept the websocket connection")
 					return
 				}
+				if h.connectionLimiter != nil {
+					defer h.connectionLimiter.release(identity)
+				}
 
 				go h.handleKeepAlive(ctx)
 			case MessageTypeStart:
@@ -185,30 +272,43 @@ func (h *Handler) ChangeSubscriptionUpdateInterval(d time.Duration) {
 }
 
 // handleInit will handle an init message.
-func (h *Handler) handleInit(ctx context.Context, payload []byte) (extendedCtx context.Context, err error) {
+// The returned identity has been acquired from the connection limiter and must be released when the connection ends.
+func (h *Handler) handleInit(ctx context.Context, payload []byte) (extendedCtx context.Context, identity string, err error) {
+	var initPayload InitPayload
+	// decode initial payload
+	if len(payload) > 0 {
+		initPayload = payload
+	}
+
 	if h.initFunc != nil {
-		var initPayload InitPayload
-		// decode initial payload
-		if len(payload) > 0 {
-			initPayload = payload
-		}
 		// check initial payload to see whether to accept the websocket connection
 		if extendedCtx, err = h.initFunc(ctx, initPayload); err != nil {
-			return extendedCtx, err
+			return extendedCtx, "", err
 		}
 	} else {
 		extendedCtx = ctx
 	}
 
+	if h.connectionLimiter != nil {
+		identity = h.connectionLimiter.identityFunc(initPayload)
+		if !h.connectionLimiter.acquire(identity) {
+			h.metrics.ConnectionRejected(identity)
+			return extendedCtx, "", ErrMaxConnectionsPerIdentityReached
+		}
+	}
+
 	ackMessage := Message{
 		Type: MessageTypeConnectionAck,
 	}
 
 	if err = h.client.WriteToClient(ackMessage); err != nil {
-		return extendedCtx, err
+		if h.connectionLimiter != nil {
+			h.connectionLimiter.release(identity)
+		}
+		return extendedCtx, "", err
 	}
 
-	return extendedCtx, nil
+	return extendedCtx, identity, nil
 }
 
 // handleStart will handle s start message.
@@ -229,6 +329,13 @@ func (h *Handler) handleStart(ctx context.Context, id string, payload []byte) {
 	}
 
 	if executor.OperationType() == ast.OperationTypeSubscription {
+		if h.maxSubscriptions > 0 && h.subCancellations.Len() >= h.maxSubscriptions {
+			h.metrics.SubscriptionRejected(id)
+			h.putExecutor(executor)
+			h.handleError(id, graphql.RequestErrorsFromError(ErrMaxSubscriptionsPerConnectionReached))
+			return
+		}
+
 		ctx := h.subCancellations.AddWithParent(id, ctx)
 		go h.startSubscription(ctx, id, executor)
 		return
@@ -286,16 +393,21 @@ func (h *Handler) handleNonSubscriptionOperation(ctx context.Context, id string,
 	h.sendComplete(id)
 }
 
+func (h *Handler) putExecutor(executor Executor) {
+	err := h.executorPool.Put(executor)
+	if err != nil {
+		h.logger.Error("subscription.Handle.putExecutor()",
+			abstractlogger.Error(err),
+		)
+	}
+}
+
 // startSubscription will invoke the actual subscription.
 func (h *Handler) startSubscription(ctx context.Context, id string, executor Executor) {
-	defer func() {
-		err := h.executorPool.Put(executor)
-		if err != nil {
-			h.logger.Error("subscription.Handle.startSubscription()",
-				abstractlogger.Error(err),
-			)
-		}
-	}()
+	defer h.putExecutor(executor)
+
+	h.metrics.SubscriptionStarted(id)
+	defer h.metrics.SubscriptionStopped(id)
 
 	executor.SetContext(ctx)
 	buf := h.bufferPool.Get().(*graphql.EngineResultWriter)
@@ -303,7 +415,19 @@ func (h *Handler) startSubscription(ctx context.Context, id string, executor Exe
 
 	defer h.bufferPool.Put(buf)
 
-	h.executeSubscription(buf, id, executor)
+	send := func(data []byte) {
+		h.sendData(id, data)
+	}
+
+	if h.maxQueuedMessages > 0 {
+		queue := newMessageQueue(h.maxQueuedMessages, h.overflowPolicy)
+		go h.writeQueuedMessages(ctx, id, queue)
+		send = func(data []byte) {
+			h.enqueueData(ctx, id, queue, data)
+		}
+	}
+
+	h.executeSubscription(buf, id, executor, send)
 
 	for {
 		buf.Reset()
@@ -311,19 +435,67 @@ func (h *Handler) startSubscription(ctx context.Context, id string, executor Exe
 		case <-ctx.Done():
 			return
 		case <-time.After(h.subscriptionUpdateInterval):
-			h.executeSubscription(buf, id, executor)
+			h.executeSubscription(buf, id, executor, send)
+		}
+	}
+
+}
+
+// enqueueData will queue a data message and apply the overflow policy when the queue is full.
+func (h *Handler) enqueueData(ctx context.Context, id string, queue *messageQueue, data []byte) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	dropped, err := queue.push(ctx, data)
+	if dropped > 0 {
+		h.logger.Debug("subscription.Handler.enqueueData()",
+			abstractlogger.String("id", id),
+			abstractlogger.Int("dropped", dropped),
+			abstractlogger.String("policy", h.overflowPolicy.String()),
+		)
+		h.metrics.MessagesDropped(id, dropped)
+	}
+
+	if err != nil {
+		if !h.subCancellations.Cancel(id) {
+			// the subscription has already been stopped
+			return
 		}
+
+		h.logger.Debug("subscription.Handler.enqueueData()",
+			abstractlogger.String("id", id),
+			abstractlogger.Error(err),
+		)
+		h.metrics.SubscriptionTerminated(id)
+		h.handleError(id, graphql.RequestErrorsFromError(err))
 	}
+}
 
+// writeQueuedMessages will write queued messages to the client until the subscription ends.
+func (h *Handler) writeQueuedMessages(ctx context.Context, id string, queue *messageQueue) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-queue.signal:
+			for _, data := range queue.pop() {
+				if ctx.Err() != nil {
+					return
+				}
+				h.sendData(id, data)
+			}
+		}
+	}
 }
 
 // executeSubscription will keep execution the subscription until it ends.
-func (h *Handler) executeSubscription(buf *graphql.EngineResultWriter, id string, executor Executor) {
+func (h *Handler) executeSubscription(buf *graphql.EngineResultWriter, id string, executor Executor, send func(data []byte)) {
 	buf.SetFlushCallback(func(data []byte) {
 		h.logger.Debug("subscription.Handle.executeSubscription()",
 			abstractlogger.ByteString("execution_result", data),
 		)
-		h.sendData(id, data)
+		send(data)
 	})
 	defer buf.SetFlushCallback(nil)
 
@@ -342,7 +514,7 @@ func (h *Handler) executeSubscription(buf *graphql.EngineResultWriter, id string
 		h.logger.Debug("subscription.Handle.executeSubscription()",
 			abstractlogger.ByteString("execution_result", data),
 		)
-		h.sendData(id, data)
+		send(data)
 	}
 }
 
@@ -0,0 +1,225 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// OverflowPolicy defines what happens when the message queue of a subscription is full
+// because the client does not read messages as fast as they are produced.
+type OverflowPolicy int
+
+const (
+	// OverflowPolicyDropOldest discards the oldest queued message to make room for the new one.
+	OverflowPolicyDropOldest OverflowPolicy = iota
+	// OverflowPolicyCoalesce discards all queued messages and only keeps the latest one.
+	OverflowPolicyCoalesce
+	// OverflowPolicyTerminate stops the subscription and sends an error message to the client.
+	OverflowPolicyTerminate
+	// OverflowPolicyBlock blocks the subscription until the client has read a queued message,
+	// so no message is lost.
+	OverflowPolicyBlock
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowPolicyDropOldest:
+		return "drop_oldest"
+	case OverflowPolicyCoalesce:
+		return "coalesce"
+	case OverflowPolicyTerminate:
+		return "terminate"
+	case OverflowPolicyBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+var (
+	ErrMaxSubscriptionsPerConnectionReached = errors.New("maximum number of subscriptions per connection reached")
+	ErrMaxConnectionsPerIdentityReached     = errors.New("maximum number of connections per identity reached")
+	ErrSubscriptionQueueOverflow            = errors.New("subscription terminated: client is too slow to receive messages")
+)
+
+// Metrics can be implemented to observe the lifecycle of subscriptions and the actions
+// the handler takes when a limit is reached.
+type Metrics interface {
+	// ConnectionRejected is called when a connection exceeds the connections allowed per identity.
+	ConnectionRejected(identity string)
+	// SubscriptionStarted is called when a subscription has been started.
+	SubscriptionStarted(id string)
+	// SubscriptionStopped is called when a subscription has ended for whatever reason.
+	SubscriptionStopped(id string)
+	// SubscriptionRejected is called when a subscription exceeds the subscriptions allowed per connection.
+	SubscriptionRejected(id string)
+	// MessagesDropped is called when queued messages of a subscription have been discarded.
+	MessagesDropped(id string, count int)
+	// SubscriptionTerminated is called when a subscription has been stopped because its queue overflowed.
+	SubscriptionTerminated(id string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ConnectionRejected(string)     {}
+func (noopMetrics) SubscriptionStarted(string)    {}
+func (noopMetrics) SubscriptionStopped(string)    {}
+func (noopMetrics) SubscriptionRejected(string)   {}
+func (noopMetrics) MessagesDropped(string, int)   {}
+func (noopMetrics) SubscriptionTerminated(string) {}
+
+// IdentityFunc extracts the identity of a connection from its init payload.
+// Connections with an empty identity are not limited.
+type IdentityFunc func(initPayload InitPayload) string
+
+// DefaultIdentityFunc identifies connections by the Authorization value of the init payload.
+func DefaultIdentityFunc(initPayload InitPayload) string {
+	return initPayload.Authorization()
+}
+
+// ConnectionLimiter limits the number of concurrent connections per identity.
+// A single ConnectionLimiter has to be shared between the handlers of all connections.
+type ConnectionLimiter struct {
+	mu                        sync.Mutex
+	maxConnectionsPerIdentity int
+	identityFunc              IdentityFunc
+	connections               map[string]int
+}
+
+// NewConnectionLimiter creates a ConnectionLimiter which allows maxConnectionsPerIdentity concurrent
+// connections per identity. If identityFunc is nil DefaultIdentityFunc is used.
+func NewConnectionLimiter(maxConnectionsPerIdentity int, identityFunc IdentityFunc) *ConnectionLimiter {
+	if identityFunc == nil {
+		identityFunc = DefaultIdentityFunc
+	}
+
+	return &ConnectionLimiter{
+		maxConnectionsPerIdentity: maxConnectionsPerIdentity,
+		identityFunc:              identityFunc,
+		connections:               make(map[string]int),
+	}
+}
+
+// Connections returns the number of active connections for the given identity.
+func (l *ConnectionLimiter) Connections(identity string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.connections[identity]
+}
+
+func (l *ConnectionLimiter) acquire(identity string) bool {
+	if identity == "" || l.maxConnectionsPerIdentity <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.connections[identity] >= l.maxConnectionsPerIdentity {
+		return false
+	}
+
+	l.connections[identity]++
+	return true
+}
+
+func (l *ConnectionLimiter) release(identity string) {
+	if identity == "" || l.maxConnectionsPerIdentity <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.connections[identity]--
+	if l.connections[identity] <= 0 {
+		delete(l.connections, identity)
+	}
+}
+
+// messageQueue is a bounded queue decoupling the production of subscription messages
+// from writing them to the client.
+type messageQueue struct {
+	mu       sync.Mutex
+	messages [][]byte
+	max      int
+	policy   OverflowPolicy
+	signal   chan struct{}
+	popped   chan struct{}
+}
+
+func newMessageQueue(max int, policy OverflowPolicy) *messageQueue {
+	return &messageQueue{
+		messages: make([][]byte, 0, max),
+		max:      max,
+		policy:   policy,
+		signal:   make(chan struct{}, 1),
+		popped:   make(chan struct{}, 1),
+	}
+}
+
+// push adds a copy of data to the queue and applies the overflow policy if the queue is full.
+// It returns the number of discarded messages or ErrSubscriptionQueueOverflow if the
+// subscription has to be terminated. With OverflowPolicyBlock it waits until the queue has room
+// or ctx is done, in which case the message is discarded because the subscription has ended.
+func (q *messageQueue) push(ctx context.Context, data []byte) (dropped int, err error) {
+	message := make([]byte, len(data))
+	copy(message, data)
+
+	q.mu.Lock()
+	for q.policy == OverflowPolicyBlock && len(q.messages) >= q.max {
+		q.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return 0, nil
+		case <-q.popped:
+		}
+		q.mu.Lock()
+	}
+	if len(q.messages) >= q.max {
+		switch q.policy {
+		case OverflowPolicyTerminate:
+			q.messages = q.messages[:0]
+			q.mu.Unlock()
+			return 0, ErrSubscriptionQueueOverflow
+		case OverflowPolicyCoalesce:
+			dropped = len(q.messages)
+			q.messages = q.messages[:0]
+		default:
+			dropped = 1
+			q.messages = append(q.messages[:0], q.messages[1:]...)
+		}
+	}
+	q.messages = append(q.messages, message)
+	q.mu.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+
+	return dropped, nil
+}
+
+// pop removes and returns all queued messages.
+func (q *messageQueue) pop() [][]byte {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.messages) == 0 {
+		return nil
+	}
+
+	messages := q.messages
+	q.messages = make([][]byte, 0, q.max)
+	select {
+	case q.popped <- struct{}{}:
+	default:
+	}
+	return messages
+}
+
+func (q *messageQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.messages)
+}
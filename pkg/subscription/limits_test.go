@@ -0,0 +1,499 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+)
+
+func TestMessageQueue(t *testing.T) {
+	pushAll := func(t *testing.T, queue *messageQueue, messages ...string) (dropped int, err error) {
+		for _, message := range messages {
+			var n int
+			n, err = queue.push(context.Background(), []byte(message))
+			dropped += n
+			if err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	popAll := func(queue *messageQueue) (messages []string) {
+		for _, message := range queue.pop() {
+			messages = append(messages, string(message))
+		}
+		return
+	}
+
+	t.Run("should drop oldest messages", func(t *testing.T) {
+		queue := newMessageQueue(2, OverflowPolicyDropOldest)
+		dropped, err := pushAll(t, queue, "1", "2", "3", "4")
+		require.NoError(t, err)
+		assert.Equal(t, 2, dropped)
+		assert.Equal(t, []string{"3", "4"}, popAll(queue))
+		assert.Equal(t, 0, queue.Len())
+	})
+
+	t.Run("should coalesce to latest message", func(t *testing.T) {
+		queue := newMessageQueue(2, OverflowPolicyCoalesce)
+		dropped, err := pushAll(t, queue, "1", "2", "3")
+		require.NoError(t, err)
+		assert.Equal(t, 2, dropped)
+		assert.Equal(t, []string{"3"}, popAll(queue))
+	})
+
+	t.Run("should return error on overflow with terminate policy", func(t *testing.T) {
+		queue := newMessageQueue(2, OverflowPolicyTerminate)
+		_, err := pushAll(t, queue, "1", "2", "3")
+		assert.Equal(t, ErrSubscriptionQueueOverflow, err)
+		assert.Equal(t, 0, queue.Len())
+	})
+
+	t.Run("should copy pushed data", func(t *testing.T) {
+		queue := newMessageQueue(1, OverflowPolicyDropOldest)
+		data := []byte("1")
+		_, err := queue.push(context.Background(), data)
+		require.NoError(t, err)
+		data[0] = '2'
+		assert.Equal(t, []string{"1"}, popAll(queue))
+	})
+
+	t.Run("should block until messages have been popped", func(t *testing.T) {
+		queue := newMessageQueue(1, OverflowPolicyBlock)
+		_, err := pushAll(t, queue, "1")
+		require.NoError(t, err)
+
+		pushed := make(chan struct{})
+		go func() {
+			defer close(pushed)
+			_, _ = pushAll(t, queue, "2")
+		}()
+
+		select {
+		case <-pushed:
+			t.Fatal("push did not block on the full queue")
+		case <-time.After(50 * time.Millisecond):
+		}
+		assert.Equal(t, []string{"1"}, popAll(queue))
+
+		select {
+		case <-pushed:
+		case <-time.After(time.Second):
+			t.Fatal("push was not unblocked by pop")
+		}
+		assert.Equal(t, []string{"2"}, popAll(queue))
+	})
+
+	t.Run("should stop blocking when the context is done", func(t *testing.T) {
+		queue := newMessageQueue(1, OverflowPolicyBlock)
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := queue.push(ctx, []byte("1"))
+		require.NoError(t, err)
+
+		cancel()
+		dropped, err := queue.push(ctx, []byte("2"))
+		require.NoError(t, err)
+		assert.Equal(t, 0, dropped)
+		assert.Equal(t, []string{"1"}, popAll(queue))
+	})
+}
+
+func TestConnectionLimiter(t *testing.T) {
+	limiter := NewConnectionLimiter(2, nil)
+
+	assert.True(t, limiter.acquire("a"))
+	assert.True(t, limiter.acquire("a"))
+	assert.False(t, limiter.acquire("a"))
+	assert.True(t, limiter.acquire("b"))
+	assert.True(t, limiter.acquire(""), "connections without identity are not limited")
+	assert.Equal(t, 2, limiter.Connections("a"))
+
+	limiter.release("a")
+	assert.Equal(t, 1, limiter.Connections("a"))
+	assert.True(t, limiter.acquire("a"))
+}
+
+type metricsRecorder struct {
+	mu                  sync.Mutex
+	rejectedConnections int
+	started             int
+	stopped             int
+	rejected            int
+	dropped             int
+	terminated          int
+}
+
+func (m *metricsRecorder) ConnectionRejected(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejectedConnections++
+}
+
+func (m *metricsRecorder) SubscriptionStarted(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started++
+}
+
+func (m *metricsRecorder) SubscriptionStopped(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopped++
+}
+
+func (m *metricsRecorder) SubscriptionRejected(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejected++
+}
+
+func (m *metricsRecorder) MessagesDropped(_ string, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dropped += count
+}
+
+func (m *metricsRecorder) SubscriptionTerminated(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.terminated++
+}
+
+func (m *metricsRecorder) snapshot() metricsRecorder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return metricsRecorder{
+		rejectedConnections: m.rejectedConnections,
+		started:             m.started,
+		stopped:             m.stopped,
+		rejected:            m.rejected,
+		dropped:             m.dropped,
+		terminated:          m.terminated,
+	}
+}
+
+// fakeSubscriptionExecutorPool creates executors which emit messages and block until the subscription is stopped.
+// After the first message it waits until the client started writing, so that all remaining messages get queued.
+type fakeSubscriptionExecutorPool struct {
+	messages []string
+	writing  chan struct{}
+	emitted  chan struct{}
+}
+
+func (f *fakeSubscriptionExecutorPool) Get(_ []byte) (Executor, error) {
+	return &fakeSubscriptionExecutor{pool: f}, nil
+}
+
+func (f *fakeSubscriptionExecutorPool) Put(_ Executor) error {
+	return nil
+}
+
+type fakeSubscriptionExecutor struct {
+	pool *fakeSubscriptionExecutorPool
+	ctx  context.Context
+}
+
+func (f *fakeSubscriptionExecutor) Execute(writer resolve.FlushWriter) error {
+	for i, message := range f.pool.messages {
+		_, _ = writer.Write([]byte(message))
+		writer.Flush()
+		if i == 0 && f.pool.writing != nil {
+			<-f.pool.writing
+		}
+	}
+	close(f.pool.emitted)
+
+	<-f.ctx.Done()
+	return nil
+}
+
+func (f *fakeSubscriptionExecutor) OperationType() ast.OperationType {
+	return ast.OperationTypeSubscription
+}
+
+func (f *fakeSubscriptionExecutor) SetContext(ctx context.Context) {
+	f.ctx = ctx
+}
+
+func (f *fakeSubscriptionExecutor) Reset() {}
+
+// slowClient blocks writing data messages until it gets released.
+type slowClient struct {
+	*mockClient
+	writing     chan struct{}
+	release     chan struct{}
+	writingOnce sync.Once
+}
+
+func newSlowClient() *slowClient {
+	return &slowClient{
+		mockClient: newMockClient(),
+		writing:    make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+}
+
+func (c *slowClient) WriteToClient(message Message) error {
+	if message.Type == MessageTypeData {
+		c.writingOnce.Do(func() {
+			close(c.writing)
+		})
+		<-c.release
+	}
+	return c.mockClient.WriteToClient(message)
+}
+
+func (c *slowClient) messagesOfType(messageType string) (payloads []string) {
+	for _, message := range c.readFromServer() {
+		if message.Type == messageType {
+			payloads = append(payloads, string(message.Payload))
+		}
+	}
+	return
+}
+
+func TestHandler_Limits(t *testing.T) {
+	runHandler := func(t *testing.T, handler *Handler) (cancel func()) {
+		ctx, cancelFunc := context.WithCancel(context.Background())
+		go handler.Handle(ctx)
+		return cancelFunc
+	}
+
+	t.Run("should reject subscriptions exceeding the max subscriptions per connection", func(t *testing.T) {
+		metrics := &metricsRecorder{}
+		pool := &fakeSubscriptionExecutorPool{emitted: make(chan struct{})}
+		client := newMockClient()
+		handler, err := NewHandler(abstractlogger.NoopLogger, client, pool, WithMaxSubscriptionsPerConnection(1), WithMetrics(metrics))
+		require.NoError(t, err)
+
+		client.prepareStartMessage("1", nil).withoutError().and().send()
+		cancel := runHandler(t, handler)
+		client.prepareStartMessage("2", nil).withoutError().and().send()
+
+		require.Eventually(t, func() bool {
+			return client.hasMoreMessagesThan(0)
+		}, time.Second, 5*time.Millisecond)
+		cancel()
+
+		messages := client.readFromServer()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "2", messages[0].Id)
+		assert.Equal(t, MessageTypeError, messages[0].Type)
+		assert.Equal(t, `[{"message":"maximum number of subscriptions per connection reached"}]`, string(messages[0].Payload))
+		assert.Equal(t, 1, handler.ActiveSubscriptions())
+		assert.Equal(t, 1, metrics.snapshot().rejected)
+	})
+
+	t.Run("should apply overflow policy for slow clients", func(t *testing.T) {
+		run := func(t *testing.T, policy OverflowPolicy) (*slowClient, *Handler, *metricsRecorder) {
+			metrics := &metricsRecorder{}
+			client := newSlowClient()
+			pool := &fakeSubscriptionExecutorPool{
+				messages: []string{"1", "2", "3", "4", "5"},
+				writing:  client.writing,
+				emitted:  make(chan struct{}),
+			}
+			handler, err := NewHandler(abstractlogger.NoopLogger, client, pool,
+				WithMaxQueuedMessagesPerSubscription(2),
+				WithOverflowPolicy(policy),
+				WithMetrics(metrics),
+			)
+			require.NoError(t, err)
+
+			client.prepareStartMessage("1", nil).withoutError().and().send()
+			cancel := runHandler(t, handler)
+			t.Cleanup(cancel)
+
+			select {
+			case <-pool.emitted:
+			case <-time.After(time.Second):
+				t.Fatal("subscription did not emit all messages while the client was blocked")
+			}
+			close(client.release)
+			return client, handler, metrics
+		}
+
+		t.Run("drop oldest", func(t *testing.T) {
+			client, handler, metrics := run(t, OverflowPolicyDropOldest)
+			require.Eventually(t, func() bool {
+				return len(client.messagesOfType(MessageTypeData)) == 3
+			}, time.Second, 5*time.Millisecond)
+
+			assert.Equal(t, []string{"1", "4", "5"}, client.messagesOfType(MessageTypeData))
+			assert.Equal(t, 2, metrics.snapshot().dropped)
+			assert.Equal(t, 1, handler.ActiveSubscriptions())
+		})
+
+		t.Run("coalesce", func(t *testing.T) {
+			client, _, metrics := run(t, OverflowPolicyCoalesce)
+			require.Eventually(t, func() bool {
+				return len(client.messagesOfType(MessageTypeData)) == 3
+			}, time.Second, 5*time.Millisecond)
+
+			assert.Equal(t, []string{"1", "4", "5"}, client.messagesOfType(MessageTypeData))
+			assert.Equal(t, 2, metrics.snapshot().dropped)
+		})
+
+		t.Run("terminate", func(t *testing.T) {
+			client, handler, metrics := run(t, OverflowPolicyTerminate)
+			require.Eventually(t, func() bool {
+				return metrics.snapshot().stopped == 1 && len(client.messagesOfType(MessageTypeData)) == 1
+			}, time.Second, 5*time.Millisecond)
+
+			assert.Equal(t, []string{"1"}, client.messagesOfType(MessageTypeData))
+			assert.Equal(t, []string{`[{"message":"subscription terminated: client is too slow to receive messages"}]`}, client.messagesOfType(MessageTypeError))
+			assert.Equal(t, 0, handler.ActiveSubscriptions())
+			assert.Equal(t, 1, metrics.snapshot().terminated)
+		})
+	})
+
+	t.Run("should not block the subscription on slow clients by default", func(t *testing.T) {
+		metrics := &metricsRecorder{}
+		client := newSlowClient()
+		pool := &fakeSubscriptionExecutorPool{
+			messages: []string{"1", "2", "3", "4", "5"},
+			writing:  client.writing,
+			emitted:  make(chan struct{}),
+		}
+		handler, err := NewHandler(abstractlogger.NoopLogger, client, pool, WithMetrics(metrics))
+		require.NoError(t, err)
+
+		client.prepareStartMessage("1", nil).withoutError().and().send()
+		cancel := runHandler(t, handler)
+		defer cancel()
+
+		select {
+		case <-pool.emitted:
+		case <-time.After(time.Second):
+			t.Fatal("subscription was blocked by the slow client")
+		}
+		close(client.release)
+
+		require.Eventually(t, func() bool {
+			return len(client.messagesOfType(MessageTypeData)) == 5
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, []string{"1", "2", "3", "4", "5"}, client.messagesOfType(MessageTypeData))
+		assert.Equal(t, 0, metrics.snapshot().dropped)
+		assert.Equal(t, 1, handler.ActiveSubscriptions())
+	})
+
+	t.Run("should not drop messages of slow clients by default when the queue is full", func(t *testing.T) {
+		metrics := &metricsRecorder{}
+		client := newSlowClient()
+		pool := &fakeSubscriptionExecutorPool{
+			messages: []string{"1", "2", "3", "4", "5"},
+			writing:  client.writing,
+			emitted:  make(chan struct{}),
+		}
+		handler, err := NewHandler(abstractlogger.NoopLogger, client, pool, WithMaxQueuedMessagesPerSubscription(2), WithMetrics(metrics))
+		require.NoError(t, err)
+
+		client.prepareStartMessage("1", nil).withoutError().and().send()
+		cancel := runHandler(t, handler)
+		defer cancel()
+
+		select {
+		case <-pool.emitted:
+			t.Fatal("subscription was not blocked by the full queue")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(client.release)
+
+		require.Eventually(t, func() bool {
+			return len(client.messagesOfType(MessageTypeData)) == 5
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, []string{"1", "2", "3", "4", "5"}, client.messagesOfType(MessageTypeData))
+		assert.Equal(t, 0, metrics.snapshot().dropped)
+	})
+
+	t.Run("should block the subscription on slow clients if queueing is disabled", func(t *testing.T) {
+		client := newSlowClient()
+		pool := &fakeSubscriptionExecutorPool{
+			messages: []string{"1", "2"},
+			writing:  client.writing,
+			emitted:  make(chan struct{}),
+		}
+		handler, err := NewHandler(abstractlogger.NoopLogger, client, pool, WithMaxQueuedMessagesPerSubscription(0))
+		require.NoError(t, err)
+
+		client.prepareStartMessage("1", nil).withoutError().and().send()
+		cancel := runHandler(t, handler)
+		defer cancel()
+
+		select {
+		case <-pool.emitted:
+			t.Fatal("subscription was not blocked by the slow client")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(client.release)
+
+		require.Eventually(t, func() bool {
+			return len(client.messagesOfType(MessageTypeData)) == 2
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("should limit connections per identity", func(t *testing.T) {
+		metrics := &metricsRecorder{}
+		limiter := NewConnectionLimiter(1, nil)
+		initPayload := []byte(`{"Authorization":"Bearer 123"}`)
+
+		newHandler := func(t *testing.T) (*Handler, *mockClient) {
+			client := newMockClient()
+			handler, err := NewHandler(abstractlogger.NoopLogger, client, &fakeSubscriptionExecutorPool{}, WithConnectionLimiter(limiter), WithMetrics(metrics))
+			require.NoError(t, err)
+			return handler, client
+		}
+
+		firstHandler, firstClient := newHandler(t)
+		firstClient.prepareConnectionInitMessageWithPayload(initPayload).withoutError().and().send()
+		cancelFirst := runHandler(t, firstHandler)
+		require.Eventually(t, func() bool {
+			return firstClient.hasMoreMessagesThan(0)
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, MessageTypeConnectionAck, firstClient.readFromServer()[0].Type)
+		assert.Equal(t, 1, limiter.Connections("Bearer 123"))
+
+		secondHandler, secondClient := newHandler(t)
+		secondClient.prepareConnectionInitMessageWithPayload(initPayload).withoutError().and().send()
+		cancelSecond := runHandler(t, secondHandler)
+		require.Eventually(t, func() bool {
+			return secondClient.hasMoreMessagesThan(0)
+		}, time.Second, 5*time.Millisecond)
+		cancelSecond()
+
+		messages := secondClient.readFromServer()
+		require.Len(t, messages, 1)
+		assert.Equal(t, MessageTypeConnectionTerminate, messages[0].Type)
+		assert.Equal(t, `"maximum number of connections per identity reached"`, string(messages[0].Payload))
+		assert.Equal(t, 1, metrics.snapshot().rejectedConnections)
+
+		firstClient.prepareConnectionTerminateMessage().withoutError().and().send()
+		cancelFirst()
+		assert.Eventually(t, func() bool {
+			return limiter.Connections("Bearer 123") == 0
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("should release the connection slot if the ack can't be written", func(t *testing.T) {
+		limiter := NewConnectionLimiter(1, nil)
+		client := newMockClient()
+		client.err = errors.New("write failed")
+		handler, err := NewHandler(abstractlogger.NoopLogger, client, &fakeSubscriptionExecutorPool{}, WithConnectionLimiter(limiter))
+		require.NoError(t, err)
+
+		_, identity, err := handler.handleInit(context.Background(), []byte(`{"Authorization":"Bearer 123"}`))
+		assert.EqualError(t, err, "write failed")
+		assert.Equal(t, "", identity)
+		assert.Equal(t, 0, limiter.Connections("Bearer 123"))
+	})
+}
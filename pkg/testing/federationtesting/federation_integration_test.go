@@ -32,7 +32,7 @@ func newFederationSetup() *federationSetup {
 	poller := gateway.NewDatasource([]gateway.ServiceConfig{
 		{Name: "accounts", URL: accountUpstreamServer.URL},
 		{Name: "products", URL: productsUpstreamServer.URL, WS: strings.ReplaceAll(productsUpstreamServer.URL, "http:", "ws:")},
-		{Name: "reviews", URL: reviewsUpstreamServer.URL},
+		{Name: "reviews", URL: reviewsUpstreamServer.URL, WS: strings.ReplaceAll(reviewsUpstreamServer.URL, "http:", "ws:")},
 	}, httpClient)
 
 	gtw := gateway.Handler(abstractlogger.NoopLogger, poller, httpClient)
@@ -86,6 +86,11 @@ func TestFederationIntegrationTest(t *testing.T) {
 		assert.Equal(t, `{"data":{"topProducts":[{"name":"Trilby","reviews":[{"body":"A highly effective form of birth control.","author":{"username":"Me"}}]},{"name":"Fedora","reviews":[{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits.","author":{"username":"Me"}}]},{"name":"Boater","reviews":[{"body":"This is the last straw. Hat you will wear. 11/10","author":{"username":"User 7777"}}]}]}}`, string(resp))
 	})
 
+	t.Run("query with parallel entity fetches", func(t *testing.T) {
+		resp := gqlClient.Query(ctx, setup.gatewayServer.URL, path.Join("testdata", "queries/parallel_entity_fetches.graphql"), nil, t)
+		assert.Equal(t, `{"data":{"me":{"reviews":[{"body":"A highly effective form of birth control.","product":{"name":"Trilby"},"author":{"history":[{"rating":5}]}},{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits.","product":{"name":"Fedora"},"author":{"history":[{"rating":5}]}}]}}}`, string(resp))
+	})
+
 	t.Run("mutation operation with variables", func(t *testing.T) {
 		resp := gqlClient.Query(ctx, setup.gatewayServer.URL, path.Join("testdata", "mutations/mutation_with_variables.query"), queryVariables{
 			"authorID": "3210",
@@ -121,6 +126,17 @@ func TestFederationIntegrationTest(t *testing.T) {
 		assert.Equal(t, `{"id":"1","type":"data","payload":{"data":{"updateProductPrice":{"upc":"top-1","name":"Trilby","price":2}}}}`, string(<-messages))
 	})
 
+	t.Run("subscription query with entity fields of another subgraph", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		wsAddr := strings.ReplaceAll(setup.gatewayServer.URL, "http://", "ws://")
+		messages := gqlClient.Subscription(ctx, wsAddr, path.Join("testdata", "subscriptions/subscription_with_entity_fields.query"), nil, t)
+
+		assert.Equal(t, `{"id":"1","type":"data","payload":{"data":{"reviewAdded":{"body":"A highly effective form of birth control.","product":{"upc":"top-1","name":"Trilby"}}}}}`, string(<-messages))
+		assert.Equal(t, `{"id":"1","type":"data","payload":{"data":{"reviewAdded":{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits.","product":{"upc":"top-2","name":"Fedora"}}}}}`, string(<-messages))
+	})
+
 	t.Run("Multiple queries and nested fragments", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(ctx)
 		defer cancel()
@@ -1,19 +1,22 @@
 package gateway
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/gobwas/ws"
 	log "github.com/jensneuse/abstractlogger"
 
-	http2 "github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/gateway/http"
-
+	"github.com/wundergraph/graphql-go-tools/pkg/gateway"
 	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+	http2 "github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/gateway/http"
 )
 
-func NewDatasource(serviceConfig []ServiceConfig, httpClient *http.Client) *DatasourcePollerPoller {
-	return NewDatasourcePoller(httpClient, DatasourcePollerConfig{
+type ServiceConfig = gateway.ServiceConfig
+
+func NewDatasource(serviceConfig []ServiceConfig, httpClient *http.Client) *gateway.Poller {
+	return gateway.NewPoller(httpClient, log.NoopLogger, gateway.PollerConfig{
 		Services:        serviceConfig,
 		PollingInterval: 30 * time.Second,
 	})
@@ -21,22 +24,29 @@ func NewDatasource(serviceConfig []ServiceConfig, httpClient *http.Client) *Data
 
 func Handler(
 	logger log.Logger,
-	datasourcePoller *DatasourcePollerPoller,
+	datasourcePoller *gateway.Poller,
 	httpClient *http.Client,
-) *Gateway {
+) *gateway.Gateway {
 	upgrader := &ws.DefaultHTTPUpgrader
 	upgrader.Header = http.Header{}
 	//upgrader.Header.Add("Sec-Websocket-Protocol", "graphql-ws")
 
-	datasourceWatcher := datasourcePoller
-
-	var gqlHandlerFactory HandlerFactoryFn = func(schema *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler {
+	var gqlHandlerFactory gateway.HandlerFactoryFn = func(schema *graphql.Schema, engine *graphql.ExecutionEngineV2) http.Handler {
 		return http2.NewGraphqlHTTPHandler(schema, engine, upgrader, logger)
 	}
 
-	gateway := NewGateway(gqlHandlerFactory, httpClient, logger)
+	// requests to unhealthy subgraphs fail fast while the other subgraphs keep resolving
+	gatewayHttpClient := &http.Client{
+		Transport: datasourcePoller.Transport(httpClient.Transport),
+		Timeout:   httpClient.Timeout,
+	}
+
+	gtw := gateway.NewGateway(context.Background(), gqlHandlerFactory,
+		gateway.WithHttpClient(gatewayHttpClient),
+		gateway.WithLogger(logger),
+	)
 
-	datasourceWatcher.Register(gateway)
+	datasourcePoller.Register(gtw)
 
-	return gateway
+	return gtw
 }
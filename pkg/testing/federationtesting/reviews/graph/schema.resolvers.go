@@ -7,6 +7,7 @@ package graph
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/reviews/graph/generated"
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/reviews/graph/model"
@@ -76,6 +77,31 @@ func (r *reviewResolver) Attachments(ctx context.Context, obj *model.Review) ([]
 	return res, nil
 }
 
+// ReviewAdded is the resolver for the reviewAdded field.
+func (r *subscriptionResolver) ReviewAdded(ctx context.Context) (<-chan *model.Review, error) {
+	reviewAdded := make(chan *model.Review)
+	added := make([]*model.Review, len(reviews))
+	copy(added, reviews)
+
+	go func() {
+		defer close(reviewAdded)
+		for _, review := range added {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case reviewAdded <- review:
+			}
+		}
+	}()
+	return reviewAdded, nil
+}
+
 // Username is the resolver for the username field.
 func (r *userResolver) Username(ctx context.Context, obj *model.User) (string, error) {
 	username := fmt.Sprintf("User %s", obj.ID)
@@ -119,6 +145,9 @@ func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 // Review returns generated.ReviewResolver implementation.
 func (r *Resolver) Review() generated.ReviewResolver { return &reviewResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 // User returns generated.UserResolver implementation.
 func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
 
@@ -126,4 +155,5 @@ type mutationResolver struct{ *Resolver }
 type productResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type reviewResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }